@@ -0,0 +1,126 @@
+package compliance
+
+import (
+	"fmt"
+
+	"github.com/bom-squad/protobom/pkg/sbom"
+)
+
+// Sections of BSI TR-03183-2 (v1.1) cited in the findings
+const (
+	BSISectionSBOM      = "5.2.1" // Required data fields for the SBOM
+	BSISectionComponent = "5.2.2" // Required data fields for each component
+)
+
+// CheckBSITR03183 checks the document against the requirements of the German
+// BSI TR-03183-2 guideline that go beyond the NTIA minimum elements: hashes
+// and license information for each component and the completeness of the
+// dependency graph. Each finding records the section of the guideline that
+// defines the violated requirement.
+func CheckBSITR03183(doc *sbom.Document) []Finding {
+	findings := []Finding{}
+	if doc == nil || doc.NodeList == nil {
+		return findings
+	}
+
+	for _, n := range doc.NodeList.Nodes {
+		findings = append(findings, checkBSIHashes(n)...)
+		findings = append(findings, checkBSILicenses(n)...)
+	}
+
+	findings = append(findings, checkBSIRelationships(doc.NodeList)...)
+	return findings
+}
+
+// checkBSIHashes checks that the node has a hash. The guideline requires the
+// hash to be computed using SHA-512 so other algorithms produce a warning.
+func checkBSIHashes(n *sbom.Node) []Finding {
+	if len(n.Hashes) == 0 {
+		return []Finding{{
+			NodeID: n.Id, Field: FieldHashes, Severity: ERROR, Section: BSISectionComponent,
+			Message: "missing hash value",
+		}}
+	}
+
+	if n.Hashes[int32(sbom.HashAlgorithm_SHA512)] == "" {
+		return []Finding{{
+			NodeID: n.Id, Field: FieldHashes, Severity: WARNING, Section: BSISectionComponent,
+			Message: "missing SHA-512 hash value",
+		}}
+	}
+	return nil
+}
+
+// checkBSILicenses checks that the node has license information. Licenses
+// set to NOASSERTION or NONE are not considered license information.
+func checkBSILicenses(n *sbom.Node) []Finding {
+	for _, l := range append([]string{n.LicenseConcluded}, n.Licenses...) {
+		if sbom.HasValue(l) {
+			return nil
+		}
+	}
+	return []Finding{{
+		NodeID: n.Id, Field: FieldLicenses, Severity: ERROR, Section: BSISectionComponent,
+		Message: "missing license information",
+	}}
+}
+
+// checkBSIRelationships checks the completeness of the dependency graph:
+// the document must have root elements, edges must not point to nodes that
+// don't exist and all nodes must be reachable from the root elements.
+func checkBSIRelationships(nl *sbom.NodeList) []Finding {
+	findings := []Finding{}
+	index := map[string]struct{}{}
+	for _, n := range nl.Nodes {
+		index[n.Id] = struct{}{}
+	}
+
+	if len(nl.RootElements) == 0 {
+		findings = append(findings, Finding{
+			Field: FieldRelationships, Severity: ERROR, Section: BSISectionSBOM,
+			Message: "document has no root elements",
+		})
+	}
+
+	children := map[string][]string{}
+	for _, e := range nl.Edges {
+		for _, id := range append([]string{e.From}, e.To...) {
+			if _, ok := index[id]; !ok {
+				findings = append(findings, Finding{
+					Field: FieldRelationships, Severity: ERROR, Section: BSISectionComponent,
+					Message: fmt.Sprintf("%s relationship references missing node %s", e.Type, id),
+				})
+			}
+		}
+		children[e.From] = append(children[e.From], e.To...)
+	}
+
+	// Without root elements there is nothing to walk, the missing
+	// roots finding already covers it
+	if len(nl.RootElements) == 0 {
+		return findings
+	}
+
+	// Walk the graph from the root elements to find unreachable nodes
+	reached := map[string]struct{}{}
+	queue := append([]string{}, nl.RootElements...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if _, ok := reached[id]; ok {
+			continue
+		}
+		reached[id] = struct{}{}
+		queue = append(queue, children[id]...)
+	}
+
+	for _, n := range nl.Nodes {
+		if _, ok := reached[n.Id]; !ok {
+			findings = append(findings, Finding{
+				NodeID: n.Id, Field: FieldRelationships, Severity: ERROR, Section: BSISectionComponent,
+				Message: "node is not reachable from the document root elements",
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,111 @@
+package compliance
+
+import (
+	"testing"
+
+	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBSITR03183(t *testing.T) {
+	completeNode := func(id string) *sbom.Node {
+		return &sbom.Node{
+			Id:               id,
+			Name:             "package-" + id,
+			LicenseConcluded: "Apache-2.0",
+			Hashes: map[int32]string{
+				int32(sbom.HashAlgorithm_SHA512): "b1f4c3a8",
+			},
+		}
+	}
+	completeDoc := func() *sbom.Document {
+		doc := sbom.NewDocument()
+		doc.NodeList.AddNode(completeNode("a"))
+		doc.NodeList.AddNode(completeNode("b"))
+		doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "a", To: []string{"b"}})
+		doc.NodeList.RootElements = []string{"a"}
+		return doc
+	}
+
+	for _, tc := range []struct {
+		name     string
+		prepare  func(*sbom.Document)
+		expected []Finding
+	}{
+		{
+			name:     "complete document",
+			prepare:  func(*sbom.Document) {},
+			expected: []Finding{},
+		},
+		{
+			name: "no hashes",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.GetNodeByID("b").Hashes = map[int32]string{}
+			},
+			expected: []Finding{
+				{NodeID: "b", Field: FieldHashes, Severity: ERROR, Section: BSISectionComponent, Message: "missing hash value"},
+			},
+		},
+		{
+			name: "no SHA-512",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.GetNodeByID("b").Hashes = map[int32]string{int32(sbom.HashAlgorithm_SHA256): "abc"}
+			},
+			expected: []Finding{
+				{NodeID: "b", Field: FieldHashes, Severity: WARNING, Section: BSISectionComponent, Message: "missing SHA-512 hash value"},
+			},
+		},
+		{
+			name: "license in list",
+			prepare: func(doc *sbom.Document) {
+				n := doc.NodeList.GetNodeByID("b")
+				n.LicenseConcluded = ""
+				n.Licenses = []string{"MIT"}
+			},
+			expected: []Finding{},
+		},
+		{
+			name: "NOASSERTION license",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.GetNodeByID("b").LicenseConcluded = "NOASSERTION"
+			},
+			expected: []Finding{
+				{NodeID: "b", Field: FieldLicenses, Severity: ERROR, Section: BSISectionComponent, Message: "missing license information"},
+			},
+		},
+		{
+			name: "unreachable node",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.AddNode(completeNode("c"))
+			},
+			expected: []Finding{
+				{NodeID: "c", Field: FieldRelationships, Severity: ERROR, Section: BSISectionComponent, Message: "node is not reachable from the document root elements"},
+			},
+		},
+		{
+			name: "dangling edge",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.Edges[0].To = append(doc.NodeList.Edges[0].To, "x")
+			},
+			expected: []Finding{
+				{Field: FieldRelationships, Severity: ERROR, Section: BSISectionComponent, Message: "dependsOn relationship references missing node x"},
+			},
+		},
+		{
+			name: "no root elements",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.RootElements = []string{}
+			},
+			expected: []Finding{
+				{Field: FieldRelationships, Severity: ERROR, Section: BSISectionSBOM, Message: "document has no root elements"},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			doc := completeDoc()
+			tc.prepare(doc)
+			require.Equal(t, tc.expected, CheckBSITR03183(doc))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package compliance
+
+import "fmt"
+
+// Severity indicates how serious a finding is
+type Severity string
+
+const (
+	ERROR   Severity = "ERROR"
+	WARNING Severity = "WARNING"
+)
+
+// Fields checked in the documents
+const (
+	FieldSupplier      = "supplier"
+	FieldName          = "name"
+	FieldVersion       = "version"
+	FieldIdentifiers   = "identifiers"
+	FieldRelationships = "relationships"
+	FieldAuthor        = "author"
+	FieldTimestamp     = "timestamp"
+	FieldHashes        = "hashes"
+	FieldLicenses      = "licenses"
+)
+
+// Finding is a problem found in a document when checking it against a
+// set of minimum requirements. Findings about the document as a whole
+// have an empty NodeID. When the requirement comes from a guideline with
+// numbered sections, Section records where it is defined.
+type Finding struct {
+	NodeID   string   `json:"nodeId,omitempty"`
+	Field    string   `json:"field"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Section  string   `json:"section,omitempty"`
+}
+
+// ComplianceReport is the result of checking a document against a set of
+// requirements. A document passes when none of the findings are errors.
+type ComplianceReport struct {
+	Passed   bool      `json:"passed"`
+	Findings []Finding `json:"findings"`
+}
+
+// NewComplianceReport returns a report with the findings, computing
+// if the document passed the check.
+func NewComplianceReport(findings []Finding) *ComplianceReport {
+	if findings == nil {
+		findings = []Finding{}
+	}
+	report := &ComplianceReport{
+		Passed:   true,
+		Findings: findings,
+	}
+	for _, f := range findings {
+		if f.Severity == ERROR {
+			report.Passed = false
+			break
+		}
+	}
+	return report
+}
+
+// String returns a human readable representation of the finding
+func (f Finding) String() string {
+	msg := f.Message
+	if f.Section != "" {
+		msg = fmt.Sprintf("%s (section %s)", f.Message, f.Section)
+	}
+	if f.NodeID == "" {
+		return fmt.Sprintf("[%s] document: %s", f.Severity, msg)
+	}
+	return fmt.Sprintf("[%s] node %s: %s", f.Severity, f.NodeID, msg)
+}
@@ -0,0 +1,83 @@
+package compliance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bom-squad/protobom/pkg/sbom"
+)
+
+// DefaultEO14028MaxAge is the maximum age of an SBOM to be considered current
+const DefaultEO14028MaxAge = 30 * 24 * time.Hour
+
+// EO14028Checker checks documents against the SBOM requirements of the
+// US Executive Order 14028.
+type EO14028Checker struct {
+	// MaxAge is the maximum time since the SBOM was generated
+	MaxAge time.Duration
+
+	now func() time.Time
+}
+
+// NewEO14028Checker returns a checker with the default options
+func NewEO14028Checker() *EO14028Checker {
+	return &EO14028Checker{
+		MaxAge: DefaultEO14028MaxAge,
+		now:    time.Now,
+	}
+}
+
+// Check verifies all nodes in the document have a supplier and a version
+// and that the SBOM was generated within the checker's MaxAge.
+func (c *EO14028Checker) Check(doc *sbom.Document) *ComplianceReport {
+	findings := []Finding{}
+	if doc == nil {
+		return NewComplianceReport(findings)
+	}
+
+	findings = append(findings, c.checkTimestamp(doc.Metadata)...)
+
+	if doc.NodeList != nil {
+		for _, n := range doc.NodeList.Nodes {
+			if !hasSupplierName(n) {
+				findings = append(findings, Finding{
+					NodeID: n.Id, Field: FieldSupplier, Severity: ERROR, Message: "missing supplier name",
+				})
+			}
+			if n.Version == "" {
+				findings = append(findings, Finding{
+					NodeID: n.Id, Field: FieldVersion, Severity: ERROR, Message: "missing component version",
+				})
+			}
+		}
+	}
+
+	return NewComplianceReport(findings)
+}
+
+// checkTimestamp checks the document has a date and it is recent enough
+func (c *EO14028Checker) checkTimestamp(md *sbom.Metadata) []Finding {
+	if md == nil || md.Date == nil || md.Date.AsTime().IsZero() {
+		return []Finding{{Field: FieldTimestamp, Severity: ERROR, Message: "document has no timestamp"}}
+	}
+
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	maxAge := c.MaxAge
+	if maxAge == 0 {
+		maxAge = DefaultEO14028MaxAge
+	}
+
+	if age := now().Sub(md.Date.AsTime()); age > maxAge {
+		return []Finding{{
+			Field: FieldTimestamp, Severity: ERROR,
+			Message: fmt.Sprintf(
+				"document was generated %d days ago, limit is %d days",
+				int(age.Hours()/24), int(maxAge.Hours()/24),
+			),
+		}}
+	}
+	return nil
+}
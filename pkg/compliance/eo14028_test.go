@@ -0,0 +1,90 @@
+package compliance
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEO14028Check(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	newDoc := func() *sbom.Document {
+		doc := sbom.NewDocument()
+		doc.Metadata.Date = timestamppb.New(now.Add(-24 * time.Hour))
+		doc.NodeList.AddNode(&sbom.Node{
+			Id: "a", Version: "1.0", Suppliers: []*sbom.Person{{Name: "Acme"}},
+		})
+		return doc
+	}
+
+	for _, tc := range []struct {
+		name     string
+		prepare  func(*sbom.Document)
+		passed   bool
+		findings []Finding
+	}{
+		{
+			name:     "compliant",
+			prepare:  func(*sbom.Document) {},
+			passed:   true,
+			findings: []Finding{},
+		},
+		{
+			name: "old document",
+			prepare: func(doc *sbom.Document) {
+				doc.Metadata.Date = timestamppb.New(now.Add(-31 * 24 * time.Hour))
+			},
+			findings: []Finding{
+				{Field: FieldTimestamp, Severity: ERROR, Message: "document was generated 31 days ago, limit is 30 days"},
+			},
+		},
+		{
+			name: "no timestamp",
+			prepare: func(doc *sbom.Document) {
+				doc.Metadata.Date = nil
+			},
+			findings: []Finding{
+				{Field: FieldTimestamp, Severity: ERROR, Message: "document has no timestamp"},
+			},
+		},
+		{
+			name: "missing supplier and version",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.AddNode(&sbom.Node{Id: "b"})
+			},
+			findings: []Finding{
+				{NodeID: "b", Field: FieldSupplier, Severity: ERROR, Message: "missing supplier name"},
+				{NodeID: "b", Field: FieldVersion, Severity: ERROR, Message: "missing component version"},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewEO14028Checker()
+			checker.now = func() time.Time { return now }
+			doc := newDoc()
+			tc.prepare(doc)
+			report := checker.Check(doc)
+			require.Equal(t, tc.passed, report.Passed)
+			require.Equal(t, tc.findings, report.Findings)
+		})
+	}
+}
+
+func TestComplianceReportJSON(t *testing.T) {
+	report := NewComplianceReport([]Finding{
+		{NodeID: "a", Field: FieldVersion, Severity: WARNING, Message: "missing component version"},
+	})
+	require.True(t, report.Passed)
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"passed": true,
+		"findings": [{"nodeId": "a", "field": "version", "severity": "WARNING", "message": "missing component version"}]
+	}`, string(data))
+}
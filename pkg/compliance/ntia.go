@@ -0,0 +1,117 @@
+package compliance
+
+import (
+	"github.com/bom-squad/protobom/pkg/sbom"
+)
+
+// CheckNTIAMinimumElements checks the document against the seven minimum data
+// fields defined by the NTIA and returns a finding for each field missing in
+// each node. Author and timestamp are document-level data, their findings are
+// returned without a node ID.
+//
+// Nodes without relationships are reported as warnings as they may be leaves
+// of the dependency graph. All other missing fields are errors.
+func CheckNTIAMinimumElements(doc *sbom.Document) []Finding {
+	findings := []Finding{}
+	if doc == nil {
+		return findings
+	}
+
+	if doc.Metadata == nil || !hasAuthor(doc.Metadata) {
+		findings = append(findings, Finding{
+			Field:    FieldAuthor,
+			Severity: ERROR,
+			Message:  "document has no author of the SBOM data",
+		})
+	}
+
+	if doc.Metadata == nil || doc.Metadata.Date == nil || doc.Metadata.Date.AsTime().IsZero() {
+		findings = append(findings, Finding{
+			Field:    FieldTimestamp,
+			Severity: ERROR,
+			Message:  "document has no timestamp",
+		})
+	}
+
+	if doc.NodeList == nil {
+		return findings
+	}
+
+	related := relatedNodes(doc.NodeList)
+	for _, n := range doc.NodeList.Nodes {
+		findings = append(findings, checkNTIANode(n, related)...)
+	}
+	return findings
+}
+
+// checkNTIANode checks the node level NTIA minimum elements
+func checkNTIANode(n *sbom.Node, related map[string]struct{}) []Finding {
+	findings := []Finding{}
+	missing := func(field string, severity Severity, msg string) {
+		findings = append(findings, Finding{
+			NodeID: n.Id, Field: field, Severity: severity, Message: msg,
+		})
+	}
+
+	if !hasSupplierName(n) {
+		missing(FieldSupplier, ERROR, "missing supplier name")
+	}
+
+	if n.Name == "" {
+		missing(FieldName, ERROR, "missing component name")
+	}
+
+	if n.Version == "" {
+		missing(FieldVersion, ERROR, "missing component version")
+	}
+
+	if n.Identifiers[int32(sbom.SoftwareIdentifierType_PURL)] == "" &&
+		n.Identifiers[int32(sbom.SoftwareIdentifierType_CPE22)] == "" &&
+		n.Identifiers[int32(sbom.SoftwareIdentifierType_CPE23)] == "" {
+		missing(FieldIdentifiers, ERROR, "missing unique identifier (purl or CPE)")
+	}
+
+	if _, ok := related[n.Id]; !ok {
+		missing(FieldRelationships, WARNING, "node has no dependency relationships")
+	}
+
+	return findings
+}
+
+// hasAuthor returns true if the metadata lists at least one author, a
+// person or an organization. The tools that generated the document are not
+// the author of the SBOM data.
+func hasAuthor(md *sbom.Metadata) bool {
+	for _, a := range md.Authors {
+		if a.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSupplierName returns true if the node has at least one named supplier
+func hasSupplierName(n *sbom.Node) bool {
+	for _, s := range n.Suppliers {
+		if s.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// relatedNodes returns an index of the IDs of all nodes that take part in
+// at least one relationship in the nodelist
+func relatedNodes(nl *sbom.NodeList) map[string]struct{} {
+	related := map[string]struct{}{}
+	for _, e := range nl.Edges {
+		if len(e.To) == 0 {
+			continue
+		}
+		related[e.From] = struct{}{}
+		for _, id := range e.To {
+			related[id] = struct{}{}
+		}
+	}
+	return related
+}
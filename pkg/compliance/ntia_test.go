@@ -0,0 +1,125 @@
+package compliance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCheckNTIAMinimumElements(t *testing.T) {
+	completeNode := func(id string) *sbom.Node {
+		return &sbom.Node{
+			Id:        id,
+			Name:      "package-" + id,
+			Version:   "1.0.0",
+			Suppliers: []*sbom.Person{{Name: "Acme", IsOrg: true}},
+			Identifiers: map[int32]string{
+				int32(sbom.SoftwareIdentifierType_PURL): "pkg:generic/package-" + id + "@1.0.0",
+			},
+		}
+	}
+	completeDoc := func() *sbom.Document {
+		doc := sbom.NewDocument()
+		doc.Metadata.Authors = []*sbom.Person{{Name: "John Doe"}}
+		doc.Metadata.Date = timestamppb.New(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+		doc.NodeList.AddNode(completeNode("a"))
+		doc.NodeList.AddNode(completeNode("b"))
+		doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "a", To: []string{"b"}})
+		return doc
+	}
+
+	for _, tc := range []struct {
+		name     string
+		prepare  func(*sbom.Document)
+		expected []Finding
+	}{
+		{
+			name:     "complete document",
+			prepare:  func(*sbom.Document) {},
+			expected: []Finding{},
+		},
+		{
+			name: "organization as author",
+			prepare: func(doc *sbom.Document) {
+				doc.Metadata.Authors = []*sbom.Person{{Name: "Acme", IsOrg: true}}
+			},
+			expected: []Finding{},
+		},
+		{
+			name: "tool is not an author",
+			prepare: func(doc *sbom.Document) {
+				doc.Metadata.Authors = []*sbom.Person{}
+				doc.Metadata.Tools = []*sbom.Tool{{Name: "syft"}}
+			},
+			expected: []Finding{
+				{Field: FieldAuthor, Severity: ERROR, Message: "document has no author of the SBOM data"},
+			},
+		},
+		{
+			name: "no author or timestamp",
+			prepare: func(doc *sbom.Document) {
+				doc.Metadata.Authors = []*sbom.Person{}
+				doc.Metadata.Date = nil
+			},
+			expected: []Finding{
+				{Field: FieldAuthor, Severity: ERROR, Message: "document has no author of the SBOM data"},
+				{Field: FieldTimestamp, Severity: ERROR, Message: "document has no timestamp"},
+			},
+		},
+		{
+			name: "missing node fields",
+			prepare: func(doc *sbom.Document) {
+				n := doc.NodeList.GetNodeByID("b")
+				n.Name = ""
+				n.Version = ""
+				n.Suppliers = []*sbom.Person{{Email: "info@example.com"}}
+				n.Identifiers = map[int32]string{}
+			},
+			expected: []Finding{
+				{NodeID: "b", Field: FieldSupplier, Severity: ERROR, Message: "missing supplier name"},
+				{NodeID: "b", Field: FieldName, Severity: ERROR, Message: "missing component name"},
+				{NodeID: "b", Field: FieldVersion, Severity: ERROR, Message: "missing component version"},
+				{NodeID: "b", Field: FieldIdentifiers, Severity: ERROR, Message: "missing unique identifier (purl or CPE)"},
+			},
+		},
+		{
+			name: "CPE is a valid identifier",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.GetNodeByID("b").Identifiers = map[int32]string{
+					int32(sbom.SoftwareIdentifierType_CPE23): "cpe:2.3:a:acme:b:1.0.0:*:*:*:*:*:*:*",
+				}
+			},
+			expected: []Finding{},
+		},
+		{
+			name: "unrelated node",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.AddNode(completeNode("c"))
+			},
+			expected: []Finding{
+				{NodeID: "c", Field: FieldRelationships, Severity: WARNING, Message: "node has no dependency relationships"},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			doc := completeDoc()
+			tc.prepare(doc)
+			require.Equal(t, tc.expected, CheckNTIAMinimumElements(doc))
+		})
+	}
+}
+
+func TestCheckNTIAMinimumElementsNil(t *testing.T) {
+	require.Empty(t, CheckNTIAMinimumElements(nil))
+	findings := CheckNTIAMinimumElements(&sbom.Document{})
+	require.Len(t, findings, 2)
+
+	// New documents only list the protobom tool, they have no author
+	require.Equal(t, []Finding{
+		{Field: FieldAuthor, Severity: ERROR, Message: "document has no author of the SBOM data"},
+	}, CheckNTIAMinimumElements(sbom.NewDocument()))
+}
@@ -0,0 +1,299 @@
+// Package convert translates protobom documents read from one SBOM format
+// so that they can be written in another format family, reporting the data
+// that is lost in the conversion.
+package convert
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/bom-squad/protobom/pkg/reader"
+	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/bom-squad/protobom/pkg/writer"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sigs.k8s.io/release-utils/version"
+)
+
+// LossWarning prefixes the comment of the annotations added to converted
+// documents to record data that could not be converted
+const LossWarning = "LossWarning"
+
+// toolName is the tool recorded in the LossWarning annotations
+const toolName = "protobom-convert"
+
+// Loss is a field of the source document that has no equivalent in the
+// target format. NodeID is blank when the field belongs to the metadata.
+type Loss struct {
+	NodeID  string
+	Mapping FieldMapping
+
+	// Value is the dropped value as found in the source document
+	Value string
+}
+
+// String returns the message of the LossWarning annotation of the loss
+func (l Loss) String() string {
+	return fmt.Sprintf("%s: %s has no equivalent in the target format", LossWarning, l.Mapping.Source)
+}
+
+// Option configures a conversion
+type Option func(*options)
+
+type options struct {
+	preserveIDs bool
+}
+
+// WithPreserveIDs makes the conversion keep the identifiers of the elements
+// of the source document where the target format allows it. When converting
+// SPDX to CycloneDX, the full SPDXIDs of the elements are used as the
+// bom-refs of the components. CycloneDX bom-refs are already kept as SPDXIDs
+// when converting the other way around, only prefixed with SPDXRef- as
+// required by SPDX. The IDMap of the report translates the identifiers in
+// any case.
+func WithPreserveIDs(preserve bool) Option {
+	return func(o *options) {
+		o.preserveIDs = preserve
+	}
+}
+
+// Convert converts doc, read from a document in the from format, into a
+// document as read back from the to format. The document is serialized into
+// the target format and parsed again, so the result only has the data that
+// the target format can carry.
+//
+// The fields of doc marked as lossy in the conversion map of the format
+// families are listed in the returned report and recorded as annotations
+// with a LossWarning comment in the returned document. Losses in nodes are
+// annotated in the node, if it is still present, and the rest in the
+// document metadata. Data of the source format not modelled by protobom
+// (such as CycloneDX services) is already lost when reading the source
+// document and cannot be reported.
+//
+// The IDMap of the report maps the identifiers of the elements in the source
+// format to their identifiers in the target format. Protobom is recorded in
+// the tools of the converted document.
+func Convert(doc *sbom.Document, from, to formats.Format, opts ...Option) (*sbom.Document, *ConversionReport, error) {
+	if doc == nil {
+		return nil, nil, fmt.Errorf("unable to convert, document is nil")
+	}
+
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cm, err := GetConversionMap(from, to)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting conversion map: %w", err)
+	}
+
+	losses, err := cm.Losses(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("checking conversion losses: %w", err)
+	}
+
+	// The document is validated when the converted document is written,
+	// here it is only serialized to read it back.
+	src, renamed := doc, map[string]string{}
+	if o.preserveIDs {
+		src, renamed = preserveIDs(doc, from, to)
+	}
+
+	var buf bytes.Buffer
+	if err := writer.New().WriteStreamWithOptions(
+		src, nopCloser{&buf}, &writer.Options{Format: to, Lenient: true},
+	); err != nil {
+		return nil, nil, fmt.Errorf("writing document as %s: %w", to, err)
+	}
+
+	converted, err := reader.New().ParseStreamWithOptions(
+		bytes.NewReader(buf.Bytes()), &reader.Options{Format: to},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading converted document: %w", err)
+	}
+
+	annotateLosses(converted, losses, renamed)
+	converted.AddTool("protobom", version.GetVersionInfo().GitVersion)
+	return converted, &ConversionReport{
+		From:   from,
+		To:     to,
+		Losses: losses,
+		IDMap:  idMap(doc, converted, renamed, from, to),
+	}, nil
+}
+
+// Losses returns the lossy fields of the conversion map that are set in
+// doc. Losses are returned in the order of the mappings, nodes and edges
+// of the document.
+func (cm *ConversionMap) Losses(doc *sbom.Document) ([]Loss, error) {
+	losses := []Loss{}
+	for _, fm := range cm.LossyFields() {
+		scope, name, ok := strings.Cut(fm.Field, ".")
+		if !ok {
+			return nil, fmt.Errorf("invalid field path %q", fm.Field)
+		}
+
+		switch scope {
+		case ScopeMetadata:
+			if doc.Metadata == nil {
+				continue
+			}
+			value, set, err := fieldValue(doc.Metadata.ProtoReflect(), name)
+			if err != nil {
+				return nil, err
+			}
+			if set {
+				losses = append(losses, Loss{Mapping: fm, Value: value})
+			}
+		case ScopeNode:
+			for _, n := range doc.GetNodeList().GetNodes() {
+				value, set, err := fieldValue(n.ProtoReflect(), name)
+				if err != nil {
+					return nil, err
+				}
+				if set {
+					losses = append(losses, Loss{NodeID: n.Id, Mapping: fm, Value: value})
+				}
+			}
+		case ScopeEdge:
+			t, ok := sbom.Edge_Type_value[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown edge type %q", name)
+			}
+			// Edge losses are reported once per node, the value lists
+			// all the related nodes
+			index := map[string]int{}
+			for _, e := range statedEdges(doc.GetNodeList().GetEdges()) {
+				if e.Type != sbom.Edge_Type(t) || len(e.To) == 0 {
+					continue
+				}
+				i, ok := index[e.From]
+				if !ok {
+					i = len(losses)
+					index[e.From] = i
+					losses = append(losses, Loss{NodeID: e.From, Mapping: fm})
+				}
+				to := strings.Join(e.To, ", ")
+				if losses[i].Value != "" {
+					to = losses[i].Value + ", " + to
+				}
+				losses[i].Value = to
+			}
+		default:
+			return nil, fmt.Errorf("unknown scope in field path %q", fm.Field)
+		}
+	}
+	return losses, nil
+}
+
+// statedEdges returns the edges in the direction they were stated in the
+// source document. Edges read from their target are turned back around so
+// their losses are reported as the relationship found in the document.
+func statedEdges(edges []*sbom.Edge) []*sbom.Edge {
+	ret := []*sbom.Edge{}
+	for _, e := range edges {
+		ret = append(ret, e.Stated()...)
+	}
+	return ret
+}
+
+// fieldValue returns the value of the field called name formatted as a
+// string and true if the message has it set. String values without actual
+// data (NOASSERTION and NONE) are not considered set as they carry nothing
+// to lose.
+func fieldValue(m protoreflect.Message, name string) (string, bool, error) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return "", false, fmt.Errorf("%s has no field %q", m.Descriptor().Name(), name)
+	}
+	if !m.Has(fd) {
+		return "", false, nil
+	}
+
+	v := m.Get(fd)
+	switch {
+	case fd.IsList():
+		values := []string{}
+		for i := 0; i < v.List().Len(); i++ {
+			if fd.Kind() == protoreflect.StringKind && !sbom.HasValue(v.List().Get(i).String()) {
+				continue
+			}
+			values = append(values, formatValue(fd, v.List().Get(i)))
+		}
+		return strings.Join(values, ", "), len(values) > 0, nil
+	case fd.IsMap():
+		values := []string{}
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			values = append(values, fmt.Sprintf("%s=%s", k.String(), formatValue(fd.MapValue(), mv)))
+			return true
+		})
+		sort.Strings(values)
+		return strings.Join(values, ", "), true, nil
+	case fd.Kind() == protoreflect.StringKind:
+		return v.String(), sbom.HasValue(v.String()), nil
+	default:
+		return formatValue(fd, v), true, nil
+	}
+}
+
+// formatValue returns a single value of a field formatted as a string
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return v.String()
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if ts, ok := v.Message().Interface().(*timestamppb.Timestamp); ok {
+			return ts.AsTime().UTC().Format(time.RFC3339)
+		}
+		return strings.TrimSpace(prototext.MarshalOptions{}.Format(v.Message().Interface()))
+	default:
+		return v.String()
+	}
+}
+
+// annotateLosses records the losses as annotations in the converted
+// document. renamed has the IDs of the nodes renamed in the conversion.
+func annotateLosses(doc *sbom.Document, losses []Loss, renamed map[string]string) {
+	if len(losses) == 0 {
+		return
+	}
+	date := timestamppb.New(time.Now().UTC())
+	for _, l := range losses {
+		a := &sbom.Annotation{
+			Tool:    &sbom.Tool{Name: toolName},
+			Date:    date,
+			Type:    sbom.Annotation_OTHER,
+			Comment: l.String(),
+		}
+		if l.NodeID != "" {
+			id := l.NodeID
+			if newID, ok := renamed[id]; ok {
+				id = newID
+			}
+			if n := doc.NodeList.GetNodeByID(id); n != nil {
+				n.Annotations = append(n.Annotations, a)
+				continue
+			}
+			a.Comment = fmt.Sprintf("%s (element %s)", a.Comment, l.NodeID)
+		}
+		doc.Metadata.Annotations = append(doc.Metadata.Annotations, a)
+	}
+}
+
+// nopCloser adds a noop Close method to a writer
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,733 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/bom-squad/protobom/pkg/reader"
+	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/bom-squad/protobom/pkg/writer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// normalize clears the data of a converted document that changes on each
+// conversion: dates, the random serial numbers of documents converted to
+// CycloneDX, the protobom tool recorded by the writers and the order of the
+// node list.
+func normalize(doc *sbom.Document) {
+	doc.Metadata.Date = nil
+	if strings.HasPrefix(doc.Metadata.Id, "urn:uuid:") {
+		doc.Metadata.Id = ""
+	}
+	tools := []*sbom.Tool{}
+	for _, t := range doc.Metadata.Tools {
+		if !strings.HasPrefix(t.Name, "protobom") {
+			tools = append(tools, t)
+		}
+	}
+	doc.Metadata.Tools = tools
+	for _, a := range doc.Metadata.Annotations {
+		a.Date = nil
+	}
+	for _, n := range doc.NodeList.Nodes {
+		for _, a := range n.Annotations {
+			a.Date = nil
+		}
+	}
+
+	// The order of nodes and edges written by the serializers is not stable
+	slices.SortFunc(doc.NodeList.Nodes, func(a, b *sbom.Node) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	for _, e := range doc.NodeList.Edges {
+		slices.Sort(e.To)
+	}
+	slices.SortFunc(doc.NodeList.Edges, func(a, b *sbom.Edge) int {
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		return int(a.Type - b.Type)
+	})
+	slices.Sort(doc.NodeList.RootElements)
+}
+
+// lossWarnings returns the LossWarning comments annotated in the node
+func lossWarnings(annotations []*sbom.Annotation) []string {
+	ret := []string{}
+	for _, a := range annotations {
+		if strings.HasPrefix(a.Comment, LossWarning) {
+			ret = append(ret, a.Comment)
+		}
+	}
+	return ret
+}
+
+func TestConvert(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		input     string
+		reference string
+		from      formats.Format
+		to        formats.Format
+		warnings  map[string][]string
+	}{
+		{
+			name:      "spdx to cyclonedx",
+			input:     "testdata/sample.spdx.json",
+			reference: "testdata/sample.spdx-to-cdx.json",
+			from:      formats.SPDX23JSON,
+			to:        formats.CDX15JSON,
+			warnings: map[string][]string{
+				"": {
+					"LossWarning: comment has no equivalent in the target format",
+				},
+				"Package-app": {
+					"LossWarning: relationships[GENERATED_FROM] has no equivalent in the target format",
+				},
+				// NOASSERTION download locations are not lost, the source
+				// information is carried in a component property
+				"Package-lib": {
+					"LossWarning: packages[].packageFileName has no equivalent in the target format",
+					"LossWarning: packages[].releaseDate has no equivalent in the target format",
+				},
+				"File-main": {
+					"LossWarning: files[].fileTypes has no equivalent in the target format",
+				},
+			},
+		},
+		{
+			name:      "cyclonedx to spdx",
+			input:     "testdata/sample.cdx.json",
+			reference: "testdata/sample.cdx-to-spdx.json",
+			from:      formats.CDX15JSON,
+			to:        formats.SPDX23JSON,
+			warnings: map[string][]string{
+				"": {
+					"LossWarning: metadata.lifecycles has no equivalent in the target format",
+				},
+				"app": {},
+				"lib": {},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := reader.New().ParseFile(tc.input)
+			require.NoError(t, err)
+
+			converted, report, err := Convert(doc, tc.from, tc.to)
+			require.NoError(t, err)
+			require.NotNil(t, report)
+
+			// The conversion is recorded once in the tools
+			protobomTools := 0
+			for _, tool := range converted.Metadata.Tools {
+				if tool.Name == "protobom" {
+					protobomTools++
+				}
+			}
+			require.Equal(t, 1, protobomTools)
+			normalize(converted)
+
+			for id, expected := range tc.warnings {
+				if id == "" {
+					require.Equal(t, expected, lossWarnings(converted.Metadata.Annotations))
+					continue
+				}
+				n := converted.NodeList.GetNodeByID(id)
+				require.NotNil(t, n, id)
+				require.Equal(t, expected, lossWarnings(n.Annotations), id)
+			}
+
+			// The report lists the same losses as the annotations
+			for id, expected := range tc.warnings {
+				messages := []string{}
+				for _, l := range report.LossesByNode(id) {
+					messages = append(messages, l.String())
+				}
+				require.Equal(t, expected, messages, id)
+			}
+
+			data, err := os.ReadFile(tc.reference)
+			require.NoError(t, err)
+			reference := &sbom.Document{}
+			require.NoError(t, protojson.Unmarshal(data, reference))
+			normalize(reference)
+			require.True(t, proto.Equal(reference, converted), "converted document does not match %s", tc.reference)
+		})
+	}
+}
+
+func TestConversionReport(t *testing.T) {
+	doc, err := reader.New().ParseFile("testdata/sample.spdx.json")
+	require.NoError(t, err)
+
+	_, report, err := Convert(doc, formats.SPDX23JSON, formats.CDX15JSON)
+	require.NoError(t, err)
+	require.False(t, report.Lossless())
+	require.Equal(t, formats.SPDX23JSON, report.From)
+	require.Equal(t, formats.CDX15JSON, report.To)
+
+	for _, tc := range []struct {
+		id      string
+		field   string
+		value   string
+		closest string
+	}{
+		{"Package-app", "edge.generatedFrom", "File-main", ""},
+		{"Package-lib", "node.file_name", "lib-2.1.0.tar.gz", "components[].properties"},
+		{"Package-lib", "node.release_date", "2023-01-01T00:00:00Z", "components[].properties"},
+		{"File-main", "node.file_types", "SOURCE", "components[].properties"},
+	} {
+		var loss *Loss
+		for _, l := range report.LossesByNode(tc.id) {
+			if l.Mapping.Field == tc.field {
+				loss = &l
+				break
+			}
+		}
+		require.NotNil(t, loss, "%s %s", tc.id, tc.field)
+		require.Equal(t, tc.value, loss.Value)
+		require.Equal(t, tc.closest, loss.Mapping.Closest)
+		require.NotEmpty(t, loss.Mapping.Reason)
+		require.Contains(t, report.String(), tc.value)
+	}
+
+	// Fields written as carrier properties are not lost
+	for _, l := range report.LossesByNode("Package-lib") {
+		require.NotEqual(t, "node.source_info", l.Mapping.Field)
+	}
+
+	// Conversions within a format family lose nothing
+	_, report, err = Convert(doc, formats.SPDX23JSON, formats.SPDX23JSON)
+	require.NoError(t, err)
+	require.True(t, report.Lossless())
+}
+
+func TestConvertErrors(t *testing.T) {
+	_, _, err := Convert(nil, formats.SPDX23JSON, formats.CDX15JSON)
+	require.Error(t, err)
+
+	_, _, err = Convert(sbom.NewDocument(), formats.Format("text/plain"), formats.CDX15JSON)
+	require.Error(t, err)
+
+	// SPDX tag-value has no serializer
+	_, _, err = Convert(sbom.NewDocument(), formats.CDX15JSON, formats.SPDX23TV)
+	require.Error(t, err)
+}
+
+func TestGetConversionMap(t *testing.T) {
+	cm, err := GetConversionMap(formats.CDX14JSON, formats.CDX15JSON)
+	require.NoError(t, err)
+	require.Empty(t, cm.LossyFields())
+
+	cm, err = GetConversionMap(formats.SPDX23JSON, formats.CDX15JSON)
+	require.NoError(t, err)
+	require.Equal(t, SPDXToCDX, cm)
+
+	cm, err = GetConversionMap(formats.CDX15JSON, formats.SPDX23TV)
+	require.NoError(t, err)
+	require.Equal(t, CDXToSPDX, cm)
+
+	_, err = GetConversionMap(formats.Format("text/plain"), formats.CDX15JSON)
+	require.Error(t, err)
+	_, err = GetConversionMap(formats.CDX15JSON, formats.Format("text/plain"))
+	require.Error(t, err)
+}
+
+func TestConversionMapFields(t *testing.T) {
+	// All the protobom field paths in the maps must be valid
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{Id: "node"})
+	for _, cm := range []*ConversionMap{CDXToSPDX, SPDXToCDX} {
+		fields := slices.Clone(cm.Fields)
+		for i := range fields {
+			fields[i].Target = ""
+		}
+		_, err := (&ConversionMap{Fields: fields}).Losses(doc)
+		require.NoError(t, err)
+	}
+
+	_, err := (&ConversionMap{Fields: []FieldMapping{{Source: "x", Field: "node.nope"}}}).Losses(doc)
+	require.Error(t, err)
+}
+
+func TestConvertPurposes(t *testing.T) {
+	cdxDoc := func(componentType string) string {
+		return `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"bom-ref": "root", "type": "application", "name": "root"}},
+  "components": [{"bom-ref": "comp", "type": "` + componentType + `", "name": "comp"}]
+}`
+	}
+
+	for _, tc := range []struct {
+		cdxType string
+		spdx    sbom.Purpose // purpose read from the SPDX document
+		cdx     sbom.Purpose // purpose read back from CycloneDX
+	}{
+		{"application", sbom.Purpose_APPLICATION, sbom.Purpose_APPLICATION},
+		{"framework", sbom.Purpose_FRAMEWORK, sbom.Purpose_FRAMEWORK},
+		{"library", sbom.Purpose_LIBRARY, sbom.Purpose_LIBRARY},
+		{"container", sbom.Purpose_CONTAINER, sbom.Purpose_CONTAINER},
+		{"operating-system", sbom.Purpose_OPERATING_SYSTEM, sbom.Purpose_OPERATING_SYSTEM},
+		{"device", sbom.Purpose_DEVICE, sbom.Purpose_DEVICE},
+		{"device-driver", sbom.Purpose_DEVICE, sbom.Purpose_DEVICE},
+		{"firmware", sbom.Purpose_FIRMWARE, sbom.Purpose_FIRMWARE},
+		{"platform", sbom.Purpose_OTHER, sbom.Purpose_DATA},
+		{"machine-learning-model", sbom.Purpose_OTHER, sbom.Purpose_DATA},
+		{"data", sbom.Purpose_OTHER, sbom.Purpose_DATA},
+		// Unknown types are not dropped, they are read as OTHER
+		{"quantum-thing", sbom.Purpose_OTHER, sbom.Purpose_DATA},
+	} {
+		t.Run(tc.cdxType, func(t *testing.T) {
+			doc, err := reader.New().ParseStreamWithOptions(
+				strings.NewReader(cdxDoc(tc.cdxType)), &reader.Options{Format: formats.CDX15JSON},
+			)
+			require.NoError(t, err)
+
+			spdxDoc, _, err := Convert(doc, formats.CDX15JSON, formats.SPDX23JSON)
+			require.NoError(t, err)
+			n := spdxDoc.NodeList.GetNodeByID("comp")
+			require.NotNil(t, n)
+			require.Equal(t, []sbom.Purpose{tc.spdx}, n.PrimaryPurpose)
+
+			cdxDoc, _, err := Convert(spdxDoc, formats.SPDX23JSON, formats.CDX15JSON)
+			require.NoError(t, err)
+			n = cdxDoc.NodeList.GetNodeByID("comp")
+			require.NotNil(t, n)
+			require.Equal(t, []sbom.Purpose{tc.cdx}, n.PrimaryPurpose)
+		})
+	}
+}
+
+func TestConvertNoAssertion(t *testing.T) {
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "noassertion",
+  "documentNamespace": "https://example.com/noassertion",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-Package-1",
+      "name": "one",
+      "downloadLocation": "NOASSERTION",
+      "filesAnalyzed": false,
+      "licenseConcluded": "NOASSERTION",
+      "copyrightText": "NOASSERTION"
+    },
+    {
+      "SPDXID": "SPDXRef-Package-2",
+      "name": "two",
+      "downloadLocation": "NONE",
+      "filesAnalyzed": false,
+      "licenseConcluded": "NONE",
+      "copyrightText": "NONE"
+    }
+  ],
+  "files": [
+    {
+      "SPDXID": "SPDXRef-File-1",
+      "fileName": "./one.txt",
+      "checksums": [{"algorithm": "SHA1", "checksumValue": "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"}],
+      "licenseConcluded": "NOASSERTION",
+      "licenseInfoInFiles": ["NOASSERTION", "NONE"],
+      "copyrightText": "NONE"
+    }
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-1"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Package-2"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-File-1"}
+  ]
+}`
+
+	doc, err := reader.New().ParseStreamWithOptions(
+		strings.NewReader(spdxDoc), &reader.Options{Format: formats.SPDX23JSON},
+	)
+	require.NoError(t, err)
+
+	// The model keeps the three states of the fields
+	one := doc.NodeList.GetNodeByID("Package-1")
+	require.Equal(t, sbom.NoAssertionValue, one.UrlDownload)
+	require.Equal(t, sbom.NoAssertionValue, one.LicenseConcluded)
+	require.Equal(t, sbom.NoAssertionValue, one.Copyright)
+	two := doc.NodeList.GetNodeByID("Package-2")
+	require.Equal(t, sbom.NoneValue, two.UrlDownload)
+	require.Equal(t, sbom.NoneValue, two.LicenseConcluded)
+	require.Equal(t, sbom.NoneValue, two.Copyright)
+
+	converted, report, err := Convert(doc, formats.SPDX23JSON, formats.CDX15JSON)
+	require.NoError(t, err)
+
+	// Fields without data are not reported as losses
+	require.True(t, report.Lossless(), report.String())
+
+	// The sentinels never make it to the CycloneDX output
+	var buf bytes.Buffer
+	require.NoError(t, writer.New().WriteStreamWithOptions(
+		converted, nopCloser{&buf}, &writer.Options{Format: formats.CDX15JSON},
+	))
+	require.NotContains(t, buf.String(), "NOASSERTION")
+	require.NotContains(t, buf.String(), "NONE")
+	for _, n := range converted.NodeList.Nodes {
+		require.False(t, n.Copyright != "" && !sbom.HasValue(n.Copyright), n.Id)
+		for _, l := range n.Licenses {
+			require.True(t, sbom.HasValue(l), n.Id)
+		}
+	}
+}
+
+func TestConvertSPDXPurposes(t *testing.T) {
+	// SPDX 2.3 package purposes and the CycloneDX component types they
+	// are written as
+	expected := map[string]string{
+		"APPLICATION":      "application",
+		"FRAMEWORK":        "framework",
+		"LIBRARY":          "library",
+		"CONTAINER":        "container",
+		"OPERATING-SYSTEM": "operating-system",
+		"DEVICE":           "device",
+		"FIRMWARE":         "firmware",
+		"SOURCE":           "file",
+		"ARCHIVE":          "file",
+		"FILE":             "file",
+		"INSTALL":          "application",
+		"OTHER":            "data",
+	}
+
+	packages := []string{
+		`{"SPDXID": "SPDXRef-root", "name": "root", "downloadLocation": "NOASSERTION", "filesAnalyzed": false}`,
+	}
+	relationships := []string{
+		`{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-root"}`,
+	}
+	for purpose := range expected {
+		packages = append(packages, fmt.Sprintf(
+			`{"SPDXID": "SPDXRef-%s", "name": %q, "downloadLocation": "NOASSERTION", "filesAnalyzed": false, "primaryPackagePurpose": %q}`,
+			purpose, purpose, purpose,
+		))
+		relationships = append(relationships, fmt.Sprintf(
+			`{"spdxElementId": "SPDXRef-root", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-%s"}`, purpose,
+		))
+	}
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "purposes",
+  "documentNamespace": "https://example.com/purposes",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [` + strings.Join(packages, ",\n") + `],
+  "relationships": [` + strings.Join(relationships, ",\n") + `]
+}`
+	doc, err := reader.New().ParseStreamWithOptions(
+		strings.NewReader(spdxDoc), &reader.Options{Format: formats.SPDX23JSON},
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writer.New().WriteStreamWithOptions(
+		doc, nopCloser{&buf}, &writer.Options{Format: formats.CDX15JSON},
+	))
+	cdxDoc := struct {
+		Components []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"components"`
+	}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &cdxDoc))
+
+	types := map[string]string{}
+	for _, c := range cdxDoc.Components {
+		types[c.Name] = c.Type
+	}
+	require.Equal(t, expected, types)
+}
+
+func TestConvertIDMap(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		input    string
+		from     formats.Format
+		to       formats.Format
+		opts     []Option
+		expected map[string]string
+	}{
+		{
+			name:  "spdx to cyclonedx",
+			input: "testdata/sample.spdx.json",
+			from:  formats.SPDX23JSON,
+			to:    formats.CDX15JSON,
+			expected: map[string]string{
+				"SPDXRef-Package-app": "Package-app",
+				"SPDXRef-Package-lib": "Package-lib",
+				"SPDXRef-File-main":   "File-main",
+			},
+		},
+		{
+			name:  "spdx to cyclonedx preserving ids",
+			input: "testdata/sample.spdx.json",
+			from:  formats.SPDX23JSON,
+			to:    formats.CDX15JSON,
+			opts:  []Option{WithPreserveIDs(true)},
+			expected: map[string]string{
+				"SPDXRef-Package-app": "SPDXRef-Package-app",
+				"SPDXRef-Package-lib": "SPDXRef-Package-lib",
+				"SPDXRef-File-main":   "SPDXRef-File-main",
+			},
+		},
+		{
+			name:     "cyclonedx to spdx",
+			input:    "testdata/sample.cdx.json",
+			from:     formats.CDX15JSON,
+			to:       formats.SPDX23JSON,
+			opts:     []Option{WithPreserveIDs(true)},
+			expected: map[string]string{"app": "SPDXRef-app", "lib": "SPDXRef-lib"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := reader.New().ParseFile(tc.input)
+			require.NoError(t, err)
+
+			converted, report, err := Convert(doc, tc.from, tc.to, tc.opts...)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, report.IDMap)
+
+			for _, id := range report.IDMap {
+				if tc.to.Type() == formats.SPDXFORMAT {
+					id = strings.TrimPrefix(id, "SPDXRef-")
+				}
+				require.NotNil(t, converted.NodeList.GetNodeByID(id), id)
+			}
+		})
+	}
+
+	// Losses are still annotated in the renamed nodes
+	doc, err := reader.New().ParseFile("testdata/sample.spdx.json")
+	require.NoError(t, err)
+	converted, report, err := Convert(doc, formats.SPDX23JSON, formats.CDX15JSON, WithPreserveIDs(true))
+	require.NoError(t, err)
+	require.NotEmpty(t, report.LossesByNode("Package-lib"))
+	require.Equal(t, len(report.LossesByNode("Package-lib")), len(converted.NodeList.GetNodeByID("SPDXRef-Package-lib").Annotations))
+}
+
+func TestConvertDownloadLocations(t *testing.T) {
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "locations",
+  "documentNamespace": "https://example.com/locations",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-Package-vcs",
+      "name": "vcs",
+      "downloadLocation": "git+https://github.com/bom-squad/protobom.git@v0.3.0",
+      "homepage": "https://github.com/bom-squad/protobom",
+      "filesAnalyzed": false
+    },
+    {
+      "SPDXID": "SPDXRef-Package-tarball",
+      "name": "tarball",
+      "downloadLocation": "https://example.com/tarball-1.0.0.tar.gz",
+      "homepage": "NONE",
+      "filesAnalyzed": false
+    },
+    {
+      "SPDXID": "SPDXRef-Package-noassertion",
+      "name": "noassertion",
+      "downloadLocation": "NOASSERTION",
+      "homepage": "NOASSERTION",
+      "filesAnalyzed": false
+    }
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-vcs"},
+    {"spdxElementId": "SPDXRef-Package-vcs", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Package-tarball"},
+    {"spdxElementId": "SPDXRef-Package-vcs", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Package-noassertion"}
+  ]
+}`
+
+	expected := map[string][2]string{
+		"Package-vcs":         {"git+https://github.com/bom-squad/protobom.git@v0.3.0", "https://github.com/bom-squad/protobom"},
+		"Package-tarball":     {"https://example.com/tarball-1.0.0.tar.gz", sbom.NoneValue},
+		"Package-noassertion": {sbom.NoAssertionValue, sbom.NoAssertionValue},
+	}
+
+	doc, err := reader.New().ParseStreamWithOptions(
+		strings.NewReader(spdxDoc), &reader.Options{Format: formats.SPDX23JSON},
+	)
+	require.NoError(t, err)
+	for id, urls := range expected {
+		n := doc.NodeList.GetNodeByID(id)
+		require.NotNil(t, n, id)
+		require.Equal(t, urls[0], n.UrlDownload, id)
+		require.Equal(t, urls[1], n.UrlHome, id)
+	}
+
+	// SPDX round trip keeps the fields and their sentinels
+	var buf bytes.Buffer
+	require.NoError(t, writer.New().WriteStreamWithOptions(
+		doc, nopCloser{&buf}, &writer.Options{Format: formats.SPDX23JSON},
+	))
+	spdxDoc2, err := reader.New().ParseStreamWithOptions(
+		bytes.NewReader(buf.Bytes()), &reader.Options{Format: formats.SPDX23JSON},
+	)
+	require.NoError(t, err)
+	for id, urls := range expected {
+		n := spdxDoc2.NodeList.GetNodeByID(id)
+		require.NotNil(t, n, id)
+		require.Equal(t, urls[0], n.UrlDownload, id)
+		require.Equal(t, urls[1], n.UrlHome, id)
+	}
+
+	// Repositories are written as vcs references and archives as
+	// distribution references, the sentinels are dropped
+	converted, _, err := Convert(doc, formats.SPDX23JSON, formats.CDX15JSON)
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, writer.New().WriteStreamWithOptions(
+		converted, nopCloser{&buf}, &writer.Options{Format: formats.CDX15JSON},
+	))
+	require.NotContains(t, buf.String(), "NOASSERTION")
+	require.NotContains(t, buf.String(), "NONE")
+
+	type cdxExternalReference struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	}
+	type cdxComponent struct {
+		Name               string                 `json:"name"`
+		ExternalReferences []cdxExternalReference `json:"externalReferences"`
+	}
+	cdxDoc := struct {
+		Metadata struct {
+			Component cdxComponent `json:"component"`
+		} `json:"metadata"`
+		Components []cdxComponent `json:"components"`
+	}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &cdxDoc))
+	refs := map[string][]cdxExternalReference{}
+	for _, c := range append(cdxDoc.Components, cdxDoc.Metadata.Component) {
+		refs[c.Name] = c.ExternalReferences
+	}
+	require.ElementsMatch(t, []cdxExternalReference{
+		{Type: "vcs", URL: "git+https://github.com/bom-squad/protobom.git@v0.3.0"},
+		{Type: "website", URL: "https://github.com/bom-squad/protobom"},
+	}, refs["vcs"])
+	require.ElementsMatch(t, []cdxExternalReference{
+		{Type: "distribution", URL: "https://example.com/tarball-1.0.0.tar.gz"},
+	}, refs["tarball"])
+	require.Empty(t, refs["noassertion"])
+
+	// And read back from CycloneDX into the download and home locations
+	cdxParsed, err := reader.New().ParseStreamWithOptions(
+		bytes.NewReader(buf.Bytes()), &reader.Options{Format: formats.CDX15JSON},
+	)
+	require.NoError(t, err)
+	back, _, err := Convert(cdxParsed, formats.CDX15JSON, formats.SPDX23JSON)
+	require.NoError(t, err)
+	locations := map[string][2]string{}
+	for _, n := range back.NodeList.Nodes {
+		locations[n.Name] = [2]string{n.UrlDownload, n.UrlHome}
+	}
+	require.Equal(t, expected["Package-vcs"], locations["vcs"])
+	require.Equal(t, "https://example.com/tarball-1.0.0.tar.gz", locations["tarball"][0])
+	require.False(t, sbom.HasValue(locations["tarball"][1]))
+	require.False(t, sbom.HasValue(locations["noassertion"][0]))
+	require.False(t, sbom.HasValue(locations["noassertion"][1]))
+}
+
+func TestConvertCarrierProperties(t *testing.T) {
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "carriers",
+  "documentNamespace": "https://example.com/carriers",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-Package-app",
+      "name": "app",
+      "downloadLocation": "NOASSERTION",
+      "filesAnalyzed": false
+    },
+    {
+      "SPDXID": "SPDXRef-Package-lib",
+      "name": "lib",
+      "versionInfo": "2.1.0",
+      "downloadLocation": "NOASSERTION",
+      "filesAnalyzed": false,
+      "sourceInfo": "Built from the upstream tag\nwith local patches",
+      "attributionTexts": ["This product includes software developed by Acme.", "Portions copyright The BOM Squad"]
+    }
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-app"},
+    {"spdxElementId": "SPDXRef-Package-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Package-lib"}
+  ]
+}`
+
+	doc, err := reader.New().ParseStreamWithOptions(
+		strings.NewReader(spdxDoc), &reader.Options{Format: formats.SPDX23JSON},
+	)
+	require.NoError(t, err)
+
+	// roundTrip converts the document to CycloneDX and back to SPDX through
+	// the writers and returns the lib package as read from the final SPDX
+	roundTrip := func(t *testing.T, opts ...writer.WriterOption) map[string]interface{} {
+		t.Helper()
+		converted, _, err := Convert(doc, formats.SPDX23JSON, formats.CDX15JSON)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, writer.New(append([]writer.WriterOption{writer.WithFormat(formats.CDX15JSON)}, opts...)...).
+			WriteStream(converted, nopCloser{&buf}))
+
+		cdxDoc, err := reader.New().ParseStreamWithOptions(
+			bytes.NewReader(buf.Bytes()), &reader.Options{Format: formats.CDX15JSON},
+		)
+		require.NoError(t, err)
+		back, _, err := Convert(cdxDoc, formats.CDX15JSON, formats.SPDX23JSON)
+		require.NoError(t, err)
+		buf.Reset()
+		require.NoError(t, writer.New(writer.WithFormat(formats.SPDX23JSON)).WriteStream(back, nopCloser{&buf}))
+
+		out := struct {
+			Packages []map[string]interface{} `json:"packages"`
+		}{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+		for _, p := range out.Packages {
+			if p["name"] == "lib" {
+				return p
+			}
+		}
+		require.Fail(t, "lib package not found")
+		return nil
+	}
+
+	// The carrier properties restore the fields
+	lib := roundTrip(t)
+	require.Equal(t, "Built from the upstream tag\nwith local patches", lib["sourceInfo"])
+	require.Equal(t, []interface{}{
+		"This product includes software developed by Acme.", "Portions copyright The BOM Squad",
+	}, lib["attributionTexts"])
+
+	// Without them the data is lost
+	lib = roundTrip(t, writer.WithOmitCarrierProperties(true))
+	require.NotContains(t, lib, "sourceInfo")
+	require.NotContains(t, lib, "attributionTexts")
+}
@@ -0,0 +1,90 @@
+package convert
+
+import (
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/bom-squad/protobom/pkg/sbom"
+	"google.golang.org/protobuf/proto"
+)
+
+// spdxIDPrefix is the prefix SPDX adds to the identifiers of the elements,
+// protobom reads the identifiers of SPDX elements without it.
+const spdxIDPrefix = "SPDXRef-"
+
+// elementID returns the identifier of the node in a document of format f
+func elementID(f formats.Format, nodeID string) string {
+	if f.Type() == formats.SPDXFORMAT {
+		return spdxIDPrefix + nodeID
+	}
+	return nodeID
+}
+
+// preserveIDs returns a copy of doc with its nodes renamed so that the
+// target format writes them with the same identifiers they have in the
+// source format, along with the new IDs of the renamed nodes. Only SPDX
+// identifiers need to be renamed when written as CycloneDX bom-refs, the
+// rest of the formats already keep them or, as with SPDX, require a prefix.
+func preserveIDs(doc *sbom.Document, from, to formats.Format) (*sbom.Document, map[string]string) {
+	renamed := map[string]string{}
+	if from.Type() != formats.SPDXFORMAT || to.Type() != formats.CDXFORMAT {
+		return doc, renamed
+	}
+
+	for _, n := range doc.GetNodeList().GetNodes() {
+		renamed[n.Id] = elementID(from, n.Id)
+	}
+
+	clone, ok := proto.Clone(doc).(*sbom.Document)
+	if !ok {
+		return doc, map[string]string{}
+	}
+	renameNodes(clone.NodeList, renamed)
+	return clone, renamed
+}
+
+// renameNodes changes the IDs of the nodes in the NodeList, and the edges
+// and root elements pointing to them, according to the renamed map
+func renameNodes(nl *sbom.NodeList, renamed map[string]string) {
+	rename := func(id string) string {
+		if newID, ok := renamed[id]; ok {
+			return newID
+		}
+		return id
+	}
+
+	for _, n := range nl.GetNodes() {
+		n.Id = rename(n.Id)
+	}
+	for _, e := range nl.GetEdges() {
+		e.From = rename(e.From)
+		for i := range e.To {
+			e.To[i] = rename(e.To[i])
+		}
+	}
+	for i := range nl.GetRootElements() {
+		nl.RootElements[i] = rename(nl.RootElements[i])
+	}
+}
+
+// idMap returns the identifiers of the elements of the converted document
+// indexed by the identifiers they had in the source document. Nodes that
+// are not found in the converted document, such as those with protobom
+// generated IDs that the target format does not keep, are not listed.
+func idMap(doc, converted *sbom.Document, renamed map[string]string, from, to formats.Format) map[string]string {
+	ret := map[string]string{}
+	index := map[string]struct{}{}
+	for _, n := range converted.GetNodeList().GetNodes() {
+		index[n.Id] = struct{}{}
+	}
+
+	for _, n := range doc.GetNodeList().GetNodes() {
+		id := n.Id
+		if newID, ok := renamed[id]; ok {
+			id = newID
+		}
+		if _, ok := index[id]; !ok {
+			continue
+		}
+		ret[elementID(from, n.Id)] = elementID(to, id)
+	}
+	return ret
+}
@@ -0,0 +1,198 @@
+package convert
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/bom-squad/protobom/pkg/sbom"
+)
+
+// Scopes of the protobom field paths in a FieldMapping
+const (
+	ScopeMetadata = "metadata"
+	ScopeNode     = "node"
+	ScopeEdge     = "edge"
+)
+
+// FieldMapping maps a field path of the source format to the field path
+// that holds the same data in the target format.
+type FieldMapping struct {
+	// Source is the path of the field in the source format
+	Source string
+
+	// Target is the path of the field in the target format. It is blank
+	// when the target format has no equivalent and the data is lost.
+	Target string
+
+	// Field is the protobom field holding the data. It is written as
+	// scope.name where scope is one of metadata or node followed by the
+	// name of the protobuf field, or edge followed by the edge type.
+	Field string
+
+	// Closest is the field of the target format closest to the source
+	// field when the data is lost, if the target format has one.
+	Closest string
+
+	// Reason explains why the data is lost in the conversion
+	Reason string
+}
+
+// Lossy returns true if the field has no equivalent in the target format
+func (fm FieldMapping) Lossy() bool {
+	return fm.Target == ""
+}
+
+// ConversionMap describes how the fields of a format family are mapped
+// when converting a document to another family.
+type ConversionMap struct {
+	From   string // Source format family
+	To     string // Target format family
+	Fields []FieldMapping
+}
+
+// LossyFields returns the mappings of the fields that have no equivalent in
+// the target format
+func (cm *ConversionMap) LossyFields() []FieldMapping {
+	ret := []FieldMapping{}
+	for _, fm := range cm.Fields {
+		if fm.Lossy() {
+			ret = append(ret, fm)
+		}
+	}
+	return ret
+}
+
+// CDXToSPDX maps the CycloneDX fields read by protobom to SPDX
+var CDXToSPDX = &ConversionMap{
+	From: formats.CDXFORMAT,
+	To:   formats.SPDXFORMAT,
+	Fields: []FieldMapping{
+		{Source: "metadata.timestamp", Target: "creationInfo.created", Field: "metadata.date"},
+		{Source: "metadata.authors", Target: "creationInfo.creators", Field: "metadata.authors"},
+		{Source: "metadata.tools", Target: "creationInfo.creators", Field: "metadata.tools"},
+		{Source: "metadata.lifecycles", Field: "metadata.documentTypes", Closest: "creationInfo.comment", Reason: "SPDX 2.3 documents have no lifecycle phases"},
+		{Source: "externalReferences", Field: "metadata.external_references", Closest: "externalDocumentRefs", Reason: "SPDX 2.3 documents only reference other SPDX documents"},
+		{Source: "compositions", Field: "metadata.compositions", Reason: "SPDX 2.3 documents cannot declare the completeness of their contents"},
+		{Source: "metadata.properties", Target: "annotations", Field: "metadata.properties"},
+		{Source: "components[].name", Target: "packages[].name", Field: "node.name"},
+		{Source: "components[].version", Target: "packages[].versionInfo", Field: "node.version"},
+		{Source: "components[].description", Target: "packages[].description", Field: "node.description"},
+		{Source: "components[].type", Target: "packages[].primaryPackagePurpose", Field: "node.primary_purpose"},
+		{Source: "components[].hashes", Target: "packages[].checksums", Field: "node.hashes"},
+		{Source: "components[].licenses", Target: "packages[].licenseDeclared", Field: "node.licenses"},
+		{Source: "components[].evidence.licenses", Field: "node.licenses_detected", Closest: "packages[].licenseInfoFromFiles", Reason: "SPDX packages only list the licenses found in their files when the files were analyzed"},
+		{Source: "components[].copyright", Target: "packages[].copyrightText", Field: "node.copyright"},
+		{Source: "components[].scope", Field: "node.scope", Reason: "SPDX packages have no scope"},
+		{Source: "components[].supplier", Target: "packages[].supplier", Field: "node.suppliers"},
+		{Source: "components[].author", Target: "packages[].originator", Field: "node.originators"},
+		{Source: "components[].purl", Target: "packages[].externalRefs", Field: "node.identifiers"},
+		{Source: "components[].externalReferences", Target: "packages[].externalRefs", Field: "node.external_references"},
+		{Source: "components[].externalReferences[distribution,vcs]", Target: "packages[].downloadLocation", Field: "node.url_download"},
+		{Source: "components[].externalReferences[website]", Target: "packages[].homepage", Field: "node.url_home"},
+		{Source: "components[].properties[protobom:attributionText]", Target: "packages[].attributionTexts", Field: "node.attribution"},
+		{Source: "components[].properties[protobom:spdx:sourceInfo]", Target: "packages[].sourceInfo", Field: "node.source_info"},
+		{Source: "components[].properties[protobom:spdx:licenseComments]", Target: "packages[].licenseComments", Field: "node.license_comments"},
+		{Source: "components[].properties", Target: "packages[].annotations", Field: "node.properties"},
+		{Source: "annotations", Target: "annotations", Field: "node.annotations"},
+		{Source: "components[].components", Target: "relationships[CONTAINS]", Field: "edge.contains"},
+		{Source: "dependencies", Target: "relationships[DEPENDS_ON]", Field: "edge.dependsOn"},
+	},
+}
+
+// SPDXToCDX maps the SPDX fields read by protobom to CycloneDX. Only the
+// CONTAINS and DEPENDS_ON relationships have a CycloneDX equivalent, the
+// mappings of the rest of the relationship types are added when the package
+// is initialized.
+var SPDXToCDX = &ConversionMap{
+	From: formats.SPDXFORMAT,
+	To:   formats.CDXFORMAT,
+	Fields: []FieldMapping{
+		{Source: "creationInfo.created", Target: "metadata.timestamp", Field: "metadata.date"},
+		{Source: "creationInfo.creators", Target: "metadata.authors", Field: "metadata.authors"},
+		{Source: "creationInfo.creators", Target: "metadata.tools", Field: "metadata.tools"},
+		{Source: "comment", Field: "metadata.comment", Closest: "metadata.properties", Reason: "the document comment is not written to CycloneDX metadata"},
+		{Source: "creationInfo.comment", Field: "metadata.creator_comment", Closest: "metadata.properties", Reason: "CycloneDX metadata has no comment about the document creation"},
+		{Source: "creationInfo.licenseListVersion", Field: "metadata.license_list_version", Closest: "metadata.properties", Reason: "CycloneDX does not record the version of the SPDX license list"},
+		{Source: "externalDocumentRefs", Field: "metadata.external_documents", Closest: "components[].externalReferences[bom]", Reason: "CycloneDX documents have no list of referenced documents"},
+		{Source: "packages[].name", Target: "components[].name", Field: "node.name"},
+		{Source: "packages[].versionInfo", Target: "components[].version", Field: "node.version"},
+		{Source: "packages[].description", Target: "components[].description", Field: "node.description"},
+		{Source: "packages[].primaryPackagePurpose", Target: "components[].type", Field: "node.primary_purpose"},
+		{Source: "packages[].checksums", Target: "components[].hashes", Field: "node.hashes"},
+		{Source: "packages[].licenseDeclared", Target: "components[].licenses", Field: "node.licenses"},
+		{Source: "packages[].copyrightText", Target: "components[].copyright", Field: "node.copyright"},
+		{Source: "packages[].supplier", Target: "components[].supplier", Field: "node.suppliers"},
+		{Source: "packages[].originator", Target: "components[].author", Field: "node.originators"},
+		{Source: "packages[].externalRefs", Target: "components[].externalReferences", Field: "node.external_references"},
+		{Source: "packages[].externalRefs[].comment", Field: "node.identifier_comments", Reason: "CycloneDX software identifiers have no comment"},
+		{Source: "packages[].annotations", Target: "annotations", Field: "node.annotations"},
+		{Source: "packages[].packageFileName", Field: "node.file_name", Closest: "components[].properties", Reason: "CycloneDX components have no file name"},
+		{Source: "packages[].homepage", Target: "components[].externalReferences[website]", Field: "node.url_home"},
+		{Source: "packages[].downloadLocation", Target: "components[].externalReferences[distribution,vcs]", Field: "node.url_download"},
+		{Source: "packages[].licenseConcluded", Target: "components[].licenses", Field: "node.license_concluded"},
+		{Source: "packages[].licenseInfoFromFiles", Target: "components[].evidence.licenses", Field: "node.licenses_detected"},
+		{Source: "packages[].licenseComments", Target: "components[].properties[protobom:spdx:licenseComments]", Field: "node.license_comments"},
+		{Source: "packages[].sourceInfo", Target: "components[].properties[protobom:spdx:sourceInfo]", Field: "node.source_info"},
+		{Source: "packages[].comment", Field: "node.comment", Closest: "components[].properties", Reason: "CycloneDX components have no comment"},
+		{Source: "packages[].summary", Field: "node.summary", Closest: "components[].description", Reason: "CycloneDX components only have a description"},
+		{Source: "packages[].attributionTexts", Target: "components[].properties[protobom:attributionText]", Field: "node.attribution"},
+		{Source: "packages[].releaseDate", Field: "node.release_date", Closest: "components[].properties", Reason: "CycloneDX components have no release date"},
+		{Source: "packages[].builtDate", Field: "node.build_date", Closest: "components[].properties", Reason: "CycloneDX components have no build date"},
+		{Source: "packages[].validUntilDate", Field: "node.valid_until_date", Closest: "components[].properties", Reason: "CycloneDX components have no expiration date"},
+		{Source: "packages[].packageVerificationCode", Field: "node.verification_code", Closest: "components[].hashes", Reason: "CycloneDX has no package verification code"},
+		{Source: "files[].attributionTexts", Target: "components[].properties[protobom:attributionText]", Field: "node.attribution"},
+		{Source: "files[].licenseInfoInFiles", Target: "components[].evidence.licenses", Field: "node.licenses_detected"},
+		{Source: "files[].fileTypes", Field: "node.file_types", Closest: "components[].properties", Reason: "CycloneDX file components have no file types"},
+		{Source: "snippets", Field: "node.snippets", Reason: "CycloneDX has no snippets"},
+		{Source: "relationships[CONTAINS]", Target: "components[].components", Field: "edge.contains"},
+		{Source: "relationships[CONTAINED_BY]", Target: "components[].components", Field: "edge.contained_by"},
+		{Source: "relationships[DEPENDS_ON]", Target: "dependencies", Field: "edge.dependsOn"},
+		{Source: "relationships[DEPENDENCY_OF]", Target: "dependencies", Field: "edge.dependencyOf"},
+	},
+}
+
+func init() {
+	// Relationships other than CONTAINS and DEPENDS_ON, and their inverses,
+	// are lost in CycloneDX
+	values := []int{}
+	for v := range sbom.Edge_Type_name {
+		values = append(values, int(v))
+	}
+	sort.Ints(values)
+	for _, v := range values {
+		t := sbom.Edge_Type(v)
+		if c, _ := t.Canonical(); t == sbom.Edge_UNKNOWN || c == sbom.Edge_contains || c == sbom.Edge_dependsOn {
+			continue
+		}
+		SPDXToCDX.Fields = append(SPDXToCDX.Fields, FieldMapping{
+			Source: fmt.Sprintf("relationships[%s]", t.ToSPDX2()),
+			Field:  fmt.Sprintf("%s.%s", ScopeEdge, t.String()),
+			Reason: "CycloneDX only has dependency and assembly relationships",
+		})
+	}
+}
+
+// GetConversionMap returns the conversion map between the families of the
+// from and to formats. Conversions between versions of the same family
+// return an empty map.
+func GetConversionMap(from, to formats.Format) (*ConversionMap, error) {
+	fromType, toType := from.Type(), to.Type()
+	if fromType == "" {
+		return nil, fmt.Errorf("unknown source format %q", from)
+	}
+	if toType == "" {
+		return nil, fmt.Errorf("unknown target format %q", to)
+	}
+
+	switch {
+	case fromType == toType:
+		return &ConversionMap{From: fromType, To: toType, Fields: []FieldMapping{}}, nil
+	case fromType == formats.CDXFORMAT && toType == formats.SPDXFORMAT:
+		return CDXToSPDX, nil
+	case fromType == formats.SPDXFORMAT && toType == formats.CDXFORMAT:
+		return SPDXToCDX, nil
+	default:
+		return nil, fmt.Errorf("no conversion map from %s to %s", fromType, toType)
+	}
+}
@@ -0,0 +1,114 @@
+package convert
+
+import (
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+)
+
+// Relationship types of CycloneDX. CycloneDX has no relationship vocabulary,
+// these name the structures of the CycloneDX documents that relate two
+// components, read from the point of view of the first component.
+const (
+	CDXDependsOn    = "DEPENDS_ON"    // dependencies[].dependsOn
+	CDXDependencyOf = "DEPENDENCY_OF" // inverse of dependencies[].dependsOn
+	CDXProvides     = "PROVIDES"      // dependencies[].provides
+	CDXContains     = "CONTAINS"      // components[].components
+	CDXContainedBy  = "CONTAINED_BY"  // inverse of components[].components
+	CDXDescribes    = "DESCRIBES"     // metadata.component
+	CDXAncestor     = "ANCESTOR"      // components[].pedigree.ancestors
+	CDXDescendant   = "DESCENDANT"    // components[].pedigree.descendants
+	CDXVariant      = "VARIANT"       // components[].pedigree.variants
+)
+
+// spdxDependencyManifestOf is missing from the SPDX go library constants
+const spdxDependencyManifestOf = "DEPENDENCY_MANIFEST_OF"
+
+// RelationshipMapping relates a CycloneDX relationship type to an SPDX 2.3
+// relationship type. Both relationships have the same direction.
+type RelationshipMapping struct {
+	CDX  string
+	SPDX string
+
+	// Exact is false when the types are only the closest equivalent
+	Exact bool
+}
+
+// RelationshipTypes is the mapping table between the CycloneDX and SPDX 2.3
+// relationship types. Every SPDX relationship type is listed once, types
+// without a CycloneDX equivalent have a blank CDX type. Each CycloneDX type
+// has exactly one exact mapping, except CDXProvides which has none.
+var RelationshipTypes = []RelationshipMapping{
+	{CDX: CDXDescribes, SPDX: common.TypeRelationshipDescribe, Exact: true},
+	{CDX: "", SPDX: common.TypeRelationshipDescribeBy},
+	{CDX: CDXContains, SPDX: common.TypeRelationshipContains, Exact: true},
+	{CDX: CDXContainedBy, SPDX: common.TypeRelationshipContainedBy, Exact: true},
+	{CDX: CDXDependsOn, SPDX: common.TypeRelationshipDependsOn, Exact: true},
+	{CDX: CDXDependencyOf, SPDX: common.TypeRelationshipDependencyOf, Exact: true},
+	{CDX: CDXDependencyOf, SPDX: spdxDependencyManifestOf},
+	{CDX: CDXDependencyOf, SPDX: common.TypeRelationshipBuildDependencyOf},
+	{CDX: CDXDependencyOf, SPDX: common.TypeRelationshipDevDependencyOf},
+	{CDX: CDXDependencyOf, SPDX: common.TypeRelationshipOptionalDependencyOf},
+	{CDX: CDXDependencyOf, SPDX: common.TypeRelationshipProvidedDependencyOf},
+	{CDX: CDXDependencyOf, SPDX: common.TypeRelationshipTestDependencyOf},
+	{CDX: CDXDependencyOf, SPDX: common.TypeRelationshipRuntimeDependencyOf},
+	{CDX: "", SPDX: common.TypeRelationshipExampleOf},
+	{CDX: "", SPDX: common.TypeRelationshipGenerates},
+	{CDX: "", SPDX: common.TypeRelationshipGeneratedFrom},
+	// A component listing an ancestor in its pedigree is a descendant of it
+	{CDX: CDXDescendant, SPDX: common.TypeRelationshipAncestorOf, Exact: true},
+	{CDX: CDXAncestor, SPDX: common.TypeRelationshipDescendantOf, Exact: true},
+	{CDX: CDXVariant, SPDX: common.TypeRelationshipVariantOf, Exact: true},
+	{CDX: "", SPDX: common.TypeRelationshipDistributionArtifact},
+	{CDX: "", SPDX: common.TypeRelationshipPatchFor},
+	{CDX: "", SPDX: common.TypeRelationshipPatchApplied},
+	{CDX: "", SPDX: common.TypeRelationshipCopyOf},
+	{CDX: "", SPDX: common.TypeRelationshipFileAdded},
+	{CDX: "", SPDX: common.TypeRelationshipFileDeleted},
+	{CDX: "", SPDX: common.TypeRelationshipFileModified},
+	{CDX: CDXContainedBy, SPDX: common.TypeRelationshipExpandedFromArchive},
+	{CDX: CDXDependsOn, SPDX: common.TypeRelationshipDynamicLink},
+	{CDX: CDXDependsOn, SPDX: common.TypeRelationshipStaticLink},
+	{CDX: "", SPDX: common.TypeRelationshipDataFileOf},
+	{CDX: "", SPDX: common.TypeRelationshipTestCaseOf},
+	{CDX: CDXDependencyOf, SPDX: common.TypeRelationshipBuildToolOf},
+	{CDX: CDXDependencyOf, SPDX: common.TypeRelationshipDevToolOf},
+	{CDX: "", SPDX: common.TypeRelationshipTestOf},
+	{CDX: CDXDependencyOf, SPDX: common.TypeRelationshipTestToolOf},
+	{CDX: "", SPDX: common.TypeRelationshipDocumentationOf},
+	{CDX: CDXContainedBy, SPDX: common.TypeRelationshipOptionalComponentOf},
+	{CDX: "", SPDX: common.TypeRelationshipMetafileOf},
+	{CDX: CDXContainedBy, SPDX: common.TypeRelationshipPackageOf},
+	{CDX: "", SPDX: common.TypeRelationshipAmends},
+	{CDX: CDXDependencyOf, SPDX: common.TypeRelationshipPrerequisiteFor},
+	{CDX: CDXDependsOn, SPDX: common.TypeRelationshipHasPrerequisite},
+	{CDX: "", SPDX: common.TypeRelationshipRequirementDescriptionFor},
+	{CDX: "", SPDX: common.TypeRelationshipSpecificationFor},
+	{CDX: "", SPDX: common.TypeRelationshipOther},
+}
+
+// MapRelationshipType returns the relationship type of the to format
+// equivalent to the relType relationship of the from format. The returned
+// bool is true when the mapping is exact. When it is not, the returned type
+// is the closest equivalent or blank if there is none; callers can fall back
+// to a generic type such as DYNAMIC_LINK or OTHER.
+func MapRelationshipType(from formats.Format, relType string, to formats.Format) (string, bool) {
+	fromType, toType := from.Type(), to.Type()
+	for _, rm := range RelationshipTypes {
+		switch {
+		case fromType == formats.CDXFORMAT && toType == formats.SPDXFORMAT:
+			if rm.CDX == relType && rm.Exact {
+				return rm.SPDX, true
+			}
+		case fromType == formats.SPDXFORMAT && toType == formats.CDXFORMAT:
+			if rm.SPDX == relType {
+				return rm.CDX, rm.Exact && rm.CDX != ""
+			}
+		case fromType != "" && fromType == toType:
+			if (fromType == formats.CDXFORMAT && rm.CDX == relType) ||
+				(fromType == formats.SPDXFORMAT && rm.SPDX == relType) {
+				return relType, true
+			}
+		}
+	}
+	return "", false
+}
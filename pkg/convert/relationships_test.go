@@ -0,0 +1,64 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelationshipTypesTable(t *testing.T) {
+	// Every SPDX type is listed once
+	seen := map[string]bool{}
+	for _, rm := range RelationshipTypes {
+		require.NotEmpty(t, rm.SPDX)
+		require.False(t, seen[rm.SPDX], "duplicate SPDX type %s", rm.SPDX)
+		seen[rm.SPDX] = true
+		if rm.Exact {
+			require.NotEmpty(t, rm.CDX, rm.SPDX)
+		}
+	}
+
+	// CycloneDX types map to SPDX and back without changes
+	for _, cdxType := range []string{
+		CDXDependsOn, CDXDependencyOf, CDXContains, CDXContainedBy,
+		CDXDescribes, CDXAncestor, CDXDescendant, CDXVariant,
+	} {
+		spdxType, exact := MapRelationshipType(formats.CDX15JSON, cdxType, formats.SPDX23JSON)
+		require.True(t, exact, cdxType)
+		back, exact := MapRelationshipType(formats.SPDX23JSON, spdxType, formats.CDX15JSON)
+		require.True(t, exact, cdxType)
+		require.Equal(t, cdxType, back)
+	}
+}
+
+func TestMapRelationshipType(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		from     formats.Format
+		relType  string
+		to       formats.Format
+		expected string
+		exact    bool
+	}{
+		{"cdx depends on", formats.CDX15JSON, CDXDependsOn, formats.SPDX23JSON, common.TypeRelationshipDependsOn, true},
+		{"cdx dependency of keeps direction", formats.CDX15JSON, CDXDependencyOf, formats.SPDX23JSON, common.TypeRelationshipDependencyOf, true},
+		{"cdx ancestor", formats.CDX14JSON, CDXAncestor, formats.SPDX23TV, common.TypeRelationshipDescendantOf, true},
+		{"cdx provides", formats.CDX15JSON, CDXProvides, formats.SPDX23JSON, "", false},
+		{"spdx dev dependency", formats.SPDX23JSON, common.TypeRelationshipDevDependencyOf, formats.CDX15JSON, CDXDependencyOf, false},
+		{"spdx static link", formats.SPDX23JSON, common.TypeRelationshipStaticLink, formats.CDX15JSON, CDXDependsOn, false},
+		{"spdx contains", formats.SPDX23TV, common.TypeRelationshipContains, formats.CDX15JSON, CDXContains, true},
+		{"spdx no equivalent", formats.SPDX23JSON, common.TypeRelationshipAmends, formats.CDX15JSON, "", false},
+		{"same family", formats.SPDX23JSON, common.TypeRelationshipAmends, formats.SPDX23TV, common.TypeRelationshipAmends, true},
+		{"unknown type", formats.SPDX23JSON, "LIKES", formats.CDX15JSON, "", false},
+		{"unknown type same family", formats.CDX15JSON, "LIKES", formats.CDX14JSON, "", false},
+		{"unknown format", formats.Format("text/plain"), CDXDependsOn, formats.SPDX23JSON, "", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, exact := MapRelationshipType(tc.from, tc.relType, tc.to)
+			require.Equal(t, tc.expected, res)
+			require.Equal(t, tc.exact, exact)
+		})
+	}
+}
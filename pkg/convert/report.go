@@ -0,0 +1,57 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bom-squad/protobom/pkg/formats"
+)
+
+// ConversionReport details the data lost when converting a document from
+// one format to another. Callers can inspect it to decide if a conversion
+// meets their fidelity requirements before persisting its output.
+type ConversionReport struct {
+	From   formats.Format
+	To     formats.Format
+	Losses []Loss
+
+	// IDMap maps the identifiers of the elements in the source format to
+	// the identifiers of the same elements in the target format
+	IDMap map[string]string
+}
+
+// Lossless returns true if no data was lost in the conversion
+func (r *ConversionReport) Lossless() bool {
+	return len(r.Losses) == 0
+}
+
+// LossesByNode returns the losses of the node with the specified ID. Use a
+// blank ID to get the losses of the document metadata.
+func (r *ConversionReport) LossesByNode(id string) []Loss {
+	ret := []Loss{}
+	for _, l := range r.Losses {
+		if l.NodeID == id {
+			ret = append(ret, l)
+		}
+	}
+	return ret
+}
+
+// String returns a human readable summary of the report with one line per
+// lost field
+func (r *ConversionReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "conversion from %s to %s: %d fields lost\n", r.From, r.To, len(r.Losses))
+	for _, l := range r.Losses {
+		element := "document"
+		if l.NodeID != "" {
+			element = l.NodeID
+		}
+		fmt.Fprintf(&sb, "  %s %s %q: %s", element, l.Mapping.Source, l.Value, l.Mapping.Reason)
+		if l.Mapping.Closest != "" {
+			fmt.Fprintf(&sb, " (closest field: %s)", l.Mapping.Closest)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
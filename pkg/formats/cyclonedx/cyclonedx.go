@@ -7,6 +7,24 @@ import (
 	"github.com/bom-squad/protobom/pkg/formats"
 )
 
+// PropertyAttributionText is the name of the component properties that hold
+// the attribution texts of a node. CycloneDX has no field for the notices an
+// SPDX package or file asks to reproduce, so each of them is written as a
+// property with this name.
+const PropertyAttributionText = "protobom:attributionText"
+
+// Properties carrying the SPDX fields that have no equivalent in CycloneDX.
+// They are written to the components so the data is restored when the
+// document is converted back to SPDX.
+const (
+	// PropertySourceInfo holds the source information of a package
+	PropertySourceInfo = "protobom:spdx:sourceInfo"
+
+	// PropertyLicenseComments holds the comments about the licenses of a
+	// package or file
+	PropertyLicenseComments = "protobom:spdx:licenseComments"
+)
+
 func ParseVersion(version string) (cyclonedx.SpecVersion, error) {
 	var specVersion cyclonedx.SpecVersion
 	switch version {
@@ -4,6 +4,9 @@
 package formats
 
 import (
+	"errors"
+	"fmt"
+	"mime"
 	"strings"
 )
 
@@ -13,6 +16,7 @@ const (
 	JSON       = "json"
 	XML        = "xml"
 	TEXT       = "text"
+	YAML       = "yaml"
 	SPDX23TV   = Format("text/spdx+text;version=2.3")
 	SPDX23JSON = Format("text/spdx+json;version=2.3")
 	SPDX22TV   = Format("text/spdx+text;version=2.2")
@@ -23,8 +27,19 @@ const (
 	CDX13JSON  = Format("application/vnd.cyclonedx+json;version=1.3")
 	CDX14JSON  = Format("application/vnd.cyclonedx+json;version=1.4")
 	CDX15JSON  = Format("application/vnd.cyclonedx+json;version=1.5")
+	CDX14XML   = Format("application/vnd.cyclonedx+xml;version=1.4")
+	CDX15XML   = Format("application/vnd.cyclonedx+xml;version=1.5")
+	CDX10YAML  = Format("application/vnd.cyclonedx+yaml;version=1.0")
+	CDX11YAML  = Format("application/vnd.cyclonedx+yaml;version=1.1")
+	CDX12YAML  = Format("application/vnd.cyclonedx+yaml;version=1.2")
+	CDX13YAML  = Format("application/vnd.cyclonedx+yaml;version=1.3")
+	CDX14YAML  = Format("application/vnd.cyclonedx+yaml;version=1.4")
+	CDX15YAML  = Format("application/vnd.cyclonedx+yaml;version=1.5")
 	CDXFORMAT  = "cyclonedx"
 	SPDXFORMAT = "spdx"
+
+	// EmptyVersion is the version of the formats returned without version
+	EmptyVersion = ""
 )
 
 type Document interface{}
@@ -34,6 +49,148 @@ var (
 	List        = []Format{SPDX23TV, SPDX23JSON, SPDX22TV, SPDX22JSON, CDX14JSON, CDX15JSON}
 )
 
+var (
+	// ErrUnknownMediaType is returned when a media type does not match any
+	// of the known formats
+	ErrUnknownMediaType = errors.New("unknown media type")
+
+	// ErrVersionNotDetermined is returned along with a format when the media
+	// type or file extension is known but does not encode the version of
+	// the format
+	ErrVersionNotDetermined = errors.New("format version could not be determined")
+
+	// ErrUnknownExtension is returned when a file extension does not tell
+	// the format of the document, either because it is unknown or because
+	// it is shared by several formats, like .json
+	ErrUnknownExtension = errors.New("format cannot be determined from the file extension")
+)
+
+// knownFormats are all the format constants, used to look them up by media type
+var knownFormats = []Format{
+	SPDX23TV, SPDX23JSON, SPDX22TV, SPDX22JSON,
+	CDX10JSON, CDX11JSON, CDX12JSON, CDX13JSON, CDX14JSON, CDX15JSON,
+	CDX14XML, CDX15XML,
+	CDX10YAML, CDX11YAML, CDX12YAML, CDX13YAML, CDX14YAML, CDX15YAML,
+}
+
+// mediaTypeAliases maps the media types used for SBOMs to the media type of
+// the format constants. Generic types map to themselves, they only tell the
+// encoding of the document.
+var mediaTypeAliases = map[string]string{
+	"application/vnd.cyclonedx+json": "application/vnd.cyclonedx+json",
+	"application/vnd.cyclonedx+xml":  "application/vnd.cyclonedx+xml",
+	"application/vnd.cyclonedx+yaml": "application/vnd.cyclonedx+yaml",
+	"text/spdx+json":                 "text/spdx+json",
+	"application/spdx+json":          "text/spdx+json",
+	"text/spdx+text":                 "text/spdx+text",
+	"text/spdx":                      "text/spdx+text",
+	"application/json":               "application/json",
+	"text/json":                      "application/json",
+	"application/xml":                "application/xml",
+	"text/xml":                       "application/xml",
+	"text/plain":                     "text/plain",
+}
+
+// registeredMediaTypes maps the media types of the format constants to the
+// media types registered for them when they differ
+var registeredMediaTypes = map[string]string{
+	"text/spdx+json": "application/spdx+json",
+	"text/spdx+text": "text/spdx",
+}
+
+// fileExtensions maps the extensions commonly used for SBOM files to the
+// format without version. Compound extensions come first so they are checked
+// before any shorter extension they end with.
+var fileExtensions = []struct {
+	ext    string
+	format Format
+}{
+	{".cdx.json", "application/vnd.cyclonedx+json"},
+	{".bom.json", "application/vnd.cyclonedx+json"},
+	{".cyclonedx.json", "application/vnd.cyclonedx+json"},
+	{".cdx.xml", "application/vnd.cyclonedx+xml"},
+	{".bom.xml", "application/vnd.cyclonedx+xml"},
+	{".cyclonedx.xml", "application/vnd.cyclonedx+xml"},
+	{".spdx.json", "text/spdx+json"},
+	{".spdx", "text/spdx+text"},
+}
+
+// FormatFromFileExtension returns the format of an SBOM file from its
+// extension, such as .cdx.json or .spdx. The extension can be passed with or
+// without the leading dot, or as part of the whole file name. As extensions
+// do not encode the version of the format, the format is returned with
+// EmptyVersion along with ErrVersionNotDetermined.
+//
+// Extensions that do not point to a single format, like .json or .xml,
+// return ErrUnknownExtension. Those files need to be sniffed.
+func FormatFromFileExtension(ext string) (Format, error) {
+	name := strings.ToLower(strings.TrimSpace(ext))
+	if name != "" && !strings.HasPrefix(name, ".") {
+		name = "." + name
+	}
+	for _, e := range fileExtensions {
+		if strings.HasSuffix(name, e.ext) {
+			return e.format, ErrVersionNotDetermined
+		}
+	}
+	return EmptyFormat, fmt.Errorf("%w: %q", ErrUnknownExtension, ext)
+}
+
+// FormatFromMediaType returns the format of a media type, as found in HTTP
+// Content-Type headers. Parameters other than the version are ignored.
+//
+// When the media type is known but does not include a version, for example
+// application/vnd.cyclonedx+json or a generic application/json, the format is
+// returned without version along with ErrVersionNotDetermined. Callers can
+// check it with errors.Is and sniff the document to find out the rest.
+func FormatFromMediaType(mediaType string) (Format, error) {
+	base, params, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return EmptyFormat, fmt.Errorf("%w: parsing %q: %w", ErrUnknownMediaType, mediaType, err)
+	}
+
+	canonical, ok := mediaTypeAliases[base]
+	if !ok {
+		return EmptyFormat, fmt.Errorf("%w: %s", ErrUnknownMediaType, base)
+	}
+
+	version := strings.TrimSpace(params["version"])
+	if version == "" {
+		return Format(canonical), ErrVersionNotDetermined
+	}
+
+	f := Format(canonical + ";version=" + version)
+	for _, known := range knownFormats {
+		if f == known {
+			return f, nil
+		}
+	}
+	return EmptyFormat, fmt.Errorf("%w: %s version %s", ErrUnknownMediaType, base, version)
+}
+
+// MediaType returns the media type of the format to use in HTTP Content-Type
+// headers, for example application/vnd.cyclonedx+json; version=1.5. SPDX
+// formats use their registered media types (application/spdx+json and
+// text/spdx). The version parameter is only included when the format has a
+// version. Returns an empty string if the format is not known.
+func (f Format) MediaType() string {
+	base, params, err := mime.ParseMediaType(string(f))
+	if err != nil {
+		return ""
+	}
+	if _, ok := mediaTypeAliases[base]; !ok {
+		return ""
+	}
+	if registered, ok := registeredMediaTypes[base]; ok {
+		base = registered
+	}
+	version := params["version"]
+	if version == "" {
+		return base
+	}
+	return mime.FormatMediaType(base, map[string]string{"version": version})
+}
+
 // Version returns the version of the format
 func (f *Format) Version() string {
 	parts := strings.Split(string(*f), ";version=")
@@ -77,8 +234,12 @@ func (f Format) Encoding() string {
 	switch {
 	case strings.Contains(string(f), JSON):
 		return JSON
+	case strings.Contains(string(f), XML):
+		return XML
 	case strings.Contains(string(f), TEXT):
 		return TEXT
+	case strings.Contains(string(f), YAML):
+		return YAML
 	default:
 		return ""
 	}
@@ -93,3 +254,13 @@ func (f *Format) Type() string {
 	}
 	return ""
 }
+
+// Compatible returns true when the format and other only differ in their
+// version, for example CDX14JSON and CDX15JSON. Formats of different families
+// or encodings are not compatible, neither are unknown formats.
+func (f Format) Compatible(other Format) bool {
+	if f.Type() == "" || f.Encoding() == "" {
+		return false
+	}
+	return f.Type() == other.Type() && f.Encoding() == other.Encoding()
+}
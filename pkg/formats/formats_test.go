@@ -0,0 +1,145 @@
+package formats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompatible(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     Format
+		expected bool
+	}{
+		{CDX14JSON, CDX15JSON, true},
+		{CDX15JSON, CDX10JSON, true},
+		{CDX15JSON, CDX15JSON, true},
+		{SPDX22JSON, SPDX23JSON, true},
+		{SPDX22TV, SPDX23TV, true},
+		{SPDX23JSON, SPDX23TV, false},
+		{SPDX23JSON, CDX15JSON, false},
+		{CDX14JSON, SPDX22JSON, false},
+		{CDX14XML, CDX15XML, true},
+		{CDX15XML, CDX15JSON, false},
+		{CDX15YAML, CDX15JSON, false},
+		{Format("text/plain"), Format("text/plain"), false},
+		{Format(""), CDX15JSON, false},
+	} {
+		require.Equal(t, tc.expected, tc.a.Compatible(tc.b), "%s %s", tc.a, tc.b)
+		require.Equal(t, tc.expected, tc.b.Compatible(tc.a), "%s %s", tc.b, tc.a)
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	for f, expected := range map[Format]string{
+		CDX15JSON:            JSON,
+		CDX14XML:             XML,
+		CDX15XML:             XML,
+		CDX15YAML:            YAML,
+		SPDX23JSON:           JSON,
+		SPDX23TV:             TEXT,
+		Format("text/plain"): TEXT,
+		Format("unknown"):    "",
+	} {
+		require.Equal(t, expected, f.Encoding(), string(f))
+	}
+}
+
+func TestFormatFromMediaType(t *testing.T) {
+	for _, tc := range []struct {
+		mediaType string
+		expected  Format
+		err       error
+	}{
+		{"application/vnd.cyclonedx+json;version=1.5", CDX15JSON, nil},
+		{"application/vnd.cyclonedx+json; version=1.4; charset=utf-8", CDX14JSON, nil},
+		{"Application/VND.CycloneDX+XML; Version=1.5", CDX15XML, nil},
+		{"text/spdx+json;version=2.3", SPDX23JSON, nil},
+		{"application/spdx+json; version=2.2", SPDX22JSON, nil},
+		{"text/spdx; version=2.3", SPDX23TV, nil},
+		{"application/vnd.cyclonedx+json", Format("application/vnd.cyclonedx+json"), ErrVersionNotDetermined},
+		{"application/spdx+json; charset=utf-8", Format("text/spdx+json"), ErrVersionNotDetermined},
+		{"application/json", Format("application/json"), ErrVersionNotDetermined},
+		{"text/xml; charset=utf-8", Format("application/xml"), ErrVersionNotDetermined},
+		{"application/vnd.cyclonedx+json;version=9.9", EmptyFormat, ErrUnknownMediaType},
+		{"image/png", EmptyFormat, ErrUnknownMediaType},
+		{"", EmptyFormat, ErrUnknownMediaType},
+	} {
+		f, err := FormatFromMediaType(tc.mediaType)
+		require.Equal(t, tc.expected, f, tc.mediaType)
+		if tc.err == nil {
+			require.NoError(t, err, tc.mediaType)
+			continue
+		}
+		require.ErrorIs(t, err, tc.err, tc.mediaType)
+	}
+
+	// Formats without version still tell the family and encoding
+	f, err := FormatFromMediaType("application/vnd.cyclonedx+xml")
+	require.ErrorIs(t, err, ErrVersionNotDetermined)
+	require.Equal(t, CDXFORMAT, f.Type())
+	require.Equal(t, XML, f.Encoding())
+	require.Empty(t, f.Version())
+}
+
+func TestMediaType(t *testing.T) {
+	mediaTypes := map[Format]string{
+		SPDX23TV:   "text/spdx; version=2.3",
+		SPDX23JSON: "application/spdx+json; version=2.3",
+		SPDX22TV:   "text/spdx; version=2.2",
+		SPDX22JSON: "application/spdx+json; version=2.2",
+		CDX10JSON:  "application/vnd.cyclonedx+json; version=1.0",
+		CDX11JSON:  "application/vnd.cyclonedx+json; version=1.1",
+		CDX12JSON:  "application/vnd.cyclonedx+json; version=1.2",
+		CDX13JSON:  "application/vnd.cyclonedx+json; version=1.3",
+		CDX14JSON:  "application/vnd.cyclonedx+json; version=1.4",
+		CDX15JSON:  "application/vnd.cyclonedx+json; version=1.5",
+		CDX14XML:   "application/vnd.cyclonedx+xml; version=1.4",
+		CDX15XML:   "application/vnd.cyclonedx+xml; version=1.5",
+		CDX10YAML:  "application/vnd.cyclonedx+yaml; version=1.0",
+		CDX11YAML:  "application/vnd.cyclonedx+yaml; version=1.1",
+		CDX12YAML:  "application/vnd.cyclonedx+yaml; version=1.2",
+		CDX13YAML:  "application/vnd.cyclonedx+yaml; version=1.3",
+		CDX14YAML:  "application/vnd.cyclonedx+yaml; version=1.4",
+		CDX15YAML:  "application/vnd.cyclonedx+yaml; version=1.5",
+	}
+	for _, f := range knownFormats {
+		expected, ok := mediaTypes[f]
+		require.True(t, ok, "missing media type of %s", f)
+		require.Equal(t, expected, f.MediaType(), string(f))
+		parsed, err := FormatFromMediaType(f.MediaType())
+		require.NoError(t, err)
+		require.Equal(t, f, parsed)
+	}
+
+	require.Equal(t, "application/vnd.cyclonedx+json", Format("application/vnd.cyclonedx+json").MediaType())
+	require.Equal(t, "application/spdx+json", Format("text/spdx+json").MediaType())
+	require.Empty(t, Format(CDXFORMAT).MediaType())
+	require.Empty(t, EmptyFormat.MediaType())
+}
+
+func TestFormatFromFileExtension(t *testing.T) {
+	for ext, expected := range map[string]Format{
+		".cdx.json":           "application/vnd.cyclonedx+json",
+		"cdx.json":            "application/vnd.cyclonedx+json",
+		"sbom.bom.json":       "application/vnd.cyclonedx+json",
+		"image.cyclonedx.xml": "application/vnd.cyclonedx+xml",
+		".CDX.XML":            "application/vnd.cyclonedx+xml",
+		".spdx.json":          "text/spdx+json",
+		"release.spdx.json":   "text/spdx+json",
+		".spdx":               "text/spdx+text",
+		"spdx":                "text/spdx+text",
+	} {
+		f, err := FormatFromFileExtension(ext)
+		require.ErrorIs(t, err, ErrVersionNotDetermined, ext)
+		require.Equal(t, expected, f, ext)
+		require.Equal(t, EmptyVersion, f.Version(), ext)
+	}
+
+	// Ambiguous and unknown extensions need sniffing
+	for _, ext := range []string{".json", ".xml", "sbom.txt", ".spdx.rdf", ""} {
+		f, err := FormatFromFileExtension(ext)
+		require.ErrorIs(t, err, ErrUnknownExtension, ext)
+		require.Equal(t, EmptyFormat, f, ext)
+	}
+}
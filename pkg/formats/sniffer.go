@@ -2,10 +2,14 @@ package formats
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"regexp"
 	"strings"
 )
 
@@ -18,6 +22,7 @@ const (
 
 var sniffFormats = []sniffFormat{
 	cdxSniff{},
+	cdxYAMLSniff{},
 	spdxSniff{},
 }
 
@@ -38,6 +43,42 @@ func (fs *Sniffer) SniffFile(path string) (Format, error) {
 	return fs.SniffReader(f)
 }
 
+// SniffHTTPResponse returns the format of the SBOM in an HTTP response. The
+// Content-Type header is checked first, when it has the full format the body
+// is not read. Otherwise, as with generic types like application/json or
+// types without version, the body is sniffed. The body is read whole and
+// replaced with a seekable reader positioned at its beginning, ready to be
+// parsed.
+func (fs *Sniffer) SniffHTTPResponse(resp *http.Response) (Format, error) {
+	if resp == nil {
+		return EmptyFormat, errors.New("response is nil")
+	}
+	if format, err := FormatFromMediaType(resp.Header.Get("Content-Type")); err == nil {
+		return format, nil
+	}
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return EmptyFormat, errors.New("unknown SBOM format, response has no body")
+	}
+
+	data, err := io.ReadAll(bufio.NewReader(resp.Body))
+	resp.Body.Close()
+	if err != nil {
+		return EmptyFormat, fmt.Errorf("reading response body: %w", err)
+	}
+	body := &responseBody{bytes.NewReader(data)}
+	resp.Body = body
+
+	return fs.SniffReader(body)
+}
+
+// responseBody replaces the body of the sniffed responses with a reader
+// that can seek
+type responseBody struct {
+	*bytes.Reader
+}
+
+func (responseBody) Close() error { return nil }
+
 // SniffReader reads a stream and return the SBOM format
 func (fs *Sniffer) SniffReader(f io.ReadSeeker) (Format, error) {
 	defer func() {
@@ -147,6 +188,33 @@ func (c cdxSniff) sniff(data []byte) Format {
 	return EmptyFormat
 }
 
+// cdxYAMLSniff recognizes CycloneDX documents encoded as YAML. The format
+// is not part of the spec but some tools emit it. As YAML is a superset of
+// JSON, this only runs for documents that failed to parse as JSON.
+type cdxYAMLSniff struct{}
+
+var (
+	yamlBomFormatRegex   = regexp.MustCompile(`^bomFormat:\s*["']?(?i:cyclonedx)["']?\s*(#.*)?$`)
+	yamlSpecVersionRegex = regexp.MustCompile(`^specVersion:\s*["']?(\d+\.\d+)["']?\s*(#.*)?$`)
+)
+
+func (c cdxYAMLSniff) sniff(data []byte) Format {
+	state := getSniffState(CDXFORMAT)
+
+	// Only the keys at the top level of the document are checked
+	line := strings.TrimRight(string(data), " \t\r")
+	if yamlBomFormatRegex.MatchString(line) {
+		state.Type = "application/vnd.cyclonedx"
+		state.Encoding = YAML
+	}
+	if m := yamlSpecVersionRegex.FindStringSubmatch(line); m != nil {
+		state.Version = m[1]
+	}
+
+	setSniffState(CDXFORMAT, state)
+	return state.Format()
+}
+
 type spdxSniff struct{}
 
 func (c spdxSniff) sniff(data []byte) Format {
@@ -1,7 +1,11 @@
 package formats
 
 import (
+	"bytes"
+	"io"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -58,6 +62,13 @@ func TestSniffReader(t *testing.T) {
 			formatType: "cyclonedx",
 			encoding:   "json",
 		},
+		{
+			filename:   "testdata/minimal.cdx.yaml",
+			mustError:  false,
+			version:    "1.5",
+			formatType: "cyclonedx",
+			encoding:   "yaml",
+		},
 		{
 			filename:  "testdata/syft.json",
 			mustError: true,
@@ -103,3 +114,81 @@ func TestSniffFile(t *testing.T) {
 		}
 	}
 }
+
+// failingBody fails the test if the response body is read
+type failingBody struct {
+	t *testing.T
+}
+
+func (b failingBody) Read([]byte) (int, error) {
+	b.t.Fatal("response body was read")
+	return 0, io.EOF
+}
+
+func (failingBody) Close() error { return nil }
+
+func TestSniffHTTPResponse(t *testing.T) {
+	fs := Sniffer{}
+	response := func(contentType string, body io.ReadCloser) *http.Response {
+		resp := &http.Response{Header: http.Header{}, Body: body}
+		if contentType != "" {
+			resp.Header.Set("Content-Type", contentType)
+		}
+		return resp
+	}
+
+	// Full media types are returned without reading the body
+	format, err := fs.SniffHTTPResponse(response("application/vnd.cyclonedx+json; version=1.5", failingBody{t}))
+	require.NoError(t, err)
+	require.Equal(t, CDX15JSON, format)
+
+	// Otherwise the body is sniffed and left ready to read again
+	data, err := os.ReadFile("testdata/nginx.spdx.json")
+	require.NoError(t, err)
+	for _, contentType := range []string{"application/json", "application/spdx+json", "application/octet-stream", ""} {
+		resp := response(contentType, io.NopCloser(bytes.NewReader(data)))
+		format, err := fs.SniffHTTPResponse(resp)
+		require.NoError(t, err, contentType)
+		require.Equal(t, SPDX23JSON, format, contentType)
+
+		_, ok := resp.Body.(io.Seeker)
+		require.True(t, ok)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, data, body)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	_, err = fs.SniffHTTPResponse(response("text/plain", io.NopCloser(strings.NewReader("not an SBOM"))))
+	require.Error(t, err)
+	_, err = fs.SniffHTTPResponse(response("application/json", http.NoBody))
+	require.Error(t, err)
+	_, err = fs.SniffHTTPResponse(nil)
+	require.Error(t, err)
+}
+
+func TestSniffCDXYAML(t *testing.T) {
+	fs := Sniffer{}
+	for _, tc := range []struct {
+		name     string
+		data     string
+		expected Format
+	}{
+		{"quoted", "bomFormat: \"CycloneDX\"\nspecVersion: \"1.5\"\n", CDX15YAML},
+		{"version first", "specVersion: '1.5'\nversion: 1\nbomFormat: CycloneDX # format\n", CDX15YAML},
+		{"other version", "bomFormat: CycloneDX\nspecVersion: \"1.4\"\n", CDX14YAML},
+		{"nested keys", "metadata:\n  bomFormat: CycloneDX\n  specVersion: \"1.5\"\n", EmptyFormat},
+		{"no version", "bomFormat: CycloneDX\n", EmptyFormat},
+		{"other format", "bomFormat: other\nspecVersion: \"1.5\"\n", EmptyFormat},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := fs.SniffReader(strings.NewReader(tc.data))
+			if tc.expected == EmptyFormat {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, f)
+		})
+	}
+}
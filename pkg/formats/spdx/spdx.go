@@ -3,7 +3,10 @@
 
 package spdx
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
 const (
 	DOCUMENT     = "DOCUMENT"
@@ -13,6 +16,17 @@ const (
 	Person       = "Person"
 	Tool         = "Tool"
 
+	// DocumentRefPrefix prefixes the IDs of external document references
+	DocumentRefPrefix = "DocumentRef-"
+
+	// SPDXRefPrefix prefixes the IDs of the SPDX elements
+	SPDXRefPrefix = "SPDXRef-"
+
+	// PropertyAnnotationPrefix prefixes the comment of the annotations that
+	// carry the name/value properties of an element, SPDX 2.3 has no field
+	// for them. The comment is written as <prefix><name>=<value>.
+	PropertyAnnotationPrefix = "protobom:property:"
+
 	// Identifier categories
 	CategorySecurity       = "SECURITY"
 	CategoryPackageManager = "PACKAGE-MANAGER"
@@ -23,6 +37,8 @@ const (
 	ExtRefTypeCPE22  = "cpe22Type"
 	ExtRefTypeCPE23  = "cpe23Type"
 	ExtRefTypeGitoid = "gitoid"
+	ExtRefTypeSWID   = "swid"
+	ExtRefTypeSWH    = "swh"
 )
 
 // ParseActorString parses an SPDX "actor string", it is a specially formatted
@@ -46,9 +62,55 @@ func ParseActorString(s string) (actorType, actorName, actorEmail string) {
 	actorName = s
 	if strings.HasSuffix(s, ")") && strings.Contains(s, "(") {
 		actorName = strings.TrimSpace(s[0:strings.LastIndex(s, "(")])
-		actorEmail = strings.TrimSpace(s[strings.LastIndex(s, "(")+1:])
-		actorEmail = strings.TrimSuffix(actorEmail, ")")
+		actorEmail = strings.TrimSuffix(s[strings.LastIndex(s, "(")+1:], ")")
+		actorEmail = strings.TrimSpace(actorEmail)
 	}
 
 	return actorType, actorName, actorEmail
 }
+
+// ParseToolString parses the identifier of an SPDX tool creator. By
+// convention, tools are written as the tool name followed by its version
+// separated with a hyphen, for example "syft-0.95.0". The version is split
+// at the last hyphen when what follows it looks like a version, otherwise
+// the whole string is returned as the name.
+func ParseToolString(s string) (name, version string) {
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), Tool+":"))
+	i := strings.LastIndex(s, "-")
+	if i <= 0 || i == len(s)-1 {
+		return s, ""
+	}
+	v := strings.TrimPrefix(s[i+1:], "v")
+	if v == "" || v[0] < '0' || v[0] > '9' {
+		return s, ""
+	}
+	return s[:i], s[i+1:]
+}
+
+// ToolString returns the identifier of a tool to write it as an SPDX tool
+// creator, the reverse of ParseToolString.
+func ToolString(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return name + "-" + version
+}
+
+// vcsTools are the version control systems allowed in SPDX download locations
+var vcsTools = []string{"git", "hg", "svn", "bzr"}
+
+// IsVCSLocation returns true if the package download location is a version
+// control system locator, written in SPDX as
+// <vcs_tool>+<transport>://<host_name>[/<path_to_repository>][@<revision_tag_or_branch>][#<sub_path>]
+// for example "git+https://github.com/bom-squad/protobom.git@v0.3.0".
+func IsVCSLocation(location string) bool {
+	tool, rest, ok := strings.Cut(strings.TrimSpace(location), "+")
+	if !ok {
+		return false
+	}
+	known := slices.ContainsFunc(vcsTools, func(t string) bool {
+		return strings.EqualFold(tool, t)
+	})
+	transport, _, ok := strings.Cut(rest, "://")
+	return known && ok && transport != ""
+}
@@ -0,0 +1,343 @@
+package license
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Operator is the conjunction of a compound license expression
+type Operator string
+
+const (
+	AND Operator = "AND"
+	OR  Operator = "OR"
+
+	with = "WITH"
+)
+
+// Expression is a parsed SPDX license expression. A simple expression has
+// its License set, a compound one has an Operator joining its Left and Right
+// subexpressions.
+type Expression struct {
+	// License is the license identifier or LicenseRef of a simple expression
+	License string
+
+	// OrLater is true when the license identifier has the "+" operator
+	OrLater bool
+
+	// Exception is the license exception added to the license with WITH
+	Exception string
+
+	// Operator joins the Left and Right subexpressions of a compound expression
+	Operator Operator
+	Left     *Expression
+	Right    *Expression
+}
+
+// Parse parses an SPDX license expression. Parse only checks the expression
+// syntax, use Validate to check the identifiers against the SPDX lists.
+// Operators are matched regardless of case. NOASSERTION and NONE are not
+// license expressions, use Normalize to handle them.
+func Parse(s string) (*Expression, error) {
+	p := &parser{tokens: tokenize(s)}
+	if len(p.tokens) == 0 {
+		return nil, errors.New("empty license expression")
+	}
+
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q at token %d", p.tokens[p.pos], p.pos+1)
+	}
+	return e, nil
+}
+
+// IsCompound returns true if the expression cannot be represented by a
+// single license identifier, that is when it has an operator, an exception
+// or the "+" suffix.
+func (e *Expression) IsCompound() bool {
+	return e.Operator != "" || e.Exception != "" || e.OrLater
+}
+
+// IsLicenseRef returns true if the expression is a single user defined
+// license reference
+func (e *Expression) IsLicenseRef() bool {
+	return !e.IsCompound() && IsLicenseRef(e.License)
+}
+
+// Licenses returns the license identifiers in the expression in the order
+// they appear, without exceptions or "+" operators.
+func (e *Expression) Licenses() []string {
+	if e.Operator == "" {
+		return []string{e.License}
+	}
+	return append(e.Left.Licenses(), e.Right.Licenses()...)
+}
+
+// Operands returns the simple expressions joined by the operators of the
+// expression in the order they appear. Unlike Licenses, the operands keep
+// their exceptions and "+" operators.
+func (e *Expression) Operands() []*Expression {
+	if e.Operator == "" {
+		return []*Expression{e}
+	}
+	return append(e.Left.Operands(), e.Right.Operands()...)
+}
+
+// Validate checks the license and exception identifiers in the expression
+// against the SPDX lists. LicenseRef and DocumentRef operands are accepted.
+func (e *Expression) Validate() error {
+	if e.Operator != "" {
+		return errors.Join(e.Left.Validate(), e.Right.Validate())
+	}
+
+	var errs []error
+	if !IsLicenseRef(e.License) && !IsLicenseID(e.License) {
+		errs = append(errs, fmt.Errorf("unknown license identifier %q", e.License))
+	}
+	if e.Exception != "" && !IsExceptionID(e.Exception) {
+		errs = append(errs, fmt.Errorf("unknown license exception %q", e.Exception))
+	}
+	return errors.Join(errs...)
+}
+
+// Canonicalize replaces the known identifiers in the expression with their
+// spelling in the SPDX lists.
+func (e *Expression) Canonicalize() {
+	if e.Operator != "" {
+		e.Left.Canonicalize()
+		e.Right.Canonicalize()
+		return
+	}
+	if id, ok := CanonicalLicenseID(e.License); ok {
+		e.License = id
+	}
+	if id, ok := CanonicalExceptionID(e.Exception); ok {
+		e.Exception = id
+	}
+}
+
+// String returns the expression as a string. Parentheses are only added
+// where they are needed to preserve the meaning of the expression.
+func (e *Expression) String() string {
+	if e.Operator == "" {
+		s := e.License
+		if e.OrLater {
+			s += "+"
+		}
+		if e.Exception != "" {
+			s += " " + with + " " + e.Exception
+		}
+		return s
+	}
+	return e.Left.operandString(e.Operator) + " " + string(e.Operator) + " " + e.Right.operandString(e.Operator)
+}
+
+// operandString returns the expression as an operand of parent, wrapping it
+// in parentheses when it is an OR in an AND expression.
+func (e *Expression) operandString(parent Operator) string {
+	if parent == AND && e.Operator == OR {
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
+
+// Normalize returns the normalized form of a license expression: NOASSERTION
+// and NONE are uppercased and valid expressions are returned with their
+// identifiers canonicalized and uppercase operators. If the expression is
+// invalid it is returned verbatim, only trimmed, along with an error
+// describing the problem.
+func Normalize(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToUpper(s) {
+	case "":
+		return "", nil
+	case NOASSERTION:
+		return NOASSERTION, nil
+	case NONE:
+		return NONE, nil
+	}
+
+	e, err := Parse(s)
+	if err != nil {
+		return s, fmt.Errorf("parsing license expression %q: %w", s, err)
+	}
+	if err := e.Validate(); err != nil {
+		return s, fmt.Errorf("validating license expression %q: %w", s, err)
+	}
+	e.Canonicalize()
+	return e.String(), nil
+}
+
+// NormalizeList normalizes a list of license expressions with Normalize,
+// dropping the blank ones. Invalid expressions are kept verbatim and the
+// errors describing them are returned joined. A nil list returns nil.
+func NormalizeList(expressions []string) ([]string, error) {
+	if expressions == nil {
+		return nil, nil
+	}
+	ret := []string{}
+	errs := []error{}
+	for _, e := range expressions {
+		n, err := Normalize(e)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if n != "" {
+			ret = append(ret, n)
+		}
+	}
+	return ret, errors.Join(errs...)
+}
+
+// Disjunction joins a list of license expressions with OR. A single
+// expression is returned verbatim, compound expressions are enclosed in
+// parentheses when joined.
+func Disjunction(expressions []string) string {
+	if len(expressions) == 1 {
+		return expressions[0]
+	}
+
+	parts := []string{}
+	for _, l := range expressions {
+		if e, err := Parse(l); err == nil && e.Operator != "" {
+			l = fmt.Sprintf("(%s)", l)
+		}
+		parts = append(parts, l)
+	}
+	return strings.Join(parts, " OR ")
+}
+
+// tokenize splits a license expression into parentheses and words
+func tokenize(s string) []string {
+	tokens := []string{}
+	word := strings.Builder{}
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range s {
+		switch r {
+		case '(', ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case ' ', '\t', '\n', '\r':
+			flush()
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// parser is a recursive descent parser for license expressions. AND takes
+// precedence over OR and WITH takes precedence over both.
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+// peek returns the next token or a blank string at the end of the expression
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+// isOperator returns true if the next token is the operator op
+func (p *parser) isOperator(op string) bool {
+	return strings.EqualFold(p.peek(), op)
+}
+
+func (p *parser) parseOr() (*Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(string(OR)) {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Expression{Operator: OR, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (*Expression, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(string(AND)) {
+		p.pos++
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Expression{Operator: AND, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parsePrimary() (*Expression, error) {
+	tok := p.peek()
+	switch {
+	case tok == "":
+		return nil, errors.New("unexpected end of expression")
+	case tok == "(":
+		p.pos++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		p.pos++
+		return e, nil
+	case tok == ")" || isOperatorToken(tok):
+		return nil, fmt.Errorf("unexpected %q at token %d", tok, p.pos+1)
+	}
+
+	p.pos++
+	e := &Expression{License: tok}
+	if !IsLicenseRef(tok) {
+		id, orLater := strings.CutSuffix(tok, "+")
+		if !isIDString(id) {
+			return nil, fmt.Errorf("invalid license identifier %q", tok)
+		}
+		// Deprecated identifiers such as GPL-2.0+ are in the list
+		if !IsLicenseID(tok) {
+			e.License = id
+			e.OrLater = orLater
+		}
+	}
+
+	if p.isOperator(with) {
+		p.pos++
+		exception := p.peek()
+		if exception == "" || exception == "(" || exception == ")" || isOperatorToken(exception) {
+			return nil, errors.New("missing license exception after WITH")
+		}
+		if !isIDString(exception) {
+			return nil, fmt.Errorf("invalid license exception %q", exception)
+		}
+		p.pos++
+		e.Exception = exception
+	}
+	return e, nil
+}
+
+// isOperatorToken returns true if tok is one of the expression operators
+func isOperatorToken(tok string) bool {
+	return strings.EqualFold(tok, string(AND)) || strings.EqualFold(tok, string(OR)) || strings.EqualFold(tok, with)
+}
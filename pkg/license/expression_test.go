@@ -0,0 +1,161 @@
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		sut      string
+		expected string
+		compound bool
+		licenses []string
+		mustErr  bool
+	}{
+		{"MIT", "MIT", false, []string{"MIT"}, false},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", "GPL-2.0-only WITH Classpath-exception-2.0", true, []string{"GPL-2.0-only"}, false},
+		{"LicenseRef-Proprietary AND MIT", "LicenseRef-Proprietary AND MIT", true, []string{"LicenseRef-Proprietary", "MIT"}, false},
+		{"DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2", "DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2", false, []string{"DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2"}, false},
+		{"Apache-2.0+", "Apache-2.0+", true, []string{"Apache-2.0"}, false},
+		{"GPL-2.0+", "GPL-2.0+", false, []string{"GPL-2.0+"}, false},
+		{"mit or apache-2.0", "mit OR apache-2.0", true, []string{"mit", "apache-2.0"}, false},
+		{"(MIT OR Apache-2.0) AND BSD-3-Clause", "(MIT OR Apache-2.0) AND BSD-3-Clause", true, []string{"MIT", "Apache-2.0", "BSD-3-Clause"}, false},
+		{"MIT OR Apache-2.0 AND BSD-3-Clause", "MIT OR Apache-2.0 AND BSD-3-Clause", true, []string{"MIT", "Apache-2.0", "BSD-3-Clause"}, false},
+		{"((MIT))", "MIT", false, []string{"MIT"}, false},
+		{"(MIT AND Apache-2.0) AND ISC", "MIT AND Apache-2.0 AND ISC", true, []string{"MIT", "Apache-2.0", "ISC"}, false},
+		{"", "", false, nil, true},
+		{"MIT AND", "", false, nil, true},
+		{"AND MIT", "", false, nil, true},
+		{"(MIT", "", false, nil, true},
+		{"MIT)", "", false, nil, true},
+		{"MIT Apache-2.0", "", false, nil, true},
+		{"GPL-2.0-only WITH", "", false, nil, true},
+		{"MIT/X11", "", false, nil, true},
+		{"(MIT) WITH Classpath-exception-2.0", "", false, nil, true},
+	} {
+		t.Run(tc.sut, func(t *testing.T) {
+			e, err := Parse(tc.sut)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, e.String())
+			require.Equal(t, tc.compound, e.IsCompound())
+			require.Equal(t, tc.licenses, e.Licenses())
+		})
+	}
+}
+
+func TestOperands(t *testing.T) {
+	e, err := Parse("(MIT OR Apache-2.0+) AND GPL-2.0-only WITH Classpath-exception-2.0")
+	require.NoError(t, err)
+	operands := []string{}
+	for _, o := range e.Operands() {
+		require.Empty(t, o.Operator)
+		operands = append(operands, o.String())
+	}
+	require.Equal(t, []string{"MIT", "Apache-2.0+", "GPL-2.0-only WITH Classpath-exception-2.0"}, operands)
+
+	e, err = Parse("MIT")
+	require.NoError(t, err)
+	require.Equal(t, []*Expression{e}, e.Operands())
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		sut     string
+		mustErr bool
+	}{
+		{"MIT", false},
+		{"mit", false},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", false},
+		{"LicenseRef-Proprietary AND MIT", false},
+		{"DocumentRef-other:LicenseRef-1 OR Apache-2.0", false},
+		{"GPL-2.0+", false},
+		{"Apache-2.0+", false},
+		{"Proprietary", true},
+		{"MIT AND Unknown-1.0", true},
+		{"GPL-2.0-only WITH Unknown-exception", true},
+		{"MIT WITH Apache-2.0", true},
+	} {
+		t.Run(tc.sut, func(t *testing.T) {
+			e, err := Parse(tc.sut)
+			require.NoError(t, err)
+			if tc.mustErr {
+				require.Error(t, e.Validate())
+			} else {
+				require.NoError(t, e.Validate())
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for _, tc := range []struct {
+		sut      string
+		expected string
+		mustErr  bool
+	}{
+		{"", "", false},
+		{"  ", "", false},
+		{"noassertion", NOASSERTION, false},
+		{" NONE ", NONE, false},
+		{"mit", "MIT", false},
+		{"apache-2.0 or mit", "Apache-2.0 OR MIT", false},
+		{"gpl-2.0-only with classpath-exception-2.0", "GPL-2.0-only WITH Classpath-exception-2.0", false},
+		{"LicenseRef-Proprietary and MIT", "LicenseRef-Proprietary AND MIT", false},
+		{"(MIT OR ISC)", "MIT OR ISC", false},
+		{" Custom License ", "Custom License", true},
+		{"MIT AND Proprietary", "MIT AND Proprietary", true},
+		{"MIT AND (ISC", "MIT AND (ISC", true},
+	} {
+		t.Run(tc.sut, func(t *testing.T) {
+			res, err := Normalize(tc.sut)
+			if tc.mustErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expected, res)
+		})
+	}
+}
+
+func TestNormalizeList(t *testing.T) {
+	res, err := NormalizeList(nil)
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	res, err = NormalizeList([]string{"mit", " ", "apache-2.0 or isc"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"MIT", "Apache-2.0 OR ISC"}, res)
+
+	// Invalid expressions are kept verbatim and all their errors returned
+	res, err = NormalizeList([]string{"Custom License", "mit", "MIT AND (ISC"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Custom License")
+	require.Contains(t, err.Error(), "MIT AND (ISC")
+	require.Equal(t, []string{"Custom License", "MIT", "MIT AND (ISC"}, res)
+}
+
+func TestDisjunction(t *testing.T) {
+	for _, tc := range []struct {
+		sut      []string
+		expected string
+	}{
+		{[]string{}, ""},
+		{[]string{"MIT"}, "MIT"},
+		{[]string{"MIT AND ISC"}, "MIT AND ISC"},
+		{[]string{"MIT", "Apache-2.0"}, "MIT OR Apache-2.0"},
+		{[]string{"MIT", "GPL-2.0-only WITH Classpath-exception-2.0"}, "MIT OR GPL-2.0-only WITH Classpath-exception-2.0"},
+		{[]string{"MIT", "LicenseRef-Proprietary AND ISC"}, "MIT OR (LicenseRef-Proprietary AND ISC)"},
+		{[]string{"Custom License", "MIT"}, "Custom License OR MIT"},
+	} {
+		t.Run(tc.expected, func(t *testing.T) {
+			require.Equal(t, tc.expected, Disjunction(tc.sut))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Package license implements parsing, validation and normalization of SPDX
+// license expressions as used by the protobom readers and writers.
+package license
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+const (
+	// NOASSERTION is used when no license information is asserted
+	NOASSERTION = "NOASSERTION"
+
+	// NONE is used when there is no license
+	NONE = "NONE"
+
+	// ListVersion is the version of the SPDX license list embedded in
+	// the package
+	ListVersion = "3.17"
+
+	licenseRefPrefix  = "LicenseRef-"
+	documentRefPrefix = "DocumentRef-"
+)
+
+var (
+	//go:embed licenses.txt
+	licensesData string
+
+	//go:embed exceptions.txt
+	exceptionsData string
+
+	indexOnce      sync.Once
+	licenseIndex   map[string]string
+	exceptionIndex map[string]string
+)
+
+// indexLists loads the embedded license and exception lists into indexes
+// keyed by the lowercased identifiers.
+func indexLists() {
+	indexOnce.Do(func() {
+		licenseIndex = parseList(licensesData)
+		exceptionIndex = parseList(exceptionsData)
+	})
+}
+
+// parseList reads an identifier list, skipping comments and blank lines
+func parseList(data string) map[string]string {
+	index := map[string]string{}
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		index[strings.ToLower(line)] = line
+	}
+	return index
+}
+
+// CanonicalLicenseID looks up id in the SPDX license list. Identifiers are
+// matched without regard to case, the returned string is the identifier as
+// spelled in the list. The second value is false if the license is unknown.
+func CanonicalLicenseID(id string) (string, bool) {
+	indexLists()
+	canonical, ok := licenseIndex[strings.ToLower(id)]
+	return canonical, ok
+}
+
+// CanonicalExceptionID looks up id in the SPDX license exceptions list. It
+// works as CanonicalLicenseID.
+func CanonicalExceptionID(id string) (string, bool) {
+	indexLists()
+	canonical, ok := exceptionIndex[strings.ToLower(id)]
+	return canonical, ok
+}
+
+// IsLicenseID returns true if id is in the SPDX license list
+func IsLicenseID(id string) bool {
+	_, ok := CanonicalLicenseID(id)
+	return ok
+}
+
+// IsExceptionID returns true if id is in the SPDX license exceptions list
+func IsExceptionID(id string) bool {
+	_, ok := CanonicalExceptionID(id)
+	return ok
+}
+
+// IsLicenseRef returns true if id is a user defined license reference, that
+// is LicenseRef-[idstring] or DocumentRef-[idstring]:LicenseRef-[idstring].
+func IsLicenseRef(id string) bool {
+	if strings.HasPrefix(id, documentRefPrefix) {
+		doc, ref, ok := strings.Cut(id, ":")
+		if !ok || !isIDString(strings.TrimPrefix(doc, documentRefPrefix)) {
+			return false
+		}
+		id = ref
+	}
+	return strings.HasPrefix(id, licenseRefPrefix) && isIDString(strings.TrimPrefix(id, licenseRefPrefix))
+}
+
+// isIDString returns true if s is a valid SPDX idstring: a non empty
+// string of letters, numbers, dots and dashes.
+func isIDString(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
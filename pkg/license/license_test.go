@@ -0,0 +1,38 @@
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalIDs(t *testing.T) {
+	id, ok := CanonicalLicenseID("apache-2.0")
+	require.True(t, ok)
+	require.Equal(t, "Apache-2.0", id)
+
+	_, ok = CanonicalLicenseID("Classpath-exception-2.0")
+	require.False(t, ok)
+
+	id, ok = CanonicalExceptionID("llvm-EXCEPTION")
+	require.True(t, ok)
+	require.Equal(t, "LLVM-exception", id)
+
+	_, ok = CanonicalExceptionID("MIT")
+	require.False(t, ok)
+}
+
+func TestIsLicenseRef(t *testing.T) {
+	for sut, expected := range map[string]bool{
+		"LicenseRef-Proprietary":                     true,
+		"LicenseRef-1.0":                             true,
+		"DocumentRef-spdx-tool-1.2:LicenseRef-MIT-2": true,
+		"LicenseRef-":                                false,
+		"LicenseRef-Bad_Chars":                       false,
+		"DocumentRef-doc":                            false,
+		"DocumentRef-doc:MIT":                        false,
+		"MIT":                                        false,
+	} {
+		require.Equal(t, expected, IsLicenseRef(sut), sut)
+	}
+}
@@ -0,0 +1,37 @@
+package native
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diagnostic describes a problem found in a document while parsing it
+type Diagnostic struct {
+	// Location points to the problem in the source document. It is a JSON
+	// pointer in JSON documents and a line number in tag-value documents.
+	Location string
+
+	// Message describes the problem
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	if d.Location == "" {
+		return d.Message
+	}
+	return fmt.Sprintf("%s: %s", d.Location, d.Message)
+}
+
+// DiagnosticsError is returned by the unserializers when a document has
+// problems that the options make fatal. It lists all of them.
+type DiagnosticsError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *DiagnosticsError) Error() string {
+	msgs := make([]string, 0, len(e.Diagnostics))
+	for _, d := range e.Diagnostics {
+		msgs = append(msgs, d.String())
+	}
+	return fmt.Sprintf("%d problems found in document: %s", len(e.Diagnostics), strings.Join(msgs, "; "))
+}
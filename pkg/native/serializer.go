@@ -18,4 +18,19 @@ type RenderOptions struct {
 	Indent int
 }
 
-type SerializeOptions struct{}
+type SerializeOptions struct {
+	// OmitNoAssertion makes the serializers leave out the optional fields
+	// whose value would be written as NOASSERTION or NONE. Fields required
+	// by the format are always written.
+	OmitNoAssertion bool
+
+	// AddProtobomTool makes the serializers record protobom as one of the
+	// tools that created the document.
+	AddProtobomTool bool
+
+	// OmitCarrierProperties makes the serializers leave out the protobom
+	// properties that carry the fields the format has no place for, such
+	// as the SPDX source information or attribution texts when writing
+	// CycloneDX. Set it to write the documents without protobom data.
+	OmitCarrierProperties bool
+}
@@ -2,17 +2,24 @@ package serializers
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	cdxformats "github.com/bom-squad/protobom/pkg/formats/cyclonedx"
+	protospdx "github.com/bom-squad/protobom/pkg/formats/spdx"
+	"github.com/bom-squad/protobom/pkg/license"
 	"github.com/bom-squad/protobom/pkg/native"
 	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
 )
 
 var _ native.Serializer = &CDX{}
@@ -29,6 +36,63 @@ type (
 	}
 )
 
+// CDXOptions are the options specific to the CycloneDX serializers. To use
+// them, set them as the format options of the serializer in the writer.
+type CDXOptions struct {
+	// ContainsAsDependencies also writes the contains edges of the document
+	// as dependencies. By default only the dependsOn edges are written to
+	// the dependency graph, contains edges nest the components.
+	ContainsAsDependencies bool
+
+	// NestedAssemblies writes the whole structure of the contains edges as
+	// nested components, walking them from the root node: the components
+	// contained by the root are written at the top level and each of them
+	// holds the components it contains, at any depth. Nodes not reachable
+	// through contains edges are written at the top level. A node contained
+	// by more than one component is nested under the first one found, the
+	// others reference it in their dependencies. Cycles are broken where
+	// they are found.
+	NestedAssemblies bool
+
+	// SerialNumber controls how the serial number of the document is
+	// written. By default, documents get a new random serial number.
+	SerialNumber SerialNumberMode
+
+	// SerialNumberNamespace is the namespace of the UUIDs generated with
+	// SerialNumberDeterministic. If not set, DefaultSerialNumberNamespace
+	// is used.
+	SerialNumberNamespace uuid.UUID
+
+	// IncrementVersion writes the version of the document increased by one,
+	// use it when the document was modified but keeps its serial number.
+	// Documents written with a random serial number always start at version 1.
+	IncrementVersion bool
+}
+
+// SerialNumberMode defines how the serial numbers of the CycloneDX documents
+// are written
+type SerialNumberMode int
+
+const (
+	// SerialNumberRandom writes a new random serial number, it is the
+	// default mode
+	SerialNumberRandom SerialNumberMode = iota
+
+	// SerialNumberPreserve writes the ID of the document as its serial
+	// number when it is a UUID URN, as read from a CycloneDX document.
+	// Documents with other IDs, like those read from SPDX, get a random one.
+	SerialNumberPreserve
+
+	// SerialNumberDeterministic derives a UUIDv5 serial number from the
+	// hash of the contents of the document, so serializing the same
+	// document always produces the same serial number.
+	SerialNumberDeterministic
+)
+
+// DefaultSerialNumberNamespace is the namespace of the serial numbers
+// generated from the document contents when the options do not set one
+var DefaultSerialNumberNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/bom-squad/protobom"))
+
 func NewCDX(version, encoding string) *CDX {
 	return &CDX{
 		version:  version,
@@ -36,7 +100,17 @@ func NewCDX(version, encoding string) *CDX {
 	}
 }
 
-func (s *CDX) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ interface{}) (interface{}, error) {
+func (s *CDX) Serialize(bom *sbom.Document, so *native.SerializeOptions, rawOpts interface{}) (interface{}, error) {
+	opts := &CDXOptions{}
+	switch o := rawOpts.(type) {
+	case *CDXOptions:
+		if o != nil {
+			opts = o
+		}
+	case CDXOptions:
+		opts = &o
+	}
+
 	// Load the context with the CDX value. We initialize a context here
 	// but we should get it as part of the method to capture cancelations
 	// from the CLI or REST API.
@@ -44,18 +118,22 @@ func (s *CDX) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ interf
 	ctx := context.WithValue(context.Background(), stateKey, state)
 
 	doc := cdx.NewBOM()
-	doc.SerialNumber = bom.Metadata.Id
-	ver, err := strconv.Atoi(bom.Metadata.Version)
-	// TODO(deprecation): If version does not parse to int, there's data loss here.
-	if err == nil {
-		doc.Version = ver
+	serialNumber, ver, err := serialNumberToCDX(bom, opts)
+	if err != nil {
+		return nil, err
 	}
+	doc.SerialNumber = serialNumber
+	doc.Version = ver
 
 	metadata := cdx.Metadata{
 		Component:  &cdx.Component{},
 		Lifecycles: &[]cdx.Lifecycle{},
 	}
 
+	if date := bom.GetMetadata().GetDate(); date.IsValid() && date.AsTime().Unix() > 0 {
+		metadata.Timestamp = date.AsTime().UTC().Format(time.RFC3339)
+	}
+
 	doc.Metadata = &metadata
 	doc.Components = &[]cdx.Component{}
 	doc.Dependencies = &[]cdx.Dependency{}
@@ -71,12 +149,14 @@ func (s *CDX) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ interf
 		return nil, fmt.Errorf("unable to build cyclonedx document, no root nodes found")
 	}
 
-	// .. or has too many root elements:
-
-	// TODO(deprecation): If there are more root nodes we need to hack them
-	// into the CycloneDX graph or error
+	// The first root element is the component the document describes, the
+	// rest of the roots are written as regular components
+	// TODO(degradation): CycloneDX documents describe a single component
 	if l := len(bom.NodeList.RootElements); l > 1 {
-		return nil, fmt.Errorf("unable to serialize multiroot cyclonedx, document has %d root nodes", l)
+		logrus.Warnf(
+			"document has %d root nodes, only %s is written as the metadata component",
+			l, bom.NodeList.RootElements[0],
+		)
 	}
 
 	rootNode := bom.NodeList.GetNodeByID(bom.NodeList.RootElements[0])
@@ -84,7 +164,15 @@ func (s *CDX) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ interf
 		return nil, fmt.Errorf("integrity error: root node %q not found", bom.NodeList.RootElements[0])
 	}
 
+	// Unless the options leave them out, the components get the properties
+	// carrying the fields CycloneDX cannot express
+	state.carrierProperties = so == nil || !so.OmitCarrierProperties
+
 	doc.Metadata.Component = s.nodeToComponent(rootNode)
+	if state.carrierProperties {
+		addCarrierProperties(doc.Metadata.Component, rootNode)
+	}
+	doc.Metadata.Supplier = doc.Metadata.Component.Supplier
 	state.addedDict[rootNode.Id] = struct{}{}
 
 	if err := s.componentsMaps(ctx, bom); err != nil {
@@ -119,36 +207,362 @@ func (s *CDX) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ interf
 		metadata.Authors = &authors
 	}
 
-	if bom.Metadata != nil && len(bom.GetMetadata().GetTools()) > 0 {
-		var tools []cdx.Tool //nolint:staticcheck
-		for _, bomtool := range bom.GetMetadata().GetTools() {
-			tools = append(tools, cdx.Tool{ //nolint:staticcheck // Tool is needed for older cdx versions
-				Name:    bomtool.Name,
-				Version: bomtool.Version,
-			})
-		}
-		metadata.Tools = &cdx.ToolsChoice{
-			Tools: &tools,
-		}
+	metadata.Tools = s.toolsToCDX(bom.GetMetadata().GetTools(), so != nil && so.AddProtobomTool)
+
+	if props := propertiesToCDX(bom.GetMetadata().GetProperties()); len(props) > 0 {
+		metadata.Properties = &props
 	}
 
-	if bom.Metadata != nil && len(bom.GetMetadata().GetName()) > 0 {
+	if refs := s.externalReferencesToCDX(bom.GetMetadata().GetId(), bom.GetMetadata().GetExternalReferences()); len(refs) > 0 {
+		doc.ExternalReferences = &refs
+	}
+
+	// The document name is only used when the root node has none
+	if doc.Metadata.Component.Name == "" && len(bom.GetMetadata().GetName()) > 0 {
 		doc.Metadata.Component.Name = bom.GetMetadata().GetName()
 	}
 
-	deps, err := s.dependencies(ctx, bom)
+	deps, err := s.dependencies(ctx, bom, opts)
 	if err != nil {
 		return nil, err
 	}
 	doc.Dependencies = &deps
 
+	if annotations := s.annotations(bom, doc.SerialNumber, doc.Version); len(annotations) > 0 {
+		doc.Annotations = &annotations
+	}
+
+	if compositions := compositionsToCDX(bom.GetMetadata().GetCompositions()); len(compositions) > 0 {
+		// Compositions have no bom-ref before CycloneDX 1.5
+		if v, err := cdxformats.ParseVersion(s.version); err == nil && v < cdx.SpecVersion1_5 {
+			for i := range compositions {
+				compositions[i].BOMRef = ""
+			}
+		}
+		doc.Compositions = &compositions
+	}
+
 	components := state.components()
 	clearAutoRefs(&components)
 	doc.Components = &components
 
+	if len(bom.Metadata.GetExtractedLicenses()) > 0 {
+		attachLicenseTexts(doc.Metadata.Component, bom.Metadata)
+		for i := range components {
+			attachLicenseTexts(&components[i], bom.Metadata)
+		}
+	}
+
 	return doc, nil
 }
 
+// toolsToCDX returns the tools of the document metadata, adding protobom
+// when requested. CycloneDX 1.5 describes the tools as components, older
+// versions use the legacy tool list.
+func (s *CDX) toolsToCDX(tools []*sbom.Tool, addProtobom bool) *cdx.ToolsChoice {
+	tools = slices.DeleteFunc(slices.Clone(tools), func(t *sbom.Tool) bool {
+		return t == nil || t.Name == ""
+	})
+	if addProtobom {
+		pt := &sbom.Tool{Name: "protobom", Version: protobomVersion()}
+		if !slices.ContainsFunc(tools, func(t *sbom.Tool) bool {
+			return t.Name == pt.Name && t.Version == pt.Version
+		}) {
+			tools = append(tools, pt)
+		}
+	}
+	if len(tools) == 0 {
+		return nil
+	}
+
+	if v, err := cdxformats.ParseVersion(s.version); err == nil && v < cdx.SpecVersion1_5 {
+		legacy := []cdx.Tool{} //nolint:staticcheck // Tool is needed for older cdx versions
+		for _, t := range tools {
+			legacy = append(legacy, cdx.Tool{ //nolint:staticcheck
+				Name:    t.Name,
+				Version: t.Version,
+				Vendor:  t.Vendor,
+			})
+		}
+		return &cdx.ToolsChoice{Tools: &legacy}
+	}
+
+	components := []cdx.Component{}
+	for _, t := range tools {
+		c := cdx.Component{
+			Type:    cdx.ComponentTypeApplication,
+			Name:    t.Name,
+			Version: t.Version,
+		}
+		if t.Vendor != "" {
+			c.Supplier = &cdx.OrganizationalEntity{Name: t.Vendor}
+		}
+		components = append(components, c)
+	}
+	return &cdx.ToolsChoice{Components: &components}
+}
+
+// serialNumberToCDX returns the serial number and version of the CycloneDX
+// document as defined by the options
+func serialNumberToCDX(bom *sbom.Document, opts *CDXOptions) (string, int, error) {
+	ver := 1
+	// TODO(deprecation): If version does not parse to int, there's data loss here.
+	if v, err := strconv.Atoi(bom.GetMetadata().GetVersion()); err == nil && v > 0 {
+		ver = v
+	}
+	if opts.IncrementVersion {
+		ver++
+	}
+
+	switch opts.SerialNumber {
+	case SerialNumberPreserve:
+		id := bom.GetMetadata().GetId()
+		if _, err := uuid.Parse(id); err == nil && strings.HasPrefix(id, "urn:uuid:") {
+			return id, ver, nil
+		}
+		if id != "" {
+			logrus.Warnf("document ID %q is not a UUID URN, writing a random serial number", id)
+		}
+		return "urn:uuid:" + uuid.NewString(), 1, nil
+	case SerialNumberRandom:
+		return "urn:uuid:" + uuid.NewString(), 1, nil
+	case SerialNumberDeterministic:
+		hash, err := contentHash(bom)
+		if err != nil {
+			return "", 0, fmt.Errorf("hashing document: %w", err)
+		}
+		ns := opts.SerialNumberNamespace
+		if ns == uuid.Nil {
+			ns = DefaultSerialNumberNamespace
+		}
+		return "urn:uuid:" + uuid.NewSHA1(ns, hash).String(), ver, nil
+	default:
+		return "", 0, fmt.Errorf("unknown serial number mode %d", opts.SerialNumber)
+	}
+}
+
+// contentHash returns the SHA256 hash of the canonical encoding of the
+// document. The ID and version of the document are not part of it.
+func contentHash(bom *sbom.Document) ([]byte, error) {
+	doc := proto.Clone(bom).(*sbom.Document)
+	if doc.Metadata != nil {
+		doc.Metadata.Id = ""
+		doc.Metadata.Version = ""
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	return sum[:], nil
+}
+
+// externalReferencesToCDX converts the external references of an element to
+// their CycloneDX equivalents, keeping their comments and hashes. The url is
+// required in CycloneDX so references without one are skipped.
+func (s *CDX) externalReferencesToCDX(id string, refs []*sbom.ExternalReference) []cdx.ExternalReference {
+	ret := []cdx.ExternalReference{}
+	for _, er := range refs {
+		if er == nil {
+			continue
+		}
+		if !sbom.HasValue(strings.TrimSpace(er.Url)) {
+			logrus.Warnf("skipping %s external reference of %s without url", er.Type, id)
+			continue
+		}
+		cdxRef := cdx.ExternalReference{
+			URL:     er.Url,
+			Comment: er.Comment,
+			Type:    s.protobomExtRefTypeToCdxType(er.Type),
+		}
+		if hashList := s.hashesToCDX(er.Url, er.Hashes); len(hashList) > 0 {
+			cdxRef.Hashes = &hashList
+		}
+		ret = append(ret, cdxRef)
+	}
+	return ret
+}
+
+// supplierToCDX returns the supplier of a component. CycloneDX components
+// have a single supplier, the first organization of the list is used or the
+// first person when there are no organizations. Returns nil when the
+// supplier has no data.
+func supplierToCDX(suppliers []*sbom.Person) *cdx.OrganizationalEntity {
+	var supplier *sbom.Person
+	for _, p := range suppliers {
+		if p == nil {
+			continue
+		}
+		if p.IsOrg {
+			supplier = p
+			break
+		}
+		if supplier == nil {
+			supplier = p
+		}
+	}
+	if supplier == nil {
+		return nil
+	}
+	// TODO(degradation): CDX type Component only supports one Supplier while protobom supports multiple
+	if len(suppliers) > 1 {
+		logrus.Debugf("writing %s as the only supplier of the component", supplier.Name)
+	}
+
+	oe := supplier.ToCDXOrganizationalEntity()
+	if !sbom.HasValue(strings.TrimSpace(oe.Name)) {
+		oe.Name = ""
+	}
+	if oe.Name == "" && oe.URL == nil && oe.Contact == nil {
+		return nil
+	}
+	return oe
+}
+
+// originatorsToCDX returns the author and publisher of a component. Both are
+// strings in CycloneDX, the names of the individual originators are joined
+// with commas into the author and the names of the organizations into the
+// publisher.
+func originatorsToCDX(originators []*sbom.Person) (author, publisher string) {
+	authors, publishers := []string{}, []string{}
+	for _, p := range originators {
+		name := strings.TrimSpace(p.GetName())
+		if !sbom.HasValue(name) {
+			continue
+		}
+		if p.IsOrg {
+			if !slices.Contains(publishers, name) {
+				publishers = append(publishers, name)
+			}
+			continue
+		}
+		if !slices.Contains(authors, name) {
+			authors = append(authors, name)
+		}
+	}
+	return strings.Join(authors, ", "), strings.Join(publishers, ", ")
+}
+
+// appendURLReference adds a reference of type refType pointing to url to the
+// references unless the url has no actual value or is already listed with
+// the same type
+func appendURLReference(refs *[]cdx.ExternalReference, refType cdx.ExternalReferenceType, url string) {
+	if !sbom.HasValue(url) {
+		return
+	}
+	for _, r := range *refs {
+		if r.Type == refType && r.URL == url {
+			return
+		}
+	}
+	*refs = append(*refs, cdx.ExternalReference{Type: refType, URL: url})
+}
+
+// attachLicenseTexts completes the licenses of the component (and its
+// subcomponents) that refer to an extracted license of the document,
+// replacing the LicenseRef with the license name and attaching its text.
+func attachLicenseTexts(c *cdx.Component, md *sbom.Metadata) {
+	if c == nil {
+		return
+	}
+	if c.Licenses != nil {
+		for i := range *c.Licenses {
+			l := (*c.Licenses)[i].License
+			if l == nil || !license.IsLicenseRef(l.Name) {
+				continue
+			}
+			extracted := md.GetExtractedLicense(l.Name)
+			if extracted == nil {
+				continue
+			}
+			if extracted.Name != "" && extracted.Name != license.NOASSERTION {
+				l.Name = extracted.Name
+			}
+			if extracted.Text != "" {
+				l.Text = &cdx.AttachedText{Content: extracted.Text}
+			}
+			if len(extracted.SeeAlso) > 0 && l.URL == "" {
+				// TODO(degradation): CycloneDX licenses only have one URL
+				l.URL = extracted.SeeAlso[0]
+			}
+		}
+	}
+	if c.Components != nil {
+		for i := range *c.Components {
+			attachLicenseTexts(&(*c.Components)[i], md)
+		}
+	}
+}
+
+// annotations returns the node and document annotations as CycloneDX
+// annotations. Annotations about the document reference it by its BOM-Link,
+// built from the serial number and version written to the document.
+func (s *CDX) annotations(bom *sbom.Document, serialNumber string, version int) []cdx.Annotation {
+	annotations := []cdx.Annotation{}
+	if bom.Metadata != nil && len(bom.Metadata.Annotations) > 0 {
+		if serialNumber == "" {
+			// TODO(degradation): Without a serial number, the document cannot
+			// be referenced so its annotations are lost
+			logrus.Warn("document has no serial number, dropping its annotations")
+		} else {
+			link := fmt.Sprintf("urn:cdx:%s/%d", strings.TrimPrefix(serialNumber, "urn:uuid:"), version)
+			for _, a := range bom.Metadata.Annotations {
+				if ca := annotationToCDX(a, link); ca != nil {
+					annotations = append(annotations, *ca)
+				}
+			}
+		}
+	}
+
+	for _, n := range bom.NodeList.Nodes {
+		for _, a := range n.Annotations {
+			if ca := annotationToCDX(a, n.Id); ca != nil {
+				annotations = append(annotations, *ca)
+			}
+		}
+	}
+	return annotations
+}
+
+// annotationToCDX converts a protobom annotation about subject to CycloneDX.
+// Annotators are translated to organizations, individuals or, in the case
+// of tools, to an application component. Returns nil if the annotation has
+// no annotator as CycloneDX requires one.
+func annotationToCDX(a *sbom.Annotation, subject string) *cdx.Annotation {
+	annotator := &cdx.Annotator{}
+	switch {
+	case a.Tool != nil:
+		annotator.Component = &cdx.Component{
+			Type:      cdx.ComponentTypeApplication,
+			Name:      a.Tool.Name,
+			Version:   a.Tool.Version,
+			Publisher: a.Tool.Vendor,
+		}
+	case a.Annotator != nil && a.Annotator.IsOrg:
+		annotator.Organization = a.Annotator.ToCDXOrganizationalEntity()
+	case a.Annotator != nil:
+		annotator.Individual = &cdx.OrganizationalContact{
+			Name:  a.Annotator.Name,
+			Email: a.Annotator.Email,
+			Phone: a.Annotator.Phone,
+		}
+	default:
+		// TODO(degradation): Annotations without annotator are lost
+		return nil
+	}
+
+	// TODO(degradation): The annotation type is lost in CycloneDX
+	ca := &cdx.Annotation{
+		Subjects:  &[]cdx.BOMReference{cdx.BOMReference(subject)},
+		Annotator: annotator,
+		Text:      a.Comment,
+	}
+	if a.Date != nil {
+		ca.Timestamp = a.Date.AsTime().UTC().Format(time.RFC3339)
+	}
+	return ca
+}
+
 // sbomTypeToPhase converts a SBOM document type to a CDX lifecycle phase
 func sbomTypeToPhase(dt *sbom.DocumentType) (cdx.LifecyclePhase, error) {
 	switch *dt.Type {
@@ -180,11 +594,8 @@ func sbomTypeToPhase(dt *sbom.DocumentType) (cdx.LifecyclePhase, error) {
 // clear their refs again before output to CDX
 func clearAutoRefs(comps *[]cdx.Component) {
 	for i := range *comps {
-		if strings.HasPrefix((*comps)[i].BOMRef, "protobom-") {
-			flags := strings.Split((*comps)[i].BOMRef, "--")
-			if strings.Contains(flags[0], "-auto") {
-				(*comps)[i].BOMRef = ""
-			}
+		if isAutoRef((*comps)[i].BOMRef) {
+			(*comps)[i].BOMRef = ""
 		}
 		if (*comps)[i].Components != nil && len(*(*comps)[i].Components) != 0 {
 			clearAutoRefs((*comps)[i].Components)
@@ -192,6 +603,59 @@ func clearAutoRefs(comps *[]cdx.Component) {
 	}
 }
 
+// isAutoRef returns true if ref is a bom-ref generated by protobom for a
+// component without one
+func isAutoRef(ref string) bool {
+	if !strings.HasPrefix(ref, "protobom-") {
+		return false
+	}
+	flags := strings.Split(ref, "--")
+	return strings.Contains(flags[0], "-auto")
+}
+
+// compositionsToCDX converts the compositions of the document metadata to
+// CycloneDX. References to components whose generated bom-ref is not
+// written are dropped.
+func compositionsToCDX(compositions []*sbom.Composition) []cdx.Composition {
+	refs := func(ids []string) *[]cdx.BOMReference {
+		ret := []cdx.BOMReference{}
+		for _, id := range ids {
+			if isAutoRef(id) {
+				continue
+			}
+			ret = append(ret, cdx.BOMReference(id))
+		}
+		if len(ret) == 0 {
+			return nil
+		}
+		return &ret
+	}
+
+	ret := []cdx.Composition{}
+	for _, c := range compositions {
+		if c == nil {
+			continue
+		}
+		ret = append(ret, cdx.Composition{
+			BOMRef:       c.Id,
+			Aggregate:    aggregateToCDX(c.Aggregate),
+			Assemblies:   refs(c.Assemblies),
+			Dependencies: refs(c.Dependencies),
+		})
+	}
+	return ret
+}
+
+// aggregateToCDX returns the CycloneDX aggregate of a composition, the
+// CycloneDX values are the protobom names in lower case
+func aggregateToCDX(aggregate sbom.Composition_Aggregate) cdx.CompositionAggregate {
+	name, ok := sbom.Composition_Aggregate_name[int32(aggregate)]
+	if !ok {
+		return cdx.CompositionAggregateNotSpecified
+	}
+	return cdx.CompositionAggregate(strings.ToLower(name))
+}
+
 func (s *CDX) componentsMaps(ctx context.Context, bom *sbom.Document) error {
 	state, err := getCDXState(ctx)
 	if err != nil {
@@ -204,42 +668,102 @@ func (s *CDX) componentsMaps(ctx context.Context, bom *sbom.Document) error {
 			// Error? Warn?
 			continue
 		}
+		if state.carrierProperties {
+			addCarrierProperties(comp, n)
+		}
 
 		state.componentsDict[comp.BOMRef] = comp
 	}
 	return nil
 }
 
+// dependencies builds the CycloneDX dependency graph from the dependsOn
+// edges of the document, and from its contains edges when the options ask
+// for them. Edges from the same component are collapsed into one entry with
+// its targets de-duplicated. The root component always gets an entry, listing
+// its direct dependencies. Edges to or from nodes that are not serialized as
+// components are skipped.
+//
 // NOTE dependencies function modifies the components dictionary
-func (s *CDX) dependencies(ctx context.Context, bom *sbom.Document) ([]cdx.Dependency, error) {
-	var dependencies []cdx.Dependency
+func (s *CDX) dependencies(ctx context.Context, bom *sbom.Document, opts *CDXOptions) ([]cdx.Dependency, error) {
 	state, err := getCDXState(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("reading state: %w", err)
 	}
 
-	for _, e := range bom.NodeList.Edges {
-		e := e
-		if _, ok := state.addedDict[e.From]; ok {
-			continue
+	rootID := bom.NodeList.RootElements[0]
+
+	// Components with generated bom-refs lose them on output, they cannot
+	// be referenced from the dependencies
+	referenceable := func(id string) bool {
+		if _, ok := state.componentsDict[id]; !ok {
+			return false
 		}
+		return id == rootID || !isAutoRef(id)
+	}
 
-		if _, ok := state.componentsDict[e.From]; !ok {
-			logrus.Info("serialize")
-			return nil, fmt.Errorf("unable to find component %s", e.From)
+	// Dependencies are collected by ref, in order of appearance
+	order := []string{rootID}
+	targets := map[string][]string{rootID: {}}
+	seen := map[string]map[string]struct{}{rootID: {}}
+	addDependencies := func(from string, to []string) {
+		if !referenceable(from) {
+			logrus.Warnf("skipping dependencies of %s, it is not in the document", from)
+			return
+		}
+		if _, ok := targets[from]; !ok {
+			order = append(order, from)
+			targets[from] = []string{}
+			seen[from] = map[string]struct{}{}
+		}
+		for _, id := range to {
+			if _, ok := seen[from][id]; ok {
+				continue
+			}
+			if !referenceable(id) {
+				logrus.Warnf("skipping dependency of %s on %s, it is not in the document", from, id)
+				continue
+			}
+			seen[from][id] = struct{}{}
+			targets[from] = append(targets[from], id)
 		}
+	}
 
+	// Inverse edges (contained_by, dependencyOf) are handled in their
+	// canonical direction
+	edges := []*sbom.Edge{}
+	for _, e := range bom.NodeList.Edges {
+		edges = append(edges, e.Canonical()...)
+	}
+
+	for _, e := range edges {
 		// In this example, we tree-ify all components related with a
 		// "contains" relationship. This is just an opinion for the demo
 		// and it is something we can parameterize
 		switch e.Type {
 		case sbom.Edge_contains:
-			// Make sure we have the target component
+			if opts.ContainsAsDependencies {
+				addDependencies(e.From, e.To)
+			}
+			if opts.NestedAssemblies {
+				continue
+			}
+
+			// Components already in the tree, such as the root component,
+			// keep the components they contain at their level
+			if _, ok := state.addedDict[e.From]; ok {
+				continue
+			}
+			if _, ok := state.componentsDict[e.From]; !ok {
+				logrus.Warnf("skipping components contained by %s, it is not in the document", e.From)
+				continue
+			}
 			for _, targetID := range e.To {
-				state.addedDict[targetID] = struct{}{}
 				if _, ok := state.componentsDict[targetID]; !ok {
-					return nil, fmt.Errorf("unable to locate node %s", targetID)
+					logrus.Warnf("skipping component %s contained by %s, it is not in the document", targetID, e.From)
+					continue
 				}
+				state.addedDict[targetID] = struct{}{}
 
 				if state.componentsDict[e.From].Components == nil {
 					state.componentsDict[e.From].Components = &[]cdx.Component{}
@@ -248,27 +772,8 @@ func (s *CDX) dependencies(ctx context.Context, bom *sbom.Document) ([]cdx.Depen
 			}
 
 		case sbom.Edge_dependsOn:
-			// Add to the dependency tree
-			targetStrings := []string{}
-			depListCheck := map[string]struct{}{}
-			for _, targetID := range e.To {
-				// Add entries to dependency only once.
-				if _, ok := depListCheck[targetID]; ok {
-					continue
-				}
+			addDependencies(e.From, e.To)
 
-				if _, ok := state.componentsDict[targetID]; !ok {
-					return nil, fmt.Errorf("unable to locate node %s", targetID)
-				}
-
-				state.addedDict[targetID] = struct{}{}
-				depListCheck[targetID] = struct{}{}
-				targetStrings = append(targetStrings, targetID)
-			}
-			dependencies = append(dependencies, cdx.Dependency{
-				Ref:          e.From,
-				Dependencies: &targetStrings,
-			})
 		default:
 			// TODO(degradation) here, we would document how relationships are lost
 			logrus.Warnf(
@@ -278,9 +783,107 @@ func (s *CDX) dependencies(ctx context.Context, bom *sbom.Document) ([]cdx.Depen
 		}
 	}
 
+	// Components nested under another parent are referenced as dependencies
+	if opts.NestedAssemblies {
+		for _, e := range nestComponents(state, rootID, bom.NodeList.Nodes, edges) {
+			addDependencies(e.From, e.To)
+		}
+	}
+
+	dependencies := []cdx.Dependency{}
+	for _, ref := range order {
+		d := cdx.Dependency{Ref: ref}
+		if len(targets[ref]) > 0 {
+			deps := targets[ref]
+			d.Dependencies = &deps
+		}
+		dependencies = append(dependencies, d)
+	}
 	return dependencies, nil
 }
 
+// nestComponents builds the top level components of the document nesting
+// the components following the contains edges from the root node. Nodes not
+// reached from the root are written at the top level, first those not
+// contained by any other node. The tree is stored in the serializer state.
+// It returns the contains edges left out of the tree because their target was
+// already nested under another component.
+func nestComponents(state *serializerCDXState, rootID string, nodes []*sbom.Node, edges []*sbom.Edge) []*sbom.Edge {
+	contains := map[string][]string{}
+	contained := map[string]struct{}{}
+	for _, e := range edges {
+		if e.Type != sbom.Edge_contains {
+			continue
+		}
+		if _, ok := state.componentsDict[e.From]; !ok {
+			continue
+		}
+		for _, id := range e.To {
+			if _, ok := state.componentsDict[id]; !ok || slices.Contains(contains[e.From], id) {
+				continue
+			}
+			contains[e.From] = append(contains[e.From], id)
+			contained[id] = struct{}{}
+		}
+	}
+
+	placed := map[string]struct{}{rootID: {}}
+	path := map[string]struct{}{rootID: {}}
+	extra := []*sbom.Edge{}
+
+	var nest func(id string) cdx.Component
+	nest = func(id string) cdx.Component {
+		placed[id] = struct{}{}
+		path[id] = struct{}{}
+		defer delete(path, id)
+
+		c := *state.componentsDict[id]
+		c.Components = nil
+		children := []cdx.Component{}
+		for _, child := range contains[id] {
+			if _, ok := path[child]; ok {
+				logrus.Warnf("breaking contains cycle between %s and %s", id, child)
+				continue
+			}
+			if _, ok := placed[child]; ok {
+				extra = append(extra, &sbom.Edge{Type: sbom.Edge_contains, From: id, To: []string{child}})
+				continue
+			}
+			children = append(children, nest(child))
+		}
+		if len(children) > 0 {
+			c.Components = &children
+		}
+		return c
+	}
+
+	top := []cdx.Component{}
+	for _, id := range contains[rootID] {
+		if _, ok := placed[id]; !ok {
+			top = append(top, nest(id))
+		}
+	}
+	delete(path, rootID)
+
+	for _, containedOnly := range []bool{false, true} {
+		for _, n := range nodes {
+			if _, ok := placed[n.Id]; ok {
+				continue
+			}
+			if _, ok := state.componentsDict[n.Id]; !ok {
+				continue
+			}
+			if _, ok := contained[n.Id]; ok != containedOnly {
+				continue
+			}
+			top = append(top, nest(n.Id))
+		}
+	}
+
+	state.nested = &top
+	return extra
+}
+
 // nodeToComponent converts a node in protobuf to a CycloneDX component
 func (s *CDX) nodeToComponent(n *sbom.Node) *cdx.Component {
 	if n == nil {
@@ -298,69 +901,44 @@ func (s *CDX) nodeToComponent(n *sbom.Node) *cdx.Component {
 
 	if n.Type == sbom.Node_FILE {
 		c.Type = cdx.ComponentTypeFile
-	} else if len(n.PrimaryPurpose) > 0 {
-		componentType, err := s.purposeToComponentType(n.PrimaryPurpose[0])
-		if err == nil {
-			c.Type = componentType
+	} else {
+		// The component type is required, nodes without a purpose are
+		// written with the default type
+		c.Type = defaultComponentType
+		if len(n.PrimaryPurpose) > 0 {
+			componentType, err := s.purposeToComponentType(n.PrimaryPurpose[0])
+			if err == nil {
+				c.Type = componentType
+			}
 		}
 		// TODO(degradation): Multiple PrimaryPurpose in protobom.Node, but
 		// cdx.Component only allows single Type so we are using the first
 	}
 
-	if n.Licenses != nil && len(n.Licenses) > 0 {
-		var licenseChoices []cdx.LicenseChoice
-		var licenses cdx.Licenses
-		for _, l := range n.Licenses {
-			licenseChoices = append(licenseChoices, cdx.LicenseChoice{
-				License: &cdx.License{
-					ID: l,
-				},
-			})
-		}
+	c.Scope = scopeToCDX(n.Scope)
 
-		licenses = licenseChoices
+	if licenses := nodeLicensesToCDX(n); len(licenses) > 0 {
 		c.Licenses = &licenses
 	}
 
-	if n.Hashes != nil && len(n.Hashes) > 0 {
-		for algo, hash := range n.Hashes {
-			cdxAlgo, err := s.protoHashAlgoToCdxAlgo(sbom.HashAlgorithm(algo))
-			if err != nil {
-				// TODO(degradation): Algorithm not supported in CDX
-				continue
-			}
-			*c.Hashes = append(*c.Hashes, cdx.Hash{
-				Algorithm: cdxAlgo,
-				Value:     hash,
-			})
-		}
+	// Licenses detected in the contents of the node are evidence
+	if detected := licensesToCDX(n.Id, n.LicensesDetected); len(detected) > 0 {
+		c.Evidence = &cdx.Evidence{Licenses: &detected}
 	}
 
-	if n.ExternalReferences != nil {
-		for _, er := range n.ExternalReferences {
-			cdxRef := cdx.ExternalReference{
-				URL:     er.Url,
-				Comment: er.Comment,
-				Type:    s.protobomExtRefTypeToCdxType(er.Type),
-			}
-			hashList := []cdx.Hash{}
-			for protoAlgo, val := range er.Hashes {
-				cdxAlgo, err := s.protoHashAlgoToCdxAlgo(sbom.HashAlgorithm(protoAlgo))
-				if err != nil {
-					// TODO(degradation): Hash not supported
-					continue
-				}
-				hashList = append(hashList, cdx.Hash{
-					Algorithm: cdxAlgo,
-					Value:     val,
-				})
-			}
-			if len(hashList) > 0 {
-				cdxRef.Hashes = &hashList
-			}
-			*c.ExternalReferences = append(*c.ExternalReferences, cdxRef)
-		}
+	*c.Hashes = append(*c.Hashes, s.hashesToCDX(n.Id, n.Hashes)...)
+
+	*c.ExternalReferences = append(*c.ExternalReferences, s.externalReferencesToCDX(n.Id, n.ExternalReferences)...)
+
+	// CycloneDX components have no download location or homepage fields,
+	// they are written as distribution and website references. Download
+	// locations pointing to a version control system are vcs references.
+	downloadType := cdx.ERTypeDistribution
+	if protospdx.IsVCSLocation(n.GetUrlDownload()) {
+		downloadType = cdx.ERTypeVCS
 	}
+	appendURLReference(c.ExternalReferences, downloadType, n.GetUrlDownload())
+	appendURLReference(c.ExternalReferences, cdx.ERTypeWebsite, n.GetUrlHome())
 
 	if n.Identifiers != nil {
 		for idType := range n.Identifiers {
@@ -378,35 +956,65 @@ func (s *CDX) nodeToComponent(n *sbom.Node) *cdx.Component {
 		}
 	}
 
-	if n.Suppliers != nil && len(n.GetSuppliers()) > 0 {
-		// TODO(degradation): CDX type Component only supports one Supplier while protobom supports multiple
+	c.Supplier = supplierToCDX(n.GetSuppliers())
+	c.Author, c.Publisher = originatorsToCDX(n.GetOriginators())
 
-		nodesupplier := n.GetSuppliers()[0]
-		oe := cdx.OrganizationalEntity{
-			Name: nodesupplier.GetName(),
-		}
-		if nodesupplier.Contacts != nil {
-			var contacts []cdx.OrganizationalContact
-			for _, nodecontact := range nodesupplier.GetContacts() {
-				newcontact := cdx.OrganizationalContact{
-					Name:  nodecontact.GetName(),
-					Email: nodecontact.GetEmail(),
-					Phone: nodecontact.GetPhone(),
-				}
-				contacts = append(contacts, newcontact)
-			}
-			oe.Contact = &contacts
-		}
-		c.Supplier = &oe
+	// CycloneDX cannot express NOASSERTION or NONE, the field is omitted
+	if sbom.HasValue(n.GetCopyright()) {
+		c.Copyright = n.GetCopyright()
 	}
 
-	if len(n.GetCopyright()) > 0 {
-		c.Copyright = n.GetCopyright()
+	if props := propertiesToCDX(n.GetProperties()); len(props) > 0 {
+		c.Properties = &props
 	}
 
 	return c
 }
 
+// addCarrierProperties appends to the properties of the component those
+// carrying the fields of the node CycloneDX has no place for, after the
+// properties of the node
+func addCarrierProperties(c *cdx.Component, n *sbom.Node) {
+	props := carrierPropertiesToCDX(n)
+	if c == nil || len(props) == 0 {
+		return
+	}
+	if c.Properties == nil {
+		c.Properties = &[]cdx.Property{}
+	}
+	*c.Properties = append(*c.Properties, props...)
+}
+
+// carrierPropertiesToCDX returns the protobom properties that keep the SPDX
+// fields of the node without a CycloneDX equivalent: the source information,
+// the license comments and the attribution texts.
+func carrierPropertiesToCDX(n *sbom.Node) []cdx.Property {
+	ret := []cdx.Property{}
+	if strings.TrimSpace(n.GetSourceInfo()) != "" {
+		ret = append(ret, cdx.Property{Name: cdxformats.PropertySourceInfo, Value: n.GetSourceInfo()})
+	}
+	if strings.TrimSpace(n.GetLicenseComments()) != "" {
+		ret = append(ret, cdx.Property{Name: cdxformats.PropertyLicenseComments, Value: n.GetLicenseComments()})
+	}
+	for _, text := range n.GetAttribution() {
+		ret = append(ret, cdx.Property{Name: cdxformats.PropertyAttributionText, Value: text})
+	}
+	return ret
+}
+
+// propertiesToCDX converts protobom properties to CycloneDX, keeping their
+// order and repeated names
+func propertiesToCDX(props []*sbom.Property) []cdx.Property {
+	ret := []cdx.Property{}
+	for _, p := range props {
+		if p.GetName() == "" {
+			continue
+		}
+		ret = append(ret, cdx.Property{Name: p.Name, Value: p.Value})
+	}
+	return ret
+}
+
 // Render calls the official CDX serializer to render the BOM into a specific version
 func (s *CDX) Render(doc interface{}, wr io.Writer, o *native.RenderOptions, _ interface{}) error {
 	if doc == nil {
@@ -440,6 +1048,14 @@ func (s *CDX) Render(doc interface{}, wr io.Writer, o *native.RenderOptions, _ i
 type serializerCDXState struct {
 	addedDict      map[string]struct{}
 	componentsDict map[string]*cdx.Component
+
+	// nested are the top level components when they are nested following
+	// all the contains edges
+	nested *[]cdx.Component
+
+	// carrierProperties is set when the components are written with the
+	// properties carrying the fields CycloneDX has no place for
+	carrierProperties bool
 }
 
 func newSerializerCDXState() *serializerCDXState {
@@ -450,6 +1066,9 @@ func newSerializerCDXState() *serializerCDXState {
 }
 
 func (s *serializerCDXState) components() []cdx.Component {
+	if s.nested != nil {
+		return *s.nested
+	}
 	components := []cdx.Component{}
 	for _, c := range s.componentsDict {
 		if _, ok := s.addedDict[c.BOMRef]; ok {
@@ -552,14 +1171,42 @@ func (s *CDX) protobomExtRefTypeToCdxType(protoExtRefType sbom.ExternalReference
 	case sbom.ExternalReference_WEBSITE:
 		return cdx.ERTypeWebsite
 	default:
+		// TODO(degradation): Types with no CycloneDX equivalent are written
+		// as "other" and will read back as such
 		return cdx.ERTypeOther
 	}
 }
 
+// hashesToCDX returns the hashes of an element as CycloneDX hashes, sorted
+// by algorithm. Hashes of algorithms that CycloneDX does not support are
+// dropped with a warning.
+func (s *CDX) hashesToCDX(id string, hashes map[int32]string) []cdx.Hash {
+	algos := make([]int32, 0, len(hashes))
+	for algo := range hashes {
+		algos = append(algos, algo)
+	}
+	slices.Sort(algos)
+
+	ret := []cdx.Hash{}
+	for _, algo := range algos {
+		cdxAlgo, err := s.protoHashAlgoToCdxAlgo(sbom.HashAlgorithm(algo))
+		if err != nil {
+			// TODO(degradation): Algorithm not supported in CDX
+			logrus.Warnf("dropping hash of %s: %v", id, err)
+			continue
+		}
+		ret = append(ret, cdx.Hash{
+			Algorithm: cdxAlgo,
+			Value:     hashes[algo],
+		})
+	}
+	return ret
+}
+
 // protoHashAlgoToCdxAlgo converts the protobom algorithm to the CDX
 // algorithm string.
 // TODO(degradation): The use of the following algorithms will result in
-// data loss when rendering to CycloneDX 1.4: ADLER32 MD4 MD6 SHA224
+// data loss when rendering to CycloneDX: MD2 ADLER32 MD4 MD6 SHA224
 // Also, HashAlgorithm_UNKNOWN also means data loss.
 func (s *CDX) protoHashAlgoToCdxAlgo(protoAlgo sbom.HashAlgorithm) (cdx.HashAlgorithm, error) {
 	switch protoAlgo {
@@ -594,8 +1241,13 @@ func (s *CDX) protoHashAlgoToCdxAlgo(protoAlgo sbom.HashAlgorithm) (cdx.HashAlgo
 	return "", fmt.Errorf("hash algorithm %q not supported by cyclonedx", protoAlgo)
 }
 
+// defaultComponentType is the type of the components written from nodes
+// without a purpose or with a purpose unknown to CycloneDX
+const defaultComponentType = cdx.ComponentTypeLibrary
+
 // purposeToComponentType converts from a protobom enumerated purpose to
-// a CycloneDC component type
+// a CycloneDX component type. Purposes without a CycloneDX equivalent are
+// written as data, Purpose_UNKNOWN_PURPOSE returns an error.
 func (s *CDX) purposeToComponentType(purpose sbom.Purpose) (cdx.ComponentType, error) {
 	switch purpose {
 	case sbom.Purpose_APPLICATION, sbom.Purpose_EXECUTABLE, sbom.Purpose_INSTALL:
@@ -626,3 +1278,106 @@ func (s *CDX) purposeToComponentType(purpose sbom.Purpose) (cdx.ComponentType, e
 
 	return "", fmt.Errorf("document purpose %q not supported", purpose)
 }
+
+// scopeToCDX returns the CycloneDX scope of a node scope, blank when the
+// scope is unknown
+func scopeToCDX(scope sbom.Node_Scope) cdx.Scope {
+	switch scope {
+	case sbom.Node_REQUIRED:
+		return cdx.ScopeRequired
+	case sbom.Node_OPTIONAL:
+		return cdx.ScopeOptional
+	case sbom.Node_EXCLUDED:
+		return cdx.ScopeExcluded
+	default:
+		return ""
+	}
+}
+
+// nodeLicensesToCDX converts the licenses of the node to CycloneDX license
+// choices. CycloneDX does not tell concluded from declared licenses, the
+// concluded license is preferred and the node licenses are written when it
+// has none. Concluded licenses that only join the node licenses, as those
+// read from CycloneDX, are written as the original list.
+func nodeLicensesToCDX(n *sbom.Node) cdx.Licenses {
+	if sbom.HasValue(n.LicenseConcluded) && n.LicenseConcluded != license.Disjunction(n.Licenses) {
+		return licensesToCDX(n.Id, []string{n.LicenseConcluded})
+	}
+	return licensesToCDX(n.Id, n.Licenses)
+}
+
+// licensesToCDX converts license expressions to CycloneDX license choices.
+// Only compound expressions are written as expressions, licenses in the SPDX
+// list are written as IDs and any other license (LicenseRefs or unknown
+// identifiers) by name.
+//
+// CycloneDX only allows a single expression in the licenses array, when the
+// list has a compound expression all the licenses are joined with OR into
+// one expression. If some of them are not valid expressions and cannot be
+// joined, the compound expressions are split into their licenses instead.
+func licensesToCDX(id string, expressions []string) cdx.Licenses {
+	licenses := cdx.Licenses{}
+	parsed := []*license.Expression{}
+	joinable := []string{}
+	compound := false
+	for _, l := range expressions {
+		normalized, err := license.Normalize(l)
+		if err != nil {
+			logrus.Warnf("invalid license in %s: %v", id, err)
+		}
+		if !sbom.HasValue(normalized) {
+			// CycloneDX cannot express NOASSERTION or NONE
+			continue
+		}
+
+		e, err := license.Parse(normalized)
+		if err != nil {
+			e = nil
+		} else {
+			joinable = append(joinable, normalized)
+		}
+		parsed = append(parsed, e)
+		switch {
+		case e != nil && e.IsCompound():
+			compound = true
+			licenses = append(licenses, cdx.LicenseChoice{Expression: normalized})
+		case e != nil:
+			licenses = append(licenses, licenseChoiceToCDX(e))
+		default:
+			licenses = append(licenses, cdx.LicenseChoice{License: &cdx.License{Name: normalized}})
+		}
+	}
+
+	if !compound || len(licenses) < 2 {
+		return licenses
+	}
+	if len(joinable) == len(licenses) {
+		return cdx.Licenses{{Expression: license.Disjunction(joinable)}}
+	}
+
+	// TODO(degradation): Licenses that are not valid expressions cannot be
+	// joined to an expression, the compound expressions are written as the
+	// list of their licenses losing the operators between them
+	logrus.Warnf("splitting the license expressions of %s, some licenses cannot be joined into an expression", id)
+	split := cdx.Licenses{}
+	for i, e := range parsed {
+		if e == nil || !e.IsCompound() {
+			split = append(split, licenses[i])
+			continue
+		}
+		for _, operand := range e.Operands() {
+			split = append(split, licenseChoiceToCDX(operand))
+		}
+	}
+	return split
+}
+
+// licenseChoiceToCDX returns the license choice of an operand of a license
+// expression. Licenses in the SPDX list are written as IDs, the rest (and
+// licenses with an exception or the "+" operator) by name.
+func licenseChoiceToCDX(e *license.Expression) cdx.LicenseChoice {
+	if !e.IsCompound() && license.IsLicenseID(e.License) {
+		return cdx.LicenseChoice{License: &cdx.License{ID: e.License}}
+	}
+	return cdx.LicenseChoice{License: &cdx.License{Name: e.String()}}
+}
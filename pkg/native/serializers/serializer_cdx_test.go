@@ -1,12 +1,26 @@
 package serializers
 
 import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/CycloneDX/cyclonedx-go"
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	cdxformats "github.com/bom-squad/protobom/pkg/formats/cyclonedx"
+	"github.com/bom-squad/protobom/pkg/license"
+	"github.com/bom-squad/protobom/pkg/native"
+	"github.com/bom-squad/protobom/pkg/native/unserializers"
 	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestComponentType(t *testing.T) {
@@ -73,6 +87,14 @@ func TestComponentType(t *testing.T) {
 			n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_PLATFORM}
 			n.Type = sbom.Node_PACKAGE
 		}, cdx.ComponentTypePlatform},
+		"no purpose": {func(n *sbom.Node) {
+			n.PrimaryPurpose = []sbom.Purpose{}
+			n.Type = sbom.Node_PACKAGE
+		}, cdx.ComponentTypeLibrary},
+		"unknown purpose": {func(n *sbom.Node) {
+			n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_UNKNOWN_PURPOSE}
+			n.Type = sbom.Node_PACKAGE
+		}, cdx.ComponentTypeLibrary},
 	} {
 		tc.prepare(node)
 		comp := sut.nodeToComponent(node)
@@ -190,3 +212,1223 @@ func TestPurposeToComponentType(t *testing.T) {
 		require.Equal(t, cdxType, res)
 	}
 }
+
+func TestAnnotationToCDX(t *testing.T) {
+	date := timestamppb.New(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	for _, tc := range []struct {
+		name     string
+		sut      *sbom.Annotation
+		expected *cdx.Annotator
+	}{
+		{
+			"individual",
+			&sbom.Annotation{Annotator: &sbom.Person{Name: "Jane Doe", Email: "jane@example.com"}},
+			&cdx.Annotator{Individual: &cdx.OrganizationalContact{Name: "Jane Doe", Email: "jane@example.com"}},
+		},
+		{
+			"organization",
+			&sbom.Annotation{Annotator: &sbom.Person{Name: "Acme", IsOrg: true}},
+			&cdx.Annotator{Organization: &cdx.OrganizationalEntity{Name: "Acme"}},
+		},
+		{
+			"tool",
+			&sbom.Annotation{Tool: &sbom.Tool{Name: "scanner", Version: "1.0", Vendor: "Acme"}},
+			&cdx.Annotator{Component: &cdx.Component{
+				Type: cdx.ComponentTypeApplication, Name: "scanner", Version: "1.0", Publisher: "Acme",
+			}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.sut.Date = date
+			tc.sut.Comment = "comment"
+			res := annotationToCDX(tc.sut, "pkg:generic/test@1.0")
+			require.NotNil(t, res)
+			require.Equal(t, tc.expected, res.Annotator)
+			require.Equal(t, &[]cdx.BOMReference{"pkg:generic/test@1.0"}, res.Subjects)
+			require.Equal(t, "2023-01-01T00:00:00Z", res.Timestamp)
+			require.Equal(t, "comment", res.Text)
+		})
+	}
+	require.Nil(t, annotationToCDX(&sbom.Annotation{Comment: "no annotator"}, "test"))
+}
+
+func TestSerializeDocumentAnnotations(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		id   string
+		opts *CDXOptions
+	}{
+		{"id read from spdx", "https://example.com/spdxdocs/app", &CDXOptions{SerialNumber: SerialNumberPreserve}},
+		{"preserved serial number", "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79", &CDXOptions{SerialNumber: SerialNumberPreserve, IncrementVersion: true}},
+		{"random serial number", "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79", &CDXOptions{SerialNumber: SerialNumberRandom}},
+		{"deterministic serial number", "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79", &CDXOptions{SerialNumber: SerialNumberDeterministic}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Id = tc.id
+			doc.Metadata.Version = "3"
+			doc.Metadata.Annotations = []*sbom.Annotation{{Annotator: &sbom.Person{Name: "Jane Doe"}, Comment: "Reviewed"}}
+			doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app"})
+
+			res, err := NewCDX("1.5", "json").Serialize(doc, nil, tc.opts)
+			require.NoError(t, err)
+			bom := res.(*cdx.BOM)
+			require.True(t, strings.HasPrefix(bom.SerialNumber, "urn:uuid:"))
+			require.NotNil(t, bom.Annotations)
+			require.Len(t, *bom.Annotations, 1)
+
+			// The BOM-Link points to the serial number and version written
+			link := fmt.Sprintf("urn:cdx:%s/%d", strings.TrimPrefix(bom.SerialNumber, "urn:uuid:"), bom.Version)
+			require.Equal(t, &[]cdx.BOMReference{cdx.BOMReference(link)}, (*bom.Annotations)[0].Subjects)
+		})
+	}
+}
+
+func TestNodeCommentToCDX(t *testing.T) {
+	// CycloneDX components have no comment, node comments are not written
+	sut := NewCDX("1.5", "json")
+	comp := sut.nodeToComponent(&sbom.Node{Id: "test", Name: "test", Comment: "Reviewed"})
+	require.Empty(t, comp.Description)
+
+	comp = sut.nodeToComponent(&sbom.Node{Id: "test", Name: "test", Description: "A library", Comment: "Reviewed"})
+	require.Equal(t, "A library", comp.Description)
+}
+
+func TestNodeLicensesToCDX(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      []string
+		expected cyclonedx.Licenses
+	}{
+		{"none", nil, cyclonedx.Licenses{}},
+		{"license id", []string{"mit"}, cyclonedx.Licenses{{License: &cyclonedx.License{ID: "MIT"}}}},
+		{"deprecated id", []string{"GPL-2.0+"}, cyclonedx.Licenses{{License: &cyclonedx.License{ID: "GPL-2.0+"}}}},
+		{
+			"with exception",
+			[]string{"GPL-2.0-only WITH Classpath-exception-2.0"},
+			cyclonedx.Licenses{{Expression: "GPL-2.0-only WITH Classpath-exception-2.0"}},
+		},
+		{
+			"compound",
+			[]string{"LicenseRef-Proprietary AND MIT"},
+			cyclonedx.Licenses{{Expression: "LicenseRef-Proprietary AND MIT"}},
+		},
+		{"or later", []string{"Apache-2.0+"}, cyclonedx.Licenses{{Expression: "Apache-2.0+"}}},
+		{"license ref", []string{"LicenseRef-Proprietary"}, cyclonedx.Licenses{{License: &cyclonedx.License{Name: "LicenseRef-Proprietary"}}}},
+		{"unknown", []string{"Custom License"}, cyclonedx.Licenses{{License: &cyclonedx.License{Name: "Custom License"}}}},
+		{"noassertion", []string{"NOASSERTION", "NONE", ""}, cyclonedx.Licenses{}},
+		{
+			"expression and licenses",
+			[]string{"Apache-2.0 OR MIT", "bsd-3-clause", "LicenseRef-Proprietary"},
+			cyclonedx.Licenses{{Expression: "(Apache-2.0 OR MIT) OR BSD-3-Clause OR LicenseRef-Proprietary"}},
+		},
+		{
+			// Names cannot be joined to the expression, so it is split
+			"expression and names",
+			[]string{"Apache-2.0 AND MIT", "Custom License"},
+			cyclonedx.Licenses{
+				{License: &cyclonedx.License{ID: "Apache-2.0"}},
+				{License: &cyclonedx.License{ID: "MIT"}},
+				{License: &cyclonedx.License{Name: "Custom License"}},
+			},
+		},
+		{
+			"expression with exception and names",
+			[]string{"Custom License", "GPL-2.0-only WITH Classpath-exception-2.0 OR LicenseRef-Proprietary"},
+			cyclonedx.Licenses{
+				{License: &cyclonedx.License{Name: "Custom License"}},
+				{License: &cyclonedx.License{Name: "GPL-2.0-only WITH Classpath-exception-2.0"}},
+				{License: &cyclonedx.License{Name: "LicenseRef-Proprietary"}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			licenses := nodeLicensesToCDX(&sbom.Node{Id: "test", Licenses: tc.sut})
+			require.Equal(t, tc.expected, licenses)
+
+			// No license is lost in the conversion
+			written := []string{}
+			for _, l := range licenses {
+				if l.License != nil {
+					written = append(written, l.License.ID+l.License.Name)
+					continue
+				}
+				e, err := license.Parse(l.Expression)
+				require.NoError(t, err)
+				for _, operand := range e.Operands() {
+					written = append(written, operand.String())
+				}
+			}
+			for _, l := range tc.sut {
+				normalized, _ := license.Normalize(l)
+				if !sbom.HasValue(normalized) {
+					continue
+				}
+				e, err := license.Parse(normalized)
+				if err != nil {
+					require.Contains(t, written, normalized)
+					continue
+				}
+				for _, operand := range e.Operands() {
+					require.Contains(t, written, operand.String())
+				}
+			}
+		})
+	}
+
+	// The concluded license is preferred over the node licenses
+	require.Equal(t, cyclonedx.Licenses{{Expression: "MIT OR Apache-2.0"}}, nodeLicensesToCDX(&sbom.Node{
+		Id: "test", Licenses: []string{"MIT"}, LicenseConcluded: "MIT OR Apache-2.0",
+	}))
+	require.Equal(t, cyclonedx.Licenses{{License: &cyclonedx.License{ID: "MIT"}}}, nodeLicensesToCDX(&sbom.Node{
+		Id: "test", Licenses: []string{"MIT"}, LicenseConcluded: sbom.NoAssertionValue,
+	}))
+
+	// Concluded licenses joining the node licenses keep the list
+	require.Equal(t, cyclonedx.Licenses{
+		{License: &cyclonedx.License{ID: "MIT"}},
+		{License: &cyclonedx.License{ID: "Apache-2.0"}},
+	}, nodeLicensesToCDX(&sbom.Node{
+		Id: "test", Licenses: []string{"MIT", "Apache-2.0"}, LicenseConcluded: "MIT OR Apache-2.0",
+	}))
+}
+
+func TestScopeToCDX(t *testing.T) {
+	for scope, expected := range map[sbom.Node_Scope]cdx.Scope{
+		sbom.Node_UNKNOWN_SCOPE: "",
+		sbom.Node_REQUIRED:      cdx.ScopeRequired,
+		sbom.Node_OPTIONAL:      cdx.ScopeOptional,
+		sbom.Node_EXCLUDED:      cdx.ScopeExcluded,
+	} {
+		require.Equal(t, expected, scopeToCDX(scope))
+	}
+}
+
+func TestSerializeMetadataAndDependencies(t *testing.T) {
+	doc := sbom.NewDocument(sbom.WithTimestamp(time.Date(2023, 8, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))))
+	doc.Metadata.Tools = []*sbom.Tool{{Name: "scanner", Version: "2.0", Vendor: "ACME"}}
+	doc.NodeList.AddNode(&sbom.Node{Id: "root", Name: "root"})
+	doc.NodeList.AddNode(&sbom.Node{Id: "lib-a", Name: "lib-a", Scope: sbom.Node_REQUIRED})
+	doc.NodeList.AddNode(&sbom.Node{Id: "lib-b", Name: "lib-b", Scope: sbom.Node_OPTIONAL})
+	doc.NodeList.RootElements = []string{"root"}
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "root", To: []string{"lib-a", "lib-b"}})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "lib-a", To: []string{"lib-b"}})
+
+	res, err := NewCDX("1.5", "json").Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	bom := res.(*cdx.BOM)
+	require.Equal(t, "2023-08-01T10:00:00Z", bom.Metadata.Timestamp)
+	require.Equal(t, "ACME", (*bom.Metadata.Tools.Components)[0].Supplier.Name)
+
+	// Dependencies do not remove their targets from the components
+	components := map[string]cdx.Scope{}
+	for _, c := range *bom.Components {
+		components[c.BOMRef] = c.Scope
+	}
+	require.Equal(t, map[string]cdx.Scope{"lib-a": cdx.ScopeRequired, "lib-b": cdx.ScopeOptional}, components)
+	require.Len(t, *bom.Dependencies, 2)
+	for _, d := range *bom.Dependencies {
+		if d.Ref == "lib-a" {
+			require.Equal(t, []string{"lib-b"}, *d.Dependencies)
+		}
+	}
+}
+
+func TestSerializeDependencies(t *testing.T) {
+	newDoc := func() *sbom.Document {
+		doc := sbom.NewDocument()
+		for _, id := range []string{"root", "lib-a", "lib-b", "lib-c", "file-a"} {
+			doc.NodeList.AddNode(&sbom.Node{Id: id, Name: id})
+		}
+		doc.NodeList.RootElements = []string{"root"}
+		return doc
+	}
+	deps := func(t *testing.T, doc *sbom.Document, opts interface{}) map[string][]string {
+		t.Helper()
+		res, err := NewCDX("1.5", "json").Serialize(doc, nil, opts)
+		require.NoError(t, err)
+		bom := res.(*cdx.BOM)
+		ret := map[string][]string{}
+		for _, d := range *bom.Dependencies {
+			require.NotContains(t, ret, d.Ref, "duplicate dependency entry")
+			ret[d.Ref] = nil
+			if d.Dependencies != nil {
+				ret[d.Ref] = *d.Dependencies
+			}
+		}
+		return ret
+	}
+
+	t.Run("root entry", func(t *testing.T) {
+		require.Equal(t, map[string][]string{"root": nil}, deps(t, newDoc(), nil))
+	})
+
+	t.Run("edges are collapsed", func(t *testing.T) {
+		doc := newDoc()
+		doc.NodeList.Edges = []*sbom.Edge{
+			{Type: sbom.Edge_dependsOn, From: "root", To: []string{"lib-a", "lib-b"}},
+			{Type: sbom.Edge_dependsOn, From: "root", To: []string{"lib-b", "lib-c", "lib-a"}},
+			{Type: sbom.Edge_dependencyOf, From: "lib-c", To: []string{"lib-a"}},
+		}
+		require.Equal(t, map[string][]string{
+			"root":  {"lib-a", "lib-b", "lib-c"},
+			"lib-a": {"lib-c"},
+		}, deps(t, doc, nil))
+	})
+
+	t.Run("missing nodes are skipped", func(t *testing.T) {
+		doc := newDoc()
+		doc.NodeList.Edges = []*sbom.Edge{
+			{Type: sbom.Edge_dependsOn, From: "root", To: []string{"lib-a", "removed"}},
+			{Type: sbom.Edge_dependsOn, From: "removed", To: []string{"lib-a"}},
+			{Type: sbom.Edge_contains, From: "lib-a", To: []string{"removed"}},
+		}
+		require.Equal(t, map[string][]string{"root": {"lib-a"}}, deps(t, doc, nil))
+	})
+
+	t.Run("contains edges", func(t *testing.T) {
+		doc := newDoc()
+		doc.NodeList.Edges = []*sbom.Edge{
+			{Type: sbom.Edge_dependsOn, From: "root", To: []string{"lib-a"}},
+			{Type: sbom.Edge_contains, From: "root", To: []string{"lib-b"}},
+			{Type: sbom.Edge_contains, From: "lib-a", To: []string{"file-a"}},
+		}
+		require.Equal(t, map[string][]string{"root": {"lib-a"}}, deps(t, doc, nil))
+		require.Equal(t, map[string][]string{
+			"root":  {"lib-a", "lib-b"},
+			"lib-a": {"file-a"},
+		}, deps(t, doc, &CDXOptions{ContainsAsDependencies: true}))
+		require.Equal(t, map[string][]string{
+			"root":  {"lib-a", "lib-b"},
+			"lib-a": {"file-a"},
+		}, deps(t, doc, CDXOptions{ContainsAsDependencies: true}))
+	})
+}
+
+func TestSerializeNestedAssemblies(t *testing.T) {
+	doc := sbom.NewDocument()
+	for _, id := range []string{"image", "layer-1", "layer-2", "pkg-a", "pkg-b", "file-a", "loose", "cycle-1", "cycle-2"} {
+		doc.NodeList.AddNode(&sbom.Node{Id: id, Name: id})
+	}
+	doc.NodeList.RootElements = []string{"image"}
+	doc.NodeList.Edges = []*sbom.Edge{
+		{Type: sbom.Edge_contains, From: "image", To: []string{"layer-1", "layer-2"}},
+		{Type: sbom.Edge_contains, From: "layer-1", To: []string{"pkg-a"}},
+		{Type: sbom.Edge_contained_by, From: "file-a", To: []string{"pkg-a"}, Inverse: true},
+		// pkg-a is also found in the second layer
+		{Type: sbom.Edge_contains, From: "layer-2", To: []string{"pkg-b", "pkg-a"}},
+		{Type: sbom.Edge_contains, From: "cycle-1", To: []string{"cycle-2"}},
+		{Type: sbom.Edge_contains, From: "cycle-2", To: []string{"cycle-1"}},
+		// Back to the root
+		{Type: sbom.Edge_contains, From: "pkg-b", To: []string{"image"}},
+	}
+
+	// tree renders the components as ref(children...)
+	var tree func(comps *[]cdx.Component) string
+	tree = func(comps *[]cdx.Component) string {
+		if comps == nil {
+			return ""
+		}
+		parts := []string{}
+		for _, c := range *comps {
+			parts = append(parts, c.BOMRef+tree(c.Components))
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	}
+
+	for i := 0; i < 5; i++ {
+		res, err := NewCDX("1.5", "json").Serialize(doc, nil, &CDXOptions{NestedAssemblies: true})
+		require.NoError(t, err)
+		bom := res.(*cdx.BOM)
+		require.Equal(t, "image", bom.Metadata.Component.BOMRef)
+		require.Nil(t, bom.Metadata.Component.Components)
+		require.Equal(t, "(layer-1(pkg-a(file-a)) layer-2(pkg-b) loose cycle-1(cycle-2))", tree(bom.Components))
+
+		// The second parent references the nested component
+		deps := map[string][]string{}
+		for _, d := range *bom.Dependencies {
+			if d.Dependencies != nil {
+				deps[d.Ref] = *d.Dependencies
+			}
+		}
+		require.Equal(t, map[string][]string{"layer-2": {"pkg-a"}}, deps)
+	}
+}
+
+func TestLicensesDetectedRoundtrip(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{
+		Id: "app", Name: "app", LicenseConcluded: "MIT",
+		LicensesDetected: []string{"MIT", "Apache-2.0", sbom.NoAssertionValue},
+	})
+	doc.NodeList.RootElements = []string{"app"}
+
+	sut := NewCDX("1.5", "json")
+	res, err := sut.Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	component := res.(*cdx.BOM).Metadata.Component
+	require.Equal(t, cdx.Licenses{{License: &cdx.License{ID: "MIT"}}}, *component.Licenses)
+	require.NotNil(t, component.Evidence)
+	require.Equal(t, cdx.Licenses{
+		{License: &cdx.License{ID: "MIT"}},
+		{License: &cdx.License{ID: "Apache-2.0"}},
+	}, *component.Evidence.Licenses)
+
+	var buf strings.Builder
+	require.NoError(t, sut.Render(res, &buf, &native.RenderOptions{}, nil))
+	doc2, err := unserializers.NewCDX("1.5", "json").Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	app := doc2.NodeList.GetNodeByID("app")
+	require.Equal(t, "MIT", app.LicenseConcluded)
+	require.Equal(t, []string{"MIT", "Apache-2.0"}, app.LicensesDetected)
+}
+
+func TestSerializeExtractedLicenses(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.Metadata.ExtractedLicenses = []*sbom.ExtractedLicense{
+		{Id: "LicenseRef-custom", Name: "Custom License", Text: "Do what you want", SeeAlso: []string{"https://example.com/license"}},
+		{Id: "LicenseRef-noname", Text: "No name"},
+	}
+	doc.NodeList.AddNode(&sbom.Node{Id: "root", Name: "root", Licenses: []string{"LicenseRef-custom"}})
+	doc.NodeList.AddNode(&sbom.Node{Id: "lib", Name: "lib", Licenses: []string{"LicenseRef-noname", "LicenseRef-undefined"}})
+	doc.NodeList.RootElements = []string{"root"}
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_contains, From: "root", To: []string{"lib"}})
+
+	res, err := NewCDX("1.5", "json").Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	bom := res.(*cdx.BOM)
+
+	require.Equal(t, cdx.Licenses{{License: &cdx.License{
+		Name: "Custom License",
+		Text: &cdx.AttachedText{Content: "Do what you want"},
+		URL:  "https://example.com/license",
+	}}}, *bom.Metadata.Component.Licenses)
+
+	require.Len(t, *bom.Components, 1)
+	require.Equal(t, cdx.Licenses{
+		{License: &cdx.License{Name: "LicenseRef-noname", Text: &cdx.AttachedText{Content: "No name"}}},
+		{License: &cdx.License{Name: "LicenseRef-undefined"}},
+	}, *(*bom.Components)[0].Licenses)
+}
+
+func TestDocumentExternalReferencesRoundtrip(t *testing.T) {
+	bom := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"bom-ref": "app", "type": "application", "name": "app"}},
+  "externalReferences": [
+    {"type": "bom", "url": "https://example.com/sboms/app.cdx.json", "comment": "Published SBOM"},
+    {"type": "build-meta", "url": "https://ci.example.com/builds/42", "hashes": [{"alg": "SHA-256", "content": "a7b2e1b8c64b1c0d4c3b3e0c6e3b4b0f1e5e0f3c2b1a0d9e8f7a6b5c4d3e2f1a"}]}
+  ]
+}`
+	check := func(doc *sbom.Document) {
+		t.Helper()
+		refs := doc.Metadata.ExternalReferences
+		require.Len(t, refs, 2)
+		require.Equal(t, sbom.ExternalReference_BOM, refs[0].Type)
+		require.Equal(t, "https://example.com/sboms/app.cdx.json", refs[0].Url)
+		require.Equal(t, "Published SBOM", refs[0].Comment)
+		require.Equal(t, sbom.ExternalReference_BUILD_META, refs[1].Type)
+		require.Equal(t, "https://ci.example.com/builds/42", refs[1].Url)
+		require.Equal(t, "a7b2e1b8c64b1c0d4c3b3e0c6e3b4b0f1e5e0f3c2b1a0d9e8f7a6b5c4d3e2f1a", refs[1].Hashes[int32(sbom.HashAlgorithm_SHA256)])
+
+		// Document references are not attached to the components
+		require.Empty(t, doc.NodeList.GetNodeByID("app").ExternalReferences)
+	}
+
+	doc, err := unserializers.NewCDX("1.5", "json").Unserialize(strings.NewReader(bom), nil, nil)
+	require.NoError(t, err)
+	check(doc)
+
+	sut := NewCDX("1.5", "json")
+	res, err := sut.Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, res.(*cdx.BOM).ExternalReferences)
+	require.Len(t, *res.(*cdx.BOM).ExternalReferences, 2)
+
+	var buf strings.Builder
+	require.NoError(t, sut.Render(res, &buf, &native.RenderOptions{}, nil))
+	doc2, err := unserializers.NewCDX("1.5", "json").Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	check(doc2)
+}
+
+func TestNodeLocationsToCDX(t *testing.T) {
+	vcs := "git+https://github.com/bom-squad/protobom.git@v0.3.0"
+	home := "https://github.com/bom-squad/protobom"
+	doc := sbom.NewDocument()
+	doc.NodeList = &sbom.NodeList{
+		Nodes: []*sbom.Node{
+			{Id: "protobom", Name: "protobom", UrlDownload: vcs, UrlHome: home},
+			{Id: "unknown", Name: "unknown", UrlDownload: sbom.NoAssertionValue, UrlHome: sbom.NoneValue},
+		},
+		Edges:        []*sbom.Edge{{From: "protobom", Type: sbom.Edge_contains, To: []string{"unknown"}}},
+		RootElements: []string{"protobom"},
+	}
+
+	sut := NewCDX("1.5", "json")
+	res, err := sut.Serialize(doc, nil, nil)
+	require.NoError(t, err)
+
+	bom := res.(*cdx.BOM)
+	// Download locations pointing to a repository are vcs references
+	require.Equal(t, []cdx.ExternalReference{
+		{Type: cdx.ERTypeVCS, URL: vcs},
+		{Type: cdx.ERTypeWebsite, URL: home},
+	}, *bom.Metadata.Component.ExternalReferences)
+	require.Len(t, *bom.Components, 1)
+	require.Empty(t, *(*bom.Components)[0].ExternalReferences)
+
+	// Reading the references back fills the node fields without
+	// duplicating them when written again
+	var buf strings.Builder
+	require.NoError(t, sut.Render(res, &buf, &native.RenderOptions{}, nil))
+	doc2, err := unserializers.NewCDX("1.5", "json").Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	n := doc2.NodeList.GetNodeByID("protobom")
+	require.NotNil(t, n)
+	require.Equal(t, vcs, n.UrlDownload)
+	require.Equal(t, home, n.UrlHome)
+	require.Len(t, n.ExternalReferences, 2)
+
+	res2, err := sut.Serialize(doc2, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, *res2.(*cdx.BOM).Metadata.Component.ExternalReferences, 2)
+}
+
+func TestCompositionsRoundtrip(t *testing.T) {
+	bom := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"bom-ref": "app", "type": "application", "name": "app"}},
+  "components": [
+    {"bom-ref": "lib-a", "type": "library", "name": "lib-a"},
+    {"bom-ref": "lib-b", "type": "library", "name": "lib-b"}
+  ],
+  "compositions": [
+    {"bom-ref": "inventory", "aggregate": "complete", "assemblies": ["lib-a", "lib-b"]}
+  ]
+}`
+	check := func(doc *sbom.Document) {
+		t.Helper()
+		require.Len(t, doc.Metadata.Compositions, 1)
+		c := doc.Metadata.Compositions[0]
+		require.Equal(t, "inventory", c.Id)
+		require.Equal(t, sbom.Composition_COMPLETE, c.Aggregate)
+		require.Equal(t, []string{"lib-a", "lib-b"}, c.Assemblies)
+		require.Empty(t, c.Dependencies)
+	}
+
+	doc, err := unserializers.NewCDX("1.5", "json").Unserialize(strings.NewReader(bom), nil, nil)
+	require.NoError(t, err)
+	check(doc)
+
+	sut := NewCDX("1.5", "json")
+	res, err := sut.Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, res.(*cdx.BOM).Compositions)
+
+	var buf strings.Builder
+	require.NoError(t, sut.Render(res, &buf, &native.RenderOptions{}, nil))
+	require.Contains(t, buf.String(), `"aggregate": "complete"`)
+	doc2, err := unserializers.NewCDX("1.5", "json").Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	check(doc2)
+}
+
+func TestPropertiesRoundtrip(t *testing.T) {
+	bom := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {
+    "component": {"bom-ref": "app", "type": "application", "name": "app"},
+    "properties": [{"name": "ci:pipeline", "value": "release"}]
+  },
+  "components": [
+    {
+      "bom-ref": "lib-a", "type": "library", "name": "lib-a",
+      "properties": [
+        {"name": "syft:package:foundBy", "value": "javascript-lock-cataloger"},
+        {"name": "syft:location:0:path", "value": "/app/package-lock.json"},
+        {"name": "syft:package:foundBy", "value": "sbom-cataloger"}
+      ]
+    }
+  ]
+}`
+	expected := []*sbom.Property{
+		{Name: "syft:package:foundBy", Value: "javascript-lock-cataloger"},
+		{Name: "syft:location:0:path", Value: "/app/package-lock.json"},
+		{Name: "syft:package:foundBy", Value: "sbom-cataloger"},
+	}
+	check := func(doc *sbom.Document) {
+		t.Helper()
+		props := doc.NodeList.GetNodeByID("lib-a").Properties
+		require.Len(t, props, len(expected))
+		for i := range expected {
+			require.True(t, proto.Equal(expected[i], props[i]), props[i].String())
+		}
+		require.Len(t, doc.Metadata.Properties, 1)
+		require.Equal(t, "ci:pipeline", doc.Metadata.Properties[0].Name)
+	}
+
+	doc, err := unserializers.NewCDX("1.5", "json").Unserialize(strings.NewReader(bom), nil, nil)
+	require.NoError(t, err)
+	check(doc)
+
+	// Attribution texts are written after the properties of the node
+	doc.NodeList.GetNodeByID("lib-a").Attribution = []string{"Copyright Acme"}
+	sut := NewCDX("1.5", "json")
+	res, err := sut.Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	var lib cdx.Component
+	for _, c := range *res.(*cdx.BOM).Components {
+		if c.BOMRef == "lib-a" {
+			lib = c
+		}
+	}
+	require.NotNil(t, lib.Properties)
+	require.Len(t, *lib.Properties, 4)
+	require.Equal(t, cdx.Property{Name: cdxformats.PropertyAttributionText, Value: "Copyright Acme"}, (*lib.Properties)[3])
+
+	var buf strings.Builder
+	require.NoError(t, sut.Render(res, &buf, &native.RenderOptions{}, nil))
+	doc2, err := unserializers.NewCDX("1.5", "json").Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	check(doc2)
+	require.Equal(t, []string{"Copyright Acme"}, doc2.NodeList.GetNodeByID("lib-a").Attribution)
+}
+
+func TestCompositionsToCDX(t *testing.T) {
+	// Every aggregate has a CycloneDX value
+	for v := range sbom.Composition_Aggregate_name {
+		aggregate := aggregateToCDX(sbom.Composition_Aggregate(v))
+		require.NotEmpty(t, aggregate)
+		require.Equal(t, strings.ToLower(string(aggregate)), string(aggregate))
+	}
+	require.Equal(t, cdx.CompositionAggregateIncompleteFirstPartyProprietaryOnly, aggregateToCDX(sbom.Composition_INCOMPLETE_FIRST_PARTY_PROPRIETARY_ONLY))
+	require.Equal(t, cdx.CompositionAggregateNotSpecified, aggregateToCDX(sbom.Composition_Aggregate(99)))
+
+	// Generated references are not written
+	res := compositionsToCDX([]*sbom.Composition{
+		{Aggregate: sbom.Composition_INCOMPLETE, Assemblies: []string{"lib", "protobom-auto--000000001"}},
+		nil,
+		{Aggregate: sbom.Composition_UNKNOWN, Dependencies: []string{"protobom-auto--000000002"}},
+	})
+	require.Equal(t, []cdx.Composition{
+		{Aggregate: cdx.CompositionAggregateIncomplete, Assemblies: &[]cdx.BOMReference{"lib"}},
+		{Aggregate: cdx.CompositionAggregateUnknown},
+	}, res)
+}
+
+func TestRenderXML(t *testing.T) {
+	bom := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "serialNumber": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "version": 3,
+  "metadata": {
+    "timestamp": "2023-08-01T10:00:00Z",
+    "tools": [{"vendor": "acme", "name": "sbom-tool", "version": "1.0.0"}],
+    "authors": [{"name": "Jane Doe", "email": "jane@example.com"}],
+    "component": {"bom-ref": "app", "type": "application", "name": "app", "version": "2.0.0", "purl": "pkg:generic/app@2.0.0"}
+  },
+  "components": [
+    {
+      "bom-ref": "lib-a", "type": "library", "name": "lib-a", "version": "1.2.3", "scope": "required",
+      "hashes": [{"alg": "SHA-256", "content": "4f0b3e1a5a8f0a1bfe0e3bbd3e4a93e2b3e0f6c4d0a4de0e3e0a2a1e0f6b3c2d"}],
+      "licenses": [{"license": {"id": "MIT"}}],
+      "externalReferences": [{"type": "website", "url": "https://example.com/lib-a"}],
+      "purl": "pkg:golang/example.com/lib-a@1.2.3"
+    },
+    {"bom-ref": "lib-b", "type": "library", "name": "lib-b", "version": "0.9.0", "licenses": [{"expression": "MIT OR Apache-2.0"}]}
+  ],
+  "dependencies": [
+    {"ref": "app", "dependsOn": ["lib-a"]},
+    {"ref": "lib-a", "dependsOn": ["lib-b"]}
+  ],
+  "compositions": [{"bom-ref": "inventory", "aggregate": "complete", "assemblies": ["lib-a", "lib-b"]}]
+}`
+	doc, err := unserializers.NewCDX("1.5", "json").Unserialize(strings.NewReader(bom), nil, nil)
+	require.NoError(t, err)
+
+	// Both encodings render the same serialized document as the order of
+	// the components is not stable between serializations
+	render := func(res interface{}, version, encoding string) string {
+		t.Helper()
+		var buf strings.Builder
+		require.NoError(t, NewCDX(version, encoding).Render(res, &buf, &native.RenderOptions{}, nil))
+		return buf.String()
+	}
+	decode := func(data string, format cdx.BOMFileFormat) *cdx.BOM {
+		t.Helper()
+		bom := &cdx.BOM{}
+		require.NoError(t, cdx.NewBOMDecoder(strings.NewReader(data), format).Decode(bom))
+		// Clear the fields that only exist in one of the encodings
+		bom.XMLName, bom.XMLNS, bom.JSONSchema, bom.BOMFormat = xml.Name{}, "", "", ""
+		return bom
+	}
+
+	for _, version := range []string{"1.4", "1.5"} {
+		t.Run(version, func(t *testing.T) {
+			res, err := NewCDX(version, "xml").Serialize(doc, nil, &CDXOptions{SerialNumber: SerialNumberPreserve})
+			require.NoError(t, err)
+			data := render(res, version, "xml")
+
+			// The root element has the namespace of the version and the
+			// document identity
+			require.True(t, strings.HasPrefix(data, xml.Header))
+			require.Contains(t, data, fmt.Sprintf(
+				`<bom xmlns="http://cyclonedx.org/schema/bom/%s" serialNumber="urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79" version="3">`, version,
+			))
+
+			// The XML document carries the same data as the JSON one
+			xmlBOM := decode(data, cdx.BOMFileFormatXML)
+			jsonBOM := decode(render(res, version, "json"), cdx.BOMFileFormatJSON)
+			require.Equal(t, jsonBOM, xmlBOM)
+			require.NotNil(t, xmlBOM.Dependencies)
+			require.Len(t, *xmlBOM.Components, 2)
+		})
+	}
+}
+
+func TestSerializeMetadataComponent(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.Metadata.Name = "app-sbom"
+	doc.NodeList.AddRootNode(&sbom.Node{
+		Id: "Package-app", Name: "app", Version: "1.0.0", LicenseConcluded: "MIT",
+		Hashes:      map[int32]string{int32(sbom.HashAlgorithm_SHA256): "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"},
+		Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): "pkg:generic/app@1.0.0"},
+		Suppliers:   []*sbom.Person{{Name: "ACME", IsOrg: true}},
+	})
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "Package-tool", Name: "tool"})
+	doc.NodeList.AddNode(&sbom.Node{Id: "Package-lib", Name: "lib"})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "Package-app", To: []string{"Package-lib"}})
+
+	res, err := NewCDX("1.5", "json").Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	bom := res.(*cdx.BOM)
+
+	// The first root is the metadata component, keeping its own name
+	component := bom.Metadata.Component
+	require.Equal(t, "Package-app", component.BOMRef)
+	require.Equal(t, "app", component.Name)
+	require.Equal(t, "pkg:generic/app@1.0.0", component.PackageURL)
+	require.Len(t, *component.Hashes, 1)
+	require.Equal(t, cdx.Licenses{{License: &cdx.License{ID: "MIT"}}}, *component.Licenses)
+	require.Equal(t, "ACME", component.Supplier.Name)
+
+	// The rest of the roots are written as components
+	refs := []string{}
+	for _, c := range *bom.Components {
+		refs = append(refs, c.BOMRef)
+	}
+	require.ElementsMatch(t, []string{"Package-tool", "Package-lib"}, refs)
+
+	require.Equal(t, "Package-app", (*bom.Dependencies)[0].Ref)
+	require.Equal(t, []string{"Package-lib"}, *(*bom.Dependencies)[0].Dependencies)
+
+	// Roots without a name get the name of the document
+	doc.NodeList.GetNodeByID("Package-app").Name = ""
+	res, err = NewCDX("1.5", "json").Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "app-sbom", res.(*cdx.BOM).Metadata.Component.Name)
+}
+
+func TestSerializeSerialNumber(t *testing.T) {
+	const serial = "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"
+	newDoc := func(id, version string) *sbom.Document {
+		doc := sbom.NewDocument(sbom.WithTimestamp(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)))
+		doc.Metadata.Id = id
+		doc.Metadata.Version = version
+		doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app"})
+		return doc
+	}
+	serialize := func(doc *sbom.Document, opts *CDXOptions) *cdx.BOM {
+		t.Helper()
+		res, err := NewCDX("1.5", "json").Serialize(doc, nil, opts)
+		require.NoError(t, err)
+		return res.(*cdx.BOM)
+	}
+	isUUID := func(serialNumber string) {
+		t.Helper()
+		require.True(t, strings.HasPrefix(serialNumber, "urn:uuid:"), serialNumber)
+		_, err := uuid.Parse(serialNumber)
+		require.NoError(t, err)
+	}
+
+	// Documents get a new random serial number by default
+	for _, opts := range []*CDXOptions{nil, {}, {SerialNumber: SerialNumberRandom}} {
+		bom := serialize(newDoc(serial, "3"), opts)
+		isUUID(bom.SerialNumber)
+		require.NotEqual(t, serial, bom.SerialNumber)
+		require.NotEqual(t, bom.SerialNumber, serialize(newDoc(serial, "3"), opts).SerialNumber)
+		require.Equal(t, 1, bom.Version)
+	}
+
+	// Preserved serial numbers keep the document version
+	preserve := &CDXOptions{SerialNumber: SerialNumberPreserve}
+	bom := serialize(newDoc(serial, "3"), preserve)
+	require.Equal(t, serial, bom.SerialNumber)
+	require.Equal(t, 3, bom.Version)
+
+	bom = serialize(newDoc(serial, "3"), &CDXOptions{SerialNumber: SerialNumberPreserve, IncrementVersion: true})
+	require.Equal(t, serial, bom.SerialNumber)
+	require.Equal(t, 4, bom.Version)
+
+	// Versions lower than 1 are not valid in CycloneDX
+	bom = serialize(newDoc(serial, "0"), preserve)
+	require.Equal(t, serial, bom.SerialNumber)
+	require.Equal(t, 1, bom.Version)
+
+	// New documents are written as version 1
+	require.Equal(t, 1, serialize(sbom.NewDocument(), preserve).Version)
+
+	// IDs that are not serial numbers are replaced
+	bom = serialize(newDoc("DOCUMENT", "0"), preserve)
+	isUUID(bom.SerialNumber)
+	require.Equal(t, 1, bom.Version)
+
+	// Deterministic serial numbers only depend on the contents and namespace
+	opts := &CDXOptions{SerialNumber: SerialNumberDeterministic}
+	bom = serialize(newDoc(serial, "3"), opts)
+	isUUID(bom.SerialNumber)
+	require.Equal(t, 3, bom.Version)
+	require.Equal(t, bom.SerialNumber, serialize(newDoc("DOCUMENT", "1"), opts).SerialNumber)
+
+	modified := newDoc(serial, "3")
+	modified.NodeList.AddNode(&sbom.Node{Id: "lib", Name: "lib"})
+	require.NotEqual(t, bom.SerialNumber, serialize(modified, opts).SerialNumber)
+
+	ns := uuid.MustParse("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	require.NotEqual(t, bom.SerialNumber, serialize(newDoc(serial, "3"), &CDXOptions{
+		SerialNumber: SerialNumberDeterministic, SerialNumberNamespace: ns,
+	}).SerialNumber)
+
+	_, err := NewCDX("1.5", "json").Serialize(newDoc(serial, "3"), nil, &CDXOptions{SerialNumber: SerialNumberMode(99)})
+	require.Error(t, err)
+}
+
+func TestSerializeTools(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.Metadata.Tools = []*sbom.Tool{{Name: "scanner", Version: "2.0", Vendor: "ACME"}, {}, nil}
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app"})
+
+	// CycloneDX 1.5 writes the tools as components
+	res, err := NewCDX("1.5", "json").Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	tools := res.(*cdx.BOM).Metadata.Tools
+	require.Nil(t, tools.Tools) //nolint:staticcheck
+	require.Equal(t, []cdx.Component{{
+		Type: cdx.ComponentTypeApplication, Name: "scanner", Version: "2.0",
+		Supplier: &cdx.OrganizationalEntity{Name: "ACME"},
+	}}, *tools.Components)
+
+	// Older versions use the legacy list
+	res, err = NewCDX("1.4", "json").Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	tools = res.(*cdx.BOM).Metadata.Tools
+	require.Nil(t, tools.Components)
+	require.Equal(t, []cdx.Tool{{Name: "scanner", Version: "2.0", Vendor: "ACME"}}, *tools.Tools) //nolint:staticcheck
+
+	// protobom is added when requested
+	so := &native.SerializeOptions{AddProtobomTool: true}
+	res, err = NewCDX("1.5", "json").Serialize(doc, so, nil)
+	require.NoError(t, err)
+	components := *res.(*cdx.BOM).Metadata.Tools.Components
+	require.Len(t, components, 2)
+	require.Equal(t, "protobom", components[1].Name)
+	require.Equal(t, protobomVersion(), components[1].Version)
+	require.NotEmpty(t, components[1].Version)
+
+	// ... once
+	doc.Metadata.Tools = append(doc.Metadata.Tools, &sbom.Tool{Name: "protobom", Version: protobomVersion()})
+	res, err = NewCDX("1.5", "json").Serialize(doc, so, nil)
+	require.NoError(t, err)
+	require.Len(t, *res.(*cdx.BOM).Metadata.Tools.Components, 2)
+
+	doc.Metadata.Tools = nil
+	res, err = NewCDX("1.5", "json").Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, res.(*cdx.BOM).Metadata.Tools)
+}
+
+func TestSerializeHashAlgorithms(t *testing.T) {
+	// The hash algorithms allowed by the CycloneDX 1.5 schema
+	data, err := os.ReadFile("testdata/bom-1.5.schema.json")
+	require.NoError(t, err)
+	schema := struct {
+		Definitions struct {
+			HashAlg struct {
+				Enum []string `json:"enum"`
+			} `json:"hash-alg"`
+		} `json:"definitions"`
+	}{}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	require.NotEmpty(t, schema.Definitions.HashAlg.Enum)
+
+	node := &sbom.Node{Id: "app", Name: "app", Hashes: map[int32]string{}}
+	for algo := range sbom.HashAlgorithm_name {
+		node.Hashes[algo] = fmt.Sprintf("%x", algo)
+	}
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(node)
+
+	sut := NewCDX("1.5", "json")
+	res, err := sut.Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	var buf strings.Builder
+	require.NoError(t, sut.Render(res, &buf, &native.RenderOptions{}, nil))
+
+	output := struct {
+		Metadata struct {
+			Component struct {
+				Hashes []struct {
+					Alg     string `json:"alg"`
+					Content string `json:"content"`
+				} `json:"hashes"`
+			} `json:"component"`
+		} `json:"metadata"`
+	}{}
+	require.NoError(t, json.Unmarshal([]byte(buf.String()), &output))
+
+	// All the algorithms of the schema are written, in order and with
+	// their values
+	written := []string{}
+	for _, h := range output.Metadata.Component.Hashes {
+		require.Contains(t, schema.Definitions.HashAlg.Enum, h.Alg)
+		written = append(written, h.Alg)
+	}
+	require.Equal(t, schema.Definitions.HashAlg.Enum, written)
+	require.Equal(t, fmt.Sprintf("%x", int32(sbom.HashAlgorithm_BLAKE3)), output.Metadata.Component.Hashes[len(written)-1].Content)
+}
+
+func TestSerializeExternalReferences(t *testing.T) {
+	// The reference types allowed by the CycloneDX 1.5 schema
+	data, err := os.ReadFile("testdata/bom-1.5.schema.json")
+	require.NoError(t, err)
+	schema := struct {
+		Definitions struct {
+			ExternalReference struct {
+				Properties struct {
+					Type struct {
+						Enum []string `json:"enum"`
+					} `json:"type"`
+				} `json:"properties"`
+			} `json:"externalReference"`
+		} `json:"definitions"`
+	}{}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	require.NotEmpty(t, schema.Definitions.ExternalReference.Properties.Type.Enum)
+
+	expected := map[string]sbom.ExternalReference_ExternalReferenceType{
+		"vcs":                      sbom.ExternalReference_VCS,
+		"issue-tracker":            sbom.ExternalReference_ISSUE_TRACKER,
+		"website":                  sbom.ExternalReference_WEBSITE,
+		"documentation":            sbom.ExternalReference_DOCUMENTATION,
+		"distribution":             sbom.ExternalReference_DOWNLOAD,
+		"advisories":               sbom.ExternalReference_SECURITY_ADVISORY,
+		"security-contact":         sbom.ExternalReference_SECURITY_CONTACT,
+		"release-notes":            sbom.ExternalReference_RELEASE_NOTES,
+		"build-system":             sbom.ExternalReference_BUILD_SYSTEM,
+		"license":                  sbom.ExternalReference_LICENSE,
+		"mailing-list":             sbom.ExternalReference_MAILING_LIST,
+		"other":                    sbom.ExternalReference_SECURITY_FIX,
+		"model-card":               sbom.ExternalReference_MODEL_CARD,
+		"threat-model":             sbom.ExternalReference_SECURITY_THREAT_MODEL,
+		"exploitability-statement": sbom.ExternalReference_VULNERABILITY_EXPLOITABILITY_ASSESSMENT,
+	}
+
+	node := &sbom.Node{Id: "app", Name: "app"}
+	for cdxType, refType := range expected {
+		node.ExternalReferences = append(node.ExternalReferences, &sbom.ExternalReference{
+			Url:     "https://example.com/" + cdxType,
+			Type:    refType,
+			Comment: "the " + cdxType,
+			Hashes:  map[int32]string{int32(sbom.HashAlgorithm_SHA256): "4f232eeb99e1663d07f0af1af6ea262bf594934b694228e71fd8f159f9a19f32"},
+		})
+	}
+	// References without url are skipped
+	node.ExternalReferences = append(node.ExternalReferences,
+		&sbom.ExternalReference{Type: sbom.ExternalReference_VCS},
+		&sbom.ExternalReference{Url: " ", Type: sbom.ExternalReference_WEBSITE},
+	)
+
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(node)
+	doc.Metadata.ExternalReferences = []*sbom.ExternalReference{
+		{Url: "https://example.com/sbom.cdx.json", Type: sbom.ExternalReference_BOM, Comment: "previous"},
+		{Type: sbom.ExternalReference_BOM},
+	}
+
+	sut := NewCDX("1.5", "json")
+	res, err := sut.Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	var buf strings.Builder
+	require.NoError(t, sut.Render(res, &buf, &native.RenderOptions{}, nil))
+
+	type reference struct {
+		URL     string `json:"url"`
+		Type    string `json:"type"`
+		Comment string `json:"comment"`
+		Hashes  []struct {
+			Alg     string `json:"alg"`
+			Content string `json:"content"`
+		} `json:"hashes"`
+	}
+	output := struct {
+		Metadata struct {
+			Component struct {
+				ExternalReferences []reference `json:"externalReferences"`
+			} `json:"component"`
+		} `json:"metadata"`
+		ExternalReferences []reference `json:"externalReferences"`
+	}{}
+	require.NoError(t, json.Unmarshal([]byte(buf.String()), &output))
+
+	refs := output.Metadata.Component.ExternalReferences
+	require.Len(t, refs, len(expected))
+	for _, r := range refs {
+		require.Contains(t, schema.Definitions.ExternalReference.Properties.Type.Enum, r.Type)
+		cdxType := strings.TrimPrefix(r.URL, "https://example.com/")
+		require.Equal(t, cdxType, r.Type)
+		require.Equal(t, "the "+cdxType, r.Comment)
+		require.Len(t, r.Hashes, 1)
+		require.Equal(t, "SHA-256", r.Hashes[0].Alg)
+	}
+
+	// Document references are written at the top level
+	require.Equal(t, []reference{
+		{URL: "https://example.com/sbom.cdx.json", Type: "bom", Comment: "previous"},
+	}, output.ExternalReferences)
+}
+
+func TestSerializeLicenseChoices(t *testing.T) {
+	// The license IDs allowed by the CycloneDX schema
+	data, err := os.ReadFile("testdata/spdx.schema.json")
+	require.NoError(t, err)
+	spdxSchema := struct {
+		Enum []string `json:"enum"`
+	}{}
+	require.NoError(t, json.Unmarshal(data, &spdxSchema))
+	require.NotEmpty(t, spdxSchema.Enum)
+
+	doc := sbom.NewDocument()
+	doc.Metadata.ExtractedLicenses = []*sbom.ExtractedLicense{
+		{Id: "LicenseRef-Proprietary", Name: "Proprietary License", Text: "All rights reserved"},
+	}
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app"})
+	for id, licenses := range map[string][]string{
+		"expression": {"Apache-2.0 OR MIT"},
+		"id":         {"MIT"},
+		"name":       {"LicenseRef-Proprietary"},
+		"mixed":      {"Apache-2.0 OR MIT", "BSD-3-Clause"},
+	} {
+		doc.NodeList.AddNode(&sbom.Node{Id: id, Name: id, Licenses: licenses})
+		doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{id}})
+	}
+
+	sut := NewCDX("1.5", "json")
+	res, err := sut.Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	var buf strings.Builder
+	require.NoError(t, sut.Render(res, &buf, &native.RenderOptions{}, nil))
+
+	output := struct {
+		Components []struct {
+			BOMRef   string                       `json:"bom-ref"`
+			Licenses []map[string]json.RawMessage `json:"licenses"`
+		} `json:"components"`
+	}{}
+	require.NoError(t, json.Unmarshal([]byte(buf.String()), &output))
+
+	type license struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Text *struct {
+			Content string `json:"content"`
+		} `json:"text"`
+	}
+	found := map[string]any{}
+	for _, c := range output.Components {
+		// The licenses are either a list of one expression or a list of
+		// licenses with an ID from the SPDX list or a name
+		require.NotEmpty(t, c.Licenses, c.BOMRef)
+		if raw, ok := c.Licenses[0]["expression"]; ok {
+			require.Len(t, c.Licenses, 1, c.BOMRef)
+			require.Len(t, c.Licenses[0], 1, c.BOMRef)
+			var expression string
+			require.NoError(t, json.Unmarshal(raw, &expression))
+			found[c.BOMRef] = expression
+			continue
+		}
+		for _, choice := range c.Licenses {
+			require.Len(t, choice, 1, c.BOMRef)
+			var l license
+			require.NoError(t, json.Unmarshal(choice["license"], &l), c.BOMRef)
+			require.True(t, (l.ID == "") != (l.Name == ""), c.BOMRef)
+			if l.ID != "" {
+				require.Contains(t, spdxSchema.Enum, l.ID)
+			}
+			found[c.BOMRef] = l
+		}
+	}
+
+	require.Equal(t, map[string]any{
+		"expression": "Apache-2.0 OR MIT",
+		"id":         license{ID: "MIT"},
+		"name": license{Name: "Proprietary License", Text: &struct {
+			Content string `json:"content"`
+		}{Content: "All rights reserved"}},
+		"mixed": "(Apache-2.0 OR MIT) OR BSD-3-Clause",
+	}, found)
+}
+
+func TestSerializeSuppliersAndOriginators(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		suppliers         []*sbom.Person
+		originators       []*sbom.Person
+		expectedSupplier  *cdx.OrganizationalEntity
+		expectedAuthor    string
+		expectedPublisher string
+	}{
+		{name: "none"},
+		{
+			name: "first organization",
+			suppliers: []*sbom.Person{
+				{Name: "John Doe", Email: "john@example.com"},
+				{Name: "ACME", IsOrg: true, Url: "https://acme.example"},
+				{Name: "Example Inc", IsOrg: true},
+			},
+			expectedSupplier: &cdx.OrganizationalEntity{Name: "ACME", URL: &[]string{"https://acme.example"}},
+		},
+		{
+			name:      "individual supplier",
+			suppliers: []*sbom.Person{{Name: "John Doe", Email: "john@example.com", Phone: "800-555-1212"}},
+			expectedSupplier: &cdx.OrganizationalEntity{
+				Name:    "John Doe",
+				Contact: &[]cdx.OrganizationalContact{{Name: "John Doe", Email: "john@example.com", Phone: "800-555-1212"}},
+			},
+		},
+		{name: "empty supplier", suppliers: []*sbom.Person{{Name: sbom.NoAssertionValue, IsOrg: true}}},
+		{
+			name: "originators",
+			originators: []*sbom.Person{
+				{Name: "Jane Doe"}, {Name: "ACME", IsOrg: true}, {Name: "John Doe"},
+				{Name: "Jane Doe"}, {Name: "Example Inc", IsOrg: true}, {Name: sbom.NoAssertionValue},
+			},
+			expectedAuthor:    "Jane Doe, John Doe",
+			expectedPublisher: "ACME, Example Inc",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app", Suppliers: tc.suppliers, Originators: tc.originators})
+			doc.NodeList.AddNode(&sbom.Node{Id: "lib", Name: "lib", Suppliers: tc.suppliers, Originators: tc.originators})
+			doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}})
+
+			sut := NewCDX("1.5", "json")
+			res, err := sut.Serialize(doc, nil, nil)
+			require.NoError(t, err)
+			bom := res.(*cdx.BOM)
+
+			for _, c := range []cdx.Component{*bom.Metadata.Component, (*bom.Components)[0]} {
+				require.Equal(t, tc.expectedSupplier, c.Supplier, c.BOMRef)
+				require.Equal(t, tc.expectedAuthor, c.Author, c.BOMRef)
+				require.Equal(t, tc.expectedPublisher, c.Publisher, c.BOMRef)
+			}
+			require.Equal(t, tc.expectedSupplier, bom.Metadata.Supplier)
+
+			// Empty structures are not rendered
+			var buf strings.Builder
+			require.NoError(t, sut.Render(res, &buf, &native.RenderOptions{}, nil))
+			if tc.expectedSupplier == nil {
+				require.NotContains(t, buf.String(), `"supplier"`)
+			}
+			if tc.expectedAuthor == "" {
+				require.NotContains(t, buf.String(), `"author"`)
+			}
+		})
+	}
+}
+
+func TestSerializeCarrierProperties(t *testing.T) {
+	node := &sbom.Node{
+		Id:              "lib",
+		Name:            "lib",
+		SourceInfo:      "Built from the v1.0.0 tag",
+		LicenseComments: "Dual licensed",
+		Attribution:     []string{"Copyright Acme", "Portions copyright The BOM Squad"},
+		Properties: []*sbom.Property{
+			{Name: "ci:pipeline", Value: "release"},
+			{Name: "ci:stage", Value: "build"},
+			{Name: "ci:pipeline", Value: "nightly"},
+		},
+	}
+	nodeProperties := []cdx.Property{
+		{Name: "ci:pipeline", Value: "release"},
+		{Name: "ci:stage", Value: "build"},
+		{Name: "ci:pipeline", Value: "nightly"},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		so       *native.SerializeOptions
+		expected []cdx.Property
+	}{
+		{
+			name: "default",
+			expected: append(slices.Clone(nodeProperties),
+				cdx.Property{Name: cdxformats.PropertySourceInfo, Value: "Built from the v1.0.0 tag"},
+				cdx.Property{Name: cdxformats.PropertyLicenseComments, Value: "Dual licensed"},
+				cdx.Property{Name: cdxformats.PropertyAttributionText, Value: "Copyright Acme"},
+				cdx.Property{Name: cdxformats.PropertyAttributionText, Value: "Portions copyright The BOM Squad"},
+			),
+		},
+		{
+			name:     "omitted",
+			so:       &native.SerializeOptions{OmitCarrierProperties: true},
+			expected: nodeProperties,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app"})
+			doc.NodeList.AddNode(proto.Clone(node).(*sbom.Node))
+			doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}})
+
+			sut := NewCDX("1.5", "json")
+			res, err := sut.Serialize(doc, tc.so, nil)
+			require.NoError(t, err)
+			bom := res.(*cdx.BOM)
+			require.Nil(t, bom.Metadata.Component.Properties)
+			require.Len(t, *bom.Components, 1)
+			require.Equal(t, tc.expected, *(*bom.Components)[0].Properties)
+
+			// The carried fields are read back into the node, the rest of
+			// the properties are kept in order
+			var buf strings.Builder
+			require.NoError(t, sut.Render(res, &buf, &native.RenderOptions{}, nil))
+			doc2, err := unserializers.NewCDX("1.5", "json").Unserialize(strings.NewReader(buf.String()), nil, nil)
+			require.NoError(t, err)
+			lib := doc2.NodeList.GetNodeByID("lib")
+			require.Len(t, lib.Properties, len(nodeProperties))
+			for i, p := range nodeProperties {
+				require.Equal(t, p.Name, lib.Properties[i].Name)
+				require.Equal(t, p.Value, lib.Properties[i].Value)
+			}
+			if tc.so == nil {
+				require.Equal(t, node.SourceInfo, lib.SourceInfo)
+				require.Equal(t, node.LicenseComments, lib.LicenseComments)
+				require.Equal(t, node.Attribution, lib.Attribution)
+			} else {
+				require.Empty(t, lib.SourceInfo)
+				require.Empty(t, lib.LicenseComments)
+				require.Empty(t, lib.Attribution)
+			}
+		})
+	}
+}
@@ -1,20 +1,28 @@
 package serializers
 
 import (
+	"crypto/sha1" //nolint:gosec // Required to compute the verification code
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
+	"slices"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	protospdx "github.com/bom-squad/protobom/pkg/formats/spdx"
+	"github.com/bom-squad/protobom/pkg/license"
 	"github.com/bom-squad/protobom/pkg/native"
 	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"github.com/spdx/tools-golang/spdx"
 	"github.com/spdx/tools-golang/spdx/v2/common"
 	"github.com/spdx/tools-golang/spdx/v2/v2_3"
-	"sigs.k8s.io/release-utils/version"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var _ native.Serializer = &SPDX23{}
@@ -25,8 +33,48 @@ type SPDX3Options struct {
 	Indent int
 }
 
+// SPDX23Options are the options specific to the SPDX 2.3 serializer. To use
+// them, set them as the format options of the serializer in the writer.
+type SPDX23Options struct {
+	// VerificationCode controls how the package verification codes are written
+	VerificationCode VerificationCodeMode
+
+	// ForceFilesNotAnalyzed writes all packages with filesAnalyzed set to
+	// false. Use it when the file level data of the packages was stripped
+	// from the document. As packages not analyzed cannot contain files, their
+	// CONTAINS relationships to file nodes are not written.
+	ForceFilesNotAnalyzed bool
+
+	// PreserveRelationshipDirection writes the relationships read from
+	// their target (CONTAINED_BY, DEPENDENCY_OF...) in their original
+	// direction. By default all relationships are written in the canonical
+	// direction (CONTAINS, DEPENDS_ON...).
+	PreserveRelationshipDirection bool
+}
+
+// VerificationCodeMode defines how the package verification codes are written
+type VerificationCodeMode int
+
+const (
+	// VerificationCodeKeep writes the verification code as read into the node
+	VerificationCodeKeep VerificationCodeMode = iota
+
+	// VerificationCodeRecompute computes the verification code from the SHA1
+	// hashes of the file nodes contained in the package. Useful when the
+	// files in the document were modified.
+	VerificationCodeRecompute
+
+	// VerificationCodeOmit drops the verification codes, writing the
+	// packages with filesAnalyzed set to false and without their files.
+	VerificationCodeOmit
+)
+
 const spdxOther = "OTHER"
 
+// defaultLicenseListVersion is the version of the SPDX license list written
+// in documents that do not record one. https://spdx.org/licenses/
+const defaultLicenseListVersion = "3.20"
+
 func NewSPDX23() *SPDX23 {
 	return &SPDX23{}
 }
@@ -43,7 +91,17 @@ func (s *SPDX23) Render(doc interface{}, wr io.Writer, o *native.RenderOptions,
 }
 
 // Serialize takes a protobom and returns an SPDX 2.3 struct
-func (s *SPDX23) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ interface{}) (interface{}, error) {
+func (s *SPDX23) Serialize(bom *sbom.Document, so *native.SerializeOptions, rawOpts interface{}) (interface{}, error) {
+	opts := &SPDX23Options{}
+	switch o := rawOpts.(type) {
+	case *SPDX23Options:
+		if o != nil {
+			opts = o
+		}
+	case SPDX23Options:
+		opts = &o
+	}
+
 	if bom == nil {
 		return nil, errors.New("document is nil, unable to serialize to SPDX 2.3")
 	}
@@ -55,45 +113,61 @@ func (s *SPDX23) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ int
 		DataLicense:       spdx.DataLicense,
 		SPDXIdentifier:    protospdx.DOCUMENT,
 		DocumentName:      bom.Metadata.Name,
-		DocumentNamespace: "https://spdx.org/spdxdocs/", // TODO(puerco): Think how to handle namespacing
+		DocumentNamespace: documentNamespace(bom.Metadata),
 		DocumentComment:   bom.Metadata.Comment,
 
 		CreationInfo: &spdx.CreationInfo{
-			LicenseListVersion: "3.20", // https://spdx.org/licenses/
-			Creators: []spdx.Creator{
-				// Register protobom as one of the document creation tools
-				{
-					Creator:     fmt.Sprintf("protobom-%s", version.GetVersionInfo().GitVersion),
-					CreatorType: "Tool",
-				},
-			},
-
-			// Interesting, should we keep the original date?
-			Created: time.Now().UTC().Format(time.RFC3339),
-			// CreatorComment: bom.Metadata.Authors(),
-			// CreatorComment: bom.Metadata.... /// TODO(puerco): Missing in the proto
+			LicenseListVersion: bom.Metadata.LicenseListVersion,
+			Creators:           []spdx.Creator{},
+			CreatorComment:     bom.Metadata.CreatorComment,
 		},
 	}
 
+	// The document keeps its creation date, documents without one are
+	// dated when they are written
+	doc.CreationInfo.Created = spdxDate(bom.Metadata.Date)
+	if doc.CreationInfo.Created == "" {
+		doc.CreationInfo.Created = spdxDate(timestamppb.Now())
+	}
+
+	if doc.CreationInfo.LicenseListVersion == "" {
+		doc.CreationInfo.LicenseListVersion = defaultLicenseListVersion
+	}
+
 	for _, t := range bom.Metadata.Tools {
-		// TODO(degradation): SPDX is prescriptive on how this field is structured
-		// it is a tool identifier word separated from the version with a dash.
-		// We should transform the field value
-		// Ref: https://spdx.github.io/spdx-spec/v2.3/document-creation-information/#68-creator-field
-		name := t.Name
-		if t.Version != "" {
-			name = fmt.Sprintf("%s-%s", t.Name, t.Version)
+		if t == nil || t.Name == "" {
+			continue
 		}
 
 		// TODO(degradation): Tool vendor gets lost here
+		// Ref: https://spdx.github.io/spdx-spec/v2.3/document-creation-information/#68-creator-field
+		doc.CreationInfo.Creators = append(doc.CreationInfo.Creators, spdx.Creator{
+			Creator:     protospdx.ToolString(t.Name, t.Version),
+			CreatorType: protospdx.Tool,
+		})
+	}
 
+	for _, a := range bom.Metadata.Authors {
+		if a.Name == "" {
+			continue
+		}
+		// TODO(degradation): URL, Phone and contacts are lost if set
 		doc.CreationInfo.Creators = append(doc.CreationInfo.Creators, spdx.Creator{
-			Creator:     name,
+			Creator:     a.ToSPDX2ClientString(),
+			CreatorType: a.ToSPDX2ClientOrg(),
+		})
+	}
+
+	// Register protobom as one of the document creation tools when
+	// requested or when there are no other creators as SPDX requires one
+	if (so != nil && so.AddProtobomTool) || len(doc.CreationInfo.Creators) == 0 {
+		doc.CreationInfo.Creators = append(doc.CreationInfo.Creators, spdx.Creator{
+			Creator:     protospdx.ToolString("protobom", protobomVersion()),
 			CreatorType: protospdx.Tool,
 		})
 	}
 
-	packages, err := s.buildPackages(bom)
+	packages, err := s.buildPackages(bom, opts)
 	if err != nil {
 		return nil, fmt.Errorf("building SPDX packages: %s", err)
 	}
@@ -103,7 +177,9 @@ func (s *SPDX23) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ int
 		return nil, fmt.Errorf("building SPDX file list: %s", err)
 	}
 
-	rels, err := buildRelationships(bom)
+	snippets := buildSnippets(bom)
+
+	rels, err := buildRelationships(bom, opts)
 	if err != nil {
 		return nil, fmt.Errorf("building relationships: %w", err)
 	}
@@ -111,7 +187,7 @@ func (s *SPDX23) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ int
 	for _, id := range bom.NodeList.RootElements {
 		rels = append(rels, &spdx.Relationship{
 			RefA:                common.MakeDocElementID("", protospdx.DOCUMENT),
-			RefB:                common.MakeDocElementID("", id),
+			RefB:                nodeDocElementID(id),
 			Relationship:        common.TypeRelationshipDescribe,
 			RelationshipComment: "",
 		})
@@ -120,60 +196,309 @@ func (s *SPDX23) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ int
 	// TODO(puerco): Files in packages
 	// TODO(puerco): Package verification data
 
+	for _, a := range bom.Metadata.Annotations {
+		if sa := annotationToSPDX(a); sa != nil {
+			sa.AnnotationSPDXIdentifier = common.MakeDocElementID("", protospdx.DOCUMENT)
+			doc.Annotations = append(doc.Annotations, sa)
+		}
+	}
+	for _, a := range propertiesToSPDX(bom.Metadata.Properties, protospdx.DOCUMENT, bom.Metadata.Date) {
+		a := a
+		doc.Annotations = append(doc.Annotations, &a)
+	}
+
+	doc.ExternalDocumentReferences = buildExternalDocumentRefs(bom)
 	doc.Packages = packages
 	doc.Files = files
+	doc.Snippets = snippets
 	doc.Relationships = rels
+	doc.OtherLicenses = buildOtherLicenses(bom)
+
+	if so != nil && so.OmitNoAssertion {
+		omitNoAssertion(doc)
+	}
 
 	return doc, nil
 }
 
-func buildRelationships(bom *sbom.Document) ([]*spdx.Relationship, error) { //nolint:unparam
+// omitNoAssertion clears the optional fields of the document elements that
+// are set to NOASSERTION or NONE. The download location of packages and the
+// copyright of files and snippets are required and kept.
+func omitNoAssertion(doc *spdx.Document) {
+	for _, p := range doc.Packages {
+		p.PackageHomePage = omitValue(p.PackageHomePage)
+		p.PackageLicenseConcluded = omitValue(p.PackageLicenseConcluded)
+		p.PackageLicenseDeclared = omitValue(p.PackageLicenseDeclared)
+		p.PackageLicenseInfoFromFiles = omitValues(p.PackageLicenseInfoFromFiles)
+		p.PackageCopyrightText = omitValue(p.PackageCopyrightText)
+		if p.PackageSupplier != nil && omitValue(p.PackageSupplier.Supplier) == "" {
+			p.PackageSupplier = nil
+		}
+		if p.PackageOriginator != nil && omitValue(p.PackageOriginator.Originator) == "" {
+			p.PackageOriginator = nil
+		}
+	}
+
+	for _, f := range doc.Files {
+		f.LicenseConcluded = omitValue(f.LicenseConcluded)
+		f.LicenseInfoInFiles = omitValues(f.LicenseInfoInFiles)
+	}
+
+	for i := range doc.Snippets {
+		doc.Snippets[i].SnippetLicenseConcluded = omitValue(doc.Snippets[i].SnippetLicenseConcluded)
+		doc.Snippets[i].LicenseInfoInSnippet = omitValues(doc.Snippets[i].LicenseInfoInSnippet)
+	}
+}
+
+// omitValue returns a blank string if value is NOASSERTION or NONE
+func omitValue(value string) string {
+	if sbom.ValueStateOf(sbom.ValueFromSPDX(value)) != sbom.ValueStateSet {
+		return ""
+	}
+	return value
+}
+
+// omitValues returns the values of a list that are not NOASSERTION or NONE.
+// When none remain, it returns nil to leave out the field.
+func omitValues(values []string) []string {
+	var ret []string
+	for _, v := range values {
+		if v = omitValue(v); v != "" {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// buildOtherLicenses returns the extracted licenses of the document that are
+// referenced by any of its nodes or snippets. LicenseRefs used in the
+// document without a definition are logged as they make the SPDX document
+// invalid.
+func buildOtherLicenses(bom *sbom.Document) []*spdx.OtherLicense {
+	refs := referencedLicenseRefs(bom)
+	otherLicenses := []*spdx.OtherLicense{}
+	for _, l := range bom.Metadata.ExtractedLicenses {
+		if _, ok := refs[l.Id]; !ok {
+			continue
+		}
+		delete(refs, l.Id)
+		otherLicenses = append(otherLicenses, &spdx.OtherLicense{
+			LicenseIdentifier:      l.Id,
+			ExtractedText:          l.Text,
+			LicenseName:            l.Name,
+			LicenseCrossReferences: l.SeeAlso,
+			LicenseComment:         l.Comment,
+		})
+	}
+
+	undefined := []string{}
+	for id := range refs {
+		undefined = append(undefined, id)
+	}
+	sort.Strings(undefined)
+	for _, id := range undefined {
+		logrus.Warnf("license %s is referenced in the document but its text is not defined", id)
+	}
+	return otherLicenses
+}
+
+// referencedLicenseRefs returns the LicenseRef identifiers used in the
+// license expressions of the nodes and snippets of the document.
+func referencedLicenseRefs(bom *sbom.Document) map[string]struct{} {
+	refs := map[string]struct{}{}
+	add := func(expressions ...string) {
+		for _, e := range expressions {
+			for _, word := range strings.FieldsFunc(e, func(r rune) bool {
+				return r == '(' || r == ')' || unicode.IsSpace(r)
+			}) {
+				if license.IsLicenseRef(word) {
+					refs[word] = struct{}{}
+				}
+			}
+		}
+	}
+	for _, n := range bom.NodeList.Nodes {
+		add(n.LicenseConcluded)
+		add(n.Licenses...)
+		add(n.LicensesDetected...)
+		for _, sn := range n.Snippets {
+			add(sn.LicenseConcluded)
+			add(sn.Licenses...)
+		}
+	}
+	return refs
+}
+
+// buildRelationships returns the SPDX relationships of the edges in the
+// document. Edges are written in their canonical direction unless the options
+// ask to preserve the direction they were read in.
+func buildRelationships(bom *sbom.Document, opts *SPDX23Options) ([]*spdx.Relationship, error) { //nolint:unparam
 	relationships := []*spdx.Relationship{}
-	for _, e := range bom.NodeList.Edges {
-		for _, dest := range e.To {
-			rel := spdx.Relationship{
-				RefA:         common.MakeDocElementID("", e.From),
-				RefB:         common.MakeDocElementID("", dest),
-				Relationship: e.Type.ToSPDX2(),
-				// RelationshipComment: "",
+	for _, edge := range bom.NodeList.Edges {
+		edges := edge.Canonical()
+		if opts.PreserveRelationshipDirection {
+			edges = edge.Stated()
+		}
+		for _, e := range edges {
+			for _, dest := range e.To {
+				if containsUnanalyzedFile(bom.NodeList, e.Type, e.From, dest, opts) {
+					// TODO(degradation): Files of packages written with
+					// filesAnalyzed false are not related to them
+					logrus.Warnf("dropping %s relationship between %s and %s: package files were not analyzed", e.Type, e.From, dest)
+					continue
+				}
+				rel := spdx.Relationship{
+					RefA:                nodeDocElementID(e.From),
+					RefB:                nodeDocElementID(dest),
+					Relationship:        e.Type.ToSPDX2(),
+					RelationshipComment: e.Comment,
+				}
+				relationships = append(relationships, &rel)
 			}
-			relationships = append(relationships, &rel)
 		}
 	}
 	return relationships, nil
 }
 
+// containsUnanalyzedFile returns true if the relationship links a package
+// written with filesAnalyzed false to one of its files. The SPDX spec does
+// not allow those packages to contain files.
+func containsUnanalyzedFile(nl *sbom.NodeList, t sbom.Edge_Type, from, to string, opts *SPDX23Options) bool {
+	pkgID, fileID := from, to
+	switch t {
+	case sbom.Edge_contains:
+	case sbom.Edge_contained_by:
+		pkgID, fileID = to, from
+	default:
+		return false
+	}
+	pkg, file := nl.GetNodeByID(pkgID), nl.GetNodeByID(fileID)
+	if pkg == nil || file == nil || pkg.Type != sbom.Node_PACKAGE || file.Type != sbom.Node_FILE {
+		return false
+	}
+	return filesNotAnalyzed(pkg, opts)
+}
+
+// filesNotAnalyzed returns true when the package node is written with
+// filesAnalyzed false regardless of its verification code
+func filesNotAnalyzed(node *sbom.Node, opts *SPDX23Options) bool {
+	return opts.ForceFilesNotAnalyzed || opts.VerificationCode == VerificationCodeOmit ||
+		(node.FilesAnalyzed != nil && !*node.FilesAnalyzed)
+}
+
+// nodeDocElementID returns the SPDX element ID of a node. Nodes defined in
+// external documents are referenced through their DocumentRef.
+func nodeDocElementID(id string) common.DocElementID {
+	if docID, elementID, ok := sbom.ParseExternalNodeID(id); ok {
+		return common.MakeDocElementID(docID, elementID)
+	}
+	return common.MakeDocElementID("", id)
+}
+
+// buildExternalDocumentRefs returns the references to the external documents
+// of the protobom document. Documents of stub nodes not listed in the
+// metadata are rebuilt from the BOM external reference of the nodes.
+func buildExternalDocumentRefs(bom *sbom.Document) []spdx.ExternalDocumentRef {
+	docs := slices.Clone(bom.Metadata.ExternalDocuments)
+	for _, n := range bom.NodeList.Nodes {
+		if n.ExternalDocument == "" || slices.ContainsFunc(docs, func(ed *sbom.ExternalDocument) bool {
+			return ed.Id == n.ExternalDocument
+		}) {
+			continue
+		}
+		ed := &sbom.ExternalDocument{Id: n.ExternalDocument}
+		for _, er := range n.ExternalReferences {
+			if er.Type == sbom.ExternalReference_BOM {
+				ed.Uri = er.Url
+				ed.Hashes = er.Hashes
+				break
+			}
+		}
+		docs = append(docs, ed)
+	}
+
+	refs := []spdx.ExternalDocumentRef{}
+	for _, ed := range docs {
+		if ed == nil || ed.Id == "" {
+			continue
+		}
+		ref := spdx.ExternalDocumentRef{
+			DocumentRefID: protospdx.DocumentRefPrefix + ed.Id,
+			URI:           ed.Uri,
+		}
+		// SPDX 2.3 only allows one checksum, SHA1 is preferred as it is
+		// the one required by the spec
+		algos := []int32{}
+		for algo := range ed.Hashes {
+			algos = append(algos, algo)
+		}
+		slices.Sort(algos)
+		if _, ok := ed.Hashes[int32(sbom.HashAlgorithm_SHA1)]; ok {
+			algos = append([]int32{int32(sbom.HashAlgorithm_SHA1)}, algos...)
+		}
+		for _, algo := range algos {
+			if spdxAlgo := sbom.HashAlgorithm(algo).ToSPDX(); spdxAlgo != "" {
+				ref.Checksum = common.Checksum{Algorithm: spdxAlgo, Value: ed.Hashes[algo]}
+				break
+			}
+		}
+		if ref.URI == "" || ref.Checksum.Value == "" {
+			logrus.Warnf("external document %s is missing its URI or checksum", ed.Id)
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
 func buildFiles(bom *sbom.Document) ([]*spdx.File, error) { //nolint:unparam
 	files := []*spdx.File{}
 	for _, node := range bom.NodeList.Nodes {
-		if node.Type == sbom.Node_PACKAGE {
+		// Nodes of external documents are only referenced
+		if node.Type == sbom.Node_PACKAGE || node.ExternalDocument != "" {
 			continue
 		}
 
+		// Invalid license expressions are written verbatim
+		concluded, concludedErr := license.Normalize(node.LicenseConcluded)
+		detected, detectedErr := license.NormalizeList(node.LicensesDetected)
+		if err := errors.Join(concludedErr, detectedErr); err != nil {
+			logrus.Warnf("invalid license in %s: %v", node.Id, err)
+		}
+
 		f := spdx.File{
 			FileName:           node.Name,
 			FileSPDXIdentifier: common.ElementID(node.Id),
-			FileTypes:          node.FileTypes,
+			FileTypes:          []string{},
 			Checksums:          []common.Checksum{},
-			LicenseConcluded:   node.LicenseConcluded,
-			// LicenseInfoInFiles:   []string{}, << bug in SPDX
-			LicenseComments:   node.LicenseComments,
-			FileCopyrightText: strings.TrimSpace(node.Copyright),
-			FileComment:       node.Comment,
+			LicenseConcluded:   concluded,
+			LicenseInfoInFiles: detected,
+			LicenseComments:    node.LicenseComments,
+			FileCopyrightText:  sbom.ValueToSPDX(node.Copyright, true),
+			FileComment:        node.Comment,
 			// FileNotice:           node.File, // Missing?
 			FileAttributionTexts: node.Attribution,
 			Annotations:          []v2_3.Annotation{},
 		}
 
-		if f.FileCopyrightText == "" {
-			f.FileCopyrightText = protospdx.NONE
+		for _, ft := range node.TypedFileTypes() {
+			f.FileTypes = append(f.FileTypes, string(ft))
+		}
+
+		// Without file types, they are derived from the node purposes
+		if len(f.FileTypes) == 0 {
+			for _, p := range node.PrimaryPurpose {
+				ft := sbom.FileTypeFromPurpose(p)
+				if ft != "" && !slices.Contains(f.FileTypes, string(ft)) {
+					f.FileTypes = append(f.FileTypes, string(ft))
+				}
+			}
 		}
 
 		for algo, hash := range node.Hashes {
 			if _, ok := sbom.HashAlgorithm_name[algo]; ok {
 				spdxAlgo := sbom.HashAlgorithm(algo).ToSPDX()
 				if spdxAlgo == "" {
-					// TODO(degradation): Data loss. How do we handle more algos?
+					logrus.Warnf("hash algorithm %s of %s not supported in SPDX, dropping it", sbom.HashAlgorithm(algo), node.Id)
 					continue
 				}
 				f.Checksums = append(f.Checksums, common.Checksum{
@@ -182,18 +507,306 @@ func buildFiles(bom *sbom.Document) ([]*spdx.File, error) { //nolint:unparam
 				})
 			}
 		}
+		f.Annotations = nodeAnnotationsToSPDX(node)
+		f.Annotations = append(f.Annotations, propertiesToSPDX(node.Properties, node.Id, bom.Metadata.Date)...)
 		files = append(files, &f)
 	}
 	return files, nil
 }
 
-func (s *SPDX23) buildPackages(bom *sbom.Document) ([]*spdx.Package, error) { //nolint:unparam
+// documentNamespace returns the namespace of the SPDX document, derived from
+// the document ID. IDs that are HTTP(S) URIs are used as the namespace and
+// UUID URNs keep their UUID in a namespace under the SPDX documents URI.
+// Documents with other IDs get a new unique namespace.
+func documentNamespace(md *sbom.Metadata) string {
+	id := md.GetId()
+	if u, err := url.Parse(id); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.Fragment == "" {
+		return id
+	}
+
+	docUUID := uuid.New()
+	if strings.HasPrefix(id, "urn:uuid:") {
+		if u, err := uuid.Parse(id); err == nil {
+			docUUID = u
+		}
+	}
+
+	name := md.GetName()
+	if name == "" {
+		name = "protobom"
+	}
+	return "https://spdx.org/spdxdocs/" + url.PathEscape(name) + "-" + docUUID.String()
+}
+
+// spdxDate formats a timestamp as an SPDX date, in UTC and without fractions
+// of a second. Unset and invalid timestamps return a blank string.
+func spdxDate(ts *timestamppb.Timestamp) string {
+	if !ts.IsValid() || ts.AsTime().Unix() <= 0 {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
+
+// supplierToSPDX returns the person as an SPDX package supplier. Persons
+// without a name are written as NOASSERTION.
+func supplierToSPDX(p *sbom.Person) *spdx.Supplier {
+	if p == nil || strings.TrimSpace(p.Name) == "" {
+		return &spdx.Supplier{Supplier: protospdx.NOASSERTION}
+	}
+	return &spdx.Supplier{
+		Supplier:     p.ToSPDX2ClientString(),
+		SupplierType: p.ToSPDX2ClientOrg(),
+	}
+}
+
+// originatorToSPDX returns the person as an SPDX package originator. Persons
+// without a name are written as NOASSERTION.
+func originatorToSPDX(p *sbom.Person) *spdx.Originator {
+	if p == nil || strings.TrimSpace(p.Name) == "" {
+		return &spdx.Originator{Originator: protospdx.NOASSERTION}
+	}
+	return &spdx.Originator{
+		Originator:     p.ToSPDX2ClientString(),
+		OriginatorType: p.ToSPDX2ClientOrg(),
+	}
+}
+
+// packageFilesAnalyzed returns the filesAnalyzed value of a package node and
+// its verification code. When the node does not record if its files were
+// analyzed, it is inferred from the availability of a verification code.
+// As the code is mandatory when filesAnalyzed is true, packages without one
+// are written as not analyzed.
+func packageFilesAnalyzed(nl *sbom.NodeList, node *sbom.Node, opts *SPDX23Options) (bool, *common.PackageVerificationCode, error) {
+	if filesNotAnalyzed(node, opts) {
+		return false, nil, nil
+	}
+
+	code, err := packageVerificationCode(nl, node, opts.VerificationCode)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// If the package was analyzed but has no code, try to compute it
+	if code == nil && node.FilesAnalyzed != nil && opts.VerificationCode == VerificationCodeKeep {
+		code, err = packageVerificationCode(nl, node, VerificationCodeRecompute)
+		if err != nil {
+			return false, nil, err
+		}
+	}
+
+	if code == nil {
+		if node.FilesAnalyzed != nil {
+			logrus.Warnf("package %s has no verification code, writing it with filesAnalyzed false", node.Id)
+		}
+		return false, nil, nil
+	}
+	return true, code, nil
+}
+
+// packageVerificationCode returns the SPDX verification code of a package
+// node as defined by the verification code mode. Returns nil if the package
+// should be written without one.
+func packageVerificationCode(nl *sbom.NodeList, node *sbom.Node, mode VerificationCodeMode) (*common.PackageVerificationCode, error) {
+	switch mode {
+	case VerificationCodeOmit:
+		return nil, nil
+	case VerificationCodeKeep:
+		if node.VerificationCode == nil || node.VerificationCode.Value == "" {
+			return nil, nil
+		}
+		return &common.PackageVerificationCode{
+			Value:         node.VerificationCode.Value,
+			ExcludedFiles: node.VerificationCode.ExcludedFiles,
+		}, nil
+	case VerificationCodeRecompute:
+		excluded := []string{}
+		if node.VerificationCode != nil {
+			excluded = node.VerificationCode.ExcludedFiles
+		}
+		value, err := computeVerificationCode(nl, node, excluded)
+		if err != nil {
+			// TODO(degradation): The package is written with filesAnalyzed
+			// false when the code cannot be computed
+			logrus.Warnf("unable to compute verification code for %s: %v", node.Id, err)
+			return nil, nil
+		}
+		return &common.PackageVerificationCode{
+			Value:         value,
+			ExcludedFiles: excluded,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown verification code mode %d", mode)
+	}
+}
+
+// computeVerificationCode computes the verification code of a package using
+// the SHA1 hashes of the file nodes it contains, as defined in the SPDX 2.3
+// spec (clause 7.9): the sorted hashes of all files not excluded are
+// concatenated and hashed again with SHA1.
+func computeVerificationCode(nl *sbom.NodeList, node *sbom.Node, excluded []string) (string, error) {
+	hashes := []string{}
+	for _, e := range nl.Edges {
+		if e.From != node.Id || e.Type != sbom.Edge_contains {
+			continue
+		}
+		for _, id := range e.To {
+			file := nl.GetNodeByID(id)
+			if file == nil || file.Type != sbom.Node_FILE {
+				continue
+			}
+			if slices.Contains(excluded, file.Name) {
+				continue
+			}
+			hash, ok := file.Hashes[int32(sbom.HashAlgorithm_SHA1)]
+			if !ok || hash == "" {
+				return "", fmt.Errorf("file %s has no SHA1 hash", file.Id)
+			}
+			hashes = append(hashes, strings.ToLower(hash))
+		}
+	}
+
+	if len(hashes) == 0 {
+		return "", errors.New("package contains no files")
+	}
+
+	sort.Strings(hashes)
+	return fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(hashes, "")))), nil //nolint:gosec // Required by the spec
+}
+
+// nodeAnnotationsToSPDX returns the annotations of a node as SPDX annotations
+func nodeAnnotationsToSPDX(node *sbom.Node) []spdx.Annotation {
+	annotations := []spdx.Annotation{}
+	for _, a := range node.Annotations {
+		if sa := annotationToSPDX(a); sa != nil {
+			sa.AnnotationSPDXIdentifier = common.MakeDocElementID("", node.Id)
+			annotations = append(annotations, *sa)
+		}
+	}
+	return annotations
+}
+
+// propertiesToSPDX returns the properties of the element id as annotations
+// made by protobom, SPDX has no name/value pairs to write them. The
+// annotations are dated with the document.
+func propertiesToSPDX(props []*sbom.Property, id string, date *timestamppb.Timestamp) []spdx.Annotation {
+	annotations := []spdx.Annotation{}
+	for _, p := range props {
+		if p.GetName() == "" {
+			continue
+		}
+		sa := annotationToSPDX(&sbom.Annotation{
+			Tool:    &sbom.Tool{Name: "protobom", Version: protobomVersion()},
+			Date:    date,
+			Comment: fmt.Sprintf("%s%s=%s", protospdx.PropertyAnnotationPrefix, p.Name, p.Value),
+		})
+		sa.AnnotationSPDXIdentifier = common.MakeDocElementID("", id)
+		annotations = append(annotations, *sa)
+	}
+	return annotations
+}
+
+// annotationToSPDX converts a protobom annotation to SPDX. Returns nil if
+// the annotation has no annotator as it is required in SPDX.
+func annotationToSPDX(a *sbom.Annotation) *spdx.Annotation {
+	sa := &spdx.Annotation{
+		AnnotationType:    "OTHER",
+		AnnotationComment: a.Comment,
+	}
+	if a.Type == sbom.Annotation_REVIEW {
+		sa.AnnotationType = "REVIEW"
+	}
+
+	switch {
+	case a.Tool != nil:
+		name := a.Tool.Name
+		if a.Tool.Version != "" {
+			name = fmt.Sprintf("%s-%s", a.Tool.Name, a.Tool.Version)
+		}
+		sa.Annotator = common.Annotator{Annotator: name, AnnotatorType: protospdx.Tool}
+	case a.Annotator != nil && a.Annotator.Name != "":
+		sa.Annotator = common.Annotator{
+			Annotator:     a.Annotator.ToSPDX2ClientString(),
+			AnnotatorType: a.Annotator.ToSPDX2ClientOrg(),
+		}
+	default:
+		// TODO(degradation): Annotations without annotator are lost
+		return nil
+	}
+
+	if a.Date != nil {
+		sa.AnnotationDate = a.Date.AsTime().UTC().Format(time.RFC3339)
+	}
+	return sa
+}
+
+// buildSnippets returns the SPDX snippets of all the file nodes in the document
+func buildSnippets(bom *sbom.Document) []spdx.Snippet {
+	snippets := []spdx.Snippet{}
+	for _, node := range bom.NodeList.Nodes {
+		if node.Type != sbom.Node_FILE || node.ExternalDocument != "" {
+			continue
+		}
+
+		for _, sn := range node.Snippets {
+			concluded, concludedErr := license.Normalize(sn.LicenseConcluded)
+			licenses, licensesErr := license.NormalizeList(sn.Licenses)
+			if err := errors.Join(concludedErr, licensesErr); err != nil {
+				logrus.Warnf("invalid license in %s: %v", sn.Id, err)
+			}
+
+			snippet := spdx.Snippet{
+				SnippetSPDXIdentifier:         common.ElementID(sn.Id),
+				SnippetFromFileSPDXIdentifier: common.ElementID(node.Id),
+				Ranges:                        []common.SnippetRange{},
+				SnippetLicenseConcluded:       concluded,
+				LicenseInfoInSnippet:          licenses,
+				SnippetLicenseComments:        sn.LicenseComments,
+				SnippetCopyrightText:          sbom.ValueToSPDX(sn.Copyright, true),
+				SnippetComment:                sn.Comment,
+				SnippetName:                   sn.Name,
+				SnippetAttributionTexts:       sn.Attribution,
+			}
+
+			for _, r := range sn.Ranges {
+				snippet.Ranges = append(snippet.Ranges, common.SnippetRange{
+					StartPointer: common.SnippetRangePointer{
+						Offset:             int(r.StartOffset),
+						LineNumber:         int(r.StartLine),
+						FileSPDXIdentifier: common.ElementID(node.Id),
+					},
+					EndPointer: common.SnippetRangePointer{
+						Offset:             int(r.EndOffset),
+						LineNumber:         int(r.EndLine),
+						FileSPDXIdentifier: common.ElementID(node.Id),
+					},
+				})
+			}
+			snippets = append(snippets, snippet)
+		}
+	}
+	return snippets
+}
+
+func (s *SPDX23) buildPackages(bom *sbom.Document, opts *SPDX23Options) ([]*spdx.Package, error) {
 	packages := []*spdx.Package{}
 	for _, node := range bom.NodeList.Nodes {
-		if node.Type == sbom.Node_FILE {
+		// Nodes of external documents are only referenced
+		if node.Type == sbom.Node_FILE || node.ExternalDocument != "" {
 			continue
 		}
 
+		if len(node.FileTypes) > 0 {
+			// SPDX packages have no file type, the data cannot be written
+			logrus.Warnf("package %s has file types, they will not be serialized", node.Id)
+		}
+
+		concluded, concludedErr := license.Normalize(node.LicenseConcluded)
+		declared, declaredErr := license.NormalizeList(node.Licenses)
+		detected, detectedErr := license.NormalizeList(node.LicensesDetected)
+		if err := errors.Join(concludedErr, declaredErr, detectedErr); err != nil {
+			logrus.Warnf("invalid license in %s: %v", node.Id, err)
+		}
+
 		p := spdx.Package{
 			IsUnpackaged:          false,
 			PackageName:           node.Name,
@@ -202,23 +815,22 @@ func (s *SPDX23) buildPackages(bom *sbom.Document) ([]*spdx.Package, error) { //
 			PackageFileName:       node.FileName,
 			// PackageSupplier:             &common.Supplier{},
 			// PackageOriginator:           &common.Originator{},
-			PackageDownloadLocation: node.UrlDownload,
+			PackageDownloadLocation: sbom.ValueToSPDX(node.UrlDownload, true),
 			// FilesAnalyzed:               false,
 			// IsFilesAnalyzedTagPresent:   false,
 			// PackageVerificationCode:     &common.PackageVerificationCode{},
 			PackageChecksums:            []common.Checksum{},
-			PackageHomePage:             node.UrlHome,
+			PackageHomePage:             sbom.ValueToSPDX(node.UrlHome, false),
 			PackageSourceInfo:           node.SourceInfo,
-			PackageLicenseConcluded:     node.LicenseConcluded,
+			PackageLicenseConcluded:     concluded,
 			PackageLicenseInfoFromFiles: []string{},
-			// PackageLicenseDeclared:      node.Licenses[0],
-			PackageLicenseComments:    node.LicenseComments,
-			PackageCopyrightText:      strings.TrimSpace(node.Copyright),
-			PackageSummary:            node.Summary,
-			PackageDescription:        node.Description,
-			PackageComment:            node.Comment,
-			PackageExternalReferences: []*v2_3.PackageExternalReference{},
-			PackageAttributionTexts:   node.Attribution,
+			PackageLicenseComments:      node.LicenseComments,
+			PackageCopyrightText:        sbom.ValueToSPDX(node.Copyright, false),
+			PackageSummary:              node.Summary,
+			PackageDescription:          node.Description,
+			PackageComment:              node.Comment,
+			PackageExternalReferences:   []*v2_3.PackageExternalReference{},
+			PackageAttributionTexts:     node.Attribution,
 			// PrimaryPackagePurpose:     node.PrimaryPurpose,
 			Annotations: []v2_3.Annotation{},
 
@@ -233,75 +845,23 @@ func (s *SPDX23) buildPackages(bom *sbom.Document) ([]*spdx.Package, error) { //
 			// Files:                       []*v2_3.File{},
 		}
 
-		if len(node.PrimaryPurpose) > 0 && (node.PrimaryPurpose[0] != sbom.Purpose_UNKNOWN_PURPOSE) {
-			// Allowed values: APPLICATION, FRAMEWORK, LIBRARY, CONTAINER, OPERATING-SYSTEM, DEVICE, FIRMWARE, SOURCE, ARCHIVE, FILE, INSTALL, OTHER
-
-			if len(node.PrimaryPurpose) > 1 {
-				// TODO(degradation): Multiple PrimaryPurpose in protobom.Node, but spdx.Package only allows single PrimaryPackagePurpose so we are using the first
-				if true { // temp workaround in favor of adding a lint tag
-					break
-				}
-			}
-
-			switch node.PrimaryPurpose[0] {
-			case sbom.Purpose_APPLICATION, sbom.Purpose_EXECUTABLE:
-				p.PrimaryPackagePurpose = "APPLICATION"
-			case sbom.Purpose_FRAMEWORK:
-				p.PrimaryPackagePurpose = "FRAMEWORK"
-			case sbom.Purpose_LIBRARY, sbom.Purpose_MODULE:
-				p.PrimaryPackagePurpose = "LIBRARY"
-			case sbom.Purpose_CONTAINER:
-				p.PrimaryPackagePurpose = "CONTAINER"
-			case sbom.Purpose_OPERATING_SYSTEM:
-				p.PrimaryPackagePurpose = "OPERATING-SYSTEM"
-			case sbom.Purpose_DEVICE, sbom.Purpose_DEVICE_DRIVER:
-				p.PrimaryPackagePurpose = "DEVICE"
-			case sbom.Purpose_FIRMWARE:
-				p.PrimaryPackagePurpose = "FIRMWARE"
-			case sbom.Purpose_SOURCE, sbom.Purpose_PATCH:
-				p.PrimaryPackagePurpose = "SOURCE"
-			case sbom.Purpose_ARCHIVE:
-				p.PrimaryPackagePurpose = "ARCHIVE"
-			case sbom.Purpose_FILE:
-				p.PrimaryPackagePurpose = "FILE"
-			case sbom.Purpose_INSTALL:
-				p.PrimaryPackagePurpose = "INSTALL"
-			case sbom.Purpose_OTHER, sbom.Purpose_DATA, sbom.Purpose_BOM, sbom.Purpose_CONFIGURATION, sbom.Purpose_DOCUMENTATION, sbom.Purpose_EVIDENCE, sbom.Purpose_MANIFEST, sbom.Purpose_REQUIREMENT, sbom.Purpose_SPECIFICATION, sbom.Purpose_TEST:
-				p.PrimaryPackagePurpose = "OTHER"
-			case sbom.Purpose_MACHINE_LEARNING_MODEL, sbom.Purpose_MODEL:
-				p.PrimaryPackagePurpose = "OTHER"
-			case sbom.Purpose_PLATFORM:
-				p.PrimaryPackagePurpose = "OTHER"
-			default:
-				// TODO(degradation): Non-matching primary purpose to component type mapping
-				if true { // temp workaround in favor of adding a lint tag
-					break
-				}
-			}
-		}
-
-		if node.ReleaseDate != nil {
-			p.ReleaseDate = node.ReleaseDate.String()
+		if len(node.PrimaryPurpose) > 1 {
+			// SPDX packages only have one purpose, we use the first one
+			logrus.Warnf("package %s has %d purposes, only the first one will be serialized", node.Id, len(node.PrimaryPurpose))
 		}
-
-		if node.BuildDate != nil {
-			p.BuiltDate = node.BuildDate.String()
+		if len(node.PrimaryPurpose) > 0 {
+			p.PrimaryPackagePurpose = s.purposeToSPDX(node.PrimaryPurpose[0])
 		}
 
-		if node.ValidUntilDate != nil {
-			p.ValidUntilDate = node.ValidUntilDate.String()
-		}
-
-		if p.PackageDownloadLocation == "" {
-			p.PackageDownloadLocation = protospdx.NOASSERTION
-		}
+		p.ReleaseDate = spdxDate(node.ReleaseDate)
+		p.BuiltDate = spdxDate(node.BuildDate)
+		p.ValidUntilDate = spdxDate(node.ValidUntilDate)
 
 		for algo, hash := range node.Hashes {
 			if _, ok := sbom.HashAlgorithm_name[algo]; ok {
 				spdxAlgo := sbom.HashAlgorithm(algo).ToSPDX()
 				if spdxAlgo == "" {
-					// Data loss here.
-					// TODO how do we handle when data loss occurs?
+					logrus.Warnf("hash algorithm %s of %s not supported in SPDX, dropping it", sbom.HashAlgorithm(algo), node.Id)
 					continue
 				}
 				p.PackageChecksums = append(p.PackageChecksums, common.Checksum{
@@ -326,32 +886,65 @@ func (s *SPDX23) buildPackages(bom *sbom.Document) ([]*spdx.Package, error) { //
 			})
 		}
 
+		// Sort the identifier types to get a deterministic output
+		idTypes := []int{}
 		for i := range node.Identifiers {
+			idTypes = append(idTypes, int(i))
+		}
+		sort.Ints(idTypes)
+		for _, i := range idTypes {
+			idType := sbom.SoftwareIdentifierType(i)
+			if idType.ToSPDX2Type() == "" {
+				// TODO(degradation): Identifier type not supported in SPDX 2
+				continue
+			}
 			p.PackageExternalReferences = append(p.PackageExternalReferences, &v2_3.PackageExternalReference{
-				Category: sbom.SoftwareIdentifierType(i).ToSPDX2Category(),
-				RefType:  sbom.SoftwareIdentifierType(i).ToSPDX2Type(),
-				Locator:  node.Identifiers[i],
+				Category:           idType.ToSPDX2Category(),
+				RefType:            idType.ToSPDX2Type(),
+				Locator:            node.Identifiers[int32(i)],
+				ExternalRefComment: node.IdentifierComments[int32(i)],
 			})
 		}
 
 		if len(node.Suppliers) > 0 {
 			// TODO(degradation): URL, Phone are lost if set
 			// TODO(degradation): If is more than one supplier, it will be lost
-			p.PackageSupplier = &spdx.Supplier{
-				Supplier:     node.Suppliers[0].ToSPDX2ClientString(),
-				SupplierType: node.Suppliers[0].ToSPDX2ClientOrg(),
-			}
+			p.PackageSupplier = supplierToSPDX(node.Suppliers[0])
 		}
 
 		if len(node.Originators) > 0 {
 			// TODO(degradation): URL, Phone are lost if set
 			// TODO(degradation): If is more than one originator, it will be lost
-			p.PackageSupplier = &spdx.Supplier{
-				Supplier:     node.Originators[0].ToSPDX2ClientString(),
-				SupplierType: node.Originators[0].ToSPDX2ClientOrg(),
+			p.PackageOriginator = originatorToSPDX(node.Originators[0])
+		}
+
+		analyzed, code, err := packageFilesAnalyzed(bom.NodeList, node, opts)
+		if err != nil {
+			return nil, fmt.Errorf("building verification code of %s: %w", node.Id, err)
+		}
+		// SPDX has a single declared license, multiple licenses are
+		// joined as a choice
+		if len(declared) > 0 {
+			p.PackageLicenseDeclared = license.Disjunction(declared)
+		}
+
+		p.FilesAnalyzed = analyzed
+		p.IsFilesAnalyzedTagPresent = true
+		if analyzed {
+			p.PackageVerificationCode = code
+			// Analyzed packages list the licenses found in their files,
+			// NOASSERTION when they were not recorded
+			p.PackageLicenseInfoFromFiles = detected
+			if len(p.PackageLicenseInfoFromFiles) == 0 {
+				p.PackageLicenseInfoFromFiles = []string{protospdx.NOASSERTION}
 			}
+		} else {
+			p.PackageLicenseInfoFromFiles = nil
 		}
 
+		p.Annotations = nodeAnnotationsToSPDX(node)
+		p.Annotations = append(p.Annotations, propertiesToSPDX(node.Properties, node.Id, bom.Metadata.Date)...)
+
 		// TODO(puerco): Reconcile file in packages
 		packages = append(packages, &p)
 	}
@@ -360,12 +953,49 @@ func (s *SPDX23) buildPackages(bom *sbom.Document) ([]*spdx.Package, error) { //
 
 // ExtRefCategoryFromProtobomExtRef reads a protobom external reference struct and returns a
 // string with the corresponding category
+// purposeToSPDX returns the SPDX 2.3 primary package purpose of a protobom
+// purpose. Purposes without an SPDX 2.3 equivalent are written as OTHER,
+// Purpose_UNKNOWN_PURPOSE returns a blank string as the field is optional.
+func (s *SPDX23) purposeToSPDX(purpose sbom.Purpose) string {
+	// Allowed values: APPLICATION, FRAMEWORK, LIBRARY, CONTAINER, OPERATING-SYSTEM, DEVICE, FIRMWARE, SOURCE, ARCHIVE, FILE, INSTALL, OTHER
+	switch purpose {
+	case sbom.Purpose_UNKNOWN_PURPOSE:
+		return ""
+	case sbom.Purpose_APPLICATION, sbom.Purpose_EXECUTABLE:
+		return "APPLICATION"
+	case sbom.Purpose_FRAMEWORK:
+		return "FRAMEWORK"
+	case sbom.Purpose_LIBRARY, sbom.Purpose_MODULE:
+		return "LIBRARY"
+	case sbom.Purpose_CONTAINER:
+		return "CONTAINER"
+	case sbom.Purpose_OPERATING_SYSTEM:
+		return "OPERATING-SYSTEM"
+	case sbom.Purpose_DEVICE, sbom.Purpose_DEVICE_DRIVER:
+		return "DEVICE"
+	case sbom.Purpose_FIRMWARE:
+		return "FIRMWARE"
+	case sbom.Purpose_SOURCE, sbom.Purpose_PATCH:
+		return "SOURCE"
+	case sbom.Purpose_ARCHIVE:
+		return "ARCHIVE"
+	case sbom.Purpose_FILE:
+		return "FILE"
+	case sbom.Purpose_INSTALL:
+		return "INSTALL"
+	default:
+		// OTHER, PLATFORM, DATA, MACHINE_LEARNING_MODEL and the SPDX 3 purposes
+		return "OTHER"
+	}
+}
+
 func (s *SPDX23) extRefCategoryFromProtobomExtRef(extref *sbom.ExternalReference) string {
 	switch extref.Type {
 	case sbom.ExternalReference_BOWER, sbom.ExternalReference_MAVEN_CENTRAL,
 		sbom.ExternalReference_NPM, sbom.ExternalReference_NUGET:
 		return spdx.CategoryPackageManager
-	case sbom.ExternalReference_SECURITY_ADVISORY, sbom.ExternalReference_SECURITY_FIX:
+	case sbom.ExternalReference_SECURITY_ADVISORY, sbom.ExternalReference_SECURITY_FIX,
+		sbom.ExternalReference_SECURITY_SWID:
 		return spdx.CategorySecurity
 	case sbom.ExternalReference_SECURITY_OTHER:
 		// This is mapped...
@@ -396,6 +1026,10 @@ func (s *SPDX23) extRefTypeFromProtobomExtRef(extref *sbom.ExternalReference) st
 	case sbom.ExternalReference_NUGET:
 		return spdx.PackageManagerNuGet
 	case sbom.ExternalReference_OTHER:
+		// The type of OTHER references is captured as the authority
+		if extref.Authority != "" {
+			return extref.Authority
+		}
 		return spdxOther
 	case sbom.ExternalReference_SECURITY_ADVISORY:
 		return spdx.SecurityAdvisory
@@ -403,6 +1037,8 @@ func (s *SPDX23) extRefTypeFromProtobomExtRef(extref *sbom.ExternalReference) st
 		return spdx.SecurityFix
 	case sbom.ExternalReference_SECURITY_OTHER:
 		return spdx.SecurityUrl
+	case sbom.ExternalReference_SECURITY_SWID:
+		return spdx.SecuritySwid
 	default:
 		return spdxOther
 	}
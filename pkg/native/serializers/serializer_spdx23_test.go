@@ -1,11 +1,27 @@
 package serializers
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	cdxformats "github.com/bom-squad/protobom/pkg/formats/cyclonedx"
+	protospdx "github.com/bom-squad/protobom/pkg/formats/spdx"
+	"github.com/bom-squad/protobom/pkg/native"
+	"github.com/bom-squad/protobom/pkg/native/unserializers"
 	"github.com/bom-squad/protobom/pkg/sbom"
+	spdxjson "github.com/spdx/tools-golang/json"
 	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestExtRefCategoryFromProtobomExtRef(t *testing.T) {
@@ -21,7 +37,7 @@ func TestExtRefCategoryFromProtobomExtRef(t *testing.T) {
 				sbom.ExternalReference_NPM, sbom.ExternalReference_NUGET:
 				require.Equal(t, spdx.CategoryPackageManager, res)
 			case sbom.ExternalReference_SECURITY_ADVISORY, sbom.ExternalReference_SECURITY_FIX,
-				sbom.ExternalReference_SECURITY_OTHER:
+				sbom.ExternalReference_SECURITY_OTHER, sbom.ExternalReference_SECURITY_SWID:
 				require.Equal(t, spdx.CategorySecurity, res)
 			default:
 				require.Equal(t, spdxOther, res)
@@ -56,9 +72,1217 @@ func TestExtRefTypeFromProtobomExtRef(t *testing.T) {
 				require.Equal(t, spdx.SecurityFix, res)
 			case sbom.ExternalReference_SECURITY_OTHER:
 				require.Equal(t, spdx.SecurityUrl, res)
+			case sbom.ExternalReference_SECURITY_SWID:
+				require.Equal(t, spdx.SecuritySwid, res)
 			default:
 				require.Equal(t, spdxOther, res)
 			}
 		})
 	}
 }
+
+func TestBuildSnippets(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:   "File-1",
+		Type: sbom.Node_FILE,
+		Snippets: []*sbom.Snippet{
+			{
+				Id:               "Snippet-1",
+				Name:             "snippet",
+				LicenseConcluded: "MIT",
+				Ranges:           []*sbom.SnippetRange{{StartOffset: 10, EndOffset: 20}},
+			},
+		},
+	})
+	doc.NodeList.AddNode(&sbom.Node{Id: "Package-1", Type: sbom.Node_PACKAGE})
+
+	snippets := buildSnippets(doc)
+	require.Len(t, snippets, 1)
+	require.Equal(t, common.ElementID("Snippet-1"), snippets[0].SnippetSPDXIdentifier)
+	require.Equal(t, common.ElementID("File-1"), snippets[0].SnippetFromFileSPDXIdentifier)
+	require.Equal(t, "MIT", snippets[0].SnippetLicenseConcluded)
+	require.Equal(t, protospdx.NOASSERTION, snippets[0].SnippetCopyrightText)
+	require.Len(t, snippets[0].Ranges, 1)
+	require.Equal(t, 10, snippets[0].Ranges[0].StartPointer.Offset)
+	require.Equal(t, 20, snippets[0].Ranges[0].EndPointer.Offset)
+	require.Equal(t, common.ElementID("File-1"), snippets[0].Ranges[0].EndPointer.FileSPDXIdentifier)
+}
+
+func TestBuildPackagesSupplierOriginator(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:          "Package-1",
+		Type:        sbom.Node_PACKAGE,
+		Suppliers:   []*sbom.Person{{Name: "Acme", IsOrg: true, Email: "info@acme.com"}},
+		Originators: []*sbom.Person{{Name: "John Doe"}},
+	})
+
+	packages, err := NewSPDX23().buildPackages(doc, &SPDX23Options{})
+	require.NoError(t, err)
+	require.Len(t, packages, 1)
+	require.Equal(t, &spdx.Supplier{Supplier: "Acme (info@acme.com)", SupplierType: protospdx.Organization}, packages[0].PackageSupplier)
+	require.Equal(t, &spdx.Originator{Originator: "John Doe", OriginatorType: protospdx.Person}, packages[0].PackageOriginator)
+}
+
+func TestAnnotationToSPDX(t *testing.T) {
+	date := timestamppb.New(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	for _, tc := range []struct {
+		name     string
+		sut      *sbom.Annotation
+		expected *spdx.Annotation
+	}{
+		{
+			"person review",
+			&sbom.Annotation{
+				Annotator: &sbom.Person{Name: "Jane Doe", Email: "jane@example.com"},
+				Type:      sbom.Annotation_REVIEW, Date: date, Comment: "LGTM",
+			},
+			&spdx.Annotation{
+				Annotator:      common.Annotator{Annotator: "Jane Doe (jane@example.com)", AnnotatorType: protospdx.Person},
+				AnnotationType: "REVIEW", AnnotationDate: "2023-01-01T00:00:00Z", AnnotationComment: "LGTM",
+			},
+		},
+		{
+			"tool",
+			&sbom.Annotation{Tool: &sbom.Tool{Name: "scanner", Version: "1.0"}, Comment: "Scanned"},
+			&spdx.Annotation{
+				Annotator:      common.Annotator{Annotator: "scanner-1.0", AnnotatorType: protospdx.Tool},
+				AnnotationType: "OTHER", AnnotationComment: "Scanned",
+			},
+		},
+		{"no annotator", &sbom.Annotation{Comment: "Lost"}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, annotationToSPDX(tc.sut))
+		})
+	}
+}
+
+func TestExternalReferencesRoundtrip(t *testing.T) {
+	f, err := os.Open("testdata/extrefs.spdx.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	original, err := spdxjson.Read(f)
+	require.NoError(t, err)
+	_, err = f.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+
+	doc, err := unserializers.NewSPDX23().Unserialize(f, nil, nil)
+	require.NoError(t, err)
+
+	// Comments of the software identifiers are kept by identifier type
+	require.Equal(t, map[int32]string{
+		int32(sbom.SoftwareIdentifierType_PURL):  "Package URL from the lockfile",
+		int32(sbom.SoftwareIdentifierType_CPE23): "Matched by the scanner",
+	}, doc.NodeList.GetNodeByID("Package-example").IdentifierComments)
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	spdxDoc, ok := res.(*spdx.Document)
+	require.True(t, ok)
+	require.Len(t, spdxDoc.Packages, 1)
+	require.ElementsMatch(t, original.Packages[0].PackageExternalReferences, spdxDoc.Packages[0].PackageExternalReferences)
+}
+
+func TestPackageVerificationCode(t *testing.T) {
+	newNodeList := func() *sbom.NodeList {
+		nl := &sbom.NodeList{
+			Nodes: []*sbom.Node{
+				{
+					Id: "Package-1", Type: sbom.Node_PACKAGE,
+					VerificationCode: &sbom.VerificationCode{Value: "d6a770ba38583ed4bb4525bd96e50461655d2758", ExcludedFiles: []string{"./package.spdx"}},
+				},
+				{Id: "File-1", Type: sbom.Node_FILE, Name: "./a.c", Hashes: map[int32]string{int32(sbom.HashAlgorithm_SHA1): "85ed0817af83a24ad8da68c2b5094de69833983c"}},
+				{Id: "File-2", Type: sbom.Node_FILE, Name: "./b.c", Hashes: map[int32]string{int32(sbom.HashAlgorithm_SHA1): "0b3f54a0a5d5a9e7d45c0b85ba8b3df4c84d0c1e"}},
+				{Id: "File-3", Type: sbom.Node_FILE, Name: "./package.spdx", Hashes: map[int32]string{int32(sbom.HashAlgorithm_SHA1): "ffffffffffffffffffffffffffffffffffffffff"}},
+			},
+			Edges: []*sbom.Edge{
+				{Type: sbom.Edge_contains, From: "Package-1", To: []string{"File-1", "File-2", "File-3"}},
+			},
+		}
+		return nl
+	}
+
+	for _, tc := range []struct {
+		name     string
+		prepare  func(*sbom.NodeList)
+		mode     VerificationCodeMode
+		expected *common.PackageVerificationCode
+	}{
+		{
+			name:     "keep",
+			prepare:  func(*sbom.NodeList) {},
+			mode:     VerificationCodeKeep,
+			expected: &common.PackageVerificationCode{Value: "d6a770ba38583ed4bb4525bd96e50461655d2758", ExcludedFiles: []string{"./package.spdx"}},
+		},
+		{
+			name:     "keep without code",
+			prepare:  func(nl *sbom.NodeList) { nl.Nodes[0].VerificationCode = nil },
+			mode:     VerificationCodeKeep,
+			expected: nil,
+		},
+		{
+			name:     "recompute",
+			prepare:  func(*sbom.NodeList) {},
+			mode:     VerificationCodeRecompute,
+			expected: &common.PackageVerificationCode{Value: "553a0676e67b6c0b178b67240777b92c11281f07", ExcludedFiles: []string{"./package.spdx"}},
+		},
+		{
+			name:     "recompute with missing hash",
+			prepare:  func(nl *sbom.NodeList) { nl.Nodes[1].Hashes = map[int32]string{} },
+			mode:     VerificationCodeRecompute,
+			expected: nil,
+		},
+		{
+			name:     "omit",
+			prepare:  func(*sbom.NodeList) {},
+			mode:     VerificationCodeOmit,
+			expected: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := newNodeList()
+			tc.prepare(nl)
+			code, err := packageVerificationCode(nl, nl.Nodes[0], tc.mode)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, code)
+		})
+	}
+}
+
+func TestSerializeFilesAnalyzed(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{
+		Id: "Package-1", Type: sbom.Node_PACKAGE,
+		VerificationCode: &sbom.VerificationCode{Value: "d6a770ba38583ed4bb4525bd96e50461655d2758"},
+	})
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	p := res.(*spdx.Document).Packages[0]
+	require.True(t, p.FilesAnalyzed)
+	require.Equal(t, "d6a770ba38583ed4bb4525bd96e50461655d2758", p.PackageVerificationCode.Value)
+
+	res, err = NewSPDX23().Serialize(doc, nil, &SPDX23Options{VerificationCode: VerificationCodeOmit})
+	require.NoError(t, err)
+	p = res.(*spdx.Document).Packages[0]
+	require.False(t, p.FilesAnalyzed)
+	require.Nil(t, p.PackageVerificationCode)
+}
+
+func TestSerializeFilesAnalyzedRelationships(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		filesAnalyzed bool
+		opts          *SPDX23Options
+		expected      []string
+	}{
+		{
+			name:          "analyzed",
+			filesAnalyzed: true,
+			expected:      []string{"Package-1 CONTAINS File-1", "Package-1 CONTAINS Package-2"},
+		},
+		{
+			name:     "not analyzed",
+			expected: []string{"Package-1 CONTAINS Package-2"},
+		},
+		{
+			name:          "forced not analyzed",
+			filesAnalyzed: true,
+			opts:          &SPDX23Options{ForceFilesNotAnalyzed: true},
+			expected:      []string{"Package-1 CONTAINS Package-2"},
+		},
+		{
+			name:     "inverse relationships",
+			opts:     &SPDX23Options{PreserveRelationshipDirection: true},
+			expected: []string{"Package-1 CONTAINS Package-2"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.NodeList.AddNode(&sbom.Node{
+				Id: "Package-1", Type: sbom.Node_PACKAGE, FilesAnalyzed: proto.Bool(tc.filesAnalyzed),
+				VerificationCode: &sbom.VerificationCode{Value: "d6a770ba38583ed4bb4525bd96e50461655d2758"},
+			})
+			doc.NodeList.AddNode(&sbom.Node{Id: "Package-2", Type: sbom.Node_PACKAGE})
+			doc.NodeList.AddNode(&sbom.Node{
+				Id: "File-1", Type: sbom.Node_FILE, Name: "./main.go",
+				Hashes: map[int32]string{int32(sbom.HashAlgorithm_SHA1): "d6a770ba38583ed4bb4525bd96e50461655d2758"},
+			})
+			doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_contains, From: "Package-1", To: []string{"Package-2"}})
+			doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_contained_by, From: "File-1", To: []string{"Package-1"}})
+
+			res, err := NewSPDX23().Serialize(doc, nil, tc.opts)
+			require.NoError(t, err)
+			out := res.(*spdx.Document)
+			require.Equal(t, tc.filesAnalyzed && tc.opts == nil, out.Packages[0].FilesAnalyzed)
+			if !out.Packages[0].FilesAnalyzed {
+				require.Nil(t, out.Packages[0].PackageVerificationCode)
+			}
+
+			rels := []string{}
+			for _, r := range out.Relationships {
+				if r.Relationship == "DESCRIBES" {
+					continue
+				}
+				rels = append(rels, fmt.Sprintf("%s %s %s", r.RefA.ElementRefID, r.Relationship, r.RefB.ElementRefID))
+			}
+			slices.Sort(rels)
+			require.Equal(t, tc.expected, rels)
+		})
+	}
+}
+
+func TestSerializeLicensesDetected(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{
+		Id: "Package-1", Type: sbom.Node_PACKAGE, LicenseConcluded: "MIT",
+		LicensesDetected: []string{"mit", "Apache-2.0"},
+		VerificationCode: &sbom.VerificationCode{Value: "d6a770ba38583ed4bb4525bd96e50461655d2758"},
+	})
+	doc.NodeList.AddNode(&sbom.Node{
+		Id: "Package-2", Type: sbom.Node_PACKAGE,
+		VerificationCode: &sbom.VerificationCode{Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"},
+	})
+	doc.NodeList.AddNode(&sbom.Node{Id: "Package-3", Type: sbom.Node_PACKAGE, LicensesDetected: []string{"MIT"}})
+	doc.NodeList.AddNode(&sbom.Node{
+		Id: "File-1", Type: sbom.Node_FILE, Name: "./main.go", LicenseConcluded: "MIT",
+		LicensesDetected: []string{"MIT", "LicenseRef-custom"},
+	})
+	doc.NodeList.AddNode(&sbom.Node{Id: "File-2", Type: sbom.Node_FILE, Name: "./README"})
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	spdxDoc := res.(*spdx.Document)
+	packages := map[string]*spdx.Package{}
+	for _, p := range spdxDoc.Packages {
+		packages[string(p.PackageSPDXIdentifier)] = p
+	}
+
+	require.Equal(t, "MIT", packages["Package-1"].PackageLicenseConcluded)
+	require.Equal(t, []string{"MIT", "Apache-2.0"}, packages["Package-1"].PackageLicenseInfoFromFiles)
+	// Analyzed packages without detected licenses are written as NOASSERTION
+	require.Equal(t, []string{protospdx.NOASSERTION}, packages["Package-2"].PackageLicenseInfoFromFiles)
+	// Packages whose files were not analyzed cannot list their licenses
+	require.False(t, packages["Package-3"].FilesAnalyzed)
+	require.Nil(t, packages["Package-3"].PackageLicenseInfoFromFiles)
+
+	require.Len(t, spdxDoc.Files, 2)
+	require.Equal(t, "MIT", spdxDoc.Files[0].LicenseConcluded)
+	require.Equal(t, []string{"MIT", "LicenseRef-custom"}, spdxDoc.Files[0].LicenseInfoInFiles)
+	require.Nil(t, spdxDoc.Files[1].LicenseInfoInFiles)
+}
+
+func TestSerializeLicenseDeclared(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{Id: "Package-1", Type: sbom.Node_PACKAGE, Licenses: []string{"mit"}})
+	doc.NodeList.AddNode(&sbom.Node{Id: "Package-2", Type: sbom.Node_PACKAGE, Licenses: []string{"MIT", "LicenseRef-a AND ISC"}})
+	doc.NodeList.AddNode(&sbom.Node{Id: "Package-3", Type: sbom.Node_PACKAGE})
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	declared := map[string]string{}
+	for _, p := range res.(*spdx.Document).Packages {
+		declared[string(p.PackageSPDXIdentifier)] = p.PackageLicenseDeclared
+	}
+	require.Equal(t, map[string]string{
+		"Package-1": "MIT",
+		"Package-2": "MIT OR (LicenseRef-a AND ISC)",
+		"Package-3": "",
+	}, declared)
+}
+
+func TestSerializeCreated(t *testing.T) {
+	created := time.Date(2023, 8, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	res, err := NewSPDX23().Serialize(sbom.NewDocument(sbom.WithTimestamp(created)), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "2023-08-01T10:00:00Z", res.(*spdx.Document).CreationInfo.Created)
+
+	// Documents without a date are dated when written
+	doc := sbom.NewDocument()
+	doc.Metadata.Date = nil
+	before := time.Now().UTC().Truncate(time.Second)
+	res, err = NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	date, err := time.Parse(time.RFC3339, res.(*spdx.Document).CreationInfo.Created)
+	require.NoError(t, err)
+	require.False(t, date.Before(before))
+}
+
+func TestDocumentNamespace(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		md       *sbom.Metadata
+		expected string
+	}{
+		{"URL", &sbom.Metadata{Id: "https://example.com/spdx/doc-1"}, "https://example.com/spdx/doc-1"},
+		{"UUID URN", &sbom.Metadata{Id: "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79", Name: "my sbom"}, "https://spdx.org/spdxdocs/my%20sbom-3e671687-395b-41f5-a30f-a58921a69b79"},
+		{"unnamed", &sbom.Metadata{Id: "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"}, "https://spdx.org/spdxdocs/protobom-3e671687-395b-41f5-a30f-a58921a69b79"},
+		{"URL with fragment", &sbom.Metadata{Id: "https://example.com/doc#SPDXRef-DOCUMENT"}, ""},
+		{"SPDX ID", &sbom.Metadata{Id: "SPDXRef-DOCUMENT"}, ""},
+		{"blank", &sbom.Metadata{}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ns := documentNamespace(tc.md)
+			if tc.expected != "" {
+				require.Equal(t, tc.expected, ns)
+				return
+			}
+			// Other IDs get a new unique namespace
+			require.True(t, strings.HasPrefix(ns, "https://spdx.org/spdxdocs/protobom-"))
+			require.NotEqual(t, ns, documentNamespace(tc.md))
+		})
+	}
+
+	// The serializer writes the namespace of the document ID
+	doc := sbom.NewDocument()
+	doc.Metadata.Name = "test"
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "https://spdx.org/spdxdocs/test-"+strings.TrimPrefix(doc.Metadata.Id, "urn:uuid:"), res.(*spdx.Document).DocumentNamespace)
+}
+
+func TestBuildFilesFileTypes(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{Id: "Package-1", Type: sbom.Node_PACKAGE, FileTypes: []string{"SOURCE"}})
+	src := &sbom.Node{Id: "File-1", Type: sbom.Node_FILE, Name: "main.c"}
+	src.SetFileTypes(sbom.FileTypeSource)
+	doc.NodeList.AddNode(src)
+	doc.NodeList.AddNode(&sbom.Node{Id: "File-2", Type: sbom.Node_FILE, Name: "main", FileTypes: []string{"binary", "BINARY", "executable"}})
+	// Without file types, they are derived from the purposes
+	doc.NodeList.AddNode(&sbom.Node{
+		Id: "File-3", Type: sbom.Node_FILE, Name: "README",
+		PrimaryPurpose: []sbom.Purpose{sbom.Purpose_DOCUMENTATION, sbom.Purpose_DATA, sbom.Purpose_OTHER},
+	})
+
+	files, err := buildFiles(doc)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	types := map[common.ElementID][]string{}
+	for _, f := range files {
+		types[f.FileSPDXIdentifier] = f.FileTypes
+	}
+	require.Equal(t, []string{"SOURCE"}, types["File-1"])
+	require.Equal(t, []string{"BINARY", "OTHER"}, types["File-2"])
+	require.Equal(t, []string{"DOCUMENTATION", "OTHER"}, types["File-3"])
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, res.(*spdx.Document).Packages, 1)
+}
+
+func TestPackageFilesAnalyzed(t *testing.T) {
+	const code = "d6a770ba38583ed4bb4525bd96e50461655d2758"
+	nl := &sbom.NodeList{}
+	nl.AddNode(&sbom.Node{
+		Id: "File-1", Type: sbom.Node_FILE, Name: "./main.c",
+		Hashes: map[int32]string{int32(sbom.HashAlgorithm_SHA1): "85ed0817af83a24ad8da68c2b5094de69833983c"},
+	})
+	nl.AddEdge(&sbom.Edge{Type: sbom.Edge_contains, From: "Package-2", To: []string{"File-1"}})
+
+	for _, tc := range []struct {
+		name         string
+		node         *sbom.Node
+		opts         *SPDX23Options
+		expected     bool
+		expectedCode bool
+	}{
+		{
+			"unknown, no code",
+			&sbom.Node{Id: "Package-1"},
+			&SPDX23Options{}, false, false,
+		},
+		{
+			"unknown with code",
+			&sbom.Node{Id: "Package-1", VerificationCode: &sbom.VerificationCode{Value: code}},
+			&SPDX23Options{}, true, true,
+		},
+		{
+			"not analyzed with code",
+			&sbom.Node{Id: "Package-1", FilesAnalyzed: proto.Bool(false), VerificationCode: &sbom.VerificationCode{Value: code}},
+			&SPDX23Options{}, false, false,
+		},
+		{
+			"analyzed, code computed from files",
+			&sbom.Node{Id: "Package-2", FilesAnalyzed: proto.Bool(true)},
+			&SPDX23Options{}, true, true,
+		},
+		{
+			"analyzed, no files to compute code",
+			&sbom.Node{Id: "Package-1", FilesAnalyzed: proto.Bool(true)},
+			&SPDX23Options{}, false, false,
+		},
+		{
+			"forced not analyzed",
+			&sbom.Node{Id: "Package-1", FilesAnalyzed: proto.Bool(true), VerificationCode: &sbom.VerificationCode{Value: code}},
+			&SPDX23Options{ForceFilesNotAnalyzed: true}, false, false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			analyzed, res, err := packageFilesAnalyzed(nl, tc.node, tc.opts)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, analyzed)
+			require.Equal(t, tc.expectedCode, res != nil)
+		})
+	}
+}
+
+func TestSerializeForceFilesNotAnalyzed(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{
+		Id: "Package-1", Type: sbom.Node_PACKAGE, FilesAnalyzed: proto.Bool(true),
+		VerificationCode: &sbom.VerificationCode{Value: "d6a770ba38583ed4bb4525bd96e50461655d2758"},
+	})
+
+	res, err := NewSPDX23().Serialize(doc, nil, &SPDX23Options{})
+	require.NoError(t, err)
+	p := res.(*spdx.Document).Packages[0]
+	require.True(t, p.FilesAnalyzed)
+	require.Equal(t, []string{protospdx.NOASSERTION}, p.PackageLicenseInfoFromFiles)
+
+	res, err = NewSPDX23().Serialize(doc, nil, &SPDX23Options{ForceFilesNotAnalyzed: true})
+	require.NoError(t, err)
+	p = res.(*spdx.Document).Packages[0]
+	require.False(t, p.FilesAnalyzed)
+	require.True(t, p.IsFilesAnalyzedTagPresent)
+	require.Nil(t, p.PackageVerificationCode)
+	require.Empty(t, p.PackageLicenseInfoFromFiles)
+}
+
+func TestSupplierOriginatorToSPDX(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      *sbom.Person
+		expected string
+	}{
+		{"org with email", &sbom.Person{Name: "Acme Corp", IsOrg: true, Email: "contact@acme.example"}, `"Organization: Acme Corp (contact@acme.example)"`},
+		{"person without email", &sbom.Person{Name: "Jane Doe"}, `"Person: Jane Doe"`},
+		{"extra whitespace", &sbom.Person{Name: " Jane Doe ", Email: " jane@acme.example "}, `"Person: Jane Doe (jane@acme.example)"`},
+		{"no name", &sbom.Person{Email: "jane@acme.example"}, `"NOASSERTION"`},
+		{"nil", nil, `"NOASSERTION"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(supplierToSPDX(tc.sut))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(data))
+
+			data, err = json.Marshal(originatorToSPDX(tc.sut))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(data))
+		})
+	}
+}
+
+func TestExtractedLicensesRoundtrip(t *testing.T) {
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "licenses",
+  "documentNamespace": "https://example.com/licenses",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-Package-1", "name": "one", "downloadLocation": "NOASSERTION",
+      "filesAnalyzed": false, "licenseConcluded": "MIT AND LicenseRef-custom"
+    }
+  ],
+  "hasExtractedLicensingInfos": [
+    {
+      "licenseId": "LicenseRef-custom", "extractedText": "Do what you want",
+      "name": "Custom License", "seeAlsos": ["https://example.com/license"], "comment": "Found in README"
+    },
+    {"licenseId": "LicenseRef-unused", "extractedText": "Not referenced"}
+  ]
+}`
+	doc, err := unserializers.NewSPDX23().Unserialize(strings.NewReader(spdxDoc), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, doc.Metadata.ExtractedLicenses, 2)
+	require.True(t, proto.Equal(&sbom.ExtractedLicense{
+		Id: "LicenseRef-custom", Name: "Custom License", Text: "Do what you want",
+		SeeAlso: []string{"https://example.com/license"}, Comment: "Found in README",
+	}, doc.Metadata.GetExtractedLicense("LicenseRef-custom")))
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	otherLicenses := res.(*spdx.Document).OtherLicenses
+	require.Len(t, otherLicenses, 1)
+	require.Equal(t, &spdx.OtherLicense{
+		LicenseIdentifier:      "LicenseRef-custom",
+		ExtractedText:          "Do what you want",
+		LicenseName:            "Custom License",
+		LicenseCrossReferences: []string{"https://example.com/license"},
+		LicenseComment:         "Found in README",
+	}, otherLicenses[0])
+
+	// Once no node references the license, it is not written
+	doc.NodeList.Nodes[0].LicenseConcluded = "MIT"
+	res, err = NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, res.(*spdx.Document).OtherLicenses)
+}
+
+func TestBuildPackagesPurpose(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:             "Package-1",
+		Type:           sbom.Node_PACKAGE,
+		PrimaryPurpose: []sbom.Purpose{sbom.Purpose_CONTAINER, sbom.Purpose_APPLICATION},
+	})
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:             "Package-2",
+		Type:           sbom.Node_PACKAGE,
+		PrimaryPurpose: []sbom.Purpose{sbom.Purpose_PLATFORM},
+	})
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:   "Package-3",
+		Type: sbom.Node_PACKAGE,
+	})
+
+	// A node with several purposes must not stop the rest of the packages
+	// from being written
+	packages, err := NewSPDX23().buildPackages(doc, &SPDX23Options{})
+	require.NoError(t, err)
+	require.Len(t, packages, 3)
+	require.Equal(t, "CONTAINER", packages[0].PrimaryPackagePurpose)
+	require.Equal(t, "OTHER", packages[1].PrimaryPackagePurpose)
+	require.Equal(t, "", packages[2].PrimaryPackagePurpose)
+}
+
+func TestPurposeToSPDX(t *testing.T) {
+	s23 := NewSPDX23()
+	for name, value := range sbom.Purpose_value {
+		purpose := sbom.Purpose(value)
+		res := s23.purposeToSPDX(purpose)
+		if purpose == sbom.Purpose_UNKNOWN_PURPOSE {
+			require.Empty(t, res, name)
+			continue
+		}
+		require.Contains(t, []string{
+			"APPLICATION", "FRAMEWORK", "LIBRARY", "CONTAINER", "OPERATING-SYSTEM", "DEVICE",
+			"FIRMWARE", "SOURCE", "ARCHIVE", "FILE", "INSTALL", "OTHER",
+		}, res, name)
+	}
+}
+
+func TestExternalDocumentsRoundtrip(t *testing.T) {
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "external",
+  "documentNamespace": "https://example.com/external",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "externalDocumentRefs": [{
+    "externalDocumentId": "DocumentRef-other",
+    "spdxDocument": "https://example.com/other",
+    "checksum": {"algorithm": "SHA1", "checksumValue": "d6a770ba38583ed4bb4525bd96e50461655d2759"}
+  }],
+  "packages": [
+    {"SPDXID": "SPDXRef-Package-1", "name": "one", "downloadLocation": "NOASSERTION", "filesAnalyzed": false}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-1"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "DocumentRef-other:SPDXRef-Package-foo"},
+    {"spdxElementId": "DocumentRef-other:SPDXRef-Package-bar", "relationshipType": "BUILD_TOOL_OF", "relatedSpdxElement": "SPDXRef-Package-1"}
+  ]
+}`
+	doc, err := unserializers.NewSPDX23().Unserialize(strings.NewReader(spdxDoc), nil, nil)
+	require.NoError(t, err)
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	out := res.(*spdx.Document)
+
+	require.Equal(t, []spdx.ExternalDocumentRef{{
+		DocumentRefID: "DocumentRef-other",
+		URI:           "https://example.com/other",
+		Checksum:      common.Checksum{Algorithm: common.SHA1, Value: "d6a770ba38583ed4bb4525bd96e50461655d2759"},
+	}}, out.ExternalDocumentReferences)
+
+	// The stub node is not written as a package
+	require.Len(t, out.Packages, 1)
+	found := false
+	for _, r := range out.Relationships {
+		if r.Relationship == common.TypeRelationshipDependsOn {
+			require.Equal(t, common.MakeDocElementID("other", "Package-foo"), r.RefB)
+			found = true
+		}
+	}
+	require.True(t, found)
+
+	// Relationships from elements of the external document are kept too
+	found = false
+	for _, r := range out.Relationships {
+		if r.Relationship == common.TypeRelationshipBuildToolOf {
+			require.Equal(t, common.MakeDocElementID("other", "Package-bar"), r.RefA)
+			require.Equal(t, common.MakeDocElementID("", "Package-1"), r.RefB)
+			found = true
+		}
+	}
+	require.True(t, found)
+
+	// The rendered document can be read back
+	var buf strings.Builder
+	require.NoError(t, NewSPDX23().Render(out, &buf, &native.RenderOptions{}, nil))
+	require.Contains(t, buf.String(), `"DocumentRef-other:SPDXRef-Package-foo"`)
+	doc2, err := unserializers.NewSPDX23().Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, doc2.Metadata.ExternalDocuments, 1)
+	require.True(t, proto.Equal(doc.Metadata.ExternalDocuments[0], doc2.Metadata.ExternalDocuments[0]))
+	require.NotNil(t, doc2.NodeList.GetNodeByID("DocumentRef-other:Package-foo"))
+	require.NotNil(t, doc2.NodeList.GetNodeByID("DocumentRef-other:Package-bar"))
+	require.Equal(t, []string{"Package-1"}, doc2.NodeList.GetEdgeByType("DocumentRef-other:Package-bar", sbom.Edge_buildTool).To)
+}
+
+func TestBuildNoAssertionValues(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{Id: "Package-1", Type: sbom.Node_PACKAGE})
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:          "Package-2",
+		Type:        sbom.Node_PACKAGE,
+		UrlDownload: sbom.NoneValue,
+		Copyright:   sbom.NoAssertionValue,
+	})
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:       "File-1",
+		Type:     sbom.Node_FILE,
+		Snippets: []*sbom.Snippet{{Id: "Snippet-1", Copyright: sbom.NoneValue}},
+	})
+
+	// Required fields are filled with NOASSERTION when unknown
+	packages, err := NewSPDX23().buildPackages(doc, &SPDX23Options{})
+	require.NoError(t, err)
+	require.Len(t, packages, 2)
+	require.Equal(t, protospdx.NOASSERTION, packages[0].PackageDownloadLocation)
+	require.Equal(t, "", packages[0].PackageCopyrightText)
+	require.Equal(t, protospdx.NONE, packages[1].PackageDownloadLocation)
+	require.Equal(t, protospdx.NOASSERTION, packages[1].PackageCopyrightText)
+
+	files, err := buildFiles(doc)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, protospdx.NOASSERTION, files[0].FileCopyrightText)
+
+	snippets := buildSnippets(doc)
+	require.Len(t, snippets, 1)
+	require.Equal(t, protospdx.NONE, snippets[0].SnippetCopyrightText)
+}
+
+func TestSerializeOmitNoAssertion(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:               "Package-1",
+		Type:             sbom.Node_PACKAGE,
+		LicenseConcluded: sbom.NoAssertionValue,
+		Copyright:        sbom.NoneValue,
+		Suppliers:        []*sbom.Person{{}},
+		VerificationCode: &sbom.VerificationCode{Value: "d6a770ba38583ed4bb4525bd96e50461655d2758"},
+	})
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:               "File-1",
+		Type:             sbom.Node_FILE,
+		LicenseConcluded: "Apache-2.0",
+		Snippets:         []*sbom.Snippet{{Id: "Snippet-1", LicenseConcluded: sbom.NoneValue}},
+	})
+
+	// By default the values are written
+	res, err := NewSPDX23().Serialize(doc, &native.SerializeOptions{}, nil)
+	require.NoError(t, err)
+	spdxDoc := res.(*spdx.Document)
+	require.Equal(t, protospdx.NOASSERTION, spdxDoc.Packages[0].PackageLicenseConcluded)
+	require.Equal(t, protospdx.NONE, spdxDoc.Packages[0].PackageCopyrightText)
+	require.NotNil(t, spdxDoc.Packages[0].PackageSupplier)
+	require.Equal(t, []string{protospdx.NOASSERTION}, spdxDoc.Packages[0].PackageLicenseInfoFromFiles)
+	require.Equal(t, protospdx.NONE, spdxDoc.Snippets[0].SnippetLicenseConcluded)
+
+	res, err = NewSPDX23().Serialize(doc, &native.SerializeOptions{OmitNoAssertion: true}, nil)
+	require.NoError(t, err)
+	spdxDoc = res.(*spdx.Document)
+	require.Equal(t, spdx.DataLicense, spdxDoc.DataLicense)
+
+	p := spdxDoc.Packages[0]
+	require.Empty(t, p.PackageLicenseConcluded)
+	require.Empty(t, p.PackageCopyrightText)
+	require.Nil(t, p.PackageSupplier)
+	require.Nil(t, p.PackageLicenseInfoFromFiles)
+	require.Equal(t, protospdx.NOASSERTION, p.PackageDownloadLocation)
+
+	require.Equal(t, "Apache-2.0", spdxDoc.Files[0].LicenseConcluded)
+	require.Equal(t, protospdx.NOASSERTION, spdxDoc.Files[0].FileCopyrightText)
+	require.Empty(t, spdxDoc.Snippets[0].SnippetLicenseConcluded)
+	require.Equal(t, protospdx.NOASSERTION, spdxDoc.Snippets[0].SnippetCopyrightText)
+}
+
+func TestDocumentCommentsRoundtrip(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: comments
+DocumentNamespace: https://example.com/comments
+DocumentComment: <text>Scope: the runtime image only.
+Build dependencies are excluded.</text>
+Creator: Tool: scanner-1.2.3
+Created: 2023-01-01T00:00:00Z
+CreatorComment: <text>Generated with:
+  scanner --deep --no-network
+
+Results were reviewed manually.</text>
+
+PackageName: one
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+`
+	documentComment := "Scope: the runtime image only.\nBuild dependencies are excluded."
+	creatorComment := "Generated with:\n  scanner --deep --no-network\n\nResults were reviewed manually."
+
+	doc, err := unserializers.NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, documentComment, doc.Metadata.Comment)
+	require.Equal(t, creatorComment, doc.Metadata.CreatorComment)
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	out := res.(*spdx.Document)
+	require.Equal(t, documentComment, out.DocumentComment)
+	require.Equal(t, creatorComment, out.CreationInfo.CreatorComment)
+
+	var buf strings.Builder
+	require.NoError(t, NewSPDX23().Render(out, &buf, &native.RenderOptions{}, nil))
+	doc2, err := unserializers.NewSPDX23().Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, documentComment, doc2.Metadata.Comment)
+	require.Equal(t, creatorComment, doc2.Metadata.CreatorComment)
+}
+
+func TestElementCommentsRoundtrip(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: comments
+DocumentNamespace: https://example.com/comments
+DocumentComment: Document comment
+Creator: Tool: scanner-1.2.3
+Created: 2023-01-01T00:00:00Z
+CreatorComment: Creator comment
+
+PackageName: one
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: true
+PackageComment: <text>Reviewed by
+the security team.</text>
+
+FileName: ./main.go
+SPDXID: SPDXRef-File-1
+FileChecksum: SHA1: d6a770ba38583ed4bb4525bd96e50461655d2758
+FileComment: File comment
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-1
+Relationship: SPDXRef-Package-1 CONTAINS SPDXRef-File-1
+RelationshipComment: Found in the source tarball
+`
+	check := func(doc *sbom.Document) {
+		t.Helper()
+		require.Equal(t, "Document comment", doc.Metadata.Comment)
+		require.Equal(t, "Creator comment", doc.Metadata.CreatorComment)
+		require.Equal(t, "Reviewed by\nthe security team.", doc.NodeList.GetNodeByID("Package-1").Comment)
+		require.Equal(t, "File comment", doc.NodeList.GetNodeByID("File-1").Comment)
+		e := doc.NodeList.GetEdgeByType("Package-1", sbom.Edge_contains)
+		require.NotNil(t, e)
+		require.Equal(t, []string{"File-1"}, e.To)
+		require.Equal(t, "Found in the source tarball", e.Comment)
+	}
+
+	doc, err := unserializers.NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+	check(doc)
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	out := res.(*spdx.Document)
+	require.Len(t, out.Relationships, 2)
+	require.Equal(t, "Found in the source tarball", out.Relationships[0].RelationshipComment)
+
+	var buf strings.Builder
+	require.NoError(t, NewSPDX23().Render(out, &buf, &native.RenderOptions{}, nil))
+	doc2, err := unserializers.NewSPDX23().Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	check(doc2)
+}
+
+func TestCreatorsRoundtrip(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: creators
+DocumentNamespace: https://example.com/creators
+LicenseListVersion: 3.21
+Creator: Tool: syft-0.95.0
+Creator: Tool: GitHub.com-Dependency-Graph
+Creator: Person: Jane Doe (jane@example.com)
+Creator: Organization: Acme
+Created: 2023-01-01T00:00:00Z
+
+PackageName: one
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+`
+	doc, err := unserializers.NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "3.21", doc.Metadata.LicenseListVersion)
+	require.Len(t, doc.Metadata.Tools, 2)
+	require.Equal(t, "syft", doc.Metadata.Tools[0].Name)
+	require.Equal(t, "0.95.0", doc.Metadata.Tools[0].Version)
+	require.Equal(t, "GitHub.com-Dependency-Graph", doc.Metadata.Tools[1].Name)
+	require.Empty(t, doc.Metadata.Tools[1].Version)
+	require.Len(t, doc.Metadata.Authors, 2)
+	require.Equal(t, "Jane Doe", doc.Metadata.Authors[0].Name)
+	require.Equal(t, "jane@example.com", doc.Metadata.Authors[0].Email)
+	require.False(t, doc.Metadata.Authors[0].IsOrg)
+	require.Equal(t, "Acme", doc.Metadata.Authors[1].Name)
+	require.True(t, doc.Metadata.Authors[1].IsOrg)
+
+	creators := []string{
+		"Tool: syft-0.95.0",
+		"Tool: GitHub.com-Dependency-Graph",
+		"Person: Jane Doe (jane@example.com)",
+		"Organization: Acme",
+	}
+	creatorStrings := func(out *spdx.Document) []string {
+		ret := []string{}
+		for _, c := range out.CreationInfo.Creators {
+			ret = append(ret, fmt.Sprintf("%s: %s", c.CreatorType, c.Creator))
+		}
+		return ret
+	}
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	out := res.(*spdx.Document)
+	require.Equal(t, "3.21", out.CreationInfo.LicenseListVersion)
+	require.Equal(t, creators, creatorStrings(out))
+
+	// protobom is only added as a creator when requested
+	res, err = NewSPDX23().Serialize(doc, &native.SerializeOptions{AddProtobomTool: true}, nil)
+	require.NoError(t, err)
+	out = res.(*spdx.Document)
+	require.Len(t, out.CreationInfo.Creators, len(creators)+1)
+	require.Equal(t, creators, creatorStrings(out)[:len(creators)])
+	require.True(t, strings.HasPrefix(creatorStrings(out)[len(creators)], "Tool: protobom-"))
+
+	// Documents without a license list version get the default one
+	doc.Metadata.LicenseListVersion = ""
+	res, err = NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, defaultLicenseListVersion, res.(*spdx.Document).CreationInfo.LicenseListVersion)
+
+	// SPDX requires a creator, protobom is added when there are none
+	doc.Metadata.Tools = nil
+	doc.Metadata.Authors = nil
+	res, err = NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	out = res.(*spdx.Document)
+	require.Len(t, out.CreationInfo.Creators, 1)
+	require.True(t, strings.HasPrefix(creatorStrings(out)[0], "Tool: protobom-"))
+}
+
+func TestChecksumsRoundtrip(t *testing.T) {
+	algorithms := []common.ChecksumAlgorithm{
+		common.SHA1, common.SHA224, common.SHA256, common.SHA384, common.SHA512,
+		common.SHA3_256, common.SHA3_384, common.SHA3_512,
+		common.BLAKE2b_256, common.BLAKE2b_384, common.BLAKE2b_512, common.BLAKE3,
+		common.MD2, common.MD4, common.MD5, common.MD6, common.ADLER32,
+	}
+	checksums := []string{}
+	for i, a := range algorithms {
+		checksums = append(checksums, fmt.Sprintf(`{"algorithm": %q, "checksumValue": "%040x"}`, a, i))
+	}
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "checksums",
+  "documentNamespace": "https://example.com/checksums",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [{
+    "SPDXID": "SPDXRef-Package-1", "name": "one", "downloadLocation": "NOASSERTION", "filesAnalyzed": false,
+    "checksums": [` + strings.Join(checksums, ",") + `]
+  }]
+}`
+	doc, err := unserializers.NewSPDX23().Unserialize(strings.NewReader(spdxDoc), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, doc.NodeList.Nodes[0].Hashes, len(algorithms))
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	packages := res.(*spdx.Document).Packages
+	require.Len(t, packages, 1)
+	expected := []common.Checksum{}
+	for i, a := range algorithms {
+		expected = append(expected, common.Checksum{Algorithm: a, Value: fmt.Sprintf("%040x", i)})
+	}
+	require.ElementsMatch(t, expected, packages[0].PackageChecksums)
+}
+
+func TestPackageLocationsRoundtrip(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: locations
+DocumentNamespace: https://example.com/locations
+Creator: Tool: scanner-1.2.3
+Created: 2023-01-01T00:00:00Z
+
+PackageName: protobom
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: git+https://github.com/bom-squad/protobom.git@v0.3.0#pkg/sbom
+PackageHomePage: https://github.com/bom-squad/protobom
+PackageSourceInfo: <text>Built from the v0.3.0 tag,
+with local patches.</text>
+FilesAnalyzed: false
+
+PackageName: unknown
+SPDXID: SPDXRef-Package-2
+PackageDownloadLocation: NONE
+PackageHomePage: NOASSERTION
+FilesAnalyzed: false
+`
+	check := func(doc *sbom.Document) {
+		t.Helper()
+		p1 := doc.NodeList.GetNodeByID("Package-1")
+		require.NotNil(t, p1)
+		require.Equal(t, "git+https://github.com/bom-squad/protobom.git@v0.3.0#pkg/sbom", p1.UrlDownload)
+		require.Equal(t, "https://github.com/bom-squad/protobom", p1.UrlHome)
+		require.Equal(t, "Built from the v0.3.0 tag,\nwith local patches.", p1.SourceInfo)
+
+		p2 := doc.NodeList.GetNodeByID("Package-2")
+		require.NotNil(t, p2)
+		require.Equal(t, sbom.NoneValue, p2.UrlDownload)
+		require.Equal(t, sbom.NoAssertionValue, p2.UrlHome)
+	}
+
+	doc, err := unserializers.NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+	check(doc)
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	var buf strings.Builder
+	require.NoError(t, NewSPDX23().Render(res, &buf, &native.RenderOptions{}, nil))
+	require.Contains(t, buf.String(), `"downloadLocation":"git+https://github.com/bom-squad/protobom.git@v0.3.0#pkg/sbom"`)
+
+	doc2, err := unserializers.NewSPDX23().Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	check(doc2)
+}
+
+func TestPackageDatesRoundtrip(t *testing.T) {
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "dates",
+  "documentNamespace": "https://example.com/dates",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-Package-1", "name": "one", "downloadLocation": "NOASSERTION", "filesAnalyzed": false,
+      "packageFileName": "one-1.0.0.tar.gz",
+      "builtDate": "2023-02-01T10:00:00Z",
+      "releaseDate": "2023-03-01T12:00:00+02:00",
+      "validUntilDate": "2025-01-01T00:00:00Z"
+    },
+    {"SPDXID": "SPDXRef-Package-2", "name": "two", "downloadLocation": "NOASSERTION", "filesAnalyzed": false}
+  ]
+}`
+	check := func(doc *sbom.Document) {
+		t.Helper()
+		p1 := doc.NodeList.GetNodeByID("Package-1")
+		require.NotNil(t, p1)
+		require.Equal(t, "one-1.0.0.tar.gz", p1.FileName)
+		require.Equal(t, time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC), p1.BuildDate.AsTime())
+		require.Equal(t, time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC), p1.ReleaseDate.AsTime())
+		require.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), p1.ValidUntilDate.AsTime())
+
+		p2 := doc.NodeList.GetNodeByID("Package-2")
+		require.NotNil(t, p2)
+		require.Empty(t, p2.FileName)
+		require.Nil(t, p2.BuildDate)
+		require.Nil(t, p2.ReleaseDate)
+		require.Nil(t, p2.ValidUntilDate)
+	}
+
+	doc, err := unserializers.NewSPDX23().Unserialize(strings.NewReader(spdxDoc), nil, nil)
+	require.NoError(t, err)
+	check(doc)
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	var buf strings.Builder
+	require.NoError(t, NewSPDX23().Render(res, &buf, &native.RenderOptions{}, nil))
+	require.Contains(t, buf.String(), `"packageFileName":"one-1.0.0.tar.gz"`)
+	require.Contains(t, buf.String(), `"builtDate":"2023-02-01T10:00:00Z"`)
+	require.Contains(t, buf.String(), `"releaseDate":"2023-03-01T10:00:00Z"`)
+	require.Contains(t, buf.String(), `"validUntilDate":"2025-01-01T00:00:00Z"`)
+	require.Equal(t, 1, strings.Count(buf.String(), `"builtDate"`))
+
+	doc2, err := unserializers.NewSPDX23().Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	check(doc2)
+}
+
+func TestSPDXDate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      *timestamppb.Timestamp
+		expected string
+	}{
+		{"nil", nil, ""},
+		{"zero", &timestamppb.Timestamp{}, ""},
+		{"invalid", &timestamppb.Timestamp{Seconds: 1, Nanos: -1}, ""},
+		{"date", timestamppb.New(time.Date(2023, 3, 1, 12, 0, 0, 500, time.FixedZone("CEST", 2*60*60))), "2023-03-01T10:00:00Z"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, spdxDate(tc.sut))
+		})
+	}
+}
+
+func TestAttributionTextsRoundtrip(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: attribution
+DocumentNamespace: https://example.com/attribution
+Creator: Tool: scanner-1.2.3
+Created: 2023-01-01T00:00:00Z
+
+PackageName: one
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PackageAttributionText: <text>This product includes software
+developed by Acme.</text>
+PackageAttributionText: <text>Portions copyright The BOM Squad</text>
+
+FileName: ./NOTICE
+SPDXID: SPDXRef-File-1
+FileChecksum: SHA1: d6a770ba38583ed4bb4525bd96e50461655d2758
+FileAttributionText: <text>Includes code from libfoo</text>
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-1
+Relationship: SPDXRef-Package-1 CONTAINS SPDXRef-File-1
+`
+	check := func(doc *sbom.Document) {
+		t.Helper()
+		require.Equal(t, []string{
+			"This product includes software\ndeveloped by Acme.",
+			"Portions copyright The BOM Squad",
+		}, doc.NodeList.GetNodeByID("Package-1").Attribution)
+		require.Equal(t, []string{"Includes code from libfoo"}, doc.NodeList.GetNodeByID("File-1").Attribution)
+	}
+
+	doc, err := unserializers.NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+	check(doc)
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	var buf strings.Builder
+	require.NoError(t, NewSPDX23().Render(res, &buf, &native.RenderOptions{}, nil))
+	doc2, err := unserializers.NewSPDX23().Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	check(doc2)
+
+	// CycloneDX keeps the texts as component properties
+	cdxSerializer := NewCDX("1.5", "json")
+	cdxDoc, err := cdxSerializer.Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []cdx.Property{
+		{Name: cdxformats.PropertyAttributionText, Value: "This product includes software\ndeveloped by Acme."},
+		{Name: cdxformats.PropertyAttributionText, Value: "Portions copyright The BOM Squad"},
+	}, *cdxDoc.(*cdx.BOM).Metadata.Component.Properties)
+
+	buf.Reset()
+	require.NoError(t, cdxSerializer.Render(cdxDoc, &buf, &native.RenderOptions{}, nil))
+	doc3, err := unserializers.NewCDX("1.5", "json").Unserialize(strings.NewReader(buf.String()), nil, nil)
+	require.NoError(t, err)
+	check(doc3)
+}
+
+func TestPropertiesToSPDX(t *testing.T) {
+	doc := sbom.NewDocument(sbom.WithTimestamp(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+	doc.Metadata.Properties = []*sbom.Property{{Name: "ci:pipeline", Value: "release"}}
+	doc.NodeList.AddRootNode(&sbom.Node{
+		Id:   "Package-1",
+		Name: "one",
+		Properties: []*sbom.Property{
+			{Name: "syft:package:foundBy", Value: "javascript-lock-cataloger"},
+			{Name: "syft:package:foundBy", Value: "sbom-cataloger"},
+			{Name: ""},
+		},
+	})
+
+	res, err := NewSPDX23().Serialize(doc, nil, nil)
+	require.NoError(t, err)
+	spdxDoc := res.(*spdx.Document)
+
+	require.Len(t, spdxDoc.Annotations, 1)
+	require.Equal(t, "protobom:property:ci:pipeline=release", spdxDoc.Annotations[0].AnnotationComment)
+	require.Equal(t, "DOCUMENT", string(spdxDoc.Annotations[0].AnnotationSPDXIdentifier.ElementRefID))
+
+	annotations := spdxDoc.Packages[0].Annotations
+	require.Len(t, annotations, 2)
+	for i, comment := range []string{
+		"protobom:property:syft:package:foundBy=javascript-lock-cataloger",
+		"protobom:property:syft:package:foundBy=sbom-cataloger",
+	} {
+		require.Equal(t, comment, annotations[i].AnnotationComment)
+		require.Equal(t, "OTHER", annotations[i].AnnotationType)
+		require.Equal(t, "Tool", annotations[i].Annotator.AnnotatorType)
+		require.Equal(t, "2023-01-01T00:00:00Z", annotations[i].AnnotationDate)
+		require.Equal(t, "Package-1", string(annotations[i].AnnotationSPDXIdentifier.ElementRefID))
+	}
+}
+
+func TestRelationshipDirection(t *testing.T) {
+	doc := sbom.NewDocument()
+	for _, id := range []string{"app", "lib", "util"} {
+		doc.NodeList.AddNode(&sbom.Node{Id: id, Type: sbom.Node_PACKAGE, Name: id})
+	}
+	doc.NodeList.RootElements = []string{"app"}
+	doc.NodeList.Edges = []*sbom.Edge{
+		{Type: sbom.Edge_contains, From: "app", To: []string{"lib"}},
+		{Type: sbom.Edge_dependsOn, From: "lib", To: []string{"util"}, Inverse: true},
+		{Type: sbom.Edge_contained_by, From: "util", To: []string{"app"}},
+	}
+
+	render := func(opts *SPDX23Options) string {
+		t.Helper()
+		res, err := NewSPDX23().Serialize(doc, nil, opts)
+		require.NoError(t, err)
+		var buf strings.Builder
+		require.NoError(t, NewSPDX23().Render(res, &buf, &native.RenderOptions{}, nil))
+		return buf.String()
+	}
+
+	// Relationships are written in their canonical direction by default
+	out := render(&SPDX23Options{})
+	require.Contains(t, out, `"spdxElementId":"SPDXRef-app","relatedSpdxElement":"SPDXRef-lib","relationshipType":"CONTAINS"`)
+	require.Contains(t, out, `"spdxElementId":"SPDXRef-lib","relatedSpdxElement":"SPDXRef-util","relationshipType":"DEPENDS_ON"`)
+	require.Contains(t, out, `"spdxElementId":"SPDXRef-app","relatedSpdxElement":"SPDXRef-util","relationshipType":"CONTAINS"`)
+	require.NotContains(t, out, `DEPENDENCY_OF`)
+	require.NotContains(t, out, `CONTAINED_BY`)
+
+	// The direction of the original statements can be kept
+	out = render(&SPDX23Options{PreserveRelationshipDirection: true})
+	require.Contains(t, out, `"spdxElementId":"SPDXRef-app","relatedSpdxElement":"SPDXRef-lib","relationshipType":"CONTAINS"`)
+	require.Contains(t, out, `"spdxElementId":"SPDXRef-util","relatedSpdxElement":"SPDXRef-lib","relationshipType":"DEPENDENCY_OF"`)
+	require.Contains(t, out, `"spdxElementId":"SPDXRef-util","relatedSpdxElement":"SPDXRef-app","relationshipType":"CONTAINED_BY"`)
+	require.NotContains(t, out, `DEPENDS_ON`)
+}
@@ -0,0 +1,33 @@
+package serializers
+
+import (
+	"runtime/debug"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+// protobomModule is the path of the protobom go module
+const protobomModule = "github.com/bom-squad/protobom"
+
+// protobomVersion returns the version of protobom recorded when the
+// serializers add it to the tools of a document. It is read from the build
+// information of the binary, where protobom is either the main module or one
+// of its dependencies. Development builds fall back to the version info set
+// when building protobom.
+func protobomVersion() string {
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		modules := append([]*debug.Module{&bi.Main}, bi.Deps...)
+		for _, m := range modules {
+			if m.Path != protobomModule {
+				continue
+			}
+			if m.Replace != nil {
+				m = m.Replace
+			}
+			if m.Version != "" && m.Version != "(devel)" {
+				return m.Version
+			}
+		}
+	}
+	return version.GetVersionInfo().GitVersion
+}
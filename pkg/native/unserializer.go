@@ -13,4 +13,35 @@ type Unserializer interface {
 	Unserialize(io.Reader, *UnserializeOptions, interface{}) (*sbom.Document, error)
 }
 
-type UnserializeOptions struct{}
+type UnserializeOptions struct {
+	// Strict makes the unserializers return an error when a value in the
+	// source document cannot be mapped to a known protobom enum instead
+	// of coercing it to a default value.
+	Strict bool
+
+	// AllowMissingRoots makes the unserializers drop the root elements that
+	// reference elements not defined in the document, logging a warning,
+	// instead of returning an error.
+	AllowMissingRoots bool
+
+	// DisallowUnknownFields makes the unserializers return an error when
+	// the document has fields not defined in the schema of its format. By
+	// default unknown fields are ignored.
+	DisallowUnknownFields bool
+
+	// Stream makes the unserializers that support it parse the document
+	// element by element instead of loading it whole in memory. Currently
+	// only the SPDX 2.3 JSON unserializer supports streaming.
+	Stream bool
+
+	// StreamThreshold enables streaming for documents of at least this
+	// size in bytes. The size is only known when the input can seek, other
+	// inputs are streamed only when Stream is set. Zero disables it.
+	StreamThreshold int64
+
+	// StrictIDs makes the unserializers return a DiagnosticsError when the
+	// identifiers of the document elements are invalid, duplicated or
+	// referenced without being defined. By default the problems are logged
+	// as warnings and duplicated identifiers are renamed.
+	StrictIDs bool
+}
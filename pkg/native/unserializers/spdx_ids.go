@@ -0,0 +1,268 @@
+package unserializers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	protospdx "github.com/bom-squad/protobom/pkg/formats/spdx"
+	"github.com/bom-squad/protobom/pkg/native"
+	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/sirupsen/logrus"
+)
+
+// spdxIDStringPattern matches the part of the SPDX identifiers after their
+// prefix, only letters, numbers, dots and dashes are allowed
+var spdxIDStringPattern = regexp.MustCompile(`^[a-zA-Z0-9.\-]+$`)
+
+// spdxIDRef is an SPDX identifier, as written in the document, and where it
+// was found
+type spdxIDRef struct {
+	id       string
+	location string
+}
+
+// spdxIDIndex lists the identifiers of the elements defined in an SPDX
+// document and the references to them
+type spdxIDIndex struct {
+	document    spdxIDRef
+	definitions []spdxIDRef
+	references  []spdxIDRef
+}
+
+// spdxIDView reads the identifiers of an SPDX JSON document, and those
+// referenced by its elements, to locate the problems with them
+type spdxIDView struct {
+	SPDXID            string                `json:"SPDXID"`
+	DocumentDescribes []string              `json:"documentDescribes"`
+	Packages          []spdxPackageIDs      `json:"packages"`
+	Files             []spdxElementIDs      `json:"files"`
+	Snippets          []spdxSnippetIDs      `json:"snippets"`
+	Relationships     []spdxRelationshipIDs `json:"relationships"`
+}
+
+type spdxElementIDs struct {
+	SPDXID string `json:"SPDXID"`
+}
+
+type spdxPackageIDs struct {
+	SPDXID   string   `json:"SPDXID"`
+	HasFiles []string `json:"hasFiles"`
+}
+
+type spdxSnippetIDs struct {
+	SPDXID          string `json:"SPDXID"`
+	SnippetFromFile string `json:"snippetFromFile"`
+}
+
+type spdxRelationshipIDs struct {
+	SpdxElementID      string `json:"spdxElementId"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
+
+// index returns the identifiers in the view, located by their JSON pointer
+func (v *spdxIDView) index() *spdxIDIndex {
+	idx := &spdxIDIndex{document: spdxIDRef{id: v.SPDXID, location: "/SPDXID"}}
+	for i, id := range v.DocumentDescribes {
+		idx.reference(id, fmt.Sprintf("/documentDescribes/%d", i))
+	}
+	for i, p := range v.Packages {
+		idx.define(p.SPDXID, fmt.Sprintf("/packages/%d/SPDXID", i))
+		for j, f := range p.HasFiles {
+			idx.reference(f, fmt.Sprintf("/packages/%d/hasFiles/%d", i, j))
+		}
+	}
+	for i, f := range v.Files {
+		idx.define(f.SPDXID, fmt.Sprintf("/files/%d/SPDXID", i))
+	}
+	for i, s := range v.Snippets {
+		idx.define(s.SPDXID, fmt.Sprintf("/snippets/%d/SPDXID", i))
+		idx.reference(s.SnippetFromFile, fmt.Sprintf("/snippets/%d/snippetFromFile", i))
+	}
+	for i, r := range v.Relationships {
+		idx.reference(r.SpdxElementID, fmt.Sprintf("/relationships/%d/spdxElementId", i))
+		idx.reference(r.RelatedSpdxElement, fmt.Sprintf("/relationships/%d/relatedSpdxElement", i))
+	}
+	return idx
+}
+
+func (idx *spdxIDIndex) define(id, location string) {
+	idx.definitions = append(idx.definitions, spdxIDRef{id: id, location: location})
+}
+
+func (idx *spdxIDIndex) reference(id, location string) {
+	idx.references = append(idx.references, spdxIDRef{id: id, location: location})
+}
+
+// jsonIDIndex reads the identifiers of an SPDX JSON document
+func jsonIDIndex(data []byte) (*spdxIDIndex, error) {
+	v := &spdxIDView{}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("reading SPDX identifiers: %w", err)
+	}
+	return v.index(), nil
+}
+
+// tagValueIDIndex reads the identifiers of an SPDX tag-value document,
+// located by their line number. The first SPDXID tag is the identifier of
+// the document.
+func tagValueIDIndex(data []byte) *spdxIDIndex {
+	idx := &spdxIDIndex{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	inText := false
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		location := fmt.Sprintf("line %d", n)
+
+		// Skip the contents of multiline <text> values
+		if inText {
+			inText = !strings.Contains(line, "</text>")
+			continue
+		}
+		tag, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if strings.Contains(value, "<text>") && !strings.Contains(value, "</text>") {
+			inText = true
+			continue
+		}
+
+		switch strings.TrimSpace(tag) {
+		case "SPDXID":
+			if idx.document.location == "" {
+				idx.document = spdxIDRef{id: value, location: location}
+				continue
+			}
+			idx.define(value, location)
+		case "SnippetSPDXID":
+			idx.define(value, location)
+		case "SnippetFromFileSPDXID", "SPDXREF":
+			idx.reference(value, location)
+		case "Relationship":
+			fields := strings.Fields(value)
+			if len(fields) != 3 {
+				continue
+			}
+			idx.reference(fields[0], location)
+			idx.reference(fields[2], location)
+		}
+	}
+	return idx
+}
+
+// validateSPDXIDs checks the identifiers in the index and returns a
+// diagnostic for each one that is malformed, defined more than once or
+// referenced without being defined in the document. References to elements
+// of external documents are not checked beyond their syntax.
+func validateSPDXIDs(idx *spdxIDIndex) []native.Diagnostic {
+	diags := []native.Diagnostic{}
+	add := func(ref spdxIDRef, format string, args ...any) {
+		diags = append(diags, native.Diagnostic{Location: ref.location, Message: fmt.Sprintf(format, args...)})
+	}
+
+	defined := map[string]spdxIDRef{}
+	if idx.document.id != "" {
+		defined[idx.document.id] = idx.document
+	}
+	for _, def := range idx.definitions {
+		if !validElementID(def.id) {
+			add(def, "invalid SPDX identifier %q", def.id)
+		}
+		if first, ok := defined[def.id]; ok {
+			add(def, "duplicate SPDX identifier %q, first defined at %s", def.id, first.location)
+			continue
+		}
+		defined[def.id] = def
+	}
+
+	for _, ref := range idx.references {
+		switch {
+		case ref.id == "" || ref.id == protospdx.NONE || ref.id == protospdx.NOASSERTION:
+			continue
+		case strings.HasPrefix(ref.id, protospdx.DocumentRefPrefix):
+			docRef, elementID, _ := strings.Cut(ref.id, ":")
+			if !validDocumentRef(docRef) || (elementID != "" && !validElementID(elementID)) {
+				add(ref, "invalid SPDX identifier %q", ref.id)
+			}
+		default:
+			// Malformed identifiers are reported where they are defined
+			if _, ok := defined[ref.id]; ok {
+				continue
+			}
+			if !validElementID(ref.id) {
+				add(ref, "invalid SPDX identifier %q", ref.id)
+				continue
+			}
+			add(ref, "reference to undefined element %q", ref.id)
+		}
+	}
+	return diags
+}
+
+// validElementID returns true if id is a well formed SPDXRef identifier
+func validElementID(id string) bool {
+	return strings.HasPrefix(id, protospdx.SPDXRefPrefix) && spdxIDStringPattern.MatchString(strings.TrimPrefix(id, protospdx.SPDXRefPrefix))
+}
+
+// validDocumentRef returns true if id is a well formed DocumentRef
+func validDocumentRef(id string) bool {
+	return strings.HasPrefix(id, protospdx.DocumentRefPrefix) && spdxIDStringPattern.MatchString(strings.TrimPrefix(id, protospdx.DocumentRefPrefix))
+}
+
+// checkIDs validates the identifiers of the document. The problems found are
+// returned as a DiagnosticsError when the options ask for strict IDs,
+// otherwise they are logged and the nodes with duplicated identifiers are
+// renamed so each node of the document can be addressed.
+func checkIDs(nl *sbom.NodeList, idx *spdxIDIndex, opts *native.UnserializeOptions) error {
+	if idx == nil {
+		return nil
+	}
+	diags := validateSPDXIDs(idx)
+	if len(diags) == 0 {
+		return nil
+	}
+	if opts != nil && opts.StrictIDs {
+		return &native.DiagnosticsError{Diagnostics: diags}
+	}
+	for _, d := range diags {
+		logrus.Warnf("SPDX identifier problem at %s", d)
+	}
+	renameDuplicateNodes(nl)
+	return nil
+}
+
+// renameDuplicateNodes gives a new ID to the nodes with the same ID as a node
+// before them. Duplicates are renamed adding the number of the occurrence
+// to the ID (Package-1, Package-1-2, Package-1-3...), skipping the IDs
+// already used in the document. Edges keep pointing to the first node.
+func renameDuplicateNodes(nl *sbom.NodeList) {
+	used := map[string]struct{}{}
+	for _, n := range nl.Nodes {
+		used[n.Id] = struct{}{}
+	}
+
+	seen := map[string]int{}
+	for _, n := range nl.Nodes {
+		seen[n.Id]++
+		if seen[n.Id] == 1 {
+			continue
+		}
+		id := n.Id
+		newID := ""
+		for i := seen[id]; ; i++ {
+			newID = fmt.Sprintf("%s-%d", id, i)
+			if _, ok := used[newID]; !ok {
+				break
+			}
+		}
+		logrus.Warnf("renaming duplicate node %s to %s", id, newID)
+		used[newID] = struct{}{}
+		n.Id = newID
+	}
+}
@@ -0,0 +1,143 @@
+package unserializers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bom-squad/protobom/pkg/native"
+	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/stretchr/testify/require"
+)
+
+// brokenIDsDocument has a duplicated package, an identifier with illegal
+// characters and relationships to undefined elements
+const brokenIDsDocument = `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "broken",
+  "documentNamespace": "https://example.com/broken",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-Package-1", "name": "one", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-Package-1", "name": "one again", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-Package-1-2", "name": "two", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-Package_3", "name": "three", "downloadLocation": "NOASSERTION"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-1"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Package_3"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Nope"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "DocumentRef-other:SPDXRef-Package-foo"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "NOASSERTION"}
+  ]
+}`
+
+const brokenIDsTagValue = `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: broken
+DocumentNamespace: https://example.com/broken
+Creator: Tool: test
+Created: 2023-01-01T00:00:00Z
+
+PackageName: one
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PackageComment: <text>
+SPDXID: SPDXRef-Not-An-Element
+</text>
+
+PackageName: one again
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-1
+Relationship: SPDXRef-Package-1 DEPENDS_ON SPDXRef-Nope
+`
+
+func TestValidateSPDXIDs(t *testing.T) {
+	idx, err := jsonIDIndex([]byte(brokenIDsDocument))
+	require.NoError(t, err)
+	require.Equal(t, []native.Diagnostic{
+		{Location: "/packages/1/SPDXID", Message: `duplicate SPDX identifier "SPDXRef-Package-1", first defined at /packages/0/SPDXID`},
+		{Location: "/packages/3/SPDXID", Message: `invalid SPDX identifier "SPDXRef-Package_3"`},
+		{Location: "/relationships/2/relatedSpdxElement", Message: `reference to undefined element "SPDXRef-Nope"`},
+	}, validateSPDXIDs(idx))
+
+	require.Equal(t, []native.Diagnostic{
+		{Location: "line 18", Message: `duplicate SPDX identifier "SPDXRef-Package-1", first defined at line 10`},
+		{Location: "line 23", Message: `reference to undefined element "SPDXRef-Nope"`},
+	}, validateSPDXIDs(tagValueIDIndex([]byte(brokenIDsTagValue))))
+
+	for _, tc := range []struct {
+		ref     spdxIDRef
+		message string
+	}{
+		{spdxIDRef{id: "Package-1", location: "/a"}, `invalid SPDX identifier "Package-1"`},
+		{spdxIDRef{id: "DocumentRef-x y:SPDXRef-a", location: "/b"}, `invalid SPDX identifier "DocumentRef-x y:SPDXRef-a"`},
+		{spdxIDRef{id: "DocumentRef-x:SPDXRef-a/b", location: "/c"}, `invalid SPDX identifier "DocumentRef-x:SPDXRef-a/b"`},
+	} {
+		diags := validateSPDXIDs(&spdxIDIndex{references: []spdxIDRef{tc.ref}})
+		require.Equal(t, []native.Diagnostic{{Location: tc.ref.location, Message: tc.message}}, diags)
+	}
+}
+
+func TestRenameDuplicateNodes(t *testing.T) {
+	nl := &sbom.NodeList{Nodes: []*sbom.Node{
+		{Id: "a"}, {Id: "b"}, {Id: "a"}, {Id: "a-2"}, {Id: "a"}, {Id: "b"},
+	}}
+	renameDuplicateNodes(nl)
+	ids := []string{}
+	for _, n := range nl.Nodes {
+		ids = append(ids, n.Id)
+	}
+	require.Equal(t, []string{"a", "b", "a-3", "a-2", "a-4", "b-2"}, ids)
+}
+
+func TestUnserializeInvalidIDs(t *testing.T) {
+	for name, tc := range map[string]struct {
+		unserializer *SPDX23
+		input        string
+		opts         native.UnserializeOptions
+		renamed      string
+		locations    []string
+	}{
+		"json": {
+			NewSPDX23(), brokenIDsDocument, native.UnserializeOptions{}, "Package-1-3",
+			[]string{"/packages/1/SPDXID", "/packages/3/SPDXID", "/relationships/2/relatedSpdxElement"},
+		},
+		"json stream": {
+			NewSPDX23(), brokenIDsDocument, native.UnserializeOptions{Stream: true}, "Package-1-3",
+			[]string{"/packages/1/SPDXID", "/packages/3/SPDXID", "/relationships/2/relatedSpdxElement"},
+		},
+		"tag-value": {
+			NewSPDX23TV(), brokenIDsTagValue, native.UnserializeOptions{}, "Package-1-2",
+			[]string{"line 18", "line 23"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// By default the duplicates are renamed, skipping the IDs in use
+			opts := tc.opts
+			doc, err := tc.unserializer.Unserialize(strings.NewReader(tc.input), &opts, nil)
+			require.NoError(t, err)
+			require.Equal(t, "one", doc.NodeList.GetNodeByID("Package-1").Name)
+			require.Equal(t, "one again", doc.NodeList.GetNodeByID(tc.renamed).Name)
+			require.Equal(t, []string{"Package-1"}, doc.NodeList.RootElements)
+
+			opts.StrictIDs = true
+			_, err = tc.unserializer.Unserialize(strings.NewReader(tc.input), &opts, nil)
+			require.Error(t, err)
+			var diagsErr *native.DiagnosticsError
+			require.True(t, errors.As(err, &diagsErr))
+			locations := []string{}
+			for _, d := range diagsErr.Diagnostics {
+				locations = append(locations, d.Location)
+			}
+			require.Equal(t, tc.locations, locations)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package unserializers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// checkUnknownFields reads a JSON document from r and checks that all its
+// fields are defined in schema, the type of the format document. extra lists
+// the fields of the format that are decoded by custom unmarshallers of the
+// schema types, indexed by type. It returns a reader with the contents of r
+// to parse the document afterwards.
+//
+// The fields are checked by walking the document along the struct types as
+// json.Decoder.DisallowUnknownFields does not reach into the types of the
+// format libraries that implement their own unmarshalling.
+func checkUnknownFields(r io.Reader, schema interface{}, extra map[reflect.Type][]string) (io.Reader, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading document: %w", err)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decoding json: %w", err)
+	}
+
+	if unknown := unknownFields(doc, reflect.TypeOf(schema), extra, ""); len(unknown) > 0 {
+		return nil, fmt.Errorf("strict mode: unknown fields in document: %s", strings.Join(unknown, ", "))
+	}
+
+	return bytes.NewReader(data), nil
+}
+
+// unknownFields returns the paths of the fields in the decoded JSON value
+// that are not defined in type t or listed in extra
+func unknownFields(value interface{}, t reflect.Type, extra map[reflect.Type][]string, path string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	ret := []string{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for _, k := range keys {
+				ft, ok := lookupField(fields, k)
+				if !ok {
+					if !slices.Contains(extra[t], k) {
+						ret = append(ret, path+"."+k)
+					}
+					continue
+				}
+				ret = append(ret, unknownFields(v[k], ft, extra, path+"."+k)...)
+			}
+		case reflect.Map:
+			for _, k := range keys {
+				ret = append(ret, unknownFields(v[k], t.Elem(), extra, path+"."+k)...)
+			}
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return ret
+		}
+		for i := range v {
+			ret = append(ret, unknownFields(v[i], t.Elem(), extra, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return ret
+}
+
+// jsonFields returns the types of the fields of struct t indexed by their
+// JSON names, including those of its embedded structs
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for k, v := range jsonFields(ft) {
+				if _, ok := fields[k]; !ok {
+					fields[k] = v
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// lookupField returns the type of a field by its JSON name. As in
+// encoding/json, names are matched case insensitively when there is no
+// exact match.
+func lookupField(fields map[string]reflect.Type, name string) (reflect.Type, bool) {
+	if t, ok := fields[name]; ok {
+		return t, true
+	}
+	for k, t := range fields {
+		if strings.EqualFold(k, name) {
+			return t, true
+		}
+	}
+	return nil, false
+}
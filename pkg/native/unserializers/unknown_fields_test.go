@@ -0,0 +1,79 @@
+package unserializers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	spdx23 "github.com/spdx/tools-golang/spdx/v2/v2_3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUnknownFields(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    string
+		schema  interface{}
+		unknown string
+	}{
+		{
+			name:   "known fields",
+			data:   `{"bomFormat": "CycloneDX", "components": [{"name": "a", "hashes": [{"alg": "SHA-1", "content": "x"}]}]}`,
+			schema: new(cdx.BOM),
+		},
+		{
+			name:    "unknown top level field",
+			data:    `{"bomFormat": "CycloneDX", "extension": true}`,
+			schema:  new(cdx.BOM),
+			unknown: ".extension",
+		},
+		{
+			name:    "unknown nested field",
+			data:    `{"components": [{"name": "a"}, {"name": "b", "hashes": [{"alg": "MD5", "salt": "x"}]}]}`,
+			schema:  new(cdx.BOM),
+			unknown: ".components[1].hashes[0].salt",
+		},
+		{
+			name:    "unknown field in a type with custom unmarshalling",
+			data:    `{"packages": [{"SPDXID": "SPDXRef-a", "name": "a", "extension": true}]}`,
+			schema:  new(spdx23.Document),
+			unknown: ".packages[0].extension",
+		},
+		{
+			name:   "fields read by custom unmarshallers",
+			data:   `{"documentDescribes": ["SPDXRef-a"], "packages": [{"SPDXID": "SPDXRef-a", "hasFiles": []}]}`,
+			schema: new(spdx23.Document),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := checkUnknownFields(strings.NewReader(tc.data), tc.schema, spdxExtraFields)
+			if tc.unknown != "" {
+				require.Error(t, err)
+				require.ErrorContains(t, err, tc.unknown)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, r)
+		})
+	}
+}
+
+func TestCheckUnknownFieldsConformance(t *testing.T) {
+	for dir, schema := range map[string]interface{}{
+		"cyclonedx": new(cdx.BOM),
+		"spdx":      new(spdx23.Document),
+	} {
+		files, err := filepath.Glob(filepath.Join("../../../test/conformance/testdata", dir, "*", "json", "*.json"))
+		require.NoError(t, err)
+		require.NotEmpty(t, files)
+		for _, path := range files {
+			f, err := os.Open(path)
+			require.NoError(t, err)
+			_, err = checkUnknownFields(f, schema, spdxExtraFields)
+			f.Close()
+			require.NoError(t, err, path)
+		}
+	}
+}
@@ -1,14 +1,22 @@
 package unserializers
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/bom-squad/protobom/pkg/formats"
 	cdxformats "github.com/bom-squad/protobom/pkg/formats/cyclonedx"
+	protospdx "github.com/bom-squad/protobom/pkg/formats/spdx"
+	"github.com/bom-squad/protobom/pkg/license"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
 
 	"github.com/bom-squad/protobom/pkg/native"
 	"github.com/bom-squad/protobom/pkg/sbom"
@@ -28,26 +36,69 @@ func NewCDX(version, encoding string) *CDX {
 	}
 }
 
+// yamlToJSON reads a YAML document from r and returns it encoded as JSON
+func yamlToJSON(r io.Reader) (io.Reader, error) {
+	var doc interface{}
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decoding yaml: %w", err)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("converting yaml to json: %w", err)
+	}
+	return bytes.NewReader(data), nil
+}
+
 // Unserialize reads datq data from io.Reader r and parses it as a CycloneDX
 // document. If successful returns a protobom Document loaded with the SBOM data.
-func (u *CDX) Unserialize(r io.Reader, _ *native.UnserializeOptions, _ interface{}) (*sbom.Document, error) {
+func (u *CDX) Unserialize(r io.Reader, opts *native.UnserializeOptions, _ interface{}) (*sbom.Document, error) {
 	bom := new(cdx.BOM)
 
-	encoding, err := cdxformats.ParseEncoding(u.encoding)
+	// YAML documents are converted to JSON, the CycloneDX library does
+	// not read them
+	encodingName := u.encoding
+	if encodingName == formats.YAML {
+		var err error
+		r, err = yamlToJSON(r)
+		if err != nil {
+			return nil, err
+		}
+		encodingName = formats.JSON
+	}
+
+	encoding, err := cdxformats.ParseEncoding(encodingName)
 	if err != nil {
 		return nil, err
 	}
+
+	// Unknown fields are only checked in JSON documents as encoding/xml
+	// has no way to reject unknown elements.
+	if opts != nil && opts.DisallowUnknownFields && encoding == cdx.BOMFileFormatJSON {
+		r, err = checkUnknownFields(r, new(cdx.BOM), nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	decoder := cdx.NewBOMDecoder(r, encoding)
 	if err := decoder.Decode(bom); err != nil {
 		return nil, fmt.Errorf("decoding cyclonedx: %w", err)
 	}
 
+	if opts != nil && opts.Strict {
+		if errs := u.unmappedValues(bom); len(errs) > 0 {
+			return nil, fmt.Errorf("strict mode: values not supported by protobom: %w", errors.Join(errs...))
+		}
+	}
+
 	md := &sbom.Metadata{
 		Id:      bom.SerialNumber,
 		Version: fmt.Sprintf("%d", bom.Version),
 		Date:    &timestamppb.Timestamp{},
 		Tools:   []*sbom.Tool{},
 		Authors: []*sbom.Person{},
+
+		ExternalReferences: u.unserializeExternalReferences(bom.ExternalReferences),
 	}
 
 	doc := &sbom.Document{
@@ -58,6 +109,7 @@ func (u *CDX) Unserialize(r io.Reader, _ *native.UnserializeOptions, _ interface
 	cc := 0
 
 	if bom.Metadata != nil {
+		u.unserializeMetadata(bom.Metadata, md)
 		if bom.Metadata.Lifecycles != nil {
 			for _, lc := range *bom.Metadata.Lifecycles {
 				lc := lc
@@ -105,9 +157,203 @@ func (u *CDX) Unserialize(r io.Reader, _ *native.UnserializeOptions, _ interface
 		}
 	}
 
+	u.unserializeDependencies(bom.Dependencies, doc.NodeList)
+	md.Compositions = u.unserializeCompositions(bom.Compositions, doc.NodeList)
+
 	return doc, nil
 }
 
+// unserializeMetadata reads the creation date, tools, authors and properties
+// of the CycloneDX metadata into the protobom document metadata
+func (u *CDX) unserializeMetadata(cdxMetadata *cdx.Metadata, md *sbom.Metadata) {
+	if cdxMetadata.Timestamp != "" {
+		t, err := time.Parse(time.RFC3339, cdxMetadata.Timestamp)
+		if err != nil {
+			logrus.Warnf("invalid metadata timestamp %q: %v", cdxMetadata.Timestamp, err)
+		} else {
+			md.Date = timestamppb.New(t)
+		}
+	}
+
+	if cdxMetadata.Tools != nil {
+		if cdxMetadata.Tools.Tools != nil {
+			for _, t := range *cdxMetadata.Tools.Tools {
+				md.Tools = append(md.Tools, &sbom.Tool{Name: t.Name, Version: t.Version, Vendor: t.Vendor})
+			}
+		}
+		// CycloneDX 1.5 describes the tools as components, their supplier
+		// is the closest field to the tool vendor
+		if cdxMetadata.Tools.Components != nil {
+			for _, c := range *cdxMetadata.Tools.Components {
+				t := &sbom.Tool{Name: c.Name, Version: c.Version, Vendor: c.Author}
+				if c.Supplier != nil && c.Supplier.Name != "" {
+					t.Vendor = c.Supplier.Name
+				}
+				md.Tools = append(md.Tools, t)
+			}
+		}
+	}
+
+	if cdxMetadata.Authors != nil {
+		for _, a := range *cdxMetadata.Authors {
+			md.Authors = append(md.Authors, &sbom.Person{Name: a.Name, Email: a.Email, Phone: a.Phone})
+		}
+	}
+
+	if cdxMetadata.Properties != nil {
+		for _, p := range *cdxMetadata.Properties {
+			md.Properties = append(md.Properties, &sbom.Property{Name: p.Name, Value: p.Value})
+		}
+	}
+}
+
+// unserializeDependencies adds a dependsOn edge for each of the entries of
+// the dependency graph. References to components not found in the document
+// are dropped.
+func (u *CDX) unserializeDependencies(dependencies *[]cdx.Dependency, nl *sbom.NodeList) {
+	if dependencies == nil {
+		return
+	}
+	index := map[string]struct{}{}
+	for _, n := range nl.Nodes {
+		index[n.Id] = struct{}{}
+	}
+
+	for _, d := range *dependencies {
+		if d.Dependencies == nil || len(*d.Dependencies) == 0 {
+			continue
+		}
+		if _, ok := index[d.Ref]; !ok {
+			logrus.Warnf("dependencies of unknown component %q, dropping them", d.Ref)
+			continue
+		}
+		edge := &sbom.Edge{Type: sbom.Edge_dependsOn, From: d.Ref, To: []string{}}
+		for _, ref := range *d.Dependencies {
+			if _, ok := index[ref]; !ok {
+				logrus.Warnf("%s depends on unknown component %q, dropping it", d.Ref, ref)
+				continue
+			}
+			edge.To = append(edge.To, ref)
+		}
+		if len(edge.To) > 0 {
+			nl.AddEdge(edge)
+		}
+	}
+}
+
+// unserializeCompositions reads the compositions of the document.
+// References to components not found in the document are dropped and
+// unknown aggregates are read as not specified.
+func (u *CDX) unserializeCompositions(compositions *[]cdx.Composition, nl *sbom.NodeList) []*sbom.Composition {
+	if compositions == nil {
+		return nil
+	}
+	index := map[string]struct{}{}
+	for _, n := range nl.Nodes {
+		index[n.Id] = struct{}{}
+	}
+	refs := func(c *cdx.Composition, field string, list *[]cdx.BOMReference) []string {
+		ret := []string{}
+		if list == nil {
+			return ret
+		}
+		for _, ref := range *list {
+			if _, ok := index[string(ref)]; !ok {
+				logrus.Warnf("composition %q %s references unknown component %q, dropping it", c.BOMRef, field, ref)
+				continue
+			}
+			ret = append(ret, string(ref))
+		}
+		return ret
+	}
+
+	ret := []*sbom.Composition{}
+	for i := range *compositions {
+		c := &(*compositions)[i]
+		aggregate, ok := u.cdxAggregateToProtobom(c.Aggregate)
+		if !ok {
+			logrus.Warnf("unknown composition aggregate %q, reading it as not specified", c.Aggregate)
+		}
+		ret = append(ret, &sbom.Composition{
+			Id:           c.BOMRef,
+			Aggregate:    aggregate,
+			Assemblies:   refs(c, "assemblies", c.Assemblies),
+			Dependencies: refs(c, "dependencies", c.Dependencies),
+		})
+	}
+	return ret
+}
+
+// unmappedValues checks the CycloneDX document for values that cannot be
+// mapped to a known protobom enum and returns an error for each of them.
+func (u *CDX) unmappedValues(bom *cdx.BOM) []error {
+	errs := []error{}
+	checkHashes := func(ref string, hashes *[]cdx.Hash) {
+		if hashes == nil {
+			return
+		}
+		for _, h := range *hashes {
+			if u.cdxHashAlgoToProtobomAlgo(h.Algorithm) == sbom.HashAlgorithm_UNKNOWN {
+				errs = append(errs, fmt.Errorf("%s: unknown hash algorithm %q", ref, h.Algorithm))
+			}
+		}
+	}
+
+	var checkComponent func(c *cdx.Component)
+	checkComponent = func(c *cdx.Component) {
+		if u.componentTypeToPurpose(c.Type) == sbom.Purpose_UNKNOWN_PURPOSE {
+			errs = append(errs, fmt.Errorf("%s: unknown component type %q", c.BOMRef, c.Type))
+		}
+		if c.Scope != "" && u.cdxScopeToProtobom(c.Scope) == sbom.Node_UNKNOWN_SCOPE {
+			errs = append(errs, fmt.Errorf("%s: unknown component scope %q", c.BOMRef, c.Scope))
+		}
+		checkHashes(c.BOMRef, c.Hashes)
+		if c.ExternalReferences != nil {
+			for _, r := range *c.ExternalReferences {
+				checkHashes(c.BOMRef, r.Hashes)
+			}
+		}
+		if c.Components != nil {
+			for i := range *c.Components {
+				checkComponent(&(*c.Components)[i])
+			}
+		}
+	}
+
+	if bom.ExternalReferences != nil {
+		for _, r := range *bom.ExternalReferences {
+			checkHashes("externalReferences", r.Hashes)
+		}
+	}
+
+	if bom.Metadata != nil {
+		if bom.Metadata.Lifecycles != nil {
+			for _, lc := range *bom.Metadata.Lifecycles {
+				lc := lc
+				if lc.Phase != "" && u.phaseToSBOMType(&lc.Phase) == nil {
+					errs = append(errs, fmt.Errorf("metadata: unknown lifecycle phase %q", lc.Phase))
+				}
+			}
+		}
+		if bom.Metadata.Component != nil {
+			checkComponent(bom.Metadata.Component)
+		}
+	}
+	if bom.Components != nil {
+		for i := range *bom.Components {
+			checkComponent(&(*bom.Components)[i])
+		}
+	}
+	if bom.Compositions != nil {
+		for _, c := range *bom.Compositions {
+			if _, ok := u.cdxAggregateToProtobom(c.Aggregate); !ok {
+				errs = append(errs, fmt.Errorf("compositions: unknown aggregate %q", c.Aggregate))
+			}
+		}
+	}
+	return errs
+}
+
 // componentToNodes takes a CycloneDX component and computes its graph fragment,
 // returning a nodelist
 func (u *CDX) componentToNodeList(component *cdx.Component, cc *int) (*sbom.NodeList, error) {
@@ -139,36 +385,97 @@ func (u *CDX) componentToNodeList(component *cdx.Component, cc *int) (*sbom.Node
 
 func (u *CDX) componentToNode(c *cdx.Component, cc *int) (*sbom.Node, error) { //nolint:unparam
 	(*cc)++
+	licenses := u.licenseChoicesToLicenseList(c.BOMRef, c.Licenses)
 	node := &sbom.Node{
-		Id:      c.BOMRef,
-		Type:    sbom.Node_PACKAGE,
-		Name:    c.Name,
-		Version: c.Version,
-		// UrlHome:     "", // Perhaps it would make sense to use the supplier URL here
-		UrlDownload:        "",
-		Licenses:           u.licenseChoicesToLicenseList(c.Licenses),
-		LicenseConcluded:   u.licenseChoicesToLicenseString(c.Licenses),
+		Id:                 c.BOMRef,
+		Type:               sbom.Node_PACKAGE,
+		Name:               c.Name,
+		Version:            c.Version,
+		Licenses:           licenses,
+		LicenseConcluded:   licenseListToExpression(licenses),
 		Copyright:          c.Copyright,
 		Hashes:             map[int32]string{},
 		Description:        c.Description,
 		Attribution:        []string{},
-		Suppliers:          []*sbom.Person{}, // TODO
-		Originators:        []*sbom.Person{}, // TODO
+		Suppliers:          []*sbom.Person{},
+		Originators:        []*sbom.Person{},
 		ExternalReferences: []*sbom.ExternalReference{},
 		Identifiers:        map[int32]string{},
 		FileTypes:          []string{},
 	}
 
-	node.PrimaryPurpose = []sbom.Purpose{u.componentTypeToPurpose(c.Type)}
+	if c.Evidence != nil {
+		node.LicensesDetected = u.licenseChoicesToLicenseList(c.BOMRef, c.Evidence.Licenses)
+	}
+
+	node.Scope = u.cdxScopeToProtobom(c.Scope)
+	if node.Scope == sbom.Node_UNKNOWN_SCOPE && c.Scope != "" {
+		logrus.Warnf("unknown component scope %q in %s, dropping it", c.Scope, c.BOMRef)
+	}
+
+	purpose := u.componentTypeToPurpose(c.Type)
+	if purpose == sbom.Purpose_UNKNOWN_PURPOSE && c.Type != "" {
+		logrus.Warnf("unknown component type %q in %s, reading it as OTHER", c.Type, c.BOMRef)
+		purpose = sbom.Purpose_OTHER
+	}
+	node.PrimaryPurpose = []sbom.Purpose{purpose}
 
 	// Protobom recognizes files in CycloneDX SBOMs when a component is of
 	// type file. In that case we flip the type bit:
-	if u.componentTypeToPurpose(c.Type) == sbom.Purpose_FILE {
+	if purpose == sbom.Purpose_FILE {
 		node.Type = sbom.Node_FILE
 	}
 
 	node.ExternalReferences = u.unserializeExternalReferences(c.ExternalReferences)
 
+	// The first distribution and website references of the component are
+	// read as the download location and homepage of the node. Without a
+	// distribution reference, vcs references written as SPDX locators
+	// (git+https://...) are taken as the download location.
+	vcsLocation := ""
+	for _, er := range node.ExternalReferences {
+		switch {
+		case er.Type == sbom.ExternalReference_DOWNLOAD && node.UrlDownload == "":
+			node.UrlDownload = er.Url
+		case er.Type == sbom.ExternalReference_WEBSITE && node.UrlHome == "":
+			node.UrlHome = er.Url
+		case er.Type == sbom.ExternalReference_VCS && vcsLocation == "" && protospdx.IsVCSLocation(er.Url):
+			vcsLocation = er.Url
+		}
+	}
+	if node.UrlDownload == "" {
+		node.UrlDownload = vcsLocation
+	}
+
+	if supplier := sbom.PersonFromCDXOrganizationalEntity(c.Supplier); supplier != nil {
+		node.Suppliers = append(node.Suppliers, supplier)
+	}
+
+	// Attribution texts and the SPDX fields without a CycloneDX equivalent
+	// are carried in properties, the rest of the properties are kept in the
+	// node as read
+	if c.Properties != nil {
+		for _, p := range *c.Properties {
+			switch {
+			case p.Name == cdxformats.PropertyAttributionText:
+				node.Attribution = append(node.Attribution, p.Value)
+			case p.Name == cdxformats.PropertySourceInfo && node.SourceInfo == "":
+				node.SourceInfo = p.Value
+			case p.Name == cdxformats.PropertyLicenseComments && node.LicenseComments == "":
+				node.LicenseComments = p.Value
+			default:
+				node.Properties = append(node.Properties, &sbom.Property{Name: p.Name, Value: p.Value})
+			}
+		}
+	}
+
+	if c.Author != "" {
+		node.Originators = append(node.Originators, &sbom.Person{Name: c.Author})
+	}
+	if c.Publisher != "" {
+		node.Originators = append(node.Originators, &sbom.Person{Name: c.Publisher, IsOrg: true})
+	}
+
 	// Named external references:
 	if c.CPE != "" {
 		t := sbom.SoftwareIdentifierType_CPE22
@@ -236,65 +543,50 @@ func (u *CDX) unserializeExternalReferences(cdxReferences *[]cdx.ExternalReferen
 }
 
 // licenseChoicesToLicenseList returns a flat list of license strings combining
-// expressions and IDs in one. This function should be part of a license package.
-func (u *CDX) licenseChoicesToLicenseList(lcs *cdx.Licenses) []string {
+// expressions and IDs in one.
+func (u *CDX) licenseChoicesToLicenseList(bomRef string, lcs *cdx.Licenses) []string {
 	list := []string{}
 	if lcs == nil {
 		return list
 	}
 	for _, lc := range *lcs {
-		// TODO(license): This should handle licenses without an ID and
-		// create custom licenses or another solution that captures the
-		// full cuistom license text.
-		if lc.Expression == "" && lc.License.ID == "" {
-			continue
+		if l := licenseChoiceToString(bomRef, lc); l != "" {
+			list = append(list, l)
 		}
-
-		if lc.Expression != "" {
-			list = append(list, lc.Expression)
-		} else {
-			list = append(list, lc.License.ID)
-		}
-		return list
 	}
 
 	return list
 }
 
-// licenseChoicesToLicenseString takes the component license data and computes
-// a license expression with its license entries. It will return the license or
-// expression verbatim if its just a single entry.
-// This function is temporary and probably should be part of a more complete
-// license package.
-func (u *CDX) licenseChoicesToLicenseString(lcs *cdx.Licenses) string {
-	if lcs == nil {
+// licenseListToExpression computes a license expression from the component
+// license entries. It will return the license or expression verbatim if its
+// just a single entry, multiple entries are joined with OR.
+func licenseListToExpression(list []string) string {
+	return license.Disjunction(list)
+}
+
+// licenseChoiceToString returns the normalized license or expression of a
+// CycloneDX license choice. Returns a blank string if it has neither.
+func licenseChoiceToString(bomRef string, lc cdx.LicenseChoice) string {
+	// TODO(license): This should handle licenses without an ID and
+	// create custom licenses or another solution that captures the
+	// full custom license text.
+	var expression string
+	switch {
+	case lc.Expression != "":
+		expression = lc.Expression
+	case lc.License != nil && lc.License.ID != "":
+		expression = lc.License.ID
+	default:
 		return ""
 	}
-	s := ""
-	for _, lc := range *lcs {
-		// TODO(license): This should handle licenses without an ID and
-		// create custom licenses or another solution that captures the
-		// full cuistom license text.
-		if lc.Expression == "" && lc.License.ID == "" {
-			continue
-		}
-		if s != "" {
-			s += fmt.Sprintf("(%s) OR ", s)
-		}
 
-		newLicense := ""
-		if lc.Expression != "" {
-			newLicense = lc.Expression
-		} else {
-			newLicense = lc.License.ID
-		}
-		if s == "" {
-			s = newLicense
-		} else {
-			s += fmt.Sprintf(" (%s)", newLicense)
-		}
+	// Invalid expressions are read verbatim
+	normalized, err := license.Normalize(expression)
+	if err != nil {
+		logrus.Warnf("invalid license in %s: %v", bomRef, err)
 	}
-	return s
+	return normalized
 }
 
 // phaseToSBOMType converts a CycloneDX lifecycle phase to an SBOM document type
@@ -323,8 +615,38 @@ func (u *CDX) phaseToSBOMType(ph *cdx.LifecyclePhase) *sbom.DocumentType_SBOMTyp
 	}
 }
 
-// componentTypeToPurpose converts the protobom catalog of purposes to the cyclonedx
-// component type.
+// cdxScopeToProtobom converts the CycloneDX component scope to its protobom
+// equivalent
+func (u *CDX) cdxScopeToProtobom(scope cdx.Scope) sbom.Node_Scope {
+	switch scope {
+	case cdx.ScopeRequired:
+		return sbom.Node_REQUIRED
+	case cdx.ScopeOptional:
+		return sbom.Node_OPTIONAL
+	case cdx.ScopeExcluded:
+		return sbom.Node_EXCLUDED
+	default:
+		return sbom.Node_UNKNOWN_SCOPE
+	}
+}
+
+// cdxAggregateToProtobom converts the aggregate of a CycloneDX composition
+// to its protobom equivalent. Blank aggregates are not specified, the
+// boolean is false when the aggregate is not known.
+func (u *CDX) cdxAggregateToProtobom(aggregate cdx.CompositionAggregate) (sbom.Composition_Aggregate, bool) {
+	if aggregate == "" {
+		return sbom.Composition_NOT_SPECIFIED, true
+	}
+	// The protobom names are the CycloneDX values in upper case
+	v, ok := sbom.Composition_Aggregate_value[strings.ToUpper(string(aggregate))]
+	if !ok || strings.ToLower(string(aggregate)) != string(aggregate) {
+		return sbom.Composition_NOT_SPECIFIED, false
+	}
+	return sbom.Composition_Aggregate(v), true
+}
+
+// componentTypeToPurpose converts the cyclonedx component type to the protobom
+// catalog of purposes. Unknown types return Purpose_UNKNOWN_PURPOSE.
 func (u *CDX) componentTypeToPurpose(cType cdx.ComponentType) sbom.Purpose {
 	// CycloneDX 1.5 types: "application", "framework", "library", "container",
 	// "platform", "operating-system", "device", "device-driver", "firmware",
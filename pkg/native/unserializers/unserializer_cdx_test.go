@@ -1,11 +1,16 @@
 package unserializers
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/bom-squad/protobom/pkg/native"
 	"github.com/bom-squad/protobom/pkg/sbom"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -175,3 +180,276 @@ func TestDeterministicIds(t *testing.T) {
 		})
 	}
 }
+
+func TestCDXLicenseChoices(t *testing.T) {
+	cdxu := NewCDX(cdxUnserializerTestVersion, cdxUnserializerTestEncoding)
+	for _, tc := range []struct {
+		name       string
+		sut        *cdx.Licenses
+		list       []string
+		expression string
+	}{
+		{"nil", nil, []string{}, ""},
+		{
+			"single id",
+			&cdx.Licenses{{License: &cdx.License{ID: "apache-2.0"}}},
+			[]string{"Apache-2.0"}, "Apache-2.0",
+		},
+		{
+			"expression",
+			&cdx.Licenses{{Expression: "GPL-2.0-only with Classpath-exception-2.0"}},
+			[]string{"GPL-2.0-only WITH Classpath-exception-2.0"}, "GPL-2.0-only WITH Classpath-exception-2.0",
+		},
+		{
+			"multiple entries",
+			&cdx.Licenses{
+				{License: &cdx.License{ID: "MIT"}},
+				{License: &cdx.License{Name: "Custom"}},
+				{Expression: "LicenseRef-Proprietary AND ISC"},
+			},
+			[]string{"MIT", "LicenseRef-Proprietary AND ISC"}, "MIT OR (LicenseRef-Proprietary AND ISC)",
+		},
+		{
+			"invalid kept verbatim",
+			&cdx.Licenses{{Expression: "MIT AND Some Thing"}},
+			[]string{"MIT AND Some Thing"}, "MIT AND Some Thing",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			list := cdxu.licenseChoicesToLicenseList("pkg", tc.sut)
+			require.Equal(t, tc.list, list)
+			require.Equal(t, tc.expression, licenseListToExpression(list))
+		})
+	}
+}
+
+func TestCDXUnmappedValues(t *testing.T) {
+	cdxDoc := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"bom-ref": "root", "type": "application", "name": "root"}},
+  "components": [
+    {"bom-ref": "lib", "type": "library", "name": "lib", "hashes": [{"alg": "SHA-256", "content": "abc"}]},
+    {"bom-ref": "odd", "type": "gizmo", "name": "odd", "scope": "sometimes", "hashes": [{"alg": "CRC32", "content": "abc"}]}
+  ],
+  "compositions": [{"aggregate": "mostly", "assemblies": ["lib"]}]
+}`
+	u := NewCDX("1.5", formats.JSON)
+
+	doc, err := u.Unserialize(strings.NewReader(cdxDoc), &native.UnserializeOptions{}, nil)
+	require.NoError(t, err)
+	require.NotNil(t, doc.NodeList.GetNodeByID("odd"))
+
+	_, err = u.Unserialize(strings.NewReader(cdxDoc), &native.UnserializeOptions{Strict: true}, nil)
+	require.Error(t, err)
+	require.ErrorContains(t, err, `odd: unknown component type "gizmo"`)
+	require.ErrorContains(t, err, `odd: unknown hash algorithm "CRC32"`)
+	require.ErrorContains(t, err, `odd: unknown component scope "sometimes"`)
+	require.ErrorContains(t, err, `compositions: unknown aggregate "mostly"`)
+	require.NotContains(t, err.Error(), "lib:")
+}
+
+func TestCDXScopeToProtobom(t *testing.T) {
+	cdxu := NewCDX(cdxUnserializerTestVersion, cdxUnserializerTestEncoding)
+	for scope, expected := range map[cdx.Scope]sbom.Node_Scope{
+		cdx.ScopeRequired:      sbom.Node_REQUIRED,
+		cdx.ScopeOptional:      sbom.Node_OPTIONAL,
+		cdx.ScopeExcluded:      sbom.Node_EXCLUDED,
+		cdx.Scope(""):          sbom.Node_UNKNOWN_SCOPE,
+		cdx.Scope("sometimes"): sbom.Node_UNKNOWN_SCOPE,
+	} {
+		require.Equal(t, expected, cdxu.cdxScopeToProtobom(scope))
+	}
+}
+
+func TestCDXUnserializeMetadata(t *testing.T) {
+	cdxDoc := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {
+    "timestamp": "2023-08-01T12:00:00+02:00",
+    "tools": {
+      "components": [
+        {"type": "application", "name": "scanner", "version": "2.0", "supplier": {"name": "ACME"}},
+        {"type": "application", "name": "helper", "version": "0.1", "author": "Jane Doe"}
+      ]
+    },
+    "authors": [{"name": "John Doe", "email": "john@example.com", "phone": "555-0100"}],
+    "component": {"bom-ref": "root", "type": "application", "name": "root", "author": "Jane Doe", "publisher": "ACME"}
+  }
+}`
+	u := NewCDX("1.5", formats.JSON)
+	doc, err := u.Unserialize(strings.NewReader(cdxDoc), &native.UnserializeOptions{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC), doc.Metadata.Date.AsTime())
+	require.Len(t, doc.Metadata.Tools, 2)
+	require.Equal(t, "scanner", doc.Metadata.Tools[0].Name)
+	require.Equal(t, "2.0", doc.Metadata.Tools[0].Version)
+	require.Equal(t, "ACME", doc.Metadata.Tools[0].Vendor)
+	require.Equal(t, "Jane Doe", doc.Metadata.Tools[1].Vendor)
+	require.Len(t, doc.Metadata.Authors, 1)
+	require.Equal(t, "John Doe", doc.Metadata.Authors[0].Name)
+	require.Equal(t, "john@example.com", doc.Metadata.Authors[0].Email)
+	require.Equal(t, "555-0100", doc.Metadata.Authors[0].Phone)
+
+	// The author and publisher of the component are its originators
+	root := doc.NodeList.GetNodeByID("root")
+	require.NotNil(t, root)
+	require.Len(t, root.Originators, 2)
+	require.Equal(t, "Jane Doe", root.Originators[0].Name)
+	require.False(t, root.Originators[0].IsOrg)
+	require.Equal(t, "ACME", root.Originators[1].Name)
+	require.True(t, root.Originators[1].IsOrg)
+
+	// Legacy tools keep their vendor
+	legacy := strings.Replace(cdxDoc, `"tools": {
+      "components": [
+        {"type": "application", "name": "scanner", "version": "2.0", "supplier": {"name": "ACME"}},
+        {"type": "application", "name": "helper", "version": "0.1", "author": "Jane Doe"}
+      ]
+    },`, `"tools": [{"vendor": "ACME", "name": "scanner", "version": "2.0"}],`, 1)
+	doc, err = u.Unserialize(strings.NewReader(legacy), &native.UnserializeOptions{}, nil)
+	require.NoError(t, err)
+	require.Len(t, doc.Metadata.Tools, 1)
+	require.Equal(t, "ACME", doc.Metadata.Tools[0].Vendor)
+}
+
+func TestCDXUnserializeDependencies(t *testing.T) {
+	cdxDoc := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"bom-ref": "root", "type": "application", "name": "root"}},
+  "components": [
+    {"bom-ref": "lib-a", "type": "library", "name": "lib-a", "scope": "required"},
+    {"bom-ref": "lib-b", "type": "library", "name": "lib-b", "scope": "optional"}
+  ],
+  "dependencies": [
+    {"ref": "root", "dependsOn": ["lib-a", "lib-b", "missing"]},
+    {"ref": "lib-a", "dependsOn": ["lib-b"]},
+    {"ref": "lib-b", "dependsOn": []},
+    {"ref": "missing", "dependsOn": ["lib-a"]}
+  ]
+}`
+	u := NewCDX("1.5", formats.JSON)
+	doc, err := u.Unserialize(strings.NewReader(cdxDoc), &native.UnserializeOptions{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, sbom.Node_REQUIRED, doc.NodeList.GetNodeByID("lib-a").Scope)
+	require.Equal(t, sbom.Node_OPTIONAL, doc.NodeList.GetNodeByID("lib-b").Scope)
+
+	deps := map[string][]string{}
+	for _, e := range doc.NodeList.Edges {
+		if e.Type == sbom.Edge_dependsOn {
+			deps[e.From] = append(deps[e.From], e.To...)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"root":  {"lib-a", "lib-b"},
+		"lib-a": {"lib-b"},
+	}, deps)
+}
+
+func TestCDXUnserializeCompositions(t *testing.T) {
+	cdxDoc := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"bom-ref": "root", "type": "application", "name": "root"}},
+  "components": [
+    {"bom-ref": "lib-a", "type": "library", "name": "lib-a"},
+    {"bom-ref": "lib-b", "type": "library", "name": "lib-b"}
+  ],
+  "compositions": [
+    {"bom-ref": "c1", "aggregate": "complete", "assemblies": ["lib-a", "lib-b"], "dependencies": ["root", "missing"]},
+    {"aggregate": "incomplete_third_party_only", "assemblies": ["missing"]},
+    {"aggregate": "mostly"}
+  ]
+}`
+	doc, err := NewCDX("1.5", formats.JSON).Unserialize(strings.NewReader(cdxDoc), &native.UnserializeOptions{}, nil)
+	require.NoError(t, err)
+
+	expected := []*sbom.Composition{
+		{Id: "c1", Aggregate: sbom.Composition_COMPLETE, Assemblies: []string{"lib-a", "lib-b"}, Dependencies: []string{"root"}},
+		{Aggregate: sbom.Composition_INCOMPLETE_THIRD_PARTY_ONLY, Assemblies: []string{}, Dependencies: []string{}},
+		{Aggregate: sbom.Composition_NOT_SPECIFIED, Assemblies: []string{}, Dependencies: []string{}},
+	}
+	require.Len(t, doc.Metadata.Compositions, len(expected))
+	for i := range expected {
+		require.True(t, proto.Equal(expected[i], doc.Metadata.Compositions[i]), doc.Metadata.Compositions[i].String())
+	}
+	require.Empty(t, doc.Validate())
+}
+
+func TestCDXAggregateToProtobom(t *testing.T) {
+	cdxu := NewCDX(cdxUnserializerTestVersion, cdxUnserializerTestEncoding)
+	for _, tc := range []struct {
+		sut      cdx.CompositionAggregate
+		expected sbom.Composition_Aggregate
+		known    bool
+	}{
+		{cdx.CompositionAggregateComplete, sbom.Composition_COMPLETE, true},
+		{cdx.CompositionAggregateIncomplete, sbom.Composition_INCOMPLETE, true},
+		{cdx.CompositionAggregateIncompleteFirstPartyOpenSourceOnly, sbom.Composition_INCOMPLETE_FIRST_PARTY_OPENSOURCE_ONLY, true},
+		{cdx.CompositionAggregateIncompleteThirdPartyProprietaryOnly, sbom.Composition_INCOMPLETE_THIRD_PARTY_PROPRIETARY_ONLY, true},
+		{cdx.CompositionAggregateUnknown, sbom.Composition_UNKNOWN, true},
+		{cdx.CompositionAggregateNotSpecified, sbom.Composition_NOT_SPECIFIED, true},
+		{"", sbom.Composition_NOT_SPECIFIED, true},
+		{"COMPLETE", sbom.Composition_NOT_SPECIFIED, false},
+		{"mostly", sbom.Composition_NOT_SPECIFIED, false},
+	} {
+		res, known := cdxu.cdxAggregateToProtobom(tc.sut)
+		require.Equal(t, tc.expected, res, string(tc.sut))
+		require.Equal(t, tc.known, known, string(tc.sut))
+	}
+}
+
+func TestCDXUnserializeYAML(t *testing.T) {
+	yamlDoc := `bomFormat: CycloneDX
+specVersion: "1.5"
+serialNumber: urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79
+version: 1
+metadata:
+  timestamp: 2023-08-01T12:00:00Z
+  component:
+    bom-ref: app
+    type: application
+    name: app
+components:
+  - bom-ref: lib-a
+    type: library
+    name: lib-a
+    version: 2.1.0
+dependencies:
+  - ref: app
+    dependsOn: [lib-a]
+`
+	jsonDoc := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "serialNumber": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "version": 1,
+  "metadata": {
+    "timestamp": "2023-08-01T12:00:00Z",
+    "component": {"bom-ref": "app", "type": "application", "name": "app"}
+  },
+  "components": [{"bom-ref": "lib-a", "type": "library", "name": "lib-a", "version": "2.1.0"}],
+  "dependencies": [{"ref": "app", "dependsOn": ["lib-a"]}]
+}`
+	fromYAML, err := NewCDX("1.5", formats.YAML).Unserialize(strings.NewReader(yamlDoc), nil, nil)
+	require.NoError(t, err)
+	fromJSON, err := NewCDX("1.5", formats.JSON).Unserialize(strings.NewReader(jsonDoc), nil, nil)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(fromJSON, fromYAML))
+	require.Equal(t, "2.1.0", fromYAML.NodeList.GetNodeByID("lib-a").Version)
+
+	// Unknown fields are also checked in YAML documents
+	_, err = NewCDX("1.5", formats.YAML).Unserialize(
+		strings.NewReader(yamlDoc+"unknown: true\n"), &native.UnserializeOptions{DisallowUnknownFields: true}, nil,
+	)
+	require.Error(t, err)
+
+	_, err = NewCDX("1.5", formats.YAML).Unserialize(strings.NewReader("bomFormat: [CycloneDX\n"), nil, nil)
+	require.Error(t, err)
+}
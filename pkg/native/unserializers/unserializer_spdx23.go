@@ -1,84 +1,344 @@
 package unserializers
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/bom-squad/protobom/pkg/formats"
 	protospdx "github.com/bom-squad/protobom/pkg/formats/spdx"
+	"github.com/bom-squad/protobom/pkg/license"
 	"github.com/bom-squad/protobom/pkg/native"
 	"github.com/bom-squad/protobom/pkg/sbom"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	spdxjson "github.com/spdx/tools-golang/json"
 	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
 	spdx23 "github.com/spdx/tools-golang/spdx/v2/v2_3"
+	"github.com/spdx/tools-golang/tagvalue"
 )
 
 var _ native.Unserializer = &SPDX23{}
 
-type SPDX23 struct{}
+// spdxExtraFields are the fields of the SPDX JSON schema that tools-golang
+// reads in the custom unmarshallers of its types
+var spdxExtraFields = map[reflect.Type][]string{
+	reflect.TypeOf(spdx23.Document{}): {"documentDescribes"},
+	reflect.TypeOf(spdx23.Package{}):  {"hasFiles"},
+}
+
+type SPDX23 struct {
+	encoding string
+}
 
+// NewSPDX23 returns an unserializer that reads SPDX 2.3 documents encoded
+// as JSON.
 func NewSPDX23() *SPDX23 {
-	return &SPDX23{}
+	return &SPDX23{
+		encoding: formats.JSON,
+	}
+}
+
+// NewSPDX23TV returns an unserializer that reads SPDX 2.3 documents in the
+// tag-value encoding.
+func NewSPDX23TV() *SPDX23 {
+	return &SPDX23{
+		encoding: formats.TEXT,
+	}
 }
 
 // ParseStream reads an io.Reader to parse an SPDX 2.3 document from it
-func (u *SPDX23) Unserialize(r io.Reader, _ *native.UnserializeOptions, _ interface{}) (*sbom.Document, error) {
-	spdxDoc, err := spdxjson.Read(r)
+func (u *SPDX23) Unserialize(r io.Reader, opts *native.UnserializeOptions, _ interface{}) (*sbom.Document, error) {
+	// The streaming parser checks the unknown fields of each element as
+	// it reads them
+	if u.encoding != formats.TEXT && streamInput(r, opts) {
+		return u.unserializeStream(r, opts)
+	}
+
+	// The tag-value parser always fails on unknown tags so unknown fields
+	// only need to be checked in JSON documents.
+	if opts != nil && opts.DisallowUnknownFields && u.encoding != formats.TEXT {
+		var err error
+		r, err = checkUnknownFields(r, new(spdx23.Document), spdxExtraFields)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := io.ReadAll(r)
 	if err != nil {
-		return nil, fmt.Errorf("parsing SPDX json: %w", err)
+		return nil, fmt.Errorf("reading SPDX document: %w", err)
 	}
 
-	bom := sbom.NewDocument()
-	bom.Metadata.Id = string(spdxDoc.SPDXIdentifier)
-	bom.Metadata.Name = spdxDoc.DocumentName
+	spdxDoc, err := u.read(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
 
-	// TODO(degradation): External document references
+	idx, err := u.idIndex(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts != nil && opts.Strict {
+		if errs := u.unmappedValues(spdxDoc); len(errs) > 0 {
+			return nil, fmt.Errorf("strict mode: values not supported by protobom: %w", errors.Join(errs...))
+		}
+	}
+
+	bom := u.newDocument(spdxDoc)
+
+	for _, p := range spdxDoc.Packages {
+		bom.NodeList.AddNode(u.packageToNode(p))
+	}
+
+	for _, f := range collectFiles(spdxDoc) {
+		bom.NodeList.AddNode(u.fileToNode(f))
+	}
+
+	for _, r := range spdxDoc.Relationships {
+		if _, ok := describedElement(spdxDoc, r); ok {
+			continue
+		}
+		bom.NodeList.AddEdge(u.relationshipToEdge(r))
+	}
+
+	if err := u.completeDocument(bom, spdxDoc, idx, opts); err != nil {
+		return nil, err
+	}
+	return bom, nil
+}
+
+// idIndex reads the identifiers defined and referenced in the document
+func (u *SPDX23) idIndex(data []byte) (*spdxIDIndex, error) {
+	if u.encoding == formats.TEXT {
+		return tagValueIDIndex(data), nil
+	}
+	return jsonIDIndex(data)
+}
+
+// newDocument returns a new protobom document with the metadata of the SPDX
+// document. The elements of the SPDX document are not added to it.
+func (u *SPDX23) newDocument(spdxDoc *spdx.Document) *sbom.Document {
+	// The metadata is read from the SPDX document, the defaults of
+	// sbom.NewDocument do not apply
+	bom := &sbom.Document{
+		Metadata: &sbom.Metadata{
+			Id:      string(spdxDoc.SPDXIdentifier),
+			Version: "0",
+			Name:    spdxDoc.DocumentName,
+			Comment: spdxDoc.DocumentComment,
+			Tools:   []*sbom.Tool{},
+			Authors: []*sbom.Person{},
+		},
+		NodeList: sbom.NewNodeList(),
+	}
+
+	bom.Metadata.ExternalDocuments = externalDocumentsToProtobom(spdxDoc.ExternalDocumentReferences)
 
 	// TODO(puerco) Top level elements
 	if spdxDoc.CreationInfo != nil {
 		if t := u.spdxDateToTime(spdxDoc.CreationInfo.Created); t != nil {
 			bom.Metadata.Date = timestamppb.New(*t)
 		}
-		if spdxDoc.CreationInfo.Creators != nil {
-			for _, c := range spdxDoc.CreationInfo.Creators {
-				// TODO: We need to create a parser library in formats/spdx
-				if c.CreatorType == "Tool" {
-					// TODO: Split the version from the Tool string here.
-					bom.Metadata.Tools = append(bom.Metadata.Tools, &sbom.Tool{Name: c.Creator})
-					continue
-				}
-				a := &sbom.Person{Name: c.Creator}
-				a.IsOrg = (c.CreatorType == protospdx.Organization)
+		bom.Metadata.CreatorComment = spdxDoc.CreationInfo.CreatorComment
+		bom.Metadata.LicenseListVersion = spdxDoc.CreationInfo.LicenseListVersion
+		for _, c := range spdxDoc.CreationInfo.Creators {
+			if c.CreatorType == protospdx.Tool {
+				name, version := protospdx.ParseToolString(c.Creator)
+				bom.Metadata.Tools = append(bom.Metadata.Tools, &sbom.Tool{Name: name, Version: version})
+				continue
+			}
+			if a := actorToPerson(c.CreatorType, c.Creator); a != nil {
 				bom.Metadata.Authors = append(bom.Metadata.Authors, a)
 			}
 		}
 	}
 
-	// TODO(degradation): SPDX LicenseVersion
+	for _, l := range spdxDoc.OtherLicenses {
+		if l == nil {
+			continue
+		}
+		bom.Metadata.ExtractedLicenses = append(bom.Metadata.ExtractedLicenses, &sbom.ExtractedLicense{
+			Id:      l.LicenseIdentifier,
+			Name:    l.LicenseName,
+			Text:    l.ExtractedText,
+			SeeAlso: l.LicenseCrossReferences,
+			Comment: l.LicenseComment,
+		})
+	}
 
-	for _, p := range spdxDoc.Packages {
-		bom.NodeList.AddNode(u.packageToNode(p))
+	return bom
+}
+
+// completeDocument finishes the protobom document once the nodes and edges
+// of the SPDX packages, files and relationships have been added to it: it
+// checks the identifiers of the elements, normalizes the direction of the
+// relationships, adds the snippets, annotations and external elements and
+// sets the root elements described by the SPDX document.
+func (u *SPDX23) completeDocument(bom *sbom.Document, spdxDoc *spdx.Document, idx *spdxIDIndex, opts *native.UnserializeOptions) error {
+	if err := checkIDs(bom.NodeList, idx, opts); err != nil {
+		return err
 	}
 
-	for _, f := range spdxDoc.Files {
-		bom.NodeList.AddNode(u.fileToNode(f))
+	u.addSnippets(bom.NodeList, collectSnippets(spdxDoc))
+	u.addAnnotations(bom, spdxDoc.Annotations)
+
+	// Relationships stated from their target (CONTAINED_BY, DEPENDENCY_OF...)
+	// are turned around, documents often state both directions of them
+	if removed := bom.NodeList.NormalizeEdges(); removed > 0 {
+		logrus.Debugf("dropped %d relationships stated more than once", removed)
 	}
+	addPackageFileEdges(bom.NodeList, spdxDoc.Packages)
+	addExternalNodes(bom)
+
+	roots, err := u.rootElements(spdxDoc, bom.NodeList, opts)
+	if err != nil {
+		return err
+	}
+	bom.NodeList.RootElements = roots
+	return nil
+}
 
+// describedElement returns the element described by the document when the
+// relationship is a DESCRIBES relationship from the document or a
+// DESCRIBED_BY relationship to it. The SPDX go library surfaces the JSON
+// documentDescribes entries as DESCRIBES relationships.
+func describedElement(spdxDoc *spdx.Document, r *spdx23.Relationship) (common.DocElementID, bool) {
+	if r == nil {
+		return common.DocElementID{}, false
+	}
+	isDocument := func(ref common.DocElementID) bool {
+		return ref.DocumentRefID == "" && ref.SpecialID == "" && ref.ElementRefID == spdxDoc.SPDXIdentifier
+	}
+	switch {
+	case strings.EqualFold(r.Relationship, common.TypeRelationshipDescribe) && isDocument(r.RefA):
+		return r.RefB, true
+	case strings.EqualFold(r.Relationship, common.TypeRelationshipDescribeBy) && isDocument(r.RefB):
+		return r.RefA, true
+	default:
+		return common.DocElementID{}, false
+	}
+}
+
+// rootElements returns the IDs of the nodes described by the document, de
+// duplicated and in the order they are declared. Roots pointing to elements
+// not defined in the document return an error unless the options allow
+// them, in which case they are dropped with a warning.
+func (*SPDX23) rootElements(spdxDoc *spdx.Document, nl *sbom.NodeList, opts *native.UnserializeOptions) ([]string, error) {
+	roots := []string{}
+	errs := []error{}
+	seen := map[string]struct{}{}
 	for _, r := range spdxDoc.Relationships {
-		// The SPDX go library surfaces the JSON top-level elements as relationships:
-		if r.RefA.ElementRefID == "DOCUMENT" && strings.EqualFold(r.Relationship, "DESCRIBES") {
-			bom.NodeList.RootElements = append(bom.NodeList.RootElements, string(r.RefB.ElementRefID))
-		} else {
-			bom.NodeList.AddEdge(u.relationshipToEdge(r))
+		ref, ok := describedElement(spdxDoc, r)
+		if !ok {
+			continue
+		}
+
+		// Node IDs are the SPDX identifiers of the elements defined in the
+		// document, references to other documents never match a node.
+		id := string(ref.ElementRefID)
+		if ref.DocumentRefID != "" || ref.SpecialID != "" || nl.GetNodeByID(id) == nil {
+			errs = append(errs, fmt.Errorf("document describes element %q which is not defined in the document", common.RenderDocElementID(ref)))
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
 		}
+		seen[id] = struct{}{}
+		roots = append(roots, id)
 	}
 
-	return bom, nil
+	if len(errs) == 0 {
+		return roots, nil
+	}
+	if opts != nil && opts.AllowMissingRoots {
+		for _, err := range errs {
+			logrus.Warnf("dropping root element: %v", err)
+		}
+		return roots, nil
+	}
+	return nil, fmt.Errorf("reading root elements: %w", errors.Join(errs...))
+}
+
+// collectFiles returns all the files in the SPDX document. The tag-value
+// reader attaches the files defined after a package to the package instead
+// of the document.
+func collectFiles(spdxDoc *spdx.Document) []*spdx.File {
+	files := []*spdx.File{}
+	seen := map[common.ElementID]struct{}{}
+	add := func(f *spdx.File) {
+		if f == nil {
+			return
+		}
+		if _, ok := seen[f.FileSPDXIdentifier]; ok {
+			return
+		}
+		seen[f.FileSPDXIdentifier] = struct{}{}
+		files = append(files, f)
+	}
+	for _, f := range spdxDoc.Files {
+		add(f)
+	}
+	for _, p := range spdxDoc.Packages {
+		for _, f := range p.Files {
+			add(f)
+		}
+	}
+	return files
+}
+
+// addPackageFileEdges records the files nested in packages as contained
+// by them unless the document already has the relationship.
+func addPackageFileEdges(nl *sbom.NodeList, packages []*spdx.Package) {
+	for _, p := range packages {
+		from := string(p.PackageSPDXIdentifier)
+		for _, f := range p.Files {
+			if f == nil {
+				continue
+			}
+			to := string(f.FileSPDXIdentifier)
+			found := false
+			for _, e := range nl.Edges {
+				if e.From == from && e.Type == sbom.Edge_contains && slices.Contains(e.To, to) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				nl.AddEdge(&sbom.Edge{Type: sbom.Edge_contains, From: from, To: []string{to}})
+			}
+		}
+	}
+}
+
+// read parses the SPDX document from r according to the unserializer encoding
+func (u *SPDX23) read(r io.Reader) (*spdx.Document, error) {
+	switch u.encoding {
+	case formats.TEXT:
+		spdxDoc, err := tagvalue.Read(r)
+		if err != nil {
+			return nil, fmt.Errorf("parsing SPDX tag-value: %w", err)
+		}
+		return spdxDoc, nil
+	case formats.JSON, "":
+		spdxDoc, err := spdxjson.Read(r)
+		if err != nil {
+			return nil, fmt.Errorf("parsing SPDX json: %w", err)
+		}
+		return spdxDoc, nil
+	default:
+		return nil, fmt.Errorf("unsupported SPDX encoding %q", u.encoding)
+	}
 }
 
 // packageToNode assigns the data from an SPDX package into a new Node
@@ -89,10 +349,10 @@ func (u *SPDX23) packageToNode(p *spdx23.Package) *sbom.Node {
 		Name:            p.PackageName,
 		Version:         p.PackageVersion,
 		FileName:        p.PackageFileName,
-		UrlHome:         p.PackageHomePage,
-		UrlDownload:     p.PackageDownloadLocation,
+		UrlHome:         sbom.ValueFromSPDX(p.PackageHomePage),
+		UrlDownload:     sbom.ValueFromSPDX(p.PackageDownloadLocation),
 		LicenseComments: p.PackageLicenseComments,
-		Copyright:       p.PackageCopyrightText,
+		Copyright:       sbom.ValueFromSPDX(p.PackageCopyrightText),
 		SourceInfo:      p.PackageSourceInfo,
 		Comment:         p.PackageComment,
 		Summary:         p.PackageSummary,
@@ -101,40 +361,26 @@ func (u *SPDX23) packageToNode(p *spdx23.Package) *sbom.Node {
 		Identifiers:     map[int32]string{},
 	}
 
-	// SPDX 2.3 PrimaryPackagePurpose types: APPLICATION | FRAMEWORK | LIBRARY | CONTAINER | OPERATING-SYSTEM | DEVICE | FIRMWARE | SOURCE | ARCHIVE | FILE | INSTALL | OTHER
-	switch p.PrimaryPackagePurpose {
-	case "APPLICATION":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_APPLICATION}
-	case "FRAMEWORK":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_FRAMEWORK}
-	case "LIBRARY":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_LIBRARY}
-	case "CONTAINER":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_CONTAINER}
-	case "OPERATING-SYSTEM":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_OPERATING_SYSTEM}
-	case "DEVICE":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_DEVICE}
-	case "FIRMWARE":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_FIRMWARE}
-	case "SOURCE":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_SOURCE}
-	case "ARCHIVE":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_ARCHIVE}
-	case "FILE":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_FILE}
-	case "INSTALL":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_INSTALL}
-	case "OTHER":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_OTHER}
-	case "":
-	default:
-		// TODO(degradation): unknown PrimaryPackagePurpose not preserved in protobom struct
+	if p.PrimaryPackagePurpose != "" {
+		purpose := spdxPurposeToProtobom(p.PrimaryPackagePurpose)
+		if purpose == sbom.Purpose_UNKNOWN_PURPOSE {
+			logrus.Warnf("unknown package purpose %q in %s, reading it as OTHER", p.PrimaryPackagePurpose, p.PackageSPDXIdentifier)
+			purpose = sbom.Purpose_OTHER
+		}
+		n.PrimaryPurpose = []sbom.Purpose{purpose}
 	}
 
-	// TODO(degradation) NOASSERTION
-	if p.PackageLicenseConcluded != protospdx.NOASSERTION && p.PackageLicenseConcluded != "" {
-		n.LicenseConcluded = p.PackageLicenseConcluded
+	// The normalized license keeps NOASSERTION and NONE, invalid
+	// expressions are read verbatim
+	var concludedErr, detectedErr error
+	n.LicenseConcluded, concludedErr = license.Normalize(p.PackageLicenseConcluded)
+	n.LicensesDetected, detectedErr = license.NormalizeList(p.PackageLicenseInfoFromFiles)
+	declared, declaredErr := license.Normalize(p.PackageLicenseDeclared)
+	if declared != "" {
+		n.Licenses = []string{declared}
+	}
+	if err := errors.Join(concludedErr, detectedErr, declaredErr); err != nil {
+		logrus.Warnf("invalid license in %s: %v", n.Id, err)
 	}
 
 	if len(p.PackageChecksums) > 0 {
@@ -142,7 +388,7 @@ func (u *SPDX23) packageToNode(p *spdx23.Package) *sbom.Node {
 		for _, h := range p.PackageChecksums {
 			algo := sbom.HashAlgorithmFromSPDX(h.Algorithm)
 			if algo == sbom.HashAlgorithm_UNKNOWN {
-				// TODO(degradation): Invalid algorithm in SBOM
+				logrus.Warnf("unknown checksum algorithm %q in %s, dropping it", h.Algorithm, p.PackageSPDXIdentifier)
 				continue
 			}
 			n.Hashes[int32(algo)] = h.Value
@@ -160,18 +406,31 @@ func (u *SPDX23) packageToNode(p *spdx23.Package) *sbom.Node {
 
 			if !isIdentifier {
 				// Else, it goes into the external references
-				n.ExternalReferences = append(n.ExternalReferences, &sbom.ExternalReference{
+				extRef := &sbom.ExternalReference{
 					Url:     r.Locator,
 					Type:    extRefType,
 					Comment: r.ExternalRefComment,
-				})
+				}
+				// References in the OTHER category have a free form type,
+				// we keep it as the reference authority
+				if extRefType == sbom.ExternalReference_OTHER {
+					extRef.Authority = r.RefType
+				}
+				n.ExternalReferences = append(n.ExternalReferences, extRef)
 				continue
 			}
 
 			// If it is a software identifier, catch it and continue:
+			// TODO(degradation): Only one identifier of each type is kept
 			idType := u.extRefTypeToIdentifierType(r.RefType)
 			if idType != sbom.SoftwareIdentifierType_UNKNOWN_IDENTIFIER_TYPE {
 				n.Identifiers[int32(idType)] = r.Locator
+				if r.ExternalRefComment != "" {
+					if n.IdentifierComments == nil {
+						n.IdentifierComments = map[int32]string{}
+					}
+					n.IdentifierComments[int32(idType)] = r.ExternalRefComment
+				}
 				continue
 			}
 		}
@@ -187,26 +446,124 @@ func (u *SPDX23) packageToNode(p *spdx23.Package) *sbom.Node {
 		n.BuildDate = timestamppb.New(*t)
 	}
 
-	// Mmh there is a limitation here on the SPDX libraries. They will not
-	// return the supplier and originator emails as a separate field. Perhaps
-	// we should upstream a fix for that.
-	if p.PackageSupplier != nil && p.PackageSupplier.Supplier != protospdx.NOASSERTION {
-		n.Suppliers = []*sbom.Person{{Name: p.PackageSupplier.Supplier}}
-		if p.PackageSupplier.SupplierType == protospdx.Organization {
-			n.Suppliers[0].IsOrg = true
+	// Per the spec filesAnalyzed defaults to true, the SPDX library
+	// already sets it when the field is missing.
+	n.FilesAnalyzed = proto.Bool(p.FilesAnalyzed)
+
+	if p.PackageVerificationCode != nil && p.PackageVerificationCode.Value != "" {
+		n.VerificationCode = &sbom.VerificationCode{
+			Value:         p.PackageVerificationCode.Value,
+			ExcludedFiles: p.PackageVerificationCode.ExcludedFiles,
+		}
+	}
+
+	for i := range p.Annotations {
+		n.Annotations = append(n.Annotations, u.annotationToProtobom(&p.Annotations[i]))
+	}
+
+	if p.PackageSupplier != nil {
+		if supplier := actorToPerson(p.PackageSupplier.SupplierType, p.PackageSupplier.Supplier); supplier != nil {
+			n.Suppliers = []*sbom.Person{supplier}
 		}
 	}
 
-	if p.PackageOriginator != nil && p.PackageOriginator.Originator != protospdx.NOASSERTION && p.PackageOriginator.Originator != "" {
-		n.Originators = []*sbom.Person{{Name: p.PackageOriginator.Originator}}
-		if p.PackageOriginator.OriginatorType == protospdx.Organization {
-			n.Originators[0].IsOrg = true
+	if p.PackageOriginator != nil {
+		if originator := actorToPerson(p.PackageOriginator.OriginatorType, p.PackageOriginator.Originator); originator != nil {
+			n.Originators = []*sbom.Person{originator}
 		}
 	}
 
 	return n
 }
 
+// spdxPurposeToProtobom returns the protobom purpose for an SPDX package
+// purpose. Unknown purposes return Purpose_UNKNOWN_PURPOSE.
+func spdxPurposeToProtobom(purpose string) sbom.Purpose {
+	// SPDX 2.3 PrimaryPackagePurpose types: APPLICATION | FRAMEWORK | LIBRARY | CONTAINER | OPERATING-SYSTEM | DEVICE | FIRMWARE | SOURCE | ARCHIVE | FILE | INSTALL | OTHER
+	switch purpose {
+	case "APPLICATION":
+		return sbom.Purpose_APPLICATION
+	case "FRAMEWORK":
+		return sbom.Purpose_FRAMEWORK
+	case "LIBRARY":
+		return sbom.Purpose_LIBRARY
+	case "CONTAINER":
+		return sbom.Purpose_CONTAINER
+	case "OPERATING-SYSTEM":
+		return sbom.Purpose_OPERATING_SYSTEM
+	case "DEVICE":
+		return sbom.Purpose_DEVICE
+	case "FIRMWARE":
+		return sbom.Purpose_FIRMWARE
+	case "SOURCE":
+		return sbom.Purpose_SOURCE
+	case "ARCHIVE":
+		return sbom.Purpose_ARCHIVE
+	case "FILE":
+		return sbom.Purpose_FILE
+	case "INSTALL":
+		return sbom.Purpose_INSTALL
+	case "OTHER":
+		return sbom.Purpose_OTHER
+	default:
+		return sbom.Purpose_UNKNOWN_PURPOSE
+	}
+}
+
+// addAnnotations adds the document-level SPDX annotations to the protobom
+// document. Annotations about the SPDX document are added to the metadata,
+// the rest are added to the node they refer to. Annotations about elements
+// not found in the document are skipped.
+func (u *SPDX23) addAnnotations(bom *sbom.Document, annotations []*spdx23.Annotation) {
+	for _, a := range annotations {
+		if a == nil {
+			continue
+		}
+		subject := a.AnnotationSPDXIdentifier
+		if subject.DocumentRefID == "" && (subject.ElementRefID == "" || subject.ElementRefID == protospdx.DOCUMENT) {
+			bom.Metadata.Annotations = append(bom.Metadata.Annotations, u.annotationToProtobom(a))
+			continue
+		}
+
+		node := bom.NodeList.GetNodeByID(string(subject.ElementRefID))
+		if subject.DocumentRefID != "" || node == nil {
+			logrus.Warnf("skipping annotation: element %s not found in document", subject)
+			continue
+		}
+		node.Annotations = append(node.Annotations, u.annotationToProtobom(a))
+	}
+}
+
+// annotationToProtobom converts an SPDX annotation into a protobom annotation
+func (u *SPDX23) annotationToProtobom(a *spdx23.Annotation) *sbom.Annotation {
+	annotation := &sbom.Annotation{
+		Type:    sbom.Annotation_OTHER,
+		Comment: a.AnnotationComment,
+	}
+	if strings.EqualFold(a.AnnotationType, "REVIEW") {
+		annotation.Type = sbom.Annotation_REVIEW
+	}
+	if a.Annotator.AnnotatorType == protospdx.Tool {
+		name, version := protospdx.ParseToolString(a.Annotator.Annotator)
+		annotation.Tool = &sbom.Tool{Name: name, Version: version}
+	} else {
+		annotation.Annotator = actorToPerson(a.Annotator.AnnotatorType, a.Annotator.Annotator)
+	}
+	if t := u.spdxDateToTime(a.AnnotationDate); t != nil {
+		annotation.Date = timestamppb.New(*t)
+	}
+	return annotation
+}
+
+// actorToPerson converts an actor as split by the SPDX go library (type and
+// the rest of the string) into a protobom person.
+func actorToPerson(actorType, actor string) *sbom.Person {
+	if actorType == "" {
+		return sbom.PersonFromSPDX2ActorString(actor)
+	}
+	return sbom.PersonFromSPDX2ActorString(fmt.Sprintf("%s: %s", actorType, actor))
+}
+
 // spdxDateToTime is a utility function that turns a date into a go time.Time
 func (*SPDX23) spdxDateToTime(date string) *time.Time {
 	if date == "" {
@@ -222,53 +579,287 @@ func (*SPDX23) spdxDateToTime(date string) *time.Time {
 
 // fileToNode converts a file from SPDX into a protobom node
 func (u *SPDX23) fileToNode(f *spdx23.File) *sbom.Node {
+	concluded, concludedErr := license.Normalize(f.LicenseConcluded)
+	detected, detectedErr := license.NormalizeList(f.LicenseInfoInFiles)
+	if err := errors.Join(concludedErr, detectedErr); err != nil {
+		logrus.Warnf("invalid license in %s: %v", f.FileSPDXIdentifier, err)
+	}
+
 	n := &sbom.Node{
 		Id:               string(f.FileSPDXIdentifier),
 		Type:             sbom.Node_FILE,
 		Name:             f.FileName,
-		Licenses:         f.LicenseInfoInFiles,
-		LicenseConcluded: f.LicenseConcluded,
+		LicenseConcluded: concluded,
+		LicensesDetected: detected,
 		LicenseComments:  f.LicenseComments,
-		Copyright:        f.FileCopyrightText,
+		Copyright:        sbom.ValueFromSPDX(f.FileCopyrightText),
 		Comment:          f.FileComment,
-		Attribution:      []string{},
+		Attribution:      f.FileAttributionTexts,
 		Suppliers:        []*sbom.Person{},
 		Originators:      []*sbom.Person{},
-		FileTypes:        f.FileTypes,
+		FileTypes:        []string{},
 	}
 
+	n.SetFileTypes(sbom.FileTypesFromStrings(f.FileTypes)...)
+	n.PrimaryPurpose = sbom.FileTypePurposes(n.TypedFileTypes())
+
 	if len(f.Checksums) > 0 {
 		n.Hashes = map[int32]string{}
 		for _, h := range f.Checksums {
 			algo := sbom.HashAlgorithmFromSPDX(h.Algorithm)
 			if algo == sbom.HashAlgorithm_UNKNOWN {
-				// TODO(degradation): Invalid SPDX algorith in SBOM. Error? Warning?
+				logrus.Warnf("unknown checksum algorithm %q in %s, dropping it", h.Algorithm, f.FileSPDXIdentifier)
 				continue
 			}
 			n.Hashes[int32(algo)] = h.Value
 		}
 	}
 
+	for i := range f.Annotations {
+		n.Annotations = append(n.Annotations, u.annotationToProtobom(&f.Annotations[i]))
+	}
+
 	return n
 }
 
+// collectSnippets returns all the snippets in the SPDX document. The JSON
+// reader surfaces them in the document while the tag-value reader
+// attaches them to the file they were defined after.
+func collectSnippets(spdxDoc *spdx.Document) []spdx.Snippet {
+	snippets := []spdx.Snippet{}
+	seen := map[common.ElementID]struct{}{}
+	for i := range spdxDoc.Snippets {
+		seen[spdxDoc.Snippets[i].SnippetSPDXIdentifier] = struct{}{}
+		snippets = append(snippets, spdxDoc.Snippets[i])
+	}
+
+	for _, f := range collectFiles(spdxDoc) {
+		ids := []string{}
+		for id := range f.Snippets {
+			ids = append(ids, string(id))
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			snippet := f.Snippets[common.ElementID(id)]
+			if snippet == nil {
+				continue
+			}
+			if _, ok := seen[snippet.SnippetSPDXIdentifier]; ok {
+				continue
+			}
+			seen[snippet.SnippetSPDXIdentifier] = struct{}{}
+			snippets = append(snippets, *snippet)
+		}
+	}
+	return snippets
+}
+
+// addSnippets reads the SPDX snippets and adds them to the file nodes they
+// belong to. Snippets pointing to files not found in the nodelist are skipped.
+func (u *SPDX23) addSnippets(nl *sbom.NodeList, snippets []spdx.Snippet) {
+	for i := range snippets {
+		snippet := &snippets[i]
+		file := nl.GetNodeByID(string(snippet.SnippetFromFileSPDXIdentifier))
+		if file == nil || file.Type != sbom.Node_FILE {
+			logrus.Warnf(
+				"skipping snippet %s: file %s not found in document",
+				snippet.SnippetSPDXIdentifier, snippet.SnippetFromFileSPDXIdentifier,
+			)
+			continue
+		}
+
+		s, err := u.snippetToProtobom(snippet)
+		if err != nil {
+			logrus.Warnf("skipping snippet %s: %v", snippet.SnippetSPDXIdentifier, err)
+			continue
+		}
+		file.Snippets = append(file.Snippets, s)
+	}
+}
+
+// snippetToProtobom converts an SPDX snippet into its protobom equivalent. It
+// returns an error if any of the snippet ranges point to a different file.
+func (*SPDX23) snippetToProtobom(snippet *spdx.Snippet) (*sbom.Snippet, error) {
+	concluded, concludedErr := license.Normalize(snippet.SnippetLicenseConcluded)
+	licenses, licensesErr := license.NormalizeList(snippet.LicenseInfoInSnippet)
+	if err := errors.Join(concludedErr, licensesErr); err != nil {
+		logrus.Warnf("invalid license in %s: %v", snippet.SnippetSPDXIdentifier, err)
+	}
+
+	s := &sbom.Snippet{
+		Id:               string(snippet.SnippetSPDXIdentifier),
+		Name:             snippet.SnippetName,
+		Ranges:           []*sbom.SnippetRange{},
+		LicenseConcluded: concluded,
+		Licenses:         licenses,
+		LicenseComments:  snippet.SnippetLicenseComments,
+		Comment:          snippet.SnippetComment,
+		Attribution:      snippet.SnippetAttributionTexts,
+	}
+
+	s.Copyright = sbom.ValueFromSPDX(snippet.SnippetCopyrightText)
+
+	for _, r := range snippet.Ranges {
+		for _, p := range []common.SnippetRangePointer{r.StartPointer, r.EndPointer} {
+			if p.FileSPDXIdentifier != "" && p.FileSPDXIdentifier != snippet.SnippetFromFileSPDXIdentifier {
+				return nil, fmt.Errorf("range references unknown file %s", p.FileSPDXIdentifier)
+			}
+		}
+		s.Ranges = append(s.Ranges, &sbom.SnippetRange{
+			StartOffset: int32(r.StartPointer.Offset),
+			EndOffset:   int32(r.EndPointer.Offset),
+			StartLine:   int32(r.StartPointer.LineNumber),
+			EndLine:     int32(r.EndPointer.LineNumber),
+		})
+	}
+	return s, nil
+}
+
+// elementNodeID returns the ID of the node representing an SPDX element.
+// Elements defined in external documents are represented by stub nodes
+// with IDs prefixed with the document reference.
+func elementNodeID(ref common.DocElementID) string {
+	if ref.DocumentRefID != "" {
+		return sbom.ExternalNodeID(ref.DocumentRefID, string(ref.ElementRefID))
+	}
+	return string(ref.ElementRefID)
+}
+
+// externalDocumentsToProtobom converts the SPDX external document
+// references to protobom external documents
+func externalDocumentsToProtobom(refs []spdx23.ExternalDocumentRef) []*sbom.ExternalDocument {
+	docs := []*sbom.ExternalDocument{}
+	for _, r := range refs {
+		ed := &sbom.ExternalDocument{
+			// The JSON reader keeps the DocumentRef- prefix, the tag-value
+			// one removes it.
+			Id:     strings.TrimPrefix(r.DocumentRefID, protospdx.DocumentRefPrefix),
+			Uri:    r.URI,
+			Hashes: map[int32]string{},
+		}
+		if r.Checksum.Value != "" {
+			algo := sbom.HashAlgorithmFromSPDX(r.Checksum.Algorithm)
+			if algo == sbom.HashAlgorithm_UNKNOWN {
+				logrus.Warnf("unknown checksum algorithm %q in external document %s", r.Checksum.Algorithm, ed.Id)
+			} else {
+				ed.Hashes[int32(algo)] = r.Checksum.Value
+			}
+		}
+		docs = append(docs, ed)
+	}
+	return docs
+}
+
+// addExternalNodes adds stub nodes for the elements of external documents
+// referenced in the edges of the document
+func addExternalNodes(bom *sbom.Document) {
+	index := map[string]struct{}{}
+	for _, n := range bom.NodeList.Nodes {
+		index[n.Id] = struct{}{}
+	}
+	add := func(id string) {
+		if _, ok := index[id]; ok {
+			return
+		}
+		docID, elementID, ok := sbom.ParseExternalNodeID(id)
+		if !ok {
+			return
+		}
+		index[id] = struct{}{}
+		ed := bom.Metadata.GetExternalDocument(docID)
+		if ed == nil {
+			logrus.Warnf("element %s references undeclared external document %s", id, docID)
+			ed = &sbom.ExternalDocument{Id: docID}
+		}
+		bom.NodeList.AddNode(sbom.NewExternalNode(ed, elementID))
+	}
+	for _, e := range bom.NodeList.Edges {
+		add(e.From)
+		for _, to := range e.To {
+			add(to)
+		}
+	}
+}
+
 // relationshipToEdge converts the SPDX relationship to a protobom Edge
+// Unknown relationship types are preserved as edges of type other. Elements
+// of external documents keep their DocumentRef in the node ID.
 func (*SPDX23) relationshipToEdge(r *spdx23.Relationship) *sbom.Edge {
-	// TODO(degradation) How to handle external documents?
 	// TODO(degradation) How to handle NOASSERTION and NONE targets
 	e := &sbom.Edge{
-		Type: sbom.EdgeTypeFromSPDX2(r.Relationship),
-		From: string(r.RefA.ElementRefID),
-		To:   []string{string(r.RefB.ElementRefID)},
+		Type:    sbom.EdgeTypeFromSPDX2(r.Relationship),
+		From:    elementNodeID(r.RefA),
+		To:      []string{elementNodeID(r.RefB)},
+		Comment: r.RelationshipComment,
+	}
+	if e.Type == sbom.Edge_UNKNOWN {
+		logrus.Warnf("unknown relationship type %q from %s, reading it as OTHER", r.Relationship, e.From)
+		e.Type = sbom.Edge_other
 	}
 	return e
 }
 
+// unmappedValues checks the SPDX document for values that cannot be mapped
+// to a known protobom enum and returns an error for each of them.
+func (u *SPDX23) unmappedValues(spdxDoc *spdx.Document) []error {
+	errs := []error{}
+	for _, p := range spdxDoc.Packages {
+		errs = append(errs, u.unmappedPackageValues(p)...)
+	}
+
+	for _, f := range collectFiles(spdxDoc) {
+		errs = append(errs, unmappedChecksums(f.FileSPDXIdentifier, f.Checksums)...)
+	}
+
+	for _, r := range spdxDoc.Relationships {
+		errs = append(errs, unmappedRelationshipValues(r)...)
+	}
+	return errs
+}
+
+// unmappedPackageValues returns an error for each value of the package that
+// has no protobom equivalent
+func (u *SPDX23) unmappedPackageValues(p *spdx.Package) []error {
+	errs := []error{}
+	if p.PrimaryPackagePurpose != "" && spdxPurposeToProtobom(p.PrimaryPackagePurpose) == sbom.Purpose_UNKNOWN_PURPOSE {
+		errs = append(errs, fmt.Errorf("%s: unknown primary package purpose %q", p.PackageSPDXIdentifier, p.PrimaryPackagePurpose))
+	}
+	errs = append(errs, unmappedChecksums(p.PackageSPDXIdentifier, p.PackageChecksums)...)
+	for _, r := range p.PackageExternalReferences {
+		if _, _, err := u.extRefToProtobomEnum(r); err != nil {
+			errs = append(errs, fmt.Errorf("%s: unknown external reference %s/%s: %w", p.PackageSPDXIdentifier, r.Category, r.RefType, err))
+		}
+	}
+	return errs
+}
+
+// unmappedChecksums returns an error for each checksum of the element with
+// an algorithm unknown to protobom
+func unmappedChecksums(id common.ElementID, checksums []common.Checksum) []error {
+	errs := []error{}
+	for _, c := range checksums {
+		if sbom.HashAlgorithmFromSPDX(c.Algorithm) == sbom.HashAlgorithm_UNKNOWN {
+			errs = append(errs, fmt.Errorf("%s: unknown checksum algorithm %q", id, c.Algorithm))
+		}
+	}
+	return errs
+}
+
+// unmappedRelationshipValues returns an error if the relationship type has
+// no protobom equivalent
+func unmappedRelationshipValues(r *spdx.Relationship) []error {
+	if sbom.EdgeTypeFromSPDX2(r.Relationship) == sbom.Edge_UNKNOWN {
+		return []error{fmt.Errorf("%s: unknown relationship type %q", r.RefA.ElementRefID, r.Relationship)}
+	}
+	return nil
+}
+
 // extRefToProtobomEnum converts the SPDX external reference to the corresponding
 // enumerated type. If the type is a software identifier, the function will return
 // -1 and the isIdentifier will be set to true.
 func (*SPDX23) extRefToProtobomEnum(extref *spdx.PackageExternalReference) (sbom.ExternalReference_ExternalReferenceType, bool, error) {
-	switch extref.Category {
+	// SPDX 2.2 documents used underscores in the category names
+	switch strings.ReplaceAll(extref.Category, "_", "-") {
 	case spdx.CategoryPackageManager:
 		switch extref.RefType {
 		case spdx.PackageManagerBower:
@@ -291,11 +882,9 @@ func (*SPDX23) extRefToProtobomEnum(extref *spdx.PackageExternalReference) (sbom
 			return sbom.ExternalReference_SECURITY_ADVISORY, false, nil
 		case spdx.SecurityFix:
 			return sbom.ExternalReference_SECURITY_FIX, false, nil
-		case spdx.SecuritySwid:
-			return sbom.ExternalReference_SECURITY_SWID, false, nil
 		case spdx.SecurityUrl:
 			return sbom.ExternalReference_SECURITY_OTHER, false, nil
-		case spdx.SecurityCPE22Type, spdx.SecurityCPE23Type:
+		case spdx.SecurityCPE22Type, spdx.SecurityCPE23Type, spdx.SecuritySwid:
 			// Software identifiers will be captured and set into the software id field
 			return -1, true, nil
 		default:
@@ -327,6 +916,10 @@ func (*SPDX23) extRefTypeToIdentifierType(spdxType string) sbom.SoftwareIdentifi
 		return sbom.SoftwareIdentifierType_CPE23
 	case spdx.TypePersistentIdGitoid:
 		return sbom.SoftwareIdentifierType_GITOID
+	case spdx.SecuritySwid:
+		return sbom.SoftwareIdentifierType_SWID
+	case spdx.TypePersistentIdSwh:
+		return sbom.SoftwareIdentifierType_SWHID
 	default:
 		return sbom.SoftwareIdentifierType_UNKNOWN_IDENTIFIER_TYPE
 	}
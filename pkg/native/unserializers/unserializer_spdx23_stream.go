@@ -0,0 +1,408 @@
+package unserializers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/bom-squad/protobom/pkg/native"
+	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	spdx23 "github.com/spdx/tools-golang/spdx/v2/v2_3"
+)
+
+// streamInput returns true if the document in r has to be parsed with the
+// streaming parser according to the options. When a size threshold is set,
+// the size of the input is only known if r can seek.
+func streamInput(r io.Reader, opts *native.UnserializeOptions) bool {
+	if opts == nil {
+		return false
+	}
+	if opts.Stream {
+		return true
+	}
+	if opts.StreamThreshold <= 0 {
+		return false
+	}
+
+	s, ok := r.(io.Seeker)
+	if !ok {
+		return false
+	}
+	current, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false
+	}
+	end, err := s.Seek(0, io.SeekEnd)
+	if err != nil {
+		return false
+	}
+	if _, err := s.Seek(current, io.SeekStart); err != nil {
+		return false
+	}
+	return end-current >= opts.StreamThreshold
+}
+
+// spdxStream holds the state of a document being parsed by the streaming
+// parser
+type spdxStream struct {
+	u    *SPDX23
+	opts *native.UnserializeOptions
+
+	// header collects the raw top level fields of the document that are not
+	// streamed, they are parsed once the whole document has been read
+	header map[string]json.RawMessage
+
+	packages []*sbom.Node
+	files    []*sbom.Node
+
+	// edges has the edges of the relationships in the order they are
+	// found. The DESCRIBES relationships are kept in describes, with the
+	// index of their slot in edges, until the document ID is known to
+	// tell the roots of the document from regular relationships.
+	edges     []*sbom.Edge
+	describes []streamedRelationship
+
+	// relationships registers the keys of the relationships read. As the
+	// SPDX library does, the files listed in the hasFiles field of the
+	// packages are only added as relationships when not already listed.
+	// hasFileIndex points to the slot of each of them in hasFiles, which
+	// is cleared if the relationship is read after the package.
+	relationships map[string]struct{}
+	hasFiles      []*spdx.Relationship
+	hasFileIndex  map[string]int
+
+	// ids has the identifiers of the elements streamed to validate them
+	ids spdxIDView
+
+	unmapped []error
+	unknown  []string
+}
+
+// streamedRelationship is a relationship parsed by the streaming parser that
+// is resolved once the document is read
+type streamedRelationship struct {
+	index        int
+	relationship *spdx.Relationship
+}
+
+// unserializeStream reads an SPDX 2.3 JSON document without loading it
+// whole in memory. The packages, files and relationships are decoded one by
+// one and converted to nodes and edges as they are read, the rest of the
+// fields are parsed when the document ends. Elements are referenced by ID so
+// relationships to elements defined later in the document are resolved,
+// along with the roots of the document, after reading it.
+//
+// The resulting document is the same the regular parser returns.
+func (u *SPDX23) unserializeStream(r io.Reader, opts *native.UnserializeOptions) (*sbom.Document, error) {
+	st := &spdxStream{
+		u:             u,
+		opts:          opts,
+		header:        map[string]json.RawMessage{},
+		relationships: map[string]struct{}{},
+		hasFileIndex:  map[string]int{},
+	}
+	if err := st.read(r); err != nil {
+		return nil, fmt.Errorf("parsing SPDX json: %w", err)
+	}
+
+	headerData, err := json.Marshal(st.header)
+	if err != nil {
+		return nil, fmt.Errorf("parsing SPDX json: %w", err)
+	}
+	if st.checkUnknown() {
+		var value interface{}
+		if err := json.Unmarshal(headerData, &value); err != nil {
+			return nil, fmt.Errorf("parsing SPDX json: %w", err)
+		}
+		st.unknown = append(unknownFields(value, reflect.TypeOf(spdx23.Document{}), spdxExtraFields, ""), st.unknown...)
+	}
+	if len(st.unknown) > 0 {
+		return nil, fmt.Errorf("strict mode: unknown fields in document: %s", strings.Join(st.unknown, ", "))
+	}
+
+	// The document header is parsed with the SPDX library. Its only
+	// relationships are the documentDescribes entries.
+	spdxDoc := &spdx.Document{}
+	if err := json.Unmarshal(headerData, spdxDoc); err != nil {
+		return nil, fmt.Errorf("parsing SPDX json: %w", err)
+	}
+	if spdxDoc.SPDXVersion != spdx.Version {
+		return nil, fmt.Errorf("parsing SPDX json: unsupported SPDX version: %s", spdxDoc.SPDXVersion)
+	}
+
+	if len(st.unmapped) > 0 {
+		return nil, fmt.Errorf("strict mode: values not supported by protobom: %w", errors.Join(st.unmapped...))
+	}
+
+	// The identifiers of the streamed elements complete those of the header
+	ids := &spdxIDView{}
+	if err := json.Unmarshal(headerData, ids); err != nil {
+		return nil, fmt.Errorf("parsing SPDX json: %w", err)
+	}
+	ids.Packages, ids.Files, ids.Relationships = st.ids.Packages, st.ids.Files, st.ids.Relationships
+
+	bom := u.newDocument(spdxDoc)
+	bom.NodeList.Nodes = append(st.packages, st.files...)
+
+	// Now that the document ID is known, split the relationships that
+	// describe the document elements from the rest. The documentDescribes
+	// entries follow the relationships unless they are already listed.
+	describes := []*spdx.Relationship{}
+	for _, d := range st.describes {
+		if _, ok := describedElement(spdxDoc, d.relationship); ok {
+			describes = append(describes, d.relationship)
+			continue
+		}
+		st.edges[d.index] = u.relationshipToEdge(d.relationship)
+	}
+	for _, r := range spdxDoc.Relationships {
+		if _, ok := st.relationships[relationshipKey(r)]; !ok {
+			describes = append(describes, r)
+		}
+	}
+	spdxDoc.Relationships = describes
+
+	for _, e := range st.edges {
+		if e != nil {
+			bom.NodeList.AddEdge(e)
+		}
+	}
+	for _, r := range st.hasFiles {
+		if r != nil {
+			bom.NodeList.AddEdge(u.relationshipToEdge(r))
+		}
+	}
+
+	if err := u.completeDocument(bom, spdxDoc, ids.index(), opts); err != nil {
+		return nil, err
+	}
+	return bom, nil
+}
+
+// checkUnknown returns true if the options disallow unknown fields
+func (st *spdxStream) checkUnknown() bool {
+	return st.opts != nil && st.opts.DisallowUnknownFields
+}
+
+// strict returns true if the options make unmapped values an error
+func (st *spdxStream) strict() bool {
+	return st.opts != nil && st.opts.Strict
+}
+
+// read walks the tokens of the top level object of the document
+func (st *spdxStream) read(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("unexpected token %v", tok)
+		}
+
+		switch key {
+		case "packages":
+			err = streamArray(dec, st.addPackage)
+		case "files":
+			err = streamArray(dec, st.addFile)
+		case "relationships":
+			err = streamArray(dec, st.addRelationship)
+		default:
+			var raw json.RawMessage
+			err = dec.Decode(&raw)
+			st.header[key] = raw
+		}
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", key, err)
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return err
+	}
+
+	if _, ok := st.header["spdxVersion"]; !ok {
+		return errors.New("JSON document does not contain spdxVersion field")
+	}
+	return nil
+}
+
+// addPackage converts a package of the document into a node. The files
+// listed in its hasFiles field are recorded as contained by the package.
+func (st *spdxStream) addPackage(i int, data []byte) error {
+	st.checkElement(data, reflect.TypeOf(spdx23.Package{}), fmt.Sprintf(".packages[%d]", i))
+
+	p := &spdx.Package{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return err
+	}
+	// The SPDX library does not expose hasFiles in the package
+	extras := struct {
+		HasFiles []common.DocElementID `json:"hasFiles"`
+	}{}
+	if err := json.Unmarshal(data, &extras); err != nil {
+		return err
+	}
+	ids := spdxPackageIDs{}
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return err
+	}
+	st.ids.Packages = append(st.ids.Packages, ids)
+
+	if st.strict() {
+		st.unmapped = append(st.unmapped, st.u.unmappedPackageValues(p)...)
+	}
+	st.packages = append(st.packages, st.u.packageToNode(p))
+
+	for _, f := range extras.HasFiles {
+		r := &spdx.Relationship{
+			RefA:         common.DocElementID{ElementRefID: p.PackageSPDXIdentifier},
+			RefB:         f,
+			Relationship: common.TypeRelationshipContains,
+		}
+		key := relationshipKey(r)
+		if _, ok := st.relationships[key]; ok {
+			continue
+		}
+		if _, ok := st.hasFileIndex[key]; ok {
+			continue
+		}
+		st.hasFileIndex[key] = len(st.hasFiles)
+		st.hasFiles = append(st.hasFiles, r)
+	}
+	return nil
+}
+
+// addFile converts a file of the document into a node
+func (st *spdxStream) addFile(i int, data []byte) error {
+	st.checkElement(data, reflect.TypeOf(spdx23.File{}), fmt.Sprintf(".files[%d]", i))
+
+	f := &spdx.File{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return err
+	}
+	ids := spdxElementIDs{}
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return err
+	}
+	st.ids.Files = append(st.ids.Files, ids)
+	if st.strict() {
+		st.unmapped = append(st.unmapped, unmappedChecksums(f.FileSPDXIdentifier, f.Checksums)...)
+	}
+	st.files = append(st.files, st.u.fileToNode(f))
+	return nil
+}
+
+// addRelationship converts a relationship of the document into an edge.
+// DESCRIBES relationships are resolved after reading the document.
+func (st *spdxStream) addRelationship(i int, data []byte) error {
+	st.checkElement(data, reflect.TypeOf(spdx23.Relationship{}), fmt.Sprintf(".relationships[%d]", i))
+
+	r := &spdx.Relationship{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return err
+	}
+	ids := spdxRelationshipIDs{}
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return err
+	}
+	st.ids.Relationships = append(st.ids.Relationships, ids)
+	if st.strict() {
+		st.unmapped = append(st.unmapped, unmappedRelationshipValues(r)...)
+	}
+
+	// hasFiles entries of the packages read before are not duplicated
+	key := relationshipKey(r)
+	if i, ok := st.hasFileIndex[key]; ok {
+		st.hasFiles[i] = nil
+	}
+	st.relationships[key] = struct{}{}
+
+	if strings.EqualFold(r.Relationship, common.TypeRelationshipDescribe) ||
+		strings.EqualFold(r.Relationship, common.TypeRelationshipDescribeBy) {
+		st.describes = append(st.describes, streamedRelationship{index: len(st.edges), relationship: r})
+		st.edges = append(st.edges, nil)
+		return nil
+	}
+	st.edges = append(st.edges, st.u.relationshipToEdge(r))
+	return nil
+}
+
+// checkElement records the unknown fields of an element of the document
+// when the options disallow them
+func (st *spdxStream) checkElement(data []byte, t reflect.Type, path string) {
+	if !st.checkUnknown() {
+		return
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return
+	}
+	st.unknown = append(st.unknown, unknownFields(value, t, spdxExtraFields, path)...)
+}
+
+// relationshipKey returns a string identifying the relationship. As the SPDX
+// library does when deduplicating relationships, inverse relationships are
+// keyed as their direct equivalents.
+func relationshipKey(r *spdx.Relationship) string {
+	refA, refB, rel := r.RefA, r.RefB, r.Relationship
+	switch r.Relationship {
+	case common.TypeRelationshipContainedBy:
+		rel = common.TypeRelationshipContains
+		refA, refB = r.RefB, r.RefA
+	case common.TypeRelationshipDescribeBy:
+		rel = common.TypeRelationshipDescribe
+		refA, refB = r.RefB, r.RefA
+	}
+	return fmt.Sprintf("%v-%v->%v", common.RenderDocElementID(refA), rel, common.RenderDocElementID(refB))
+}
+
+// streamArray decodes the elements of a JSON array one by one, calling fn
+// with the index and raw data of each. A null value is read as an empty
+// array.
+func streamArray(dec *json.Decoder, fn func(int, []byte) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("expected an array, found %v", tok)
+	}
+
+	for i := 0; dec.More(); i++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		if err := fn(i, raw); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token and checks it is the delimiter d
+func expectDelim(dec *json.Decoder, d json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != d {
+		return fmt.Errorf("expected %v, found %v", d, tok)
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package unserializers
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bom-squad/protobom/pkg/native"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// streamTestDocument lists the relationships before the packages and has
+// files in the hasFiles field of packages that are also relationships,
+// documentDescribes entries, DESCRIBES relationships between elements and a
+// relationship to an element of an external document.
+const streamTestDocument = `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "stream",
+  "documentNamespace": "https://example.com/stream",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "externalDocumentRefs": [{
+    "externalDocumentId": "DocumentRef-other",
+    "spdxDocument": "https://example.com/other",
+    "checksum": {"algorithm": "SHA1", "checksumValue": "d6a770ba38583ed4bb4525bd96e50461655d2759"}
+  }],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-Package-2", "relationshipType": "DESCRIBED_BY", "relatedSpdxElement": "SPDXRef-DOCUMENT"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Package-2"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-File-1"},
+    {"spdxElementId": "SPDXRef-File-2", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-2"},
+    {"spdxElementId": "SPDXRef-Package-2", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "DocumentRef-other:SPDXRef-Package-foo"}
+  ],
+  "packages": [
+    {"SPDXID": "SPDXRef-Package-1", "name": "one", "downloadLocation": "NOASSERTION", "hasFiles": ["SPDXRef-File-1", "SPDXRef-File-2"]},
+    {"SPDXID": "SPDXRef-Package-2", "name": "two", "downloadLocation": "NOASSERTION", "hasFiles": ["SPDXRef-File-2"]}
+  ],
+  "files": [
+    {"SPDXID": "SPDXRef-File-1", "fileName": "./one", "checksums": [{"algorithm": "SHA1", "checksumValue": "85ed0817af83a24ad8da68c2b5094de69833983c"}]},
+    {"SPDXID": "SPDXRef-File-2", "fileName": "./two", "checksums": [{"algorithm": "SHA1", "checksumValue": "85ed0817af83a24ad8da68c2b5094de69833983d"}]}
+  ],
+  "documentDescribes": ["SPDXRef-Package-1", "SPDXRef-Package-2"]
+}`
+
+func TestUnserializeStream(t *testing.T) {
+	documents := map[string][]byte{
+		"crafted": []byte(streamTestDocument),
+		"large":   largeSPDXDocument(50, 1000),
+	}
+	paths, err := filepath.Glob("../../../test/conformance/testdata/spdx/2.3/json/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		documents[filepath.Base(path)] = data
+	}
+
+	u := NewSPDX23()
+	for name, data := range documents {
+		t.Run(name, func(t *testing.T) {
+			expected, err := u.Unserialize(bytes.NewReader(data), &native.UnserializeOptions{}, nil)
+			require.NoError(t, err)
+			streamed, err := u.Unserialize(bytes.NewReader(data), &native.UnserializeOptions{Stream: true}, nil)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expected, streamed), "streamed document differs")
+		})
+	}
+
+	// Check the crafted document resolves its roots and hasFiles edges
+	doc, err := u.Unserialize(strings.NewReader(streamTestDocument), &native.UnserializeOptions{Stream: true}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Package-2", "Package-1"}, doc.NodeList.RootElements)
+	require.Len(t, doc.NodeList.Nodes, 5)
+	require.Len(t, doc.NodeList.Edges, 6)
+	require.Len(t, doc.Metadata.ExternalDocuments, 1)
+	require.Equal(t, "other", doc.NodeList.GetNodeByID("DocumentRef-other:Package-foo").ExternalDocument)
+}
+
+func TestUnserializeStreamErrors(t *testing.T) {
+	u := NewSPDX23()
+	for _, tc := range []struct {
+		name     string
+		document string
+		opts     native.UnserializeOptions
+		contains string
+	}{
+		{
+			name:     "no version",
+			document: `{"SPDXID": "SPDXRef-DOCUMENT", "packages": []}`,
+			contains: "does not contain spdxVersion",
+		},
+		{
+			name:     "unsupported version",
+			document: `{"spdxVersion": "SPDX-2.1", "SPDXID": "SPDXRef-DOCUMENT"}`,
+			contains: "unsupported SPDX version",
+		},
+		{
+			name:     "invalid json",
+			document: `{"spdxVersion": "SPDX-2.3", "packages": [{"SPDXID": }]}`,
+			contains: "reading packages",
+		},
+		{
+			name:     "not an array",
+			document: `{"spdxVersion": "SPDX-2.3", "files": {}}`,
+			contains: "expected an array",
+		},
+		{
+			name:     "unknown package field",
+			document: strings.Replace(streamTestDocument, `"name": "two",`, `"name": "two", "vendorExtension": "yes",`, 1),
+			opts:     native.UnserializeOptions{DisallowUnknownFields: true},
+			contains: ".packages[1].vendorExtension",
+		},
+		{
+			name:     "unknown document field",
+			document: strings.Replace(streamTestDocument, `"name": "stream",`, `"name": "stream", "vendorExtension": "yes",`, 1),
+			opts:     native.UnserializeOptions{DisallowUnknownFields: true},
+			contains: "vendorExtension",
+		},
+		{
+			name:     "unmapped relationship",
+			document: strings.Replace(streamTestDocument, `"DEPENDS_ON"`, `"BORROWS_FROM"`, 1),
+			opts:     native.UnserializeOptions{Strict: true},
+			contains: `unknown relationship type "BORROWS_FROM"`,
+		},
+		{
+			name:     "unmapped checksum",
+			document: strings.Replace(streamTestDocument, `"SHA1", "checksumValue": "85ed`, `"CRC32", "checksumValue": "85ed`, 1),
+			opts:     native.UnserializeOptions{Strict: true},
+			contains: `File-1: unknown checksum algorithm "CRC32"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := tc.opts
+			opts.Stream = true
+			_, err := u.Unserialize(strings.NewReader(tc.document), &opts, nil)
+			require.Error(t, err)
+			require.ErrorContains(t, err, tc.contains)
+		})
+	}
+}
+
+func TestStreamInput(t *testing.T) {
+	data := []byte(streamTestDocument)
+	size := int64(len(data))
+	for _, tc := range []struct {
+		name     string
+		opts     *native.UnserializeOptions
+		seekable bool
+		expected bool
+	}{
+		{"nil options", nil, true, false},
+		{"default options", &native.UnserializeOptions{}, true, false},
+		{"stream", &native.UnserializeOptions{Stream: true}, false, true},
+		{"under threshold", &native.UnserializeOptions{StreamThreshold: size + 1}, true, false},
+		{"at threshold", &native.UnserializeOptions{StreamThreshold: size}, true, true},
+		{"not seekable", &native.UnserializeOptions{StreamThreshold: 1}, false, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := bytes.NewReader(data)
+			if tc.seekable {
+				require.Equal(t, tc.expected, streamInput(r, tc.opts))
+				// The position of the reader is kept
+				require.Equal(t, size, int64(r.Len()))
+				return
+			}
+			require.Equal(t, tc.expected, streamInput(bytes.NewBufferString(streamTestDocument), tc.opts))
+		})
+	}
+}
+
+// BenchmarkUnserializeLargeSPDX compares the memory used by the regular and
+// the streaming parsers to read a document with 200k files
+func BenchmarkUnserializeLargeSPDX(b *testing.B) {
+	data := largeSPDXDocument(1000, 200)
+	u := NewSPDX23()
+	for _, bc := range []struct {
+		name string
+		opts *native.UnserializeOptions
+	}{
+		{"full", &native.UnserializeOptions{}},
+		{"stream", &native.UnserializeOptions{Stream: true}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := u.Unserialize(bytes.NewReader(data), bc.opts, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// largeSPDXDocument generates an SPDX 2.3 JSON document with the number of
+// packages specified, each containing filesPerPackage files
+func largeSPDXDocument(packages, filesPerPackage int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"spdxVersion":"SPDX-2.3","dataLicense":"CC0-1.0","SPDXID":"SPDXRef-DOCUMENT",` +
+		`"name":"large","documentNamespace":"https://example.com/large",` +
+		`"creationInfo":{"created":"2023-01-01T00:00:00Z","creators":["Tool: test"]},` +
+		`"documentDescribes":["SPDXRef-Package-0"],"packages":[`)
+	for p := 0; p < packages; p++ {
+		if p > 0 {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, `{"SPDXID":"SPDXRef-Package-%d","name":"package-%d","versionInfo":"1.0.%d",`+
+			`"downloadLocation":"NOASSERTION","filesAnalyzed":true,"licenseConcluded":"MIT",`+
+			`"externalRefs":[{"referenceCategory":"PACKAGE-MANAGER","referenceType":"purl","referenceLocator":"pkg:generic/package-%d@1.0.%d"}]}`,
+			p, p, p, p, p)
+	}
+	buf.WriteString(`],"files":[`)
+	for p := 0; p < packages; p++ {
+		for f := 0; f < filesPerPackage; f++ {
+			if p > 0 || f > 0 {
+				buf.WriteString(",")
+			}
+			fmt.Fprintf(&buf, `{"SPDXID":"SPDXRef-File-%d-%d","fileName":"./package-%d/file-%d.go",`+
+				`"checksums":[{"algorithm":"SHA256","checksumValue":"%064x"}],"licenseConcluded":"MIT"}`,
+				p, f, p, f, p*filesPerPackage+f)
+		}
+	}
+	buf.WriteString(`],"relationships":[`)
+	relationship := func(from, rel, to string) {
+		if buf.Bytes()[buf.Len()-1] != '[' {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, `{"spdxElementId":"SPDXRef-%s","relationshipType":"%s","relatedSpdxElement":"SPDXRef-%s"}`, from, rel, to)
+	}
+	for p := 0; p < packages; p++ {
+		if p > 0 {
+			relationship("Package-0", "DEPENDS_ON", fmt.Sprintf("Package-%d", p))
+		}
+		for f := 0; f < filesPerPackage; f++ {
+			relationship(fmt.Sprintf("Package-%d", p), "CONTAINS", fmt.Sprintf("File-%d-%d", p, f))
+		}
+	}
+	buf.WriteString(`]}`)
+	return buf.Bytes()
+}
@@ -2,11 +2,17 @@ package unserializers
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
+	protospdx "github.com/bom-squad/protobom/pkg/formats/spdx"
+	"github.com/bom-squad/protobom/pkg/native"
 	"github.com/bom-squad/protobom/pkg/sbom"
 	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestSPDXExtRefToProtobomEnum(t *testing.T) {
@@ -53,7 +59,7 @@ func TestSPDXExtRefToProtobomEnum(t *testing.T) {
 		},
 		{
 			spdx.PackageExternalReference{Category: spdx.CategorySecurity, RefType: spdx.SecuritySwid},
-			false, sbom.ExternalReference_SECURITY_SWID, false,
+			true, -1, false,
 		},
 		{
 			spdx.PackageExternalReference{Category: spdx.CategorySecurity, RefType: spdx.SecurityUrl},
@@ -84,6 +90,15 @@ func TestSPDXExtRefToProtobomEnum(t *testing.T) {
 			spdx.PackageExternalReference{Category: spdx.CategoryPersistentId, RefType: "chacha"},
 			false, 0, true,
 		},
+		{
+			spdx.PackageExternalReference{Category: "PACKAGE_MANAGER", RefType: spdx.PackageManagerNpm},
+			false, sbom.ExternalReference_NPM, false,
+		},
+		// Category other
+		{
+			spdx.PackageExternalReference{Category: "OTHER", RefType: "LocationRef-acmeforge"},
+			false, sbom.ExternalReference_OTHER, false,
+		},
 		// TODO(complete this)
 	} {
 		tc := tc
@@ -109,9 +124,606 @@ func TestExtRefTypeToIdentifierType(t *testing.T) {
 		{spdx.SecurityCPE23Type, sbom.SoftwareIdentifierType_CPE23},
 		{spdx.SecurityCPE22Type, sbom.SoftwareIdentifierType_CPE22},
 		{spdx.TypePersistentIdGitoid, sbom.SoftwareIdentifierType_GITOID},
+		{spdx.SecuritySwid, sbom.SoftwareIdentifierType_SWID},
+		{spdx.TypePersistentIdSwh, sbom.SoftwareIdentifierType_SWHID},
 		{"", sbom.SoftwareIdentifierType_UNKNOWN_IDENTIFIER_TYPE},
 	} {
 		identifier := s23.extRefTypeToIdentifierType(tc.sut)
 		require.Equal(t, tc.expected, identifier)
 	}
 }
+
+func TestAddSnippets(t *testing.T) {
+	s23 := NewSPDX23()
+	rng := func(file string, start, end int) common.SnippetRange {
+		return common.SnippetRange{
+			StartPointer: common.SnippetRangePointer{Offset: start, FileSPDXIdentifier: common.ElementID(file)},
+			EndPointer:   common.SnippetRangePointer{Offset: end, FileSPDXIdentifier: common.ElementID(file)},
+		}
+	}
+	for _, tc := range []struct {
+		name     string
+		snippet  spdx.Snippet
+		expected []*sbom.Snippet
+	}{
+		{
+			name: "snippet added to file",
+			snippet: spdx.Snippet{
+				SnippetSPDXIdentifier:         "Snippet-1",
+				SnippetFromFileSPDXIdentifier: "File-1",
+				Ranges:                        []common.SnippetRange{rng("File-1", 10, 20)},
+				SnippetLicenseConcluded:       "MIT",
+				SnippetCopyrightText:          protospdx.NOASSERTION,
+			},
+			expected: []*sbom.Snippet{
+				{
+					Id:               "Snippet-1",
+					Ranges:           []*sbom.SnippetRange{{StartOffset: 10, EndOffset: 20}},
+					LicenseConcluded: "MIT",
+					Copyright:        sbom.NoAssertionValue,
+				},
+			},
+		},
+		{
+			name: "file not in document",
+			snippet: spdx.Snippet{
+				SnippetSPDXIdentifier:         "Snippet-1",
+				SnippetFromFileSPDXIdentifier: "File-2",
+				Ranges:                        []common.SnippetRange{rng("File-2", 10, 20)},
+			},
+		},
+		{
+			name: "range points to another file",
+			snippet: spdx.Snippet{
+				SnippetSPDXIdentifier:         "Snippet-1",
+				SnippetFromFileSPDXIdentifier: "File-1",
+				Ranges:                        []common.SnippetRange{rng("File-2", 10, 20)},
+			},
+		},
+		{
+			name: "snippet points to a package",
+			snippet: spdx.Snippet{
+				SnippetSPDXIdentifier:         "Snippet-1",
+				SnippetFromFileSPDXIdentifier: "Package-1",
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &sbom.NodeList{
+				Nodes: []*sbom.Node{
+					{Id: "File-1", Type: sbom.Node_FILE},
+					{Id: "Package-1", Type: sbom.Node_PACKAGE},
+				},
+			}
+			s23.addSnippets(nl, []spdx.Snippet{tc.snippet})
+			file := nl.GetNodeByID("File-1")
+			require.Len(t, file.Snippets, len(tc.expected))
+			for i := range tc.expected {
+				require.True(t, proto.Equal(tc.expected[i], file.Snippets[i]))
+			}
+			require.Empty(t, nl.GetNodeByID("Package-1").Snippets)
+		})
+	}
+}
+
+func TestUnserializeTagValueSnippets(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: test
+DocumentNamespace: https://example.com/test
+Creator: Tool: test
+Created: 2023-01-01T00:00:00Z
+
+FileName: ./main.c
+SPDXID: SPDXRef-File-1
+FileChecksum: SHA1: 85ed0817af83a24ad8da68c2b5094de69833983c
+LicenseConcluded: MIT
+FileCopyrightText: NOASSERTION
+
+SnippetSPDXID: SPDXRef-Snippet-1
+SnippetFromFileSPDXID: SPDXRef-File-1
+SnippetByteRange: 100:200
+SnippetLineRange: 5:10
+SnippetLicenseConcluded: Apache-2.0
+SnippetCopyrightText: Copyright 2023 Example
+SnippetName: from-linux
+
+SnippetSPDXID: SPDXRef-Snippet-2
+SnippetFromFileSPDXID: SPDXRef-File-2
+SnippetByteRange: 1:2
+SnippetLicenseConcluded: NOASSERTION
+SnippetCopyrightText: NOASSERTION
+`
+	doc, err := NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+
+	file := doc.NodeList.GetNodeByID("File-1")
+	require.NotNil(t, file)
+	require.Len(t, file.Snippets, 1)
+	require.Equal(t, "Snippet-1", file.Snippets[0].Id)
+	require.Equal(t, "from-linux", file.Snippets[0].Name)
+	require.Equal(t, "Apache-2.0", file.Snippets[0].LicenseConcluded)
+	require.Equal(t, "Copyright 2023 Example", file.Snippets[0].Copyright)
+	require.Len(t, file.Snippets[0].Ranges, 2)
+}
+
+func TestUnserializeTagValueAnnotations(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: test
+DocumentNamespace: https://example.com/test
+Creator: Tool: test
+Created: 2023-01-01T00:00:00Z
+
+Annotator: Person: Jane Doe (jane@example.com)
+AnnotationDate: 2023-02-01T00:00:00Z
+AnnotationType: REVIEW
+SPDXREF: SPDXRef-DOCUMENT
+AnnotationComment: Document reviewed
+
+PackageName: test
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+
+Annotator: Tool: scanner-1.0
+AnnotationDate: 2023-03-01T00:00:00Z
+AnnotationType: OTHER
+SPDXREF: SPDXRef-Package-1
+AnnotationComment: Scanned
+
+Annotator: Organization: Acme
+AnnotationDate: 2023-03-01T00:00:00Z
+AnnotationType: OTHER
+SPDXREF: SPDXRef-Package-2
+AnnotationComment: Not in document
+`
+	doc, err := NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+
+	require.Len(t, doc.Metadata.Annotations, 1)
+	require.Equal(t, sbom.Annotation_REVIEW, doc.Metadata.Annotations[0].Type)
+	require.Equal(t, &sbom.Person{Name: "Jane Doe", Email: "jane@example.com"}, doc.Metadata.Annotations[0].Annotator)
+	require.Equal(t, "Document reviewed", doc.Metadata.Annotations[0].Comment)
+	require.Equal(t, int64(1675209600), doc.Metadata.Annotations[0].Date.AsTime().Unix())
+
+	node := doc.NodeList.GetNodeByID("Package-1")
+	require.NotNil(t, node)
+	require.Len(t, node.Annotations, 1)
+	require.Equal(t, sbom.Annotation_OTHER, node.Annotations[0].Type)
+	require.Nil(t, node.Annotations[0].Annotator)
+	require.Equal(t, "scanner", node.Annotations[0].Tool.Name)
+	require.Equal(t, "1.0", node.Annotations[0].Tool.Version)
+}
+
+func TestUnserializeVerificationCode(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: test
+DocumentNamespace: https://example.com/test
+Creator: Tool: test
+Created: 2023-01-01T00:00:00Z
+
+PackageName: test
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: true
+PackageVerificationCode: d6a770ba38583ed4bb4525bd96e50461655d2758 (excludes: ./package.spdx)
+`
+	doc, err := NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+	node := doc.NodeList.GetNodeByID("Package-1")
+	require.NotNil(t, node)
+	require.Equal(t, "d6a770ba38583ed4bb4525bd96e50461655d2758", node.VerificationCode.Value)
+	require.Equal(t, []string{"./package.spdx"}, node.VerificationCode.ExcludedFiles)
+	require.NotNil(t, node.FilesAnalyzed)
+	require.True(t, *node.FilesAnalyzed)
+}
+
+func TestUnserializeFileTypes(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: test
+DocumentNamespace: https://example.com/test
+Creator: Tool: test
+Created: 2023-01-01T00:00:00Z
+
+PackageName: test
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+
+FileName: ./main.c
+SPDXID: SPDXRef-File-1
+FileType: SOURCE
+FileType: TEXT
+FileChecksum: SHA1: 85ed0817af83a24ad8da68c2b5094de69833983c
+
+FileName: ./main
+SPDXID: SPDXRef-File-2
+FileType: binary
+FileChecksum: SHA1: d6a770ba38583ed4bb4525bd96e50461655d2758
+`
+	doc, err := NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+
+	src := doc.NodeList.GetNodeByID("File-1")
+	require.NotNil(t, src)
+	require.Equal(t, []sbom.FileType{sbom.FileTypeSource, sbom.FileTypeText}, src.TypedFileTypes())
+	require.Equal(t, []sbom.Purpose{sbom.Purpose_SOURCE, sbom.Purpose_DATA}, src.PrimaryPurpose)
+	require.Equal(t, "85ed0817af83a24ad8da68c2b5094de69833983c", src.Hashes[int32(sbom.HashAlgorithm_SHA1)])
+
+	bin := doc.NodeList.GetNodeByID("File-2")
+	require.NotNil(t, bin)
+	require.Equal(t, []sbom.FileType{sbom.FileTypeBinary}, bin.TypedFileTypes())
+	require.Equal(t, []string{"BINARY"}, bin.FileTypes)
+	require.Equal(t, []sbom.Purpose{sbom.Purpose_EXECUTABLE}, bin.PrimaryPurpose)
+
+	pkg := doc.NodeList.GetNodeByID("Package-1")
+	require.NotNil(t, pkg)
+	require.Empty(t, pkg.TypedFileTypes())
+	require.NotNil(t, pkg.FilesAnalyzed)
+	require.False(t, *pkg.FilesAnalyzed)
+
+	// Files defined after the package are contained by it
+	require.Len(t, doc.NodeList.Edges, 2)
+	for _, e := range doc.NodeList.Edges {
+		require.Equal(t, "Package-1", e.From)
+		require.Equal(t, sbom.Edge_contains, e.Type)
+	}
+}
+
+func TestSPDXPurposeToProtobom(t *testing.T) {
+	for spdxPurpose, expected := range map[string]sbom.Purpose{
+		"APPLICATION":      sbom.Purpose_APPLICATION,
+		"FRAMEWORK":        sbom.Purpose_FRAMEWORK,
+		"LIBRARY":          sbom.Purpose_LIBRARY,
+		"CONTAINER":        sbom.Purpose_CONTAINER,
+		"OPERATING-SYSTEM": sbom.Purpose_OPERATING_SYSTEM,
+		"DEVICE":           sbom.Purpose_DEVICE,
+		"FIRMWARE":         sbom.Purpose_FIRMWARE,
+		"SOURCE":           sbom.Purpose_SOURCE,
+		"ARCHIVE":          sbom.Purpose_ARCHIVE,
+		"FILE":             sbom.Purpose_FILE,
+		"INSTALL":          sbom.Purpose_INSTALL,
+		"OTHER":            sbom.Purpose_OTHER,
+		"GADGET":           sbom.Purpose_UNKNOWN_PURPOSE,
+	} {
+		require.Equal(t, expected, spdxPurposeToProtobom(spdxPurpose), spdxPurpose)
+	}
+}
+
+func TestUnserializeSupplierOriginator(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: test
+DocumentNamespace: https://example.com/test
+Creator: Tool: test
+Created: 2023-01-01T00:00:00Z
+
+PackageName: one
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PackageSupplier: Organization: Acme Corp (contact@acme.example)
+PackageOriginator: Person: Jane Doe ()
+
+PackageName: two
+SPDXID: SPDXRef-Package-2
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PackageSupplier: NOASSERTION
+PackageOriginator: Person:   Jane Doe   (  jane@acme.example )
+`
+	doc, err := NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+
+	one := doc.NodeList.GetNodeByID("Package-1")
+	require.NotNil(t, one)
+	require.Len(t, one.Suppliers, 1)
+	require.True(t, proto.Equal(&sbom.Person{Name: "Acme Corp", IsOrg: true, Email: "contact@acme.example"}, one.Suppliers[0]))
+	require.Len(t, one.Originators, 1)
+	require.True(t, proto.Equal(&sbom.Person{Name: "Jane Doe"}, one.Originators[0]))
+
+	two := doc.NodeList.GetNodeByID("Package-2")
+	require.NotNil(t, two)
+	require.Empty(t, two.Suppliers)
+	require.Len(t, two.Originators, 1)
+	require.True(t, proto.Equal(&sbom.Person{Name: "Jane Doe", Email: "jane@acme.example"}, two.Originators[0]))
+}
+
+func TestUnserializeLicenses(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: test
+DocumentNamespace: https://example.com/test
+Creator: Tool: test
+Created: 2023-01-01T00:00:00Z
+
+PackageName: one
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PackageLicenseConcluded: (gpl-2.0-only with classpath-exception-2.0)
+
+PackageName: two
+SPDXID: SPDXRef-Package-2
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PackageLicenseConcluded: noassertion
+
+PackageName: three
+SPDXID: SPDXRef-Package-3
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PackageLicenseConcluded: LicenseRef-Proprietary AND Not-A-License
+
+PackageName: four
+SPDXID: SPDXRef-Package-4
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: true
+PackageVerificationCode: d6a770ba38583ed4bb4525bd96e50461655d2758
+PackageLicenseConcluded: MIT
+PackageLicenseDeclared: apache-2.0 or mit
+PackageLicenseInfoFromFiles: mit
+PackageLicenseInfoFromFiles: Apache-2.0
+
+FileName: ./main.go
+SPDXID: SPDXRef-File-1
+FileChecksum: SHA1: 85ed0817af83a24ad8da68c2b5094de69833983c
+LicenseConcluded: MIT
+LicenseInfoInFile: mit
+LicenseInfoInFile: NOASSERTION
+`
+	doc, err := NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "GPL-2.0-only WITH Classpath-exception-2.0", doc.NodeList.GetNodeByID("Package-1").LicenseConcluded)
+	require.Equal(t, sbom.NoAssertionValue, doc.NodeList.GetNodeByID("Package-2").LicenseConcluded)
+	require.Equal(t, "LicenseRef-Proprietary AND Not-A-License", doc.NodeList.GetNodeByID("Package-3").LicenseConcluded)
+
+	// Detected licenses are kept apart from the concluded one
+	four := doc.NodeList.GetNodeByID("Package-4")
+	require.Equal(t, "MIT", four.LicenseConcluded)
+	require.Equal(t, []string{"MIT", "Apache-2.0"}, four.LicensesDetected)
+	require.Equal(t, []string{"Apache-2.0 OR MIT"}, four.Licenses)
+	require.Empty(t, doc.NodeList.GetNodeByID("Package-1").Licenses)
+	file := doc.NodeList.GetNodeByID("File-1")
+	require.Equal(t, "MIT", file.LicenseConcluded)
+	require.Equal(t, []string{"MIT", sbom.NoAssertionValue}, file.LicensesDetected)
+	require.Empty(t, file.Licenses)
+}
+
+func TestUnserializeRootElements(t *testing.T) {
+	doc := func(describes, relationships string) string {
+		return `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "test",
+  "documentNamespace": "https://example.com/test",
+  "creationInfo": {"creators": ["Tool: test"], "created": "2023-01-01T00:00:00Z"},
+  "documentDescribes": [` + describes + `],
+  "packages": [
+    {"name": "one", "SPDXID": "SPDXRef-Package-1", "downloadLocation": "NOASSERTION"},
+    {"name": "two", "SPDXID": "SPDXRef-Package-2", "downloadLocation": "NOASSERTION"},
+    {"name": "three", "SPDXID": "SPDXRef-Package-3", "downloadLocation": "NOASSERTION"}
+  ],
+  "relationships": [` + relationships + `]
+}`
+	}
+	rel := func(a, t, b string) string {
+		return `{"spdxElementId": "` + a + `", "relationshipType": "` + t + `", "relatedSpdxElement": "` + b + `"}`
+	}
+
+	for _, tc := range []struct {
+		name        string
+		input       string
+		opts        *native.UnserializeOptions
+		expected    []string
+		shouldError bool
+	}{
+		{
+			name:     "document describes",
+			input:    doc(`"SPDXRef-Package-3", "SPDXRef-Package-1"`, ""),
+			expected: []string{"Package-3", "Package-1"},
+		},
+		{
+			name: "describes and described by relationships",
+			input: doc("", strings.Join([]string{
+				rel("SPDXRef-DOCUMENT", "DESCRIBES", "SPDXRef-Package-1"),
+				rel("SPDXRef-Package-2", "DESCRIBED_BY", "SPDXRef-DOCUMENT"),
+				rel("SPDXRef-Package-1", "DEPENDS_ON", "SPDXRef-Package-3"),
+			}, ",")),
+			expected: []string{"Package-1", "Package-2"},
+		},
+		{
+			name: "duplicates",
+			input: doc(`"SPDXRef-Package-2"`, strings.Join([]string{
+				rel("SPDXRef-DOCUMENT", "DESCRIBES", "SPDXRef-Package-2"),
+				rel("SPDXRef-Package-2", "DESCRIBED_BY", "SPDXRef-DOCUMENT"),
+				rel("SPDXRef-DOCUMENT", "DESCRIBES", "SPDXRef-Package-1"),
+			}, ",")),
+			expected: []string{"Package-2", "Package-1"},
+		},
+		{
+			name:        "missing root",
+			input:       doc(`"SPDXRef-Package-1", "SPDXRef-Nope"`, ""),
+			shouldError: true,
+		},
+		{
+			name:        "external document root",
+			input:       doc(`"DocumentRef-other:SPDXRef-Package-1"`, ""),
+			shouldError: true,
+		},
+		{
+			name:     "missing root allowed",
+			input:    doc(`"SPDXRef-Package-1", "SPDXRef-Nope"`, ""),
+			opts:     &native.UnserializeOptions{AllowMissingRoots: true},
+			expected: []string{"Package-1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bom, err := NewSPDX23().Unserialize(strings.NewReader(tc.input), tc.opts, nil)
+			if tc.shouldError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, bom.NodeList.RootElements)
+
+			// Root nodes are returned in document order and the
+			// relationships to the document are not read as edges
+			ids := []string{}
+			for _, n := range bom.NodeList.GetRootNodes() {
+				ids = append(ids, n.Id)
+			}
+			expected := slices.Clone(tc.expected)
+			slices.Sort(expected)
+			require.Equal(t, expected, ids)
+			for _, e := range bom.NodeList.Edges {
+				require.NotEqual(t, "DOCUMENT", e.From)
+				require.NotContains(t, e.To, "DOCUMENT")
+			}
+		})
+	}
+}
+
+func TestUnserializeExternalDocuments(t *testing.T) {
+	json := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "test",
+  "documentNamespace": "https://example.com/test",
+  "creationInfo": {"creators": ["Tool: test"], "created": "2023-01-01T00:00:00Z"},
+  "externalDocumentRefs": [{
+    "externalDocumentId": "DocumentRef-other",
+    "spdxDocument": "https://example.com/other",
+    "checksum": {"algorithm": "SHA1", "checksumValue": "d6a770ba38583ed4bb4525bd96e50461655d2759"}
+  }],
+  "packages": [
+    {"name": "one", "SPDXID": "SPDXRef-Package-1", "downloadLocation": "NOASSERTION"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-1"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "DocumentRef-other:SPDXRef-Package-foo"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "DocumentRef-undeclared:SPDXRef-Package-bar"}
+  ]
+}`
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: test
+DocumentNamespace: https://example.com/test
+ExternalDocumentRef: DocumentRef-other https://example.com/other SHA1: d6a770ba38583ed4bb4525bd96e50461655d2759
+Creator: Tool: test
+Created: 2023-01-01T00:00:00Z
+
+PackageName: one
+SPDXID: SPDXRef-Package-1
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-1
+Relationship: SPDXRef-Package-1 DEPENDS_ON DocumentRef-other:SPDXRef-Package-foo
+Relationship: SPDXRef-Package-1 DEPENDS_ON DocumentRef-undeclared:SPDXRef-Package-bar
+`
+	for name, tc := range map[string]struct {
+		unserializer *SPDX23
+		input        string
+	}{
+		"json":      {NewSPDX23(), json},
+		"tag-value": {NewSPDX23TV(), tv},
+	} {
+		t.Run(name, func(t *testing.T) {
+			doc, err := tc.unserializer.Unserialize(strings.NewReader(tc.input), nil, nil)
+			require.NoError(t, err)
+
+			require.Len(t, doc.Metadata.ExternalDocuments, 1)
+			ed := doc.Metadata.ExternalDocuments[0]
+			require.Equal(t, "other", ed.Id)
+			require.Equal(t, "https://example.com/other", ed.Uri)
+			require.Equal(t, map[int32]string{
+				int32(sbom.HashAlgorithm_SHA1): "d6a770ba38583ed4bb4525bd96e50461655d2759",
+			}, ed.Hashes)
+
+			require.Len(t, doc.NodeList.Edges, 2)
+			require.Equal(t, []string{"DocumentRef-other:Package-foo"}, doc.NodeList.Edges[0].To)
+			require.Equal(t, []string{"DocumentRef-undeclared:Package-bar"}, doc.NodeList.Edges[1].To)
+			require.Equal(t, []string{"Package-1"}, doc.NodeList.RootElements)
+
+			// Edges into external documents point to stub nodes
+			stub := doc.NodeList.GetNodeByID("DocumentRef-other:Package-foo")
+			require.NotNil(t, stub)
+			require.Equal(t, "other", stub.ExternalDocument)
+			require.Len(t, stub.ExternalReferences, 1)
+			require.Equal(t, sbom.ExternalReference_BOM, stub.ExternalReferences[0].Type)
+			require.Equal(t, "https://example.com/other", stub.ExternalReferences[0].Url)
+			require.Equal(t, ed.Hashes, stub.ExternalReferences[0].Hashes)
+
+			stub = doc.NodeList.GetNodeByID("DocumentRef-undeclared:Package-bar")
+			require.NotNil(t, stub)
+			require.Equal(t, "undeclared", stub.ExternalDocument)
+			require.Empty(t, stub.ExternalReferences)
+		})
+	}
+}
+
+func TestUnserializeInverseRelationships(t *testing.T) {
+	tv := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: test
+DocumentNamespace: https://example.com/test
+Creator: Tool: test
+Created: 2023-01-01T00:00:00Z
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-app
+
+PackageName: app
+SPDXID: SPDXRef-app
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+
+PackageName: lib
+SPDXID: SPDXRef-lib
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+
+PackageName: util
+SPDXID: SPDXRef-util
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+
+Relationship: SPDXRef-app CONTAINS SPDXRef-lib
+Relationship: SPDXRef-lib CONTAINED_BY SPDXRef-app
+Relationship: SPDXRef-util DEPENDENCY_OF SPDXRef-lib
+`
+	doc, err := NewSPDX23TV().Unserialize(strings.NewReader(tv), nil, nil)
+	require.NoError(t, err)
+
+	// Both statements of the containment are read as one edge
+	contains := []*sbom.Edge{}
+	for _, e := range doc.NodeList.Edges {
+		require.NotEqual(t, sbom.Edge_contained_by, e.Type)
+		require.NotEqual(t, sbom.Edge_dependencyOf, e.Type)
+		if e.Type == sbom.Edge_contains {
+			contains = append(contains, e)
+		}
+	}
+	require.Len(t, contains, 1)
+	require.Equal(t, "app", contains[0].From)
+	require.Equal(t, []string{"lib"}, contains[0].To)
+	require.False(t, contains[0].Inverse)
+
+	// The inverse dependency is turned around and flagged
+	edge := doc.NodeList.GetEdgeByType("lib", sbom.Edge_dependsOn)
+	require.NotNil(t, edge)
+	require.Equal(t, []string{"util"}, edge.To)
+	require.True(t, edge.Inverse)
+}
@@ -0,0 +1,179 @@
+package reader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/bom-squad/protobom/pkg/sbom"
+)
+
+// ArchiveOption configures ParseArchive
+type ArchiveOption func(*archiveOptions)
+
+type archiveOptions struct {
+	patterns []string
+}
+
+// WithArchiveGlob only parses the archive entries whose name matches one of
+// the patterns. Patterns use the syntax of path.Match and are matched
+// against the base name of the entries, for example "*.spdx" or "*.cdx.json".
+func WithArchiveGlob(patterns ...string) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.patterns = append(o.patterns, patterns...)
+	}
+}
+
+// matches returns true if the entry name matches the options patterns
+func (o *archiveOptions) matches(name string) (bool, error) {
+	if len(o.patterns) == 0 {
+		return true, nil
+	}
+	for _, p := range o.patterns {
+		ok, err := path.Match(p, path.Base(name))
+		if err != nil {
+			return false, fmt.Errorf("matching pattern %q: %w", p, err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ParseArchive reads the SBOMs bundled in a zip or tar archive, the latter
+// optionally compressed with gzip. The format of each file in the archive is
+// sniffed and the recognized SBOMs are parsed, other files are skipped.
+//
+// The documents are returned in the order they are found in the archive.
+// Errors parsing a document do not stop reading the archive, the documents
+// that could be parsed are returned along with the errors joined.
+func (r *Reader) ParseArchive(archivePath string, opts ...ArchiveOption) ([]*sbom.Document, error) {
+	o := &archiveOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	defer f.Close()
+
+	docs := []*sbom.Document{}
+	errs := []error{}
+	walk := func(name string, entry io.Reader) error {
+		ok, err := o.matches(name)
+		if err != nil || !ok {
+			return err
+		}
+		data, err := io.ReadAll(entry)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", name, err)
+		}
+		format, err := r.detectFormat(bytes.NewReader(data))
+		if err != nil {
+			return nil
+		}
+		doc, err := r.parse(bytes.NewReader(data), format, r.Options)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("parsing %s: %w", name, err))
+			return nil
+		}
+		docs = append(docs, doc)
+		return nil
+	}
+
+	isZip, err := hasPrefix(f, []byte("PK\x03\x04"))
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+	if isZip {
+		err = walkZip(f, walk)
+	} else {
+		err = walkTar(f, walk)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return docs, errors.Join(errs...)
+}
+
+// hasPrefix checks if the file starts with prefix and rewinds it
+func hasPrefix(f io.ReadSeeker, prefix []byte) (bool, error) {
+	head := make([]byte, len(prefix))
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+	return bytes.Equal(head[:n], prefix), nil
+}
+
+// walkZip calls fn with the name and contents of each file in a zip archive
+func walkZip(f *os.File, fn func(string, io.Reader) error) error {
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("checking archive: %w", err)
+	}
+	zr, err := zip.NewReader(f, info.Size())
+	if err != nil {
+		return fmt.Errorf("opening zip archive: %w", err)
+	}
+	for _, entry := range zr.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", entry.Name, err)
+		}
+		err = fn(entry.Name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkTar calls fn with the name and contents of each regular file in a
+// tar archive, decompressing it first if it is gzipped
+func walkTar(f io.Reader, fn func(string, io.Reader) error) error {
+	br := bufio.NewReader(f)
+	var in io.Reader = br
+	if magic, err := br.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return fmt.Errorf("opening gzip stream: %w", err)
+		}
+		defer gz.Close()
+		in = gz
+	}
+
+	tr := tar.NewReader(in)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading tar archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := fn(hdr.Name, tr); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package reader
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/bom-squad/protobom/pkg/native"
+	"github.com/bom-squad/protobom/pkg/sbom"
+	"google.golang.org/protobuf/proto"
+)
+
+// documentCache is a least recently used cache of parsed documents keyed by
+// the hash of their contents. It stores and returns copies of the documents
+// so the cached ones cannot be modified by the callers.
+type documentCache struct {
+	mtx     sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type cacheEntry struct {
+	key string
+	doc *sbom.Document
+}
+
+func newDocumentCache(size int) *documentCache {
+	return &documentCache{
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// cacheKey returns the key of a document, the unserialize and format
+// options are part of it as they change the parsed document
+func cacheKey(data []byte, format formats.Format, uo *native.UnserializeOptions, formatOptions interface{}) string {
+	h := sha256.New()
+	h.Write(data)
+	fmt.Fprintf(h, "\x00%s\x00", format)
+	if uo != nil {
+		fmt.Fprintf(h, "%+v", *uo)
+	}
+	fmt.Fprintf(h, "\x00%#v", formatOptions)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns a copy of the document cached under key
+func (c *documentCache) get(key string) (*sbom.Document, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return cloneDocument(e.Value.(*cacheEntry).doc), true
+}
+
+// add caches a copy of doc, evicting the least recently used document when
+// the cache is full
+func (c *documentCache) add(key string, doc *sbom.Document) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		e.Value.(*cacheEntry).doc = cloneDocument(doc)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, doc: cloneDocument(doc)})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func cloneDocument(doc *sbom.Document) *sbom.Document {
+	clone := &sbom.Document{NodeList: doc.NodeList.Clone()}
+	if doc.Metadata != nil {
+		clone.Metadata = proto.Clone(doc.Metadata).(*sbom.Metadata)
+	}
+	return clone
+}
@@ -5,14 +5,25 @@ import (
 
 	"github.com/bom-squad/protobom/pkg/formats"
 	"github.com/bom-squad/protobom/pkg/native"
+	"github.com/bom-squad/protobom/pkg/sbom"
 )
 
 type Options struct {
 	Format             formats.Format
 	UnserializeOptions *native.UnserializeOptions
-	formatOptions      map[string]interface{}
+
+	// ExternalDocumentResolver is called to fetch the external documents
+	// referenced by the parsed document to merge them into it
+	ExternalDocumentResolver ExternalDocumentResolver
+
+	formatOptions map[string]interface{}
 }
 
+// ExternalDocumentResolver returns the document referenced by ed. It
+// returns a nil document when the external document is not available,
+// those are left as references in the parsed document.
+type ExternalDocumentResolver func(ed *sbom.ExternalDocument) (*sbom.Document, error)
+
 // argToOptsKeyVal returns a key value to access the options dictionary by using
 // key as a string or its type if its a serializer driver.
 func argToOptsKeyVal(key interface{}) string {
@@ -43,6 +54,24 @@ func (o *Options) SetFormatOptions(key, opts interface{}) {
 	o.formatOptions[keyVal] = opts
 }
 
+// copy returns a copy of the options that can be modified without
+// changing the original
+func (o *Options) copy() *Options {
+	ret := &Options{
+		Format:                   o.Format,
+		ExternalDocumentResolver: o.ExternalDocumentResolver,
+		formatOptions:            map[string]interface{}{},
+	}
+	if o.UnserializeOptions != nil {
+		uo := *o.UnserializeOptions
+		ret.UnserializeOptions = &uo
+	}
+	for k, v := range o.formatOptions {
+		ret.formatOptions[k] = v
+	}
+	return ret
+}
+
 type ReaderOption func(*Reader)
 
 func WithFormatOptions(driverKey string, opts interface{}) ReaderOption {
@@ -66,3 +95,121 @@ func WithSniffer(s Sniffer) ReaderOption {
 		}
 	}
 }
+
+// WithStrict enables the strict mode of the unserializers. In strict mode,
+// parsing fails with an error listing each value of the document that could
+// not be mapped to a known protobom enum, such as an unknown relationship
+// type, purpose or hash algorithm. By default those values are coerced to a
+// default value.
+func WithStrict(strict bool) ReaderOption {
+	return func(r *Reader) {
+		uo := native.UnserializeOptions{}
+		if r.Options.UnserializeOptions != nil {
+			uo = *r.Options.UnserializeOptions
+		}
+		uo.Strict = strict
+		r.Options.UnserializeOptions = &uo
+	}
+}
+
+// WithAllowMissingRoots makes the unserializers drop, with a warning, the
+// root elements of the document that reference undefined elements. By
+// default parsing a document with such roots fails.
+func WithAllowMissingRoots(allow bool) ReaderOption {
+	return func(r *Reader) {
+		uo := native.UnserializeOptions{}
+		if r.Options.UnserializeOptions != nil {
+			uo = *r.Options.UnserializeOptions
+		}
+		uo.AllowMissingRoots = allow
+		r.Options.UnserializeOptions = &uo
+	}
+}
+
+// WithStrictMode makes the unserializers reject documents with fields not
+// recognized by the schema of their format. This is useful to check that
+// an SBOM producer does not emit non-standard fields. By default unknown
+// fields are ignored to tolerate future extensions of the formats.
+func WithStrictMode() ReaderOption {
+	return func(r *Reader) {
+		uo := native.UnserializeOptions{}
+		if r.Options.UnserializeOptions != nil {
+			uo = *r.Options.UnserializeOptions
+		}
+		uo.DisallowUnknownFields = true
+		r.Options.UnserializeOptions = &uo
+	}
+}
+
+// WithStrictIDs makes parsing fail when the identifiers of the document
+// elements are malformed, duplicated or referenced without being defined.
+// The returned error wraps a native.DiagnosticsError locating each problem
+// in the document. By default the problems are logged as warnings and the
+// duplicated identifiers are renamed.
+func WithStrictIDs(strict bool) ReaderOption {
+	return func(r *Reader) {
+		uo := native.UnserializeOptions{}
+		if r.Options.UnserializeOptions != nil {
+			uo = *r.Options.UnserializeOptions
+		}
+		uo.StrictIDs = strict
+		r.Options.UnserializeOptions = &uo
+	}
+}
+
+// WithStreaming makes the reader parse SPDX JSON documents element by
+// element, without loading the whole document in memory. This reduces the
+// memory used to read very large documents, the parsed document is the same.
+func WithStreaming(stream bool) ReaderOption {
+	return func(r *Reader) {
+		uo := native.UnserializeOptions{}
+		if r.Options.UnserializeOptions != nil {
+			uo = *r.Options.UnserializeOptions
+		}
+		uo.Stream = stream
+		r.Options.UnserializeOptions = &uo
+	}
+}
+
+// WithStreamingThreshold enables the streaming parser for the documents of
+// size bytes or more. As the size of the document is checked by seeking the
+// input, documents read with ParseReaderWithFormat from inputs that cannot
+// seek are not streamed.
+func WithStreamingThreshold(size int64) ReaderOption {
+	return func(r *Reader) {
+		uo := native.UnserializeOptions{}
+		if r.Options.UnserializeOptions != nil {
+			uo = *r.Options.UnserializeOptions
+		}
+		uo.StreamThreshold = size
+		r.Options.UnserializeOptions = &uo
+	}
+}
+
+// WithExternalDocumentResolver sets a function to resolve the external
+// documents referenced by the parsed documents. The nodes and edges of the
+// resolved documents are merged into the parsed document, replacing the
+// stub nodes that represent their elements.
+func WithExternalDocumentResolver(resolver ExternalDocumentResolver) ReaderOption {
+	return func(r *Reader) {
+		r.Options.ExternalDocumentResolver = resolver
+	}
+}
+
+// WithCache keeps the last size parsed documents in memory, keyed by the
+// hash of their contents, format, unserialize and format options. Parsing
+// the same data again returns a copy of the cached document without
+// unserializing it. Documents are cached before resolving their external
+// documents, the resolver of the options runs on every parse. The returned
+// documents are deep copies, modifying them does not alter the cached
+// ones. With the cache enabled, the whole input is read into memory so it
+// can be hashed. A size of zero or less disables the cache.
+func WithCache(size int) ReaderOption {
+	return func(r *Reader) {
+		if size <= 0 {
+			r.cache = nil
+			return
+		}
+		r.cache = newDocumentCache(size)
+	}
+}
@@ -6,6 +6,8 @@ package reader
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -31,7 +33,14 @@ func init() {
 	unserializers[formats.CDX13JSON] = drivers.NewCDX("1.3", formats.JSON)
 	unserializers[formats.CDX14JSON] = drivers.NewCDX("1.4", formats.JSON)
 	unserializers[formats.CDX15JSON] = drivers.NewCDX("1.5", formats.JSON)
+	unserializers[formats.CDX10YAML] = drivers.NewCDX("1.0", formats.YAML)
+	unserializers[formats.CDX11YAML] = drivers.NewCDX("1.1", formats.YAML)
+	unserializers[formats.CDX12YAML] = drivers.NewCDX("1.2", formats.YAML)
+	unserializers[formats.CDX13YAML] = drivers.NewCDX("1.3", formats.YAML)
+	unserializers[formats.CDX14YAML] = drivers.NewCDX("1.4", formats.YAML)
+	unserializers[formats.CDX15YAML] = drivers.NewCDX("1.5", formats.YAML)
 	unserializers[formats.SPDX23JSON] = drivers.NewSPDX23()
+	unserializers[formats.SPDX23TV] = drivers.NewSPDX23TV()
 	regMtx.Unlock()
 }
 
@@ -60,6 +69,7 @@ func GetFormatUnserializer(format formats.Format) (native.Unserializer, error) {
 type Reader struct {
 	sniffer Sniffer
 	Options *Options
+	cache   *documentCache
 }
 
 //counterfeiter:generate . Sniffer
@@ -76,7 +86,7 @@ var defaultOptions = &Options{
 func New(opts ...ReaderOption) *Reader {
 	r := &Reader{
 		sniffer: &formats.Sniffer{},
-		Options: defaultOptions,
+		Options: defaultOptions.copy(),
 	}
 
 	for _, opt := range opts {
@@ -86,6 +96,50 @@ func New(opts ...ReaderOption) *Reader {
 	return r
 }
 
+// ErrUnknownFormat is returned by NewAutoReader when the format of the
+// stream cannot be recognized
+var ErrUnknownFormat = errors.New("unrecognized SBOM format")
+
+// ErrUnsupportedFormat is returned by NewAutoReader when the format of the
+// stream is recognized but there is no unserializer registered for it
+var ErrUnsupportedFormat = errors.New("unsupported SBOM format")
+
+// NewAutoReader detects the format of the SBOM in rs and returns a reader
+// set to parse it. The formats supported by default are CycloneDX 1.0 to 1.5
+// in JSON and SPDX 2.3 in JSON and tag-value, plus any format with an
+// unserializer added with RegisterUnserializer that the sniffer recognizes.
+//
+// When the format cannot be recognized, the returned error wraps
+// ErrUnknownFormat, when it is recognized but not supported (for example
+// SPDX 2.2) it wraps ErrUnsupportedFormat. The stream is rewound to its
+// beginning, ready to be parsed with ParseStream:
+//
+//	r, err := reader.NewAutoReader(f)
+//	if err != nil { ... }
+//	doc, err := r.ParseStream(f)
+func NewAutoReader(rs io.ReadSeeker, opts ...ReaderOption) (*Reader, error) {
+	r := New(opts...)
+
+	format, err := r.detectFormat(rs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnknownFormat, err)
+	}
+
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("rewinding stream: %w", err)
+	}
+
+	regMtx.RLock()
+	_, ok := unserializers[format]
+	regMtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+
+	r.Options.Format = format
+	return r, nil
+}
+
 // ParseFile reads a file and returns an sbom.Document
 func (r *Reader) ParseFile(path string) (*sbom.Document, error) {
 	f, err := os.Open(path)
@@ -123,19 +177,78 @@ func (r *Reader) ParseStreamWithOptions(f io.ReadSeeker, o *Options) (*sbom.Docu
 		format = f
 	}
 
+	return r.parse(f, format, o)
+}
+
+// ParseReaderWithFormat parses a document of a known format from a reader.
+// As the format is not detected, the reader does not need to be seekable
+// which allows parsing from streaming sources such as network connections.
+func (r *Reader) ParseReaderWithFormat(f io.Reader, format formats.Format) (*sbom.Document, error) {
+	if format == "" {
+		return nil, fmt.Errorf("format cannot be empty")
+	}
+	return r.parse(f, format, r.Options)
+}
+
+// parse unserializes a document in the specified format from f
+func (r *Reader) parse(f io.Reader, format formats.Format, o *Options) (*sbom.Document, error) {
 	unserializer, err := GetFormatUnserializer(format)
 	if err != nil {
 		return nil, fmt.Errorf("getting format parser: %w", err)
 	}
 
-	doc, err := unserializer.Unserialize(
-		f, o.UnserializeOptions, r.Options.GetFormatOptions(unserializer),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("unserializing: %w", err)
+	formatOptions := r.Options.GetFormatOptions(unserializer)
+
+	// With the cache enabled, the input is read whole to hash it. The cache
+	// holds the documents as unserialized, external documents are resolved
+	// on each parse as the resolver may differ.
+	var doc *sbom.Document
+	key := ""
+	if r.cache != nil {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading SBOM data: %w", err)
+		}
+		key = cacheKey(data, format, o.UnserializeOptions, formatOptions)
+		doc, _ = r.cache.get(key)
+		f = bytes.NewReader(data)
+	}
+
+	if doc == nil {
+		doc, err = unserializer.Unserialize(f, o.UnserializeOptions, formatOptions)
+		if err != nil {
+			return nil, fmt.Errorf("unserializing: %w", err)
+		}
+		if r.cache != nil {
+			r.cache.add(key, doc)
+		}
+	}
+
+	if o.ExternalDocumentResolver != nil {
+		if err := resolveExternalDocuments(doc, o.ExternalDocumentResolver); err != nil {
+			return nil, fmt.Errorf("resolving external documents: %w", err)
+		}
 	}
 
-	return doc, err
+	return doc, nil
+}
+
+// resolveExternalDocuments merges the external documents referenced by doc
+// that the resolver returns
+func resolveExternalDocuments(doc *sbom.Document, resolver ExternalDocumentResolver) error {
+	for _, ed := range doc.GetMetadata().GetExternalDocuments() {
+		ext, err := resolver(ed)
+		if err != nil {
+			return fmt.Errorf("resolving %s: %w", ed.Id, err)
+		}
+		if ext == nil {
+			continue
+		}
+		if err := doc.MergeExternalDocument(ed.Id, ext); err != nil {
+			return fmt.Errorf("merging %s: %w", ed.Id, err)
+		}
+	}
+	return nil
 }
 
 // ParseStreamWithOptions returns a document from a ioreader
@@ -143,6 +256,78 @@ func (r *Reader) ParseStream(f io.ReadSeeker) (*sbom.Document, error) {
 	return r.ParseStreamWithOptions(f, r.Options)
 }
 
+// ParseStreamMulti reads a stream containing multiple concatenated SBOMs and
+// returns all the documents found in it. Two kinds of streams are supported:
+// newline delimited JSON (one SBOM per line) and documents separated by YAML
+// style "---" lines. Streams not matching either are parsed as a single SBOM.
+//
+// Each document is parsed independently. The returned slice has one entry per
+// document in the stream, when a document fails to parse its entry is nil and
+// the error, noting its index, is included in the returned error.
+func (r *Reader) ParseStreamMulti(f io.Reader) ([]*sbom.Document, error) {
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading stream: %w", err)
+	}
+
+	entries := splitStream(data)
+	docs := make([]*sbom.Document, len(entries))
+	errs := []error{}
+	for i, entry := range entries {
+		doc, err := r.ParseStreamWithOptions(bytes.NewReader(entry), r.Options)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("parsing document #%d: %w", i, err))
+			continue
+		}
+		docs[i] = doc
+	}
+	return docs, errors.Join(errs...)
+}
+
+// splitStream splits the data of a multi document stream into the raw
+// data of each document.
+func splitStream(data []byte) [][]byte {
+	lines := bytes.Split(data, []byte("\n"))
+
+	// Check if the stream is newline delimited JSON: all non-empty
+	// lines are JSON objects
+	ndjson := [][]byte{}
+	for _, line := range lines {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if !bytes.HasPrefix(line, []byte("{")) || !bytes.HasSuffix(line, []byte("}")) {
+			ndjson = nil
+			break
+		}
+		ndjson = append(ndjson, line)
+	}
+	if len(ndjson) > 0 {
+		return ndjson
+	}
+
+	// Otherwise, split the documents at the separator lines
+	entries := [][]byte{}
+	current := [][]byte{}
+	flush := func() {
+		entry := bytes.Join(current, []byte("\n"))
+		if len(bytes.TrimSpace(entry)) > 0 {
+			entries = append(entries, entry)
+		}
+		current = [][]byte{}
+	}
+	for _, line := range lines {
+		if string(bytes.TrimSpace(line)) == "---" {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+	return entries
+}
+
 func (r *Reader) detectFormat(rs io.ReadSeeker) (formats.Format, error) {
 	format, err := r.sniffer.SniffReader(rs)
 	if err != nil {
@@ -1,20 +1,27 @@
 package reader_test
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
+	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/bom-squad/protobom/pkg/formats"
 	"github.com/bom-squad/protobom/pkg/native"
 	"github.com/bom-squad/protobom/pkg/native/nativefakes"
+	"github.com/bom-squad/protobom/pkg/native/unserializers"
 	"github.com/bom-squad/protobom/pkg/reader"
 	"github.com/bom-squad/protobom/pkg/reader/readerfakes"
 	"github.com/bom-squad/protobom/pkg/sbom"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 type fakeReadSeeker struct {
@@ -399,3 +406,581 @@ func TestUnserializerRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStreamMulti(t *testing.T) {
+	reader.RegisterUnserializer(formats.CDX14JSON, unserializers.NewCDX("1.4", formats.JSON))
+	cdxDoc := func(name string) string {
+		return fmt.Sprintf(
+			`{"bomFormat":"CycloneDX","specVersion":"1.4","version":1,"metadata":{"component":{"bom-ref":"%s","type":"application","name":"%s"}}}`,
+			name, name,
+		)
+	}
+
+	for _, tc := range []struct {
+		name        string
+		stream      string
+		expected    []string
+		shouldError bool
+	}{
+		{
+			name:     "ndjson",
+			stream:   cdxDoc("one") + "\n" + cdxDoc("two") + "\n" + cdxDoc("three") + "\n",
+			expected: []string{"one", "two", "three"},
+		},
+		{
+			name:        "ndjson with malformed entry",
+			stream:      cdxDoc("one") + "\n" + `{"bomFormat":"CycloneDX","specVersion":"1.4",}` + "\n" + cdxDoc("three"),
+			expected:    []string{"one", "", "three"},
+			shouldError: true,
+		},
+		{
+			name:     "separated documents",
+			stream:   "---\n" + cdxDoc("one") + "\n---\n" + cdxDoc("two") + "\n",
+			expected: []string{"one", "two"},
+		},
+		{
+			name:     "single document",
+			stream:   "{\n" + cdxDoc("one")[1:],
+			expected: []string{"one"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			docs, err := reader.New().ParseStreamMulti(strings.NewReader(tc.stream))
+			if tc.shouldError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, docs, len(tc.expected))
+			for i, name := range tc.expected {
+				if name == "" {
+					require.Nil(t, docs[i])
+					require.ErrorContains(t, err, fmt.Sprintf("document #%d", i))
+					continue
+				}
+				require.NotNil(t, docs[i])
+				require.Equal(t, []string{name}, docs[i].NodeList.RootElements)
+			}
+		})
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "strict",
+  "documentNamespace": "https://example.com/strict",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-Package-1", "name": "one", "downloadLocation": "NOASSERTION", "filesAnalyzed": false},
+    {"SPDXID": "SPDXRef-Package-2", "name": "two", "downloadLocation": "NOASSERTION", "filesAnalyzed": false}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-1"},
+    {"spdxElementId": "SPDXRef-Package-1", "relationshipType": "FROBNICATES", "relatedSpdxElement": "SPDXRef-Package-2"}
+  ]
+}`
+
+	// By default the unknown relationship is read as OTHER
+	doc, err := reader.New().ParseStream(strings.NewReader(spdxDoc))
+	require.NoError(t, err)
+	require.Len(t, doc.NodeList.Edges, 1)
+	require.Equal(t, sbom.Edge_other, doc.NodeList.Edges[0].Type)
+
+	_, err = reader.New(reader.WithStrict(true)).ParseStream(strings.NewReader(spdxDoc))
+	require.Error(t, err)
+	require.ErrorContains(t, err, `Package-1: unknown relationship type "FROBNICATES"`)
+
+	// Strict mode in a reader does not change the defaults
+	_, err = reader.New().ParseStream(strings.NewReader(spdxDoc))
+	require.NoError(t, err)
+}
+
+func TestStrictModeUnknownFields(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	reader.RegisterUnserializer(formats.SPDX23TV, unserializers.NewSPDX23TV())
+	reader.RegisterUnserializer(formats.CDX15JSON, unserializers.NewCDX("1.5", formats.JSON))
+	for _, tc := range []struct {
+		name     string
+		document string
+		unknown  string
+	}{
+		{
+			name: "spdx json",
+			document: `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "unknown",
+  "documentNamespace": "https://example.com/unknown",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-Package-1", "name": "one", "downloadLocation": "NOASSERTION", "filesAnalyzed": false, "vendorExtension": "yes"}
+  ]
+}`,
+			unknown: "vendorExtension",
+		},
+		{
+			name: "cyclonedx json",
+			document: `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "components": [
+    {"type": "library", "bom-ref": "one", "name": "one", "vendorExtension": "yes"}
+  ]
+}`,
+			unknown: "vendorExtension",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Readers tolerate unknown fields by default
+			_, err := reader.New().ParseStream(strings.NewReader(tc.document))
+			require.NoError(t, err)
+
+			_, err = reader.New(reader.WithStrictMode()).ParseStream(strings.NewReader(tc.document))
+			require.Error(t, err)
+			require.ErrorContains(t, err, tc.unknown)
+
+			// Documents without unknown fields parse in strict mode
+			clean := strings.Replace(tc.document, `, "vendorExtension": "yes"`, "", 1)
+			_, err = reader.New(reader.WithStrictMode()).ParseStream(strings.NewReader(clean))
+			require.NoError(t, err)
+		})
+	}
+
+	// Unknown tags are always rejected in tag-value documents
+	tvDoc := "SPDXVersion: SPDX-2.3\nDataLicense: CC0-1.0\nSPDXID: SPDXRef-DOCUMENT\n" +
+		"DocumentName: unknown\nDocumentNamespace: https://example.com/unknown\n" +
+		"Creator: Tool: test\nCreated: 2023-01-01T00:00:00Z\nVendorExtension: yes\n"
+	_, err := reader.New(reader.WithStrictMode()).ParseStream(strings.NewReader(tvDoc))
+	require.Error(t, err)
+}
+
+func TestAllowMissingRoots(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "roots",
+  "documentNamespace": "https://example.com/roots",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "documentDescribes": ["SPDXRef-Package-1", "SPDXRef-Missing"],
+  "packages": [
+    {"SPDXID": "SPDXRef-Package-1", "name": "one", "downloadLocation": "NOASSERTION", "filesAnalyzed": false}
+  ]
+}`
+
+	_, err := reader.New().ParseStream(strings.NewReader(spdxDoc))
+	require.Error(t, err)
+	require.ErrorContains(t, err, "SPDXRef-Missing")
+
+	doc, err := reader.New(reader.WithAllowMissingRoots(true)).ParseStream(strings.NewReader(spdxDoc))
+	require.NoError(t, err)
+	require.Equal(t, []string{"Package-1"}, doc.NodeList.RootElements)
+	require.Len(t, doc.NodeList.GetRootNodes(), 1)
+}
+
+func TestExternalDocumentResolver(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "main",
+  "documentNamespace": "https://example.com/main",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "externalDocumentRefs": [
+    {"externalDocumentId": "DocumentRef-lib", "spdxDocument": "https://example.com/lib", "checksum": {"algorithm": "SHA1", "checksumValue": "d6a770ba38583ed4bb4525bd96e50461655d2759"}},
+    {"externalDocumentId": "DocumentRef-missing", "spdxDocument": "https://example.com/missing", "checksum": {"algorithm": "SHA1", "checksumValue": "d6a770ba38583ed4bb4525bd96e50461655d2759"}}
+  ],
+  "packages": [
+    {"SPDXID": "SPDXRef-Package-app", "name": "app", "downloadLocation": "NOASSERTION", "filesAnalyzed": false}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-app"},
+    {"spdxElementId": "SPDXRef-Package-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "DocumentRef-lib:SPDXRef-Package-lib"},
+    {"spdxElementId": "SPDXRef-Package-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "DocumentRef-missing:SPDXRef-Package-x"}
+  ]
+}`
+	lib := sbom.NewDocument()
+	lib.NodeList.AddRootNode(&sbom.Node{Id: "Package-lib", Name: "lib", Version: "2.0.0"})
+
+	resolved := []string{}
+	resolver := func(ed *sbom.ExternalDocument) (*sbom.Document, error) {
+		resolved = append(resolved, ed.Uri)
+		if ed.Id == "lib" {
+			return lib, nil
+		}
+		return nil, nil
+	}
+
+	doc, err := reader.New(reader.WithExternalDocumentResolver(resolver)).ParseStream(strings.NewReader(spdxDoc))
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://example.com/lib", "https://example.com/missing"}, resolved)
+
+	n := doc.NodeList.GetNodeByID("DocumentRef-lib:Package-lib")
+	require.NotNil(t, n)
+	require.Equal(t, "lib", n.Name)
+	require.Equal(t, "2.0.0", n.Version)
+
+	// Unresolved documents are kept as stubs
+	n = doc.NodeList.GetNodeByID("DocumentRef-missing:Package-x")
+	require.NotNil(t, n)
+	require.Empty(t, n.Name)
+
+	_, err = reader.New(reader.WithExternalDocumentResolver(func(*sbom.ExternalDocument) (*sbom.Document, error) {
+		return nil, errors.New("fetch failed")
+	})).ParseStream(strings.NewReader(spdxDoc))
+	require.Error(t, err)
+}
+
+// nonSeekableReader hides the Seek method of the wrapped reader
+type nonSeekableReader struct {
+	io.Reader
+}
+
+func TestReader_ParseReaderWithFormat(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	spdxDoc := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "stream",
+  "documentNamespace": "https://example.com/stream",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-Package-1", "name": "one", "downloadLocation": "NOASSERTION", "filesAnalyzed": false}
+  ]
+}`
+
+	r := reader.New()
+	doc, err := r.ParseReaderWithFormat(&nonSeekableReader{strings.NewReader(spdxDoc)}, formats.SPDX23JSON)
+	require.NoError(t, err)
+	require.Equal(t, "stream", doc.Metadata.Name)
+	require.NotNil(t, doc.NodeList.GetNodeByID("Package-1"))
+
+	// Streaming source
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := io.Copy(pw, strings.NewReader(spdxDoc))
+		pw.CloseWithError(err)
+	}()
+	doc, err = r.ParseReaderWithFormat(pr, formats.SPDX23JSON)
+	require.NoError(t, err)
+	require.Len(t, doc.NodeList.Nodes, 1)
+
+	_, err = r.ParseReaderWithFormat(strings.NewReader(spdxDoc), "")
+	require.Error(t, err)
+	_, err = r.ParseReaderWithFormat(strings.NewReader(spdxDoc), formats.Format("text/plain"))
+	require.Error(t, err)
+}
+
+func TestNewAutoReader(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23TV, unserializers.NewSPDX23TV())
+	reader.RegisterUnserializer(formats.CDX15JSON, unserializers.NewCDX("1.5", formats.JSON))
+
+	for _, tc := range []struct {
+		name     string
+		data     string
+		format   formats.Format
+		expected error
+	}{
+		{
+			name:   "cyclonedx json",
+			data:   `{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1, "components": [{"bom-ref": "one", "type": "library", "name": "one"}]}`,
+			format: formats.CDX15JSON,
+		},
+		{
+			name: "spdx tag-value",
+			data: "SPDXVersion: SPDX-2.3\nDataLicense: CC0-1.0\nSPDXID: SPDXRef-DOCUMENT\nDocumentName: auto\n" +
+				"DocumentNamespace: https://example.com/auto\nCreator: Tool: test\nCreated: 2023-01-01T00:00:00Z\n\n" +
+				"PackageName: one\nSPDXID: SPDXRef-one\nPackageDownloadLocation: NOASSERTION\nFilesAnalyzed: false\n",
+			format: formats.SPDX23TV,
+		},
+		{
+			name:   "cyclonedx yaml",
+			data:   "bomFormat: CycloneDX\nspecVersion: \"1.5\"\nversion: 1\ncomponents:\n  - bom-ref: one\n    type: library\n    name: one\n",
+			format: formats.CDX15YAML,
+		},
+		{
+			name:   "cyclonedx 1.4 yaml",
+			data:   "bomFormat: CycloneDX\nspecVersion: \"1.4\"\nversion: 1\ncomponents:\n  - bom-ref: one\n    type: library\n    name: one\n",
+			format: formats.CDX14YAML,
+		},
+		{
+			name:     "unknown format",
+			data:     "just some text\n",
+			expected: reader.ErrUnknownFormat,
+		},
+		{
+			name:     "unsupported format",
+			data:     `{"spdxVersion": "SPDX-2.2", "SPDXID": "SPDXRef-DOCUMENT"}`,
+			expected: reader.ErrUnsupportedFormat,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := strings.NewReader(tc.data)
+			r, err := reader.NewAutoReader(rs)
+			if tc.expected != nil {
+				require.ErrorIs(t, err, tc.expected)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.format, r.Options.Format)
+
+			// The stream is ready to be parsed from the start
+			pos, err := rs.Seek(0, io.SeekCurrent)
+			require.NoError(t, err)
+			require.Zero(t, pos)
+
+			doc, err := r.ParseStream(rs)
+			require.NoError(t, err)
+			require.NotNil(t, doc.NodeList.GetNodeByID("one"))
+		})
+	}
+}
+
+func TestStreamingOptions(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	const path = "../../test/conformance/testdata/spdx/2.3/json/curl.spdx.json"
+
+	r := reader.New(reader.WithStreaming(true), reader.WithStrict(true))
+	require.True(t, r.Options.UnserializeOptions.Stream)
+	require.True(t, r.Options.UnserializeOptions.Strict)
+
+	r = reader.New(reader.WithStreamingThreshold(1024))
+	require.Equal(t, int64(1024), r.Options.UnserializeOptions.StreamThreshold)
+
+	expected, err := reader.New().ParseFile(path)
+	require.NoError(t, err)
+	for _, opt := range []reader.ReaderOption{
+		reader.WithStreaming(true), reader.WithStreamingThreshold(1024),
+	} {
+		doc, err := reader.New(opt).ParseFile(path)
+		require.NoError(t, err)
+		require.Equal(t, expected.NodeList.RootElements, doc.NodeList.RootElements)
+		require.Len(t, doc.NodeList.Nodes, len(expected.NodeList.Nodes))
+		require.Len(t, doc.NodeList.Edges, len(expected.NodeList.Edges))
+	}
+}
+
+func TestStrictIDs(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	document := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "duplicates",
+  "documentNamespace": "https://example.com/duplicates",
+  "creationInfo": {"created": "2023-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-Package-1", "name": "one", "downloadLocation": "NOASSERTION", "filesAnalyzed": false},
+    {"SPDXID": "SPDXRef-Package-1", "name": "two", "downloadLocation": "NOASSERTION", "filesAnalyzed": false}
+  ]
+}`
+	doc, err := reader.New().ParseStream(strings.NewReader(document))
+	require.NoError(t, err)
+	require.Equal(t, "two", doc.NodeList.GetNodeByID("Package-1-2").Name)
+
+	_, err = reader.New(reader.WithStrictIDs(true)).ParseStream(strings.NewReader(document))
+	require.Error(t, err)
+	var diagsErr *native.DiagnosticsError
+	require.True(t, errors.As(err, &diagsErr))
+	require.Len(t, diagsErr.Diagnostics, 1)
+	require.Equal(t, "/packages/1/SPDXID", diagsErr.Diagnostics[0].Location)
+}
+
+func TestCache(t *testing.T) {
+	format := formats.Format("application/x-cache-test+json;version=1")
+	fake := &nativefakes.FakeUnserializer{}
+	fake.UnserializeReturns(&sbom.Document{
+		Metadata: &sbom.Metadata{Id: "cached"},
+		NodeList: &sbom.NodeList{Nodes: []*sbom.Node{{Id: "node1", Name: "original"}}},
+	}, nil)
+	reader.RegisterUnserializer(format, fake)
+	defer reader.UnregisterUnserializer(format)
+
+	r := reader.New(reader.WithCache(2))
+	parse := func(data string) *sbom.Document {
+		t.Helper()
+		doc, err := r.ParseReaderWithFormat(strings.NewReader(data), format)
+		require.NoError(t, err)
+		return doc
+	}
+
+	doc1 := parse("document a")
+	doc2 := parse("document a")
+	require.Equal(t, 1, fake.UnserializeCallCount())
+	require.True(t, proto.Equal(doc1, doc2))
+
+	// Cached documents are independent copies
+	require.NotSame(t, doc1, doc2)
+	doc1.NodeList.Nodes[0].Name = "modified"
+	doc2.Metadata.Id = "modified"
+	doc3 := parse("document a")
+	require.Equal(t, 1, fake.UnserializeCallCount())
+	require.Equal(t, "original", doc3.NodeList.Nodes[0].Name)
+	require.Equal(t, "cached", doc3.Metadata.Id)
+
+	// Different data is parsed and the least recently used entry evicted
+	parse("document b")
+	parse("document c")
+	require.Equal(t, 3, fake.UnserializeCallCount())
+	parse("document c")
+	parse("document a")
+	require.Equal(t, 4, fake.UnserializeCallCount())
+
+	// Different options are cached separately
+	r.Options.UnserializeOptions = &native.UnserializeOptions{Strict: true}
+	parse("document a")
+	require.Equal(t, 5, fake.UnserializeCallCount())
+
+	// And so are different format options
+	r.Options.SetFormatOptions(fake, &struct{ Mode string }{"fast"})
+	parse("document a")
+	parse("document a")
+	require.Equal(t, 6, fake.UnserializeCallCount())
+	r.Options.SetFormatOptions(fake, &struct{ Mode string }{"slow"})
+	parse("document a")
+	require.Equal(t, 7, fake.UnserializeCallCount())
+
+	// Without a cache every parse unserializes the document
+	r = reader.New(reader.WithCache(0))
+	parse("document a")
+	parse("document a")
+	require.Equal(t, 9, fake.UnserializeCallCount())
+}
+
+func TestCacheExternalDocumentResolver(t *testing.T) {
+	format := formats.Format("application/x-cache-resolver-test+json;version=1")
+	fake := &nativefakes.FakeUnserializer{}
+	fake.UnserializeStub = func(io.Reader, *native.UnserializeOptions, interface{}) (*sbom.Document, error) {
+		doc := sbom.NewDocument()
+		doc.Metadata.ExternalDocuments = []*sbom.ExternalDocument{{Id: "lib", Uri: "https://example.com/lib"}}
+		doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app"})
+		return doc, nil
+	}
+	reader.RegisterUnserializer(format, fake)
+	defer reader.UnregisterUnserializer(format)
+
+	resolver := func(version string) reader.ExternalDocumentResolver {
+		return func(*sbom.ExternalDocument) (*sbom.Document, error) {
+			lib := sbom.NewDocument()
+			lib.NodeList.AddRootNode(&sbom.Node{Id: "Package-lib", Name: "lib", Version: version})
+			return lib, nil
+		}
+	}
+
+	r := reader.New(reader.WithCache(2))
+	parse := func(o *reader.Options) *sbom.Document {
+		t.Helper()
+		doc, err := r.ParseStreamWithOptions(strings.NewReader("document"), o)
+		require.NoError(t, err)
+		return doc
+	}
+
+	doc := parse(&reader.Options{Format: format, ExternalDocumentResolver: resolver("1.0.0")})
+	require.Equal(t, "1.0.0", doc.NodeList.GetNodeByID("DocumentRef-lib:Package-lib").Version)
+
+	// The cached document is not the merged one
+	doc = parse(&reader.Options{Format: format})
+	require.Equal(t, 1, fake.UnserializeCallCount())
+	require.Nil(t, doc.NodeList.GetNodeByID("DocumentRef-lib:Package-lib"))
+
+	// Each parse resolves the documents with its own resolver
+	doc = parse(&reader.Options{Format: format, ExternalDocumentResolver: resolver("2.0.0")})
+	require.Equal(t, 1, fake.UnserializeCallCount())
+	require.Equal(t, "2.0.0", doc.NodeList.GetNodeByID("DocumentRef-lib:Package-lib").Version)
+}
+
+func TestParseArchive(t *testing.T) {
+	files := []struct {
+		name string
+		data string
+	}{
+		{"sboms/app.cdx.json", `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"metadata":{"component":{"bom-ref":"app","type":"application","name":"app"}}}`},
+		{"sboms/lib.spdx", "SPDXVersion: SPDX-2.3\nDataLicense: CC0-1.0\nSPDXID: SPDXRef-DOCUMENT\n" +
+			"DocumentName: lib\nDocumentNamespace: https://example.com/lib\nCreator: Tool: test\n" +
+			"Created: 2023-01-01T00:00:00Z\n\nPackageName: lib\nSPDXID: SPDXRef-Package-lib\n" +
+			"PackageDownloadLocation: NOASSERTION\nFilesAnalyzed: false\n\n" +
+			"Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-lib\n"},
+		{"README.md", "# Release artifacts\n"},
+	}
+
+	dir := t.TempDir()
+	writeZip := func(path string) {
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		defer f.Close()
+		zw := zip.NewWriter(f)
+		for _, file := range files {
+			w, err := zw.Create(file.name)
+			require.NoError(t, err)
+			_, err = w.Write([]byte(file.data))
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+	}
+	writeTar := func(path string, compress bool) {
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		defer f.Close()
+		var w io.Writer = f
+		if compress {
+			gz := gzip.NewWriter(f)
+			defer gz.Close()
+			w = gz
+		}
+		tw := tar.NewWriter(w)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "sboms/", Typeflag: tar.TypeDir, Mode: 0o755}))
+		for _, file := range files {
+			require.NoError(t, tw.WriteHeader(&tar.Header{
+				Name: file.name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(file.data)),
+			}))
+			_, err := tw.Write([]byte(file.data))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+	}
+	writeZip(filepath.Join(dir, "sboms.zip"))
+	writeTar(filepath.Join(dir, "sboms.tar"), false)
+	writeTar(filepath.Join(dir, "sboms.tar.gz"), true)
+
+	for _, archive := range []string{"sboms.zip", "sboms.tar", "sboms.tar.gz"} {
+		t.Run(archive, func(t *testing.T) {
+			path := filepath.Join(dir, archive)
+			docs, err := reader.New().ParseArchive(path)
+			require.NoError(t, err)
+			require.Len(t, docs, 2)
+			require.Equal(t, []string{"app"}, docs[0].NodeList.RootElements)
+			require.Equal(t, []string{"Package-lib"}, docs[1].NodeList.RootElements)
+
+			docs, err = reader.New().ParseArchive(path, reader.WithArchiveGlob("*.spdx"))
+			require.NoError(t, err)
+			require.Len(t, docs, 1)
+			require.Equal(t, "lib", docs[0].Metadata.Name)
+
+			docs, err = reader.New().ParseArchive(path, reader.WithArchiveGlob("*.txt"))
+			require.NoError(t, err)
+			require.Empty(t, docs)
+
+			_, err = reader.New().ParseArchive(path, reader.WithArchiveGlob("[]"))
+			require.Error(t, err)
+		})
+	}
+
+	_, err := reader.New().ParseArchive(filepath.Join(dir, "missing.zip"))
+	require.Error(t, err)
+
+	// Files that are not archives fail
+	notArchive := filepath.Join(dir, "sbom.json")
+	require.NoError(t, os.WriteFile(notArchive, []byte(files[0].data), 0o600))
+	_, err = reader.New().ParseArchive(notArchive)
+	require.Error(t, err)
+}
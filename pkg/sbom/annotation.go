@@ -0,0 +1,43 @@
+package sbom
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// flatString returns a deterministic string that can be used to hash the annotation
+func (a *Annotation) flatString() string {
+	ret := fmt.Sprintf("(t)%s", a.Type.String())
+	if a.Annotator != nil {
+		ret += fmt.Sprintf("(a)%s", a.Annotator.flatString())
+	}
+	if a.Tool != nil {
+		ret += fmt.Sprintf("(tl)%s:%s:%s", a.Tool.Name, a.Tool.Version, a.Tool.Vendor)
+	}
+	if a.Date != nil {
+		ret += fmt.Sprintf("(d)%d", a.Date.AsTime().Unix())
+	}
+	if a.Comment != "" {
+		ret += fmt.Sprintf("(c)%s", a.Comment)
+	}
+	return ret
+}
+
+// Copy returns an exact copy of Annotation a.
+func (a *Annotation) Copy() *Annotation {
+	na := &Annotation{
+		Type:    a.Type,
+		Comment: a.Comment,
+	}
+	if a.Annotator != nil {
+		na.Annotator = a.Annotator.Copy()
+	}
+	if a.Tool != nil {
+		na.Tool = &Tool{Name: a.Tool.Name, Version: a.Tool.Version, Vendor: a.Tool.Vendor}
+	}
+	if a.Date != nil {
+		na.Date = timestamppb.New(a.Date.AsTime())
+	}
+	return na
+}
@@ -0,0 +1,100 @@
+package sbom
+
+import "slices"
+
+// DeduplicateByPURL collapses the nodes that share the same package URL into
+// the first one found. The data of the duplicates is merged into it using
+// Node.MergeWith and, as the first occurrence wins when values differ, no
+// conflicts are raised. Edges and root elements pointing to the removed nodes
+// are updated to point to the surviving node. It returns the number of nodes
+// removed from the NodeList.
+func (nl *NodeList) DeduplicateByPURL() (removed int) {
+	return nl.deduplicate(func(n *Node) string {
+		return string(n.Purl())
+	})
+}
+
+// DeduplicateByCPE collapses the nodes that share the same CPE into the first
+// one found, just as DeduplicateByPURL does. Nodes are compared by their
+// CPE 2.3 identifier, or by their CPE 2.2 one when they have no 2.3 CPE. It
+// returns the number of nodes removed from the NodeList.
+func (nl *NodeList) DeduplicateByCPE() (removed int) {
+	return nl.deduplicate(func(n *Node) string {
+		if cpe := n.Identifiers[int32(SoftwareIdentifierType_CPE23)]; cpe != "" {
+			return cpe
+		}
+		return n.Identifiers[int32(SoftwareIdentifierType_CPE22)]
+	})
+}
+
+// deduplicate merges the nodes with the same key into the first of them and
+// rewires the graph to the surviving nodes. Nodes with a blank key are never
+// merged.
+func (nl *NodeList) deduplicate(key func(*Node) string) int {
+	survivors := map[string]int{}
+	renamed := map[string]string{}
+	nodes := []*Node{}
+	for _, n := range nl.Nodes {
+		k := key(n)
+		if k == "" {
+			nodes = append(nodes, n)
+			continue
+		}
+		i, ok := survivors[k]
+		if !ok {
+			survivors[k] = len(nodes)
+			nodes = append(nodes, n)
+			continue
+		}
+
+		// Conflicts are resolved in favor of the survivor so MergeWith
+		// does not return an error here
+		merged, err := nodes[i].MergeWith(n, WithConflictResolution(ConflictPreferReceiver))
+		if err != nil {
+			continue
+		}
+		nodes[i] = merged
+		renamed[n.Id] = merged.Id
+	}
+
+	if len(renamed) == 0 {
+		return 0
+	}
+
+	nl.Nodes = nodes
+
+	rename := func(id string) string {
+		if to, ok := renamed[id]; ok {
+			return to
+		}
+		return id
+	}
+
+	for _, e := range nl.Edges {
+		e.From = rename(e.From)
+		tos := []string{}
+		for _, id := range e.To {
+			id = rename(id)
+			// Drop the edges between duplicates of the same node
+			if id == e.From || slices.Contains(tos, id) {
+				continue
+			}
+			tos = append(tos, id)
+		}
+		e.To = tos
+	}
+
+	roots := []string{}
+	for _, id := range nl.RootElements {
+		id = rename(id)
+		if !slices.Contains(roots, id) {
+			roots = append(roots, id)
+		}
+	}
+	nl.RootElements = roots
+
+	// Consolidate the edges of the merged nodes
+	nl.cleanEdges()
+
+	return len(renamed)
+}
@@ -0,0 +1,86 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplicateByPURL(t *testing.T) {
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+	}
+	nl := &NodeList{
+		Nodes: []*Node{
+			{Id: "app", Name: "app"},
+			{Id: "syft-lib", Name: "lib", Version: "1.0.0", Identifiers: purl("pkg:generic/lib@1.0.0")},
+			{Id: "syft-dep", Name: "dep", Identifiers: purl("pkg:generic/dep@2.0.0")},
+			{Id: "trivy-lib", Name: "lib", Version: "1.0", Licenses: []string{"MIT"}, Identifiers: purl("pkg:generic/lib@1.0.0")},
+			{Id: "trivy-dep", Name: "dep", Identifiers: purl("pkg:generic/dep@2.0.0")},
+			{Id: "other-lib", Name: "lib", Identifiers: purl("pkg:generic/lib@1.0.0")},
+			{Id: "file", Type: Node_FILE, Name: "lib.go"},
+		},
+		Edges: []*Edge{
+			{Type: Edge_dependsOn, From: "app", To: []string{"syft-lib", "trivy-lib"}},
+			{Type: Edge_dependsOn, From: "syft-lib", To: []string{"syft-dep"}},
+			{Type: Edge_dependsOn, From: "trivy-lib", To: []string{"trivy-dep", "other-lib"}},
+			{Type: Edge_contains, From: "trivy-lib", To: []string{"file"}},
+		},
+		RootElements: []string{"app", "trivy-lib"},
+	}
+
+	require.Equal(t, 3, nl.DeduplicateByPURL())
+
+	require.Len(t, nl.Nodes, 4)
+	lib := nl.GetNodeByID("syft-lib")
+	require.NotNil(t, lib)
+	// The first occurrence wins conflicts, the rest of the data is merged
+	require.Equal(t, "1.0.0", lib.Version)
+	require.Equal(t, []string{"MIT"}, lib.Licenses)
+	require.NotNil(t, nl.GetNodeByID("syft-dep"))
+	for _, id := range []string{"trivy-lib", "trivy-dep", "other-lib"} {
+		require.Nil(t, nl.GetNodeByID(id), id)
+	}
+
+	require.Equal(t, []string{"app", "syft-lib"}, nl.RootElements)
+	require.Equal(t, []string{"syft-lib"}, nl.GetEdgeByType("app", Edge_dependsOn).To)
+	require.Equal(t, []string{"syft-dep"}, nl.GetEdgeByType("syft-lib", Edge_dependsOn).To)
+	require.Equal(t, []string{"file"}, nl.GetEdgeByType("syft-lib", Edge_contains).To)
+	require.Len(t, nl.Edges, 3)
+
+	// Running it again finds nothing to remove
+	require.Equal(t, 0, nl.DeduplicateByPURL())
+	require.Len(t, nl.Nodes, 4)
+}
+
+func TestDeduplicateByCPE(t *testing.T) {
+	nl := &NodeList{
+		Nodes: []*Node{
+			{Id: "a", Name: "openssl", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_CPE23): "cpe:2.3:a:openssl:openssl:3.0.0:*:*:*:*:*:*:*",
+			}},
+			{Id: "b", Name: "openssl", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_CPE23): "cpe:2.3:a:openssl:openssl:3.0.0:*:*:*:*:*:*:*",
+				int32(SoftwareIdentifierType_PURL):  "pkg:generic/openssl@3.0.0",
+			}},
+			{Id: "c", Name: "zlib", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_CPE22): "cpe:/a:zlib:zlib:1.3",
+			}},
+			{Id: "d", Name: "zlib", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_CPE22): "cpe:/a:zlib:zlib:1.3",
+			}},
+			{Id: "e", Name: "no cpe"},
+			{Id: "f", Name: "no cpe"},
+		},
+		Edges: []*Edge{
+			{Type: Edge_dependsOn, From: "b", To: []string{"d", "e"}},
+		},
+		RootElements: []string{"b"},
+	}
+
+	require.Equal(t, 2, nl.DeduplicateByCPE())
+	require.Len(t, nl.Nodes, 4)
+	require.Equal(t, "pkg:generic/openssl@3.0.0", string(nl.GetNodeByID("a").Purl()))
+	require.Equal(t, []string{"a"}, nl.RootElements)
+	require.ElementsMatch(t, []string{"c", "e"}, nl.GetEdgeByType("a", Edge_dependsOn).To)
+}
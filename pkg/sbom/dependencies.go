@@ -0,0 +1,381 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrNoPath is returned by ShortestPath when the nodes are not connected by
+// dependency relationships
+var ErrNoPath = errors.New("no dependency path between nodes")
+
+// CyclicDependencyError is returned when traversing the dependency graph
+// finds a cycle. Path lists the IDs of the nodes in the cycle, starting and
+// ending with the same node.
+type CyclicDependencyError struct {
+	Path []string
+}
+
+func (e *CyclicDependencyError) Error() string {
+	return fmt.Sprintf("cyclic dependency: %s", strings.Join(e.Path, " -> "))
+}
+
+// dependencyGraph is an adjacency list of node IDs keyed by the ID of
+// the dependent node.
+type dependencyGraph map[string][]string
+
+// indexDependencies returns the dependency graph of the NodeList. Edges of
+// type dependsOn point from the dependent node to its dependencies while
+// dependencyOf edges point the other way around. If reverse is true, the
+// graph points from the dependencies to their dependents.
+func (nl *NodeList) indexDependencies(reverse bool) dependencyGraph {
+	graph := dependencyGraph{}
+	add := func(from, to string) {
+		if reverse {
+			from, to = to, from
+		}
+		if !slices.Contains(graph[from], to) {
+			graph[from] = append(graph[from], to)
+		}
+	}
+	for _, e := range nl.Edges {
+		for _, to := range e.To {
+			switch e.Type {
+			case Edge_dependsOn:
+				add(e.From, to)
+			case Edge_dependencyOf:
+				add(to, e.From)
+			}
+		}
+	}
+	return graph
+}
+
+// walk traverses the graph depth first starting at id. It returns the IDs
+// of all the nodes reachable from id, in the order they were found, and the
+// path of the first cycle encountered. Cycles are broken by not visiting a
+// node twice.
+func (g dependencyGraph) walk(id string) (found, cycle []string) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := map[string]int{}
+	stack := []string{}
+
+	var visit func(string)
+	visit = func(current string) {
+		state[current] = visiting
+		stack = append(stack, current)
+		for _, next := range g[current] {
+			if state[next] == visiting && cycle == nil {
+				i := slices.Index(stack, next)
+				cycle = append(slices.Clone(stack[i:]), next)
+			}
+			if state[next] != 0 {
+				continue
+			}
+			found = append(found, next)
+			visit(next)
+		}
+		stack = stack[:len(stack)-1]
+		state[current] = visited
+	}
+	visit(id)
+	return found, cycle
+}
+
+// traverseDependencies walks the dependency graph from nodeID and returns
+// the nodes found. Related IDs that are not in the document are skipped.
+func (d *Document) traverseDependencies(nodeID string, reverse bool) ([]*Node, error) {
+	if d.NodeList == nil {
+		return nil, fmt.Errorf("node %q not found in document", nodeID)
+	}
+	index := d.NodeList.indexNodes()
+	if _, ok := index[nodeID]; !ok {
+		return nil, fmt.Errorf("node %q not found in document", nodeID)
+	}
+
+	ids, cycle := d.NodeList.indexDependencies(reverse).walk(nodeID)
+	nodes := []*Node{}
+	for _, id := range ids {
+		if id == nodeID {
+			continue
+		}
+		if n, ok := index[id]; ok {
+			nodes = append(nodes, n)
+		}
+	}
+
+	if cycle != nil {
+		return nodes, &CyclicDependencyError{Path: cycle}
+	}
+	return nodes, nil
+}
+
+// TransitiveDependencies returns all the nodes that the node identified by
+// nodeID depends on, directly or transitively, following the dependsOn and
+// dependencyOf relationships of the document. Nodes are returned in the
+// order they are found.
+//
+// If the graph has a cycle, the traversal breaks it and returns the nodes
+// found along with a *CyclicDependencyError describing the cycle.
+func (d *Document) TransitiveDependencies(nodeID string) ([]*Node, error) {
+	return d.traverseDependencies(nodeID, false)
+}
+
+// Ancestors returns all the nodes that depend, directly or transitively, on
+// the node identified by nodeID. It is the inverse of TransitiveDependencies
+// and can be used to find the components impacted by a vulnerable
+// dependency. The result is sorted by node name.
+//
+// If the graph has a cycle, the traversal breaks it and returns the nodes
+// found along with a *CyclicDependencyError describing the cycle.
+func (d *Document) Ancestors(nodeID string) ([]*Node, error) {
+	nodes, err := d.traverseDependencies(nodeID, true)
+	slices.SortStableFunc(nodes, func(a, b *Node) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+	return nodes, err
+}
+
+// path returns the shortest path from one node to another using a breadth
+// first search. Only the nodes in the index are traversed. Returns nil if to
+// cannot be reached from from.
+func (g dependencyGraph) path(from, to string, index nodeIndex) []string {
+	if from == to {
+		return []string{from}
+	}
+	parents := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range g[current] {
+			if _, ok := parents[next]; ok {
+				continue
+			}
+			if _, ok := index[next]; !ok {
+				continue
+			}
+			parents[next] = current
+			if next != to {
+				queue = append(queue, next)
+				continue
+			}
+
+			path := []string{to}
+			for id := current; id != ""; id = parents[id] {
+				path = append(path, id)
+			}
+			slices.Reverse(path)
+			return path
+		}
+	}
+	return nil
+}
+
+// ShortestPath returns the shortest chain of dependencies from the node
+// fromID to the node toID, following the dependsOn and dependencyOf
+// relationships of the document. The returned slice starts with the from
+// node and ends with the to node. If there is no path, ErrNoPath is returned.
+func (d *Document) ShortestPath(fromID, toID string) ([]*Node, error) {
+	if d.NodeList == nil {
+		return nil, fmt.Errorf("node %q not found in document", fromID)
+	}
+	index := d.NodeList.indexNodes()
+	for _, id := range []string{fromID, toID} {
+		if _, ok := index[id]; !ok {
+			return nil, fmt.Errorf("node %q not found in document", id)
+		}
+	}
+
+	ids := d.NodeList.indexDependencies(false).path(fromID, toID, index)
+	if ids == nil {
+		return nil, fmt.Errorf("%w: %s to %s", ErrNoPath, fromID, toID)
+	}
+
+	nodes := make([]*Node, 0, len(ids))
+	for _, id := range ids {
+		nodes = append(nodes, index[id])
+	}
+	return nodes, nil
+}
+
+// DependencyDepths returns the depth of every node of the NodeList in the
+// dependency graph: the length of the shortest chain of dependsOn or
+// dependencyOf relationships from any of the nodes in rootIDs. The roots
+// have depth 0 and nodes that cannot be reached from them are reported as
+// -1. If rootIDs is empty, the root elements of the NodeList are used.
+// Cycles in the graph are traversed only once.
+func (nl *NodeList) DependencyDepths(rootIDs []string) map[string]int {
+	index := nl.indexNodes()
+	depths := make(map[string]int, len(nl.Nodes))
+	for _, n := range nl.Nodes {
+		depths[n.Id] = -1
+	}
+
+	if len(rootIDs) == 0 {
+		rootIDs = nl.RootElements
+	}
+
+	// Breadth first search from all the roots at once so each node is
+	// first reached through its shortest chain
+	queue := []string{}
+	for _, id := range rootIDs {
+		if _, ok := index[id]; !ok || depths[id] == 0 {
+			continue
+		}
+		depths[id] = 0
+		queue = append(queue, id)
+	}
+
+	graph := nl.indexDependencies(false)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range graph[current] {
+			if _, ok := index[next]; !ok || depths[next] != -1 {
+				continue
+			}
+			depths[next] = depths[current] + 1
+			queue = append(queue, next)
+		}
+	}
+	return depths
+}
+
+// components returns the strongly connected components of the graph that
+// contain a cycle, using Tarjan's algorithm. Only the nodes in the index are
+// considered and they are visited in the order of ids so the result is
+// deterministic.
+func (g dependencyGraph) components(ids []string, index nodeIndex) [][]string {
+	counter := 0
+	order := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	sccs := [][]string{}
+
+	var connect func(string)
+	connect = func(id string) {
+		order[id] = counter
+		lowlink[id] = counter
+		counter++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		selfLoop := false
+		for _, next := range g[id] {
+			if _, ok := index[next]; !ok {
+				continue
+			}
+			if next == id {
+				selfLoop = true
+			}
+			if _, seen := order[next]; !seen {
+				connect(next)
+				lowlink[id] = min(lowlink[id], lowlink[next])
+			} else if onStack[next] {
+				lowlink[id] = min(lowlink[id], order[next])
+			}
+		}
+
+		if lowlink[id] != order[id] {
+			return
+		}
+		i := slices.Index(stack, id)
+		scc := slices.Clone(stack[i:])
+		for _, member := range scc {
+			onStack[member] = false
+		}
+		stack = stack[:i]
+		if len(scc) > 1 || selfLoop {
+			sccs = append(sccs, scc)
+		}
+	}
+
+	for _, id := range ids {
+		if _, seen := order[id]; !seen {
+			connect(id)
+		}
+	}
+	return sccs
+}
+
+// cycle returns the shortest cycle through start that only visits the nodes
+// in the component. The start node is not repeated at the end.
+func (g dependencyGraph) cycle(start string, component []string) []string {
+	members := map[string]struct{}{}
+	for _, id := range component {
+		members[id] = struct{}{}
+	}
+
+	parents := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range g[current] {
+			if _, ok := members[next]; !ok {
+				continue
+			}
+			if next == start {
+				path := []string{}
+				for id := current; id != start; id = parents[id] {
+					path = append(path, id)
+				}
+				path = append(path, start)
+				slices.Reverse(path)
+				return path
+			}
+			if _, ok := parents[next]; ok {
+				continue
+			}
+			parents[next] = current
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// DetectCycles finds the cycles in the dependency graph formed by the
+// dependsOn and dependencyOf relationships of the document. Each cycle is
+// returned as an ordered list of node IDs where every node depends on the
+// next one and the last node depends on the first.
+//
+// One cycle is reported for each strongly connected component of the graph,
+// starting at the node with the lowest ID of the component. Relationships
+// to nodes that are not in the document are ignored. When cycles are found,
+// the error joins a *CyclicDependencyError for each of them.
+func (d *Document) DetectCycles() ([][]string, error) {
+	if d.NodeList == nil {
+		return nil, nil
+	}
+
+	index := d.NodeList.indexNodes()
+	ids := make([]string, 0, len(d.NodeList.Nodes))
+	for _, n := range d.NodeList.Nodes {
+		ids = append(ids, n.Id)
+	}
+
+	graph := d.NodeList.indexDependencies(false)
+	cycles := [][]string{}
+	for _, component := range graph.components(ids, index) {
+		cycles = append(cycles, graph.cycle(slices.Min(component), component))
+	}
+	slices.SortFunc(cycles, func(a, b []string) int {
+		return strings.Compare(a[0], b[0])
+	})
+
+	errs := []error{}
+	for _, c := range cycles {
+		errs = append(errs, &CyclicDependencyError{Path: append(slices.Clone(c), c[0])})
+	}
+	return cycles, errors.Join(errs...)
+}
@@ -0,0 +1,473 @@
+package sbom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testDependencyDocument(edges ...*Edge) *Document {
+	doc := NewDocument()
+	for _, id := range []string{"app", "lib1", "lib2", "lib3", "lib4"} {
+		doc.NodeList.AddNode(&Node{Id: id, Name: id})
+	}
+	doc.NodeList.Edges = edges
+	return doc
+}
+
+func nodeIDs(nodes []*Node) []string {
+	ret := []string{}
+	for _, n := range nodes {
+		ret = append(ret, n.Id)
+	}
+	return ret
+}
+
+func TestTransitiveDependencies(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		doc           *Document
+		nodeID        string
+		expected      []string
+		expectedCycle []string
+		mustErr       bool
+	}{
+		{
+			name: "chain",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2", "lib3"}},
+			),
+			nodeID:   "app",
+			expected: []string{"lib1", "lib2", "lib3"},
+		},
+		{
+			name: "dependencyOf",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependencyOf, From: "lib2", To: []string{"lib1"}},
+			),
+			nodeID:   "app",
+			expected: []string{"lib1", "lib2"},
+		},
+		{
+			name: "other edges ignored",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_contains, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib2"}},
+			),
+			nodeID:   "app",
+			expected: []string{"lib2"},
+		},
+		{
+			name: "diamond",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1", "lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib3"}},
+				&Edge{Type: Edge_dependsOn, From: "lib2", To: []string{"lib3"}},
+			),
+			nodeID:   "app",
+			expected: []string{"lib1", "lib3", "lib2"},
+		},
+		{
+			name: "dangling edge",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"missing", "lib1"}},
+			),
+			nodeID:   "app",
+			expected: []string{"lib1"},
+		},
+		{
+			name:     "no dependencies",
+			doc:      testDependencyDocument(),
+			nodeID:   "lib4",
+			expected: []string{},
+		},
+		{
+			name: "cycle",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "lib2", To: []string{"lib3", "lib1"}},
+			),
+			nodeID:        "app",
+			expected:      []string{"lib1", "lib2", "lib3"},
+			expectedCycle: []string{"lib1", "lib2", "lib1"},
+		},
+		{
+			name: "cycle through start",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"app"}},
+			),
+			nodeID:        "app",
+			expected:      []string{"lib1"},
+			expectedCycle: []string{"app", "lib1", "app"},
+		},
+		{
+			name:    "unknown node",
+			doc:     testDependencyDocument(),
+			nodeID:  "nope",
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := tc.doc.TransitiveDependencies(tc.nodeID)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			if tc.expectedCycle != nil {
+				cycleErr := &CyclicDependencyError{}
+				require.True(t, errors.As(err, &cycleErr))
+				require.Equal(t, tc.expectedCycle, cycleErr.Path)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expected, nodeIDs(res))
+		})
+	}
+}
+
+func TestAncestors(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		doc           *Document
+		nodeID        string
+		expected      []string
+		expectedCycle []string
+		mustErr       bool
+	}{
+		{
+			name: "chain",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+			),
+			nodeID:   "lib2",
+			expected: []string{"app", "lib1"},
+		},
+		{
+			name: "dependencyOf",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependencyOf, From: "lib3", To: []string{"lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib2"}},
+			),
+			nodeID:   "lib3",
+			expected: []string{"app", "lib2"},
+		},
+		{
+			name: "diamond",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib2", "lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "lib2", To: []string{"lib3"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib3"}},
+			),
+			nodeID:   "lib3",
+			expected: []string{"app", "lib1", "lib2"},
+		},
+		{
+			name: "dependencies not included",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+			),
+			nodeID:   "lib1",
+			expected: []string{"app"},
+		},
+		{
+			name:     "root node",
+			doc:      testDependencyDocument(&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}}),
+			nodeID:   "app",
+			expected: []string{},
+		},
+		{
+			name: "unreachable cycle",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "lib2", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib2"}},
+			),
+			nodeID:   "lib3",
+			expected: []string{},
+		},
+		{
+			name: "cycle in ancestors",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib3"}},
+				&Edge{Type: Edge_dependsOn, From: "lib2", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib2"}},
+			),
+			nodeID:        "lib3",
+			expected:      []string{"app", "lib1", "lib2"},
+			expectedCycle: []string{"lib1", "lib2", "lib1"},
+		},
+		{
+			name:    "unknown node",
+			doc:     testDependencyDocument(),
+			nodeID:  "nope",
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := tc.doc.Ancestors(tc.nodeID)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			if tc.expectedCycle != nil {
+				cycleErr := &CyclicDependencyError{}
+				require.True(t, errors.As(err, &cycleErr))
+				require.Equal(t, tc.expectedCycle, cycleErr.Path)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expected, nodeIDs(res))
+		})
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		doc      *Document
+		from     string
+		to       string
+		expected []string
+		noPath   bool
+		mustErr  bool
+	}{
+		{
+			name:     "direct",
+			doc:      testDependencyDocument(&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}}),
+			from:     "app",
+			to:       "lib1",
+			expected: []string{"app", "lib1"},
+		},
+		{
+			name: "shortest of two",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1", "lib3"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "lib2", To: []string{"lib4"}},
+				&Edge{Type: Edge_dependsOn, From: "lib3", To: []string{"lib4"}},
+			),
+			from:     "app",
+			to:       "lib4",
+			expected: []string{"app", "lib3", "lib4"},
+		},
+		{
+			name: "dependencyOf",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependencyOf, From: "lib2", To: []string{"lib1"}},
+			),
+			from:     "app",
+			to:       "lib2",
+			expected: []string{"app", "lib1", "lib2"},
+		},
+		{
+			name: "cycle",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"app", "lib2"}},
+			),
+			from:     "app",
+			to:       "lib2",
+			expected: []string{"app", "lib1", "lib2"},
+		},
+		{
+			name: "skips missing nodes",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"missing", "lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "missing", To: []string{"lib3"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "lib2", To: []string{"lib3"}},
+			),
+			from:     "app",
+			to:       "lib3",
+			expected: []string{"app", "lib1", "lib2", "lib3"},
+		},
+		{
+			name:     "same node",
+			doc:      testDependencyDocument(),
+			from:     "app",
+			to:       "app",
+			expected: []string{"app"},
+		},
+		{
+			name:   "wrong direction",
+			doc:    testDependencyDocument(&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}}),
+			from:   "lib1",
+			to:     "app",
+			noPath: true,
+		},
+		{
+			name:   "other relationships",
+			doc:    testDependencyDocument(&Edge{Type: Edge_contains, From: "app", To: []string{"lib1"}}),
+			from:   "app",
+			to:     "lib1",
+			noPath: true,
+		},
+		{
+			name:    "unknown node",
+			doc:     testDependencyDocument(),
+			from:    "app",
+			to:      "nope",
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := tc.doc.ShortestPath(tc.from, tc.to)
+			switch {
+			case tc.noPath:
+				require.ErrorIs(t, err, ErrNoPath)
+			case tc.mustErr:
+				require.Error(t, err)
+				require.NotErrorIs(t, err, ErrNoPath)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, nodeIDs(res))
+			}
+		})
+	}
+}
+
+func TestDetectCycles(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		doc      *Document
+		expected [][]string
+	}{
+		{
+			name: "no cycles",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1", "lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+			),
+			expected: [][]string{},
+		},
+		{
+			name: "simple cycle",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "lib2", To: []string{"lib3"}},
+				&Edge{Type: Edge_dependsOn, From: "lib3", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+			),
+			expected: [][]string{{"lib1", "lib2", "lib3"}},
+		},
+		{
+			name: "cycle through dependencyOf",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+				&Edge{Type: Edge_dependencyOf, From: "lib2", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependencyOf, From: "lib1", To: []string{"lib2"}},
+			),
+			expected: [][]string{{"lib1", "lib2"}},
+		},
+		{
+			name: "self dependency",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "lib4", To: []string{"lib4"}},
+			),
+			expected: [][]string{{"lib4"}},
+		},
+		{
+			name: "two cycles",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "lib3", To: []string{"lib4"}},
+				&Edge{Type: Edge_dependsOn, From: "lib4", To: []string{"lib3"}},
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"app", "lib3"}},
+			),
+			expected: [][]string{{"app", "lib1"}, {"lib3", "lib4"}},
+		},
+		{
+			name: "dangling edges ignored",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"missing"}},
+				&Edge{Type: Edge_dependsOn, From: "missing", To: []string{"app"}},
+			),
+			expected: [][]string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cycles, err := tc.doc.DetectCycles()
+			require.Equal(t, tc.expected, cycles)
+			if len(tc.expected) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			var cycleErr *CyclicDependencyError
+			require.True(t, errors.As(err, &cycleErr))
+			require.Equal(t, append(tc.expected[0], tc.expected[0][0]), cycleErr.Path)
+		})
+	}
+}
+
+func TestDependencyDepths(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		doc      *Document
+		roots    []string
+		expected map[string]int
+	}{
+		{
+			name: "diamond",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1", "lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib3"}},
+				&Edge{Type: Edge_dependsOn, From: "lib3", To: []string{"lib4"}},
+				&Edge{Type: Edge_dependsOn, From: "lib2", To: []string{"lib4"}},
+			),
+			roots:    []string{"app"},
+			expected: map[string]int{"app": 0, "lib1": 1, "lib2": 1, "lib3": 2, "lib4": 2},
+		},
+		{
+			name: "unreachable nodes",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "lib3", To: []string{"lib4"}},
+			),
+			roots:    []string{"app"},
+			expected: map[string]int{"app": 0, "lib1": 1, "lib2": -1, "lib3": -1, "lib4": -1},
+		},
+		{
+			name: "cycle",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+				&Edge{Type: Edge_dependsOn, From: "lib2", To: []string{"lib1", "app"}},
+			),
+			roots:    []string{"app"},
+			expected: map[string]int{"app": 0, "lib1": 1, "lib2": 2, "lib3": -1, "lib4": -1},
+		},
+		{
+			name: "dependencyOf and multiple roots",
+			doc: testDependencyDocument(
+				&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependencyOf, From: "lib2", To: []string{"lib1"}},
+				&Edge{Type: Edge_dependencyOf, From: "lib2", To: []string{"lib3"}},
+			),
+			roots:    []string{"app", "lib3", "missing"},
+			expected: map[string]int{"app": 0, "lib1": 1, "lib2": 1, "lib3": 0, "lib4": -1},
+		},
+		{
+			name: "document roots",
+			doc: func() *Document {
+				doc := testDependencyDocument(&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}})
+				doc.NodeList.RootElements = []string{"app"}
+				return doc
+			}(),
+			expected: map[string]int{"app": 0, "lib1": 1, "lib2": -1, "lib3": -1, "lib4": -1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.doc.NodeList.DependencyDepths(tc.roots))
+		})
+	}
+}
@@ -31,6 +31,11 @@ func (n *Node) Diff(n2 *Node) *NodeDiff {
 		nd.DiffCount++
 	}
 
+	if n.Scope != n2.Scope {
+		nd.Added.Scope = n2.Scope
+		nd.DiffCount++
+	}
+
 	a, r, c = diffString(n.Name, n2.Name)
 	nd.Added.Name = a
 	nd.Removed.Name = r
@@ -116,6 +121,11 @@ func (n *Node) Diff(n2 *Node) *NodeDiff {
 	nd.Removed.Licenses = removed
 	nd.DiffCount += count
 
+	added, removed, count = diffStrSlice(n.LicensesDetected, n2.LicensesDetected)
+	nd.Added.LicensesDetected = added
+	nd.Removed.LicensesDetected = removed
+	nd.DiffCount += count
+
 	added, removed, count = diffStrSlice(n.Attribution, n2.Attribution)
 	nd.Added.Attribution = added
 	nd.Removed.Attribution = removed
@@ -151,6 +161,11 @@ func (n *Node) Diff(n2 *Node) *NodeDiff {
 	nd.Removed.Hashes = removedM
 	nd.DiffCount += count
 
+	addedM, removedM, count = diffIntStrMap(n.IdentifierComments, n2.IdentifierComments)
+	nd.Added.IdentifierComments = addedM
+	nd.Removed.IdentifierComments = removedM
+	nd.DiffCount += count
+
 	if nd.DiffCount > 0 {
 		return &nd
 	}
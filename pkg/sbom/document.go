@@ -1,12 +1,47 @@
 package sbom
 
-func NewDocument() *Document {
-	return &Document{
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sigs.k8s.io/release-utils/version"
+)
+
+// DocumentOption configures the documents created by NewDocument
+type DocumentOption func(*Document)
+
+// WithTimestamp sets the creation date of the document to t instead of the
+// current time
+func WithTimestamp(t time.Time) DocumentOption {
+	return func(d *Document) {
+		d.Metadata.Date = timestamp(t)
+	}
+}
+
+// WithToolName records the tool named name at version as the tool that
+// created the document instead of protobom
+func WithToolName(name, version string) DocumentOption {
+	return func(d *Document) {
+		d.Metadata.Tools = []*Tool{{Name: name, Version: version}}
+	}
+}
+
+// NewDocument returns a new empty document with its metadata initialized to
+// sensible defaults: the creation date is set to the current time (with
+// the seconds precision of RFC 3339 dates), the ID to a new UUID URN that
+// uniquely identifies the document, the version to 1 and protobom, at the
+// version of the library, is recorded as the tool that created it.
+func NewDocument(opts ...DocumentOption) *Document {
+	d := &Document{
 		Metadata: &Metadata{
-			Id:      "",
-			Version: "0",
+			Id:      "urn:uuid:" + uuid.NewString(),
+			Version: "1",
 			Name:    "",
-			// Date:    &timestamppb.New(spdxDoc.CreationInfo.Created), // bug in onesbom
+			Date:    timestamp(time.Now()),
 			Tools:   []*Tool{},
 			Authors: []*Person{},
 		},
@@ -16,6 +51,128 @@ func NewDocument() *Document {
 			RootElements: []string{},
 		},
 	}
+	d.addProtobomTool()
+	for _, o := range opts {
+		o(d)
+	}
+	return d
+}
+
+// timestamp returns t as a UTC protobuf timestamp truncated to the second, the
+// precision of the RFC 3339 dates written by the SBOM formats
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	return timestamppb.New(t.UTC().Truncate(time.Second))
+}
+
+// UpdateTimestamp sets the date of the document metadata to the current
+// time. Call it after modifying a document to record when it changed.
+func (d *Document) UpdateTimestamp() {
+	if d.Metadata == nil {
+		d.Metadata = &Metadata{}
+	}
+	d.Metadata.Date = timestamp(time.Now())
+}
+
+// Timestamp returns the date of the document metadata. It returns an error
+// if the document has no date or it is not a valid timestamp.
+func (d *Document) Timestamp() (time.Time, error) {
+	date := d.GetMetadata().GetDate()
+	if date == nil {
+		return time.Time{}, errors.New("document has no timestamp")
+	}
+	if err := date.CheckValid(); err != nil {
+		return time.Time{}, fmt.Errorf("invalid document timestamp: %w", err)
+	}
+	return date.AsTime(), nil
+}
+
+// CreatorOption configures how Document.SetCreator records the creator of
+// the document
+type CreatorOption func(*creatorOptions)
+
+type creatorOptions struct {
+	format formats.Format
+}
+
+// WithTargetFormat sets the format the document will be written in so the
+// creator is recorded in the way that format expects it
+func WithTargetFormat(f formats.Format) CreatorOption {
+	return func(o *creatorOptions) {
+		o.format = f
+	}
+}
+
+// SetCreator records who created the document: the person named name, with
+// the email address, and the tool used to create it. The metadata authors
+// and tools are replaced with them, arguments left blank leave the
+// corresponding field untouched.
+//
+// The creator is stored in the format-neutral metadata fields, which the
+// SPDX serializer writes as the document creators and the CycloneDX one as
+// metadata.authors and metadata.tools. When the target format is SPDX, a
+// creator with only an email is recorded with the email as its name, as
+// SPDX person creators are identified by their name. Without a target
+// format, the creator is recorded for both formats.
+func (d *Document) SetCreator(name, email, tool string, opts ...CreatorOption) {
+	o := &creatorOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if d.Metadata == nil {
+		d.Metadata = &Metadata{}
+	}
+
+	if name != "" || email != "" {
+		p := &Person{Name: name, Email: email}
+		if p.Name == "" && o.format.Type() == formats.SPDXFORMAT {
+			p.Name, p.Email = email, ""
+		}
+		d.Metadata.Authors = []*Person{p}
+	}
+
+	if tool != "" {
+		d.Metadata.Tools = []*Tool{{Name: tool}}
+	}
+}
+
+// AddTool records the tool named name, at version, in the document
+// metadata. Tools are identified by their name, if the document already
+// lists a tool with the same name it is not added again and only its
+// version is updated. Tools post-processing a document use it to record
+// their involvement.
+func (d *Document) AddTool(name, version string) {
+	if name == "" {
+		return
+	}
+	if d.Metadata == nil {
+		d.Metadata = &Metadata{}
+	}
+	d.Metadata.AddTool(name, version)
+}
+
+// AddAuthor adds a copy of person p to the authors of the document. Authors
+// are identified by their name, or by their email when they have no name,
+// p is not added if the document already lists an author with it.
+func (d *Document) AddAuthor(p *Person) {
+	if p == nil || (p.Name == "" && p.Email == "") {
+		return
+	}
+	if d.Metadata == nil {
+		d.Metadata = &Metadata{}
+	}
+	for _, a := range d.Metadata.Authors {
+		if a.GetName() == p.Name && (p.Name != "" || a.GetEmail() == p.Email) {
+			return
+		}
+	}
+	d.Metadata.Authors = append(d.Metadata.Authors, p.Copy())
+}
+
+// addProtobomTool records the running version of protobom in the tools of
+// the document
+func (d *Document) addProtobomTool() {
+	d.AddTool("protobom", version.GetVersionInfo().GitVersion)
 }
 
 // GetRootNodes returns the top level nodes of the document. It calls the underlying
@@ -1 +1,179 @@
 package sbom
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNewDocument(t *testing.T) {
+	before := time.Now().UTC().Truncate(time.Second)
+	doc := NewDocument()
+	require.NotNil(t, doc.Metadata)
+	require.NotNil(t, doc.NodeList)
+	require.Empty(t, doc.NodeList.Nodes)
+
+	// The ID is a UUID URN, unique for each document
+	require.True(t, strings.HasPrefix(doc.Metadata.Id, "urn:uuid:"))
+	_, err := uuid.Parse(strings.TrimPrefix(doc.Metadata.Id, "urn:uuid:"))
+	require.NoError(t, err)
+	require.NotEqual(t, doc.Metadata.Id, NewDocument().Metadata.Id)
+	require.Equal(t, "1", doc.Metadata.Version)
+
+	require.NotNil(t, doc.Metadata.Date)
+	date := doc.Metadata.Date.AsTime()
+	require.False(t, date.Before(before))
+	require.Equal(t, date, date.Truncate(time.Second))
+
+	require.Len(t, doc.Metadata.Tools, 1)
+	require.Equal(t, "protobom", doc.Metadata.Tools[0].Name)
+	require.NotEmpty(t, doc.Metadata.Tools[0].Version)
+}
+
+func TestNewDocumentOptions(t *testing.T) {
+	ts := time.Date(2023, 6, 1, 12, 30, 15, 500, time.FixedZone("CEST", 2*60*60))
+	doc := NewDocument(WithTimestamp(ts), WithToolName("sbom-tool", "1.2.3"))
+	require.Equal(t, time.Date(2023, 6, 1, 10, 30, 15, 0, time.UTC), doc.Metadata.Date.AsTime())
+	require.Len(t, doc.Metadata.Tools, 1)
+	require.Equal(t, "sbom-tool", doc.Metadata.Tools[0].Name)
+	require.Equal(t, "1.2.3", doc.Metadata.Tools[0].Version)
+}
+
+func TestSetCreator(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		creator         [3]string
+		opts            []CreatorOption
+		expectedAuthors []*Person
+		expectedTools   []*Tool
+	}{
+		{
+			name:            "all fields",
+			creator:         [3]string{"Jane Doe", "jane@example.com", "sbom-tool"},
+			expectedAuthors: []*Person{{Name: "Jane Doe", Email: "jane@example.com"}},
+			expectedTools:   []*Tool{{Name: "sbom-tool"}},
+		},
+		{
+			name:            "no tool keeps the default",
+			creator:         [3]string{"Jane Doe", "", ""},
+			expectedAuthors: []*Person{{Name: "Jane Doe"}},
+			expectedTools:   []*Tool{{Name: "protobom", Version: "devel"}},
+		},
+		{
+			name:            "only email",
+			creator:         [3]string{"", "jane@example.com", "sbom-tool"},
+			expectedAuthors: []*Person{{Email: "jane@example.com"}},
+			expectedTools:   []*Tool{{Name: "sbom-tool"}},
+		},
+		{
+			name:            "only email for spdx",
+			creator:         [3]string{"", "jane@example.com", "sbom-tool"},
+			opts:            []CreatorOption{WithTargetFormat(formats.SPDX23JSON)},
+			expectedAuthors: []*Person{{Name: "jane@example.com"}},
+			expectedTools:   []*Tool{{Name: "sbom-tool"}},
+		},
+		{
+			name:            "only email for cyclonedx",
+			creator:         [3]string{"", "jane@example.com", "sbom-tool"},
+			opts:            []CreatorOption{WithTargetFormat(formats.CDX15JSON)},
+			expectedAuthors: []*Person{{Email: "jane@example.com"}},
+			expectedTools:   []*Tool{{Name: "sbom-tool"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := NewDocument(WithToolName("protobom", "devel"))
+			doc.Metadata.Authors = []*Person{{Name: "Previous Author"}}
+			doc.SetCreator(tc.creator[0], tc.creator[1], tc.creator[2], tc.opts...)
+			require.Len(t, doc.Metadata.Authors, len(tc.expectedAuthors))
+			for i := range tc.expectedAuthors {
+				require.True(t, proto.Equal(tc.expectedAuthors[i], doc.Metadata.Authors[i]))
+			}
+			require.Len(t, doc.Metadata.Tools, len(tc.expectedTools))
+			for i := range tc.expectedTools {
+				require.True(t, proto.Equal(tc.expectedTools[i], doc.Metadata.Tools[i]))
+			}
+		})
+	}
+
+	// Blank arguments leave the metadata untouched
+	doc := &Document{}
+	doc.SetCreator("", "", "")
+	require.Empty(t, doc.Metadata.Authors)
+	require.Empty(t, doc.Metadata.Tools)
+}
+
+func TestAddTool(t *testing.T) {
+	doc := NewDocument(WithToolName("sbom-tool", "1.0.0"))
+	doc.AddTool("post-processor", "0.1.0")
+	doc.AddTool("post-processor", "0.1.0")
+	require.Len(t, doc.Metadata.Tools, 2)
+	require.True(t, proto.Equal(&Tool{Name: "post-processor", Version: "0.1.0"}, doc.Metadata.Tools[1]))
+
+	// Tools are identified by name, adding them again updates the version
+	doc.AddTool("sbom-tool", "2.0.0")
+	doc.AddTool("sbom-tool", "")
+	require.Len(t, doc.Metadata.Tools, 2)
+	require.Equal(t, "2.0.0", doc.Metadata.Tools[0].Version)
+
+	doc.AddTool("", "1.0")
+	require.Len(t, doc.Metadata.Tools, 2)
+
+	doc = &Document{}
+	doc.AddTool("sbom-tool", "1.0.0")
+	require.Len(t, doc.Metadata.Tools, 1)
+}
+
+func TestAddAuthor(t *testing.T) {
+	doc := NewDocument()
+	author := &Person{Name: "Jane Doe", Email: "jane@example.com"}
+	doc.AddAuthor(author)
+	doc.AddAuthor(&Person{Name: "Jane Doe"})
+	doc.AddAuthor(&Person{Email: "ops@example.com"})
+	doc.AddAuthor(&Person{Email: "ops@example.com"})
+	doc.AddAuthor(&Person{Name: "ACME", IsOrg: true})
+	doc.AddAuthor(&Person{})
+	doc.AddAuthor(nil)
+
+	require.Len(t, doc.Metadata.Authors, 3)
+	require.True(t, proto.Equal(author, doc.Metadata.Authors[0]))
+	require.Equal(t, "ops@example.com", doc.Metadata.Authors[1].Email)
+	require.Equal(t, "ACME", doc.Metadata.Authors[2].Name)
+
+	// Authors are copied
+	author.Name = "modified"
+	require.Equal(t, "Jane Doe", doc.Metadata.Authors[0].Name)
+}
+
+func TestUpdateTimestamp(t *testing.T) {
+	doc := NewDocument(WithTimestamp(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+	ts, err := doc.Timestamp()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), ts)
+
+	before := time.Now().UTC().Truncate(time.Second)
+	doc.UpdateTimestamp()
+	ts, err = doc.Timestamp()
+	require.NoError(t, err)
+	require.False(t, ts.Before(before))
+	require.False(t, ts.After(time.Now()))
+	require.Equal(t, time.UTC, ts.Location())
+	require.Equal(t, ts, ts.Truncate(time.Second))
+
+	// Documents without metadata get it
+	doc = &Document{}
+	_, err = doc.Timestamp()
+	require.Error(t, err)
+	doc.UpdateTimestamp()
+	_, err = doc.Timestamp()
+	require.NoError(t, err)
+
+	doc.Metadata.Date = &timestamppb.Timestamp{Seconds: 1, Nanos: -1}
+	_, err = doc.Timestamp()
+	require.Error(t, err)
+}
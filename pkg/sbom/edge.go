@@ -1,6 +1,7 @@
 package sbom
 
 import (
+	"slices"
 	"sort"
 	"strings"
 )
@@ -14,12 +15,92 @@ func NewEdge() *Edge {
 // Copy returns a new edge with copies of all edges
 func (e *Edge) Copy() *Edge {
 	return &Edge{
-		Type: e.Type,
-		From: e.From,
-		To:   e.To,
+		Type:    e.Type,
+		From:    e.From,
+		To:      slices.Clone(e.To),
+		Comment: e.Comment,
+		Inverse: e.Inverse,
 	}
 }
 
+// inverseEdgeTypes maps the edge types that state a relationship from its
+// target to the canonical type stating it from its source. The canonical
+// types are those kept in SPDX 3.
+var inverseEdgeTypes = map[Edge_Type]Edge_Type{
+	Edge_contained_by:    Edge_contains,
+	Edge_dependencyOf:    Edge_dependsOn,
+	Edge_describedBy:     Edge_describes,
+	Edge_generatedFrom:   Edge_generates,
+	Edge_prerequisiteFor: Edge_prerequisite,
+}
+
+// Canonical returns the type stating the relationship in its canonical
+// direction and true if et is the inverse form of it. Types without an
+// inverse are returned as they are.
+func (et Edge_Type) Canonical() (Edge_Type, bool) {
+	if c, ok := inverseEdgeTypes[et]; ok {
+		return c, true
+	}
+	return et, false
+}
+
+// Inverse returns the type stating the same relationship in the opposite
+// direction, UNKNOWN if the type has no inverse.
+func (et Edge_Type) Inverse() Edge_Type {
+	if c, ok := inverseEdgeTypes[et]; ok {
+		return c
+	}
+	for inverse, c := range inverseEdgeTypes {
+		if c == et {
+			return inverse
+		}
+	}
+	return Edge_UNKNOWN
+}
+
+// Canonical returns the edge stating its relationships in the canonical
+// direction. Edges of an inverse type are turned into one edge for each of
+// their targets, pointing back to the edge source and flagged as inverse.
+// Edges already in the canonical direction are returned as they are.
+func (e *Edge) Canonical() []*Edge {
+	t, inverse := e.Type.Canonical()
+	if !inverse {
+		return []*Edge{e}
+	}
+	edges := []*Edge{}
+	for _, to := range e.To {
+		edges = append(edges, &Edge{
+			Type:    t,
+			From:    to,
+			To:      []string{e.From},
+			Comment: e.Comment,
+			Inverse: true,
+		})
+	}
+	return edges
+}
+
+// Stated returns the edge in the direction its relationships were originally
+// stated. Edges flagged as inverse are turned back into one edge of the
+// inverse type for each of their targets. Other edges are returned as they
+// are.
+func (e *Edge) Stated() []*Edge {
+	inverse := e.Type.Inverse()
+	if _, isInverse := e.Type.Canonical(); !e.Inverse || isInverse || inverse == Edge_UNKNOWN {
+		return []*Edge{e}
+	}
+	edges := []*Edge{}
+	for _, to := range e.To {
+		edges = append(edges, &Edge{
+			Type:    inverse,
+			From:    to,
+			To:      []string{e.From},
+			Comment: e.Comment,
+		})
+	}
+	return edges
+}
+
 // PointsTo returns true if an edge points to a node, in other words if it has
 // id in its list of Tos
 func (e *Edge) PointsTo(id string) bool {
@@ -241,5 +322,9 @@ func (e *Edge) Equal(e2 *Edge) bool {
 func (e *Edge) flatString() string {
 	tos := e.To
 	sort.Strings(tos)
-	return e.From + ":" + e.Type.String() + ":" + strings.Join(tos, "+")
+	s := e.From + ":" + e.Type.String() + ":" + strings.Join(tos, "+")
+	if e.Comment != "" {
+		s += ":" + e.Comment
+	}
+	return s
 }
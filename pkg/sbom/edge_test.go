@@ -0,0 +1,62 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEdgeTypeCanonical(t *testing.T) {
+	for _, tc := range []struct {
+		sut       Edge_Type
+		canonical Edge_Type
+		isInverse bool
+		inverse   Edge_Type
+	}{
+		{Edge_contains, Edge_contains, false, Edge_contained_by},
+		{Edge_contained_by, Edge_contains, true, Edge_contains},
+		{Edge_dependsOn, Edge_dependsOn, false, Edge_dependencyOf},
+		{Edge_dependencyOf, Edge_dependsOn, true, Edge_dependsOn},
+		{Edge_describedBy, Edge_describes, true, Edge_describes},
+		{Edge_generatedFrom, Edge_generates, true, Edge_generates},
+		{Edge_prerequisiteFor, Edge_prerequisite, true, Edge_prerequisite},
+		{Edge_buildTool, Edge_buildTool, false, Edge_UNKNOWN},
+		{Edge_UNKNOWN, Edge_UNKNOWN, false, Edge_UNKNOWN},
+	} {
+		t.Run(tc.sut.String(), func(t *testing.T) {
+			canonical, isInverse := tc.sut.Canonical()
+			require.Equal(t, tc.canonical, canonical)
+			require.Equal(t, tc.isInverse, isInverse)
+			require.Equal(t, tc.inverse, tc.sut.Inverse())
+		})
+	}
+}
+
+func TestEdgeCanonicalAndStated(t *testing.T) {
+	// Canonical edges are returned as they are
+	e := &Edge{Type: Edge_contains, From: "a", To: []string{"b", "c"}}
+	require.Equal(t, []*Edge{e}, e.Canonical())
+	require.Equal(t, []*Edge{e}, e.Stated())
+
+	// Inverse edges are split and turned around
+	inverse := &Edge{Type: Edge_contained_by, From: "a", To: []string{"b", "c"}, Comment: "note"}
+	canonical := inverse.Canonical()
+	require.Len(t, canonical, 2)
+	require.True(t, proto.Equal(&Edge{Type: Edge_contains, From: "b", To: []string{"a"}, Comment: "note", Inverse: true}, canonical[0]))
+	require.True(t, proto.Equal(&Edge{Type: Edge_contains, From: "c", To: []string{"a"}, Comment: "note", Inverse: true}, canonical[1]))
+	require.Equal(t, []*Edge{inverse}, inverse.Stated())
+
+	// The stated edges turn the canonical ones back
+	for i, c := range canonical {
+		stated := c.Stated()
+		require.Len(t, stated, 1)
+		require.True(t, proto.Equal(&Edge{Type: Edge_contained_by, From: "a", To: []string{inverse.To[i]}, Comment: "note"}, stated[0]))
+	}
+
+	// Types without an inverse ignore the flag
+	flagged := &Edge{Type: Edge_buildTool, From: "a", To: []string{"b"}, Inverse: true}
+	require.Equal(t, []*Edge{flagged}, flagged.Stated())
+
+	require.True(t, canonical[0].Copy().Inverse)
+}
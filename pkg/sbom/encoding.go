@@ -0,0 +1,49 @@
+package sbom
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ToJSON returns the document encoded as JSON using the protobuf JSON
+// mapping of the protobom data model. The encoding is independent of the
+// SBOM formats, use it to store or exchange documents between processes
+// and for debugging.
+func (d *Document) ToJSON() ([]byte, error) {
+	data, err := protojson.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling document: %w", err)
+	}
+	return data, nil
+}
+
+// DocumentFromJSON returns the document encoded in data by Document.ToJSON
+func DocumentFromJSON(data []byte) (*Document, error) {
+	d := &Document{}
+	if err := protojson.Unmarshal(data, d); err != nil {
+		return nil, fmt.Errorf("unmarshaling document: %w", err)
+	}
+	return d, nil
+}
+
+// ToBinary returns the document encoded in the protobuf wire format. The
+// binary encoding is much more compact and faster to produce than JSON,
+// use it to store large numbers of documents.
+func (d *Document) ToBinary() ([]byte, error) {
+	data, err := proto.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling document: %w", err)
+	}
+	return data, nil
+}
+
+// DocumentFromBinary returns the document encoded in data by Document.ToBinary
+func DocumentFromBinary(data []byte) (*Document, error) {
+	d := &Document{}
+	if err := proto.Unmarshal(data, d); err != nil {
+		return nil, fmt.Errorf("unmarshaling document: %w", err)
+	}
+	return d, nil
+}
@@ -0,0 +1,96 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// testEncodingDocument returns a document using most of the data model
+func testEncodingDocument() *Document {
+	doc := NewDocument(WithTimestamp(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)))
+	doc.Metadata.Name = "encoding"
+	doc.NodeList.AddRootNode(&Node{
+		Id: "app", Name: "app", Version: "1.0.0", Type: Node_PACKAGE,
+		Hashes:        map[int32]string{int32(HashAlgorithm_SHA256): "3f1c"},
+		Identifiers:   map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:generic/app@1.0.0"},
+		FilesAnalyzed: proto.Bool(false),
+		Properties:    []*Property{{Name: "key", Value: "value"}},
+	})
+	doc.NodeList.AddNode(&Node{Id: "file", Name: "main.go", Type: Node_FILE})
+	doc.NodeList.AddEdge(&Edge{Type: Edge_contains, From: "app", To: []string{"file"}})
+	return doc
+}
+
+func TestJSONEncoding(t *testing.T) {
+	doc := testEncodingDocument()
+	data, err := doc.ToJSON()
+	require.NoError(t, err)
+	// The output is plain JSON, protojson randomizes its whitespace
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(data, &parsed))
+	require.Equal(t, "encoding", parsed["metadata"].(map[string]any)["name"])
+
+	doc2, err := DocumentFromJSON(data)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(doc, doc2))
+
+	_, err = DocumentFromJSON([]byte(`{"metadata": `))
+	require.Error(t, err)
+	_, err = DocumentFromJSON([]byte(`{"unknown": true}`))
+	require.Error(t, err)
+}
+
+func TestBinaryEncoding(t *testing.T) {
+	doc := testEncodingDocument()
+	data, err := doc.ToBinary()
+	require.NoError(t, err)
+
+	doc2, err := DocumentFromBinary(data)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(doc, doc2))
+
+	_, err = DocumentFromBinary([]byte{0xff, 0xff})
+	require.Error(t, err)
+}
+
+// BenchmarkEncoding compares the size and the time taken to encode a
+// document with 10k components in the binary and JSON encodings
+func BenchmarkEncoding(b *testing.B) {
+	doc := NewDocument()
+	doc.NodeList.AddRootNode(&Node{Id: "root", Name: "root", Type: Node_PACKAGE})
+	for i := 0; i < 10000; i++ {
+		id := fmt.Sprintf("package-%d", i)
+		doc.NodeList.AddNode(&Node{
+			Id: id, Name: id, Version: "1.0.0", Type: Node_PACKAGE, LicenseConcluded: "MIT",
+			Hashes:      map[int32]string{int32(HashAlgorithm_SHA256): fmt.Sprintf("%064x", i)},
+			Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): fmt.Sprintf("pkg:generic/%s@1.0.0", id)},
+		})
+		doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "root", To: []string{id}})
+	}
+
+	for _, bc := range []struct {
+		name   string
+		encode func() ([]byte, error)
+	}{
+		{"binary", doc.ToBinary},
+		{"json", doc.ToJSON},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var size int
+			for i := 0; i < b.N; i++ {
+				data, err := bc.encode()
+				if err != nil {
+					b.Fatal(err)
+				}
+				size = len(data)
+			}
+			b.ReportMetric(float64(size), "bytes/doc")
+		})
+	}
+}
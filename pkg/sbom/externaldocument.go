@@ -0,0 +1,99 @@
+package sbom
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// externalDocumentPrefix prefixes the IDs of the nodes defined in external
+// documents, it matches the SPDX DocumentRef syntax
+const externalDocumentPrefix = "DocumentRef-"
+
+// ExternalNodeID returns the ID of the stub node representing the element
+// with ID elementID defined in the external document documentID. The
+// returned ID uses the SPDX syntax: DocumentRef-<documentID>:<elementID>
+func ExternalNodeID(documentID, elementID string) string {
+	return externalDocumentPrefix + documentID + ":" + elementID
+}
+
+// ParseExternalNodeID splits the ID of a node defined in an external
+// document into the external document ID and the ID of the element in it.
+// It returns false if id does not reference an external document.
+func ParseExternalNodeID(id string) (documentID, elementID string, ok bool) {
+	if !strings.HasPrefix(id, externalDocumentPrefix) {
+		return "", "", false
+	}
+	documentID, elementID, ok = strings.Cut(strings.TrimPrefix(id, externalDocumentPrefix), ":")
+	if !ok || documentID == "" || elementID == "" {
+		return "", "", false
+	}
+	return documentID, elementID, true
+}
+
+// NewExternalNode returns a stub node representing the element elementID
+// defined in the external document ed. The node carries a BOM external
+// reference with the URI and hashes of the document.
+func NewExternalNode(ed *ExternalDocument, elementID string) *Node {
+	n := NewNode()
+	n.Id = ExternalNodeID(ed.Id, elementID)
+	n.ExternalDocument = ed.Id
+	if ed.Uri != "" || len(ed.Hashes) > 0 {
+		n.ExternalReferences = append(n.ExternalReferences, &ExternalReference{
+			Url:    ed.Uri,
+			Type:   ExternalReference_BOM,
+			Hashes: maps.Clone(ed.Hashes),
+		})
+	}
+	return n
+}
+
+// MergeExternalDocument merges the nodes and edges of ext, the external
+// document referenced as documentID, into the document. The IDs of the
+// merged nodes are prefixed with the document reference and the stub nodes
+// already in the document are completed with the data of the nodes they
+// represent. Nodes that ext references in other external documents are
+// not merged. Protobom is recorded in the tools of the document.
+func (d *Document) MergeExternalDocument(documentID string, ext *Document) error {
+	if d.GetMetadata() == nil || d.Metadata.GetExternalDocument(documentID) == nil {
+		return fmt.Errorf("document does not reference external document %q", documentID)
+	}
+	if ext.GetNodeList() == nil {
+		return nil
+	}
+	d.addProtobomTool()
+
+	for _, n := range ext.NodeList.Nodes {
+		if n.ExternalDocument != "" {
+			continue
+		}
+		nn := n.Copy()
+		nn.Id = ExternalNodeID(documentID, n.Id)
+		nn.ExternalDocument = documentID
+		stub := d.NodeList.GetNodeByID(nn.Id)
+		if stub == nil {
+			d.NodeList.AddNode(nn)
+			continue
+		}
+		// Keep the references of the stub to the external document
+		nn.ExternalReferences = append(nn.ExternalReferences, stub.ExternalReferences...)
+		stub.Update(nn)
+	}
+
+	for _, e := range ext.NodeList.Edges {
+		if _, _, ok := ParseExternalNodeID(e.From); ok {
+			continue
+		}
+		ne := &Edge{Type: e.Type, From: ExternalNodeID(documentID, e.From), To: []string{}}
+		for _, to := range e.To {
+			if _, _, ok := ParseExternalNodeID(to); ok {
+				continue
+			}
+			ne.To = append(ne.To, ExternalNodeID(documentID, to))
+		}
+		if len(ne.To) > 0 {
+			d.NodeList.AddEdge(ne)
+		}
+	}
+	return nil
+}
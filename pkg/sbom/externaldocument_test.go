@@ -0,0 +1,73 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExternalNodeID(t *testing.T) {
+	for _, tc := range []struct {
+		id       string
+		document string
+		element  string
+		ok       bool
+	}{
+		{ExternalNodeID("other", "Package-1"), "other", "Package-1", true},
+		{"DocumentRef-other:Package-1", "other", "Package-1", true},
+		{"Package-1", "", "", false},
+		{"DocumentRef-other", "", "", false},
+		{"DocumentRef-:Package-1", "", "", false},
+		{"DocumentRef-other:", "", "", false},
+	} {
+		document, element, ok := ParseExternalNodeID(tc.id)
+		require.Equal(t, tc.ok, ok, tc.id)
+		require.Equal(t, tc.document, document, tc.id)
+		require.Equal(t, tc.element, element, tc.id)
+	}
+}
+
+func TestMergeExternalDocument(t *testing.T) {
+	ed := &ExternalDocument{Id: "other", Uri: "https://example.com/other", Hashes: map[int32]string{int32(HashAlgorithm_SHA1): "abc"}}
+	doc := NewDocument()
+	doc.Metadata.ExternalDocuments = []*ExternalDocument{ed}
+	doc.NodeList.AddRootNode(&Node{Id: "app", Name: "app"})
+	doc.NodeList.AddNode(NewExternalNode(ed, "lib"))
+	doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "app", To: []string{"DocumentRef-other:lib"}})
+	doc.Metadata.Tools = []*Tool{{Name: "sbom-tool", Version: "1.0"}}
+
+	ext := NewDocument()
+	ext.NodeList.AddRootNode(&Node{Id: "lib", Name: "lib", Version: "1.0.0"})
+	ext.NodeList.AddNode(&Node{Id: "zlib", Name: "zlib"})
+	ext.NodeList.AddNode(&Node{Id: "DocumentRef-third:x", ExternalDocument: "third"})
+	ext.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "lib", To: []string{"zlib", "DocumentRef-third:x"}})
+
+	require.Error(t, doc.MergeExternalDocument("unknown", ext))
+	require.NoError(t, doc.MergeExternalDocument("other", ext))
+
+	// The merge is recorded in the tools
+	require.Len(t, doc.Metadata.Tools, 2)
+	require.Equal(t, "protobom", doc.Metadata.Tools[1].Name)
+
+	// The stub is completed with the external node data
+	require.Len(t, doc.NodeList.Nodes, 3)
+	lib := doc.NodeList.GetNodeByID("DocumentRef-other:lib")
+	require.NotNil(t, lib)
+	require.Equal(t, "lib", lib.Name)
+	require.Equal(t, "1.0.0", lib.Version)
+	require.Equal(t, "other", lib.ExternalDocument)
+	require.Len(t, lib.ExternalReferences, 1)
+	require.Equal(t, ExternalReference_BOM, lib.ExternalReferences[0].Type)
+
+	zlib := doc.NodeList.GetNodeByID("DocumentRef-other:zlib")
+	require.NotNil(t, zlib)
+	require.Equal(t, "other", zlib.ExternalDocument)
+	require.Nil(t, doc.NodeList.GetNodeByID("DocumentRef-other:DocumentRef-third:x"))
+
+	// Edges are prefixed, the ones to other documents are dropped and the
+	// roots of the external document are not added
+	require.Len(t, doc.NodeList.Edges, 2)
+	require.Equal(t, "DocumentRef-other:lib", doc.NodeList.Edges[1].From)
+	require.Equal(t, []string{"DocumentRef-other:zlib"}, doc.NodeList.Edges[1].To)
+	require.Equal(t, []string{"app"}, doc.NodeList.RootElements)
+}
@@ -0,0 +1,144 @@
+package sbom
+
+import (
+	"slices"
+	"strings"
+)
+
+// FileType is the classification of a file node as defined by the SPDX
+// 2.3 fileType field.
+type FileType string
+
+const (
+	FileTypeSource        FileType = "SOURCE"
+	FileTypeBinary        FileType = "BINARY"
+	FileTypeArchive       FileType = "ARCHIVE"
+	FileTypeApplication   FileType = "APPLICATION"
+	FileTypeAudio         FileType = "AUDIO"
+	FileTypeImage         FileType = "IMAGE"
+	FileTypeText          FileType = "TEXT"
+	FileTypeVideo         FileType = "VIDEO"
+	FileTypeDocumentation FileType = "DOCUMENTATION"
+	FileTypeSPDX          FileType = "SPDX"
+	FileTypeOther         FileType = "OTHER"
+)
+
+var fileTypes = []FileType{
+	FileTypeSource, FileTypeBinary, FileTypeArchive, FileTypeApplication,
+	FileTypeAudio, FileTypeImage, FileTypeText, FileTypeVideo,
+	FileTypeDocumentation, FileTypeSPDX, FileTypeOther,
+}
+
+// FileTypeFromString parses a file type label. Labels are matched without
+// regard to case, anything not in the SPDX list is returned as FileTypeOther.
+func FileTypeFromString(s string) FileType {
+	label := FileType(strings.ToUpper(strings.TrimSpace(s)))
+	for _, ft := range fileTypes {
+		if ft == label {
+			return ft
+		}
+	}
+	return FileTypeOther
+}
+
+// TypedFileTypes returns the file types of the node as FileType values.
+func (n *Node) TypedFileTypes() []FileType {
+	return FileTypesFromStrings(n.FileTypes)
+}
+
+// FileTypesFromStrings parses a list of file type labels. Empty labels are
+// skipped and duplicates are collapsed keeping the order of first occurrence.
+func FileTypesFromStrings(labels []string) []FileType {
+	if len(labels) == 0 {
+		return nil
+	}
+	ret := []FileType{}
+	seen := map[FileType]struct{}{}
+	for _, s := range labels {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		ft := FileTypeFromString(s)
+		if _, ok := seen[ft]; ok {
+			continue
+		}
+		seen[ft] = struct{}{}
+		ret = append(ret, ft)
+	}
+	return ret
+}
+
+// SetFileTypes replaces the file types of the node
+func (n *Node) SetFileTypes(fts ...FileType) {
+	n.FileTypes = make([]string, 0, len(fts))
+	for _, ft := range fts {
+		n.FileTypes = append(n.FileTypes, string(ft))
+	}
+}
+
+// Purpose returns the purpose of a file of the type. Media files (audio,
+// images, text and video) are DATA, SPDX documents are BOMs and binaries
+// are EXECUTABLE.
+func (ft FileType) Purpose() Purpose {
+	switch ft {
+	case FileTypeSource:
+		return Purpose_SOURCE
+	case FileTypeBinary:
+		return Purpose_EXECUTABLE
+	case FileTypeArchive:
+		return Purpose_ARCHIVE
+	case FileTypeApplication:
+		return Purpose_APPLICATION
+	case FileTypeAudio, FileTypeImage, FileTypeText, FileTypeVideo:
+		return Purpose_DATA
+	case FileTypeDocumentation:
+		return Purpose_DOCUMENTATION
+	case FileTypeSPDX:
+		return Purpose_BOM
+	case FileTypeOther:
+		return Purpose_OTHER
+	default:
+		return Purpose_UNKNOWN_PURPOSE
+	}
+}
+
+// FileTypeFromPurpose returns the file type of a file with the purpose.
+// Purposes without an equivalent file type return FileTypeOther and
+// Purpose_UNKNOWN_PURPOSE returns a blank file type.
+func FileTypeFromPurpose(p Purpose) FileType {
+	switch p {
+	case Purpose_UNKNOWN_PURPOSE:
+		return ""
+	case Purpose_SOURCE, Purpose_PATCH:
+		return FileTypeSource
+	case Purpose_EXECUTABLE, Purpose_LIBRARY, Purpose_MODULE:
+		return FileTypeBinary
+	case Purpose_ARCHIVE:
+		return FileTypeArchive
+	case Purpose_APPLICATION:
+		return FileTypeApplication
+	case Purpose_DOCUMENTATION:
+		return FileTypeDocumentation
+	case Purpose_BOM:
+		return FileTypeSPDX
+	default:
+		return FileTypeOther
+	}
+}
+
+// FileTypePurposes returns the purposes of a file with the file types,
+// without duplicates.
+func FileTypePurposes(fts []FileType) []Purpose {
+	if len(fts) == 0 {
+		return nil
+	}
+	ret := []Purpose{}
+	for _, ft := range fts {
+		p := ft.Purpose()
+		if p == Purpose_UNKNOWN_PURPOSE || slices.Contains(ret, p) {
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
@@ -0,0 +1,72 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedFileTypes(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      []string
+		expected []FileType
+	}{
+		{"empty", nil, nil},
+		{"known", []string{"SOURCE", "BINARY"}, []FileType{FileTypeSource, FileTypeBinary}},
+		{"case", []string{"source", "Archive"}, []FileType{FileTypeSource, FileTypeArchive}},
+		{"unknown", []string{"executable"}, []FileType{FileTypeOther}},
+		{"duplicates", []string{"TEXT", "", "text", "OTHER", "weird"}, []FileType{FileTypeText, FileTypeOther}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &Node{FileTypes: tc.sut}
+			require.Equal(t, tc.expected, n.TypedFileTypes())
+		})
+	}
+}
+
+func TestSetFileTypes(t *testing.T) {
+	n := &Node{FileTypes: []string{"TEXT"}}
+	n.SetFileTypes(FileTypeSource, FileTypeDocumentation)
+	require.Equal(t, []string{"SOURCE", "DOCUMENTATION"}, n.FileTypes)
+}
+
+func TestFileTypePurpose(t *testing.T) {
+	for _, tc := range []struct {
+		fileType FileType
+		purpose  Purpose
+		back     FileType
+	}{
+		{FileTypeSource, Purpose_SOURCE, FileTypeSource},
+		{FileTypeBinary, Purpose_EXECUTABLE, FileTypeBinary},
+		{FileTypeArchive, Purpose_ARCHIVE, FileTypeArchive},
+		{FileTypeApplication, Purpose_APPLICATION, FileTypeApplication},
+		{FileTypeAudio, Purpose_DATA, FileTypeOther},
+		{FileTypeImage, Purpose_DATA, FileTypeOther},
+		{FileTypeText, Purpose_DATA, FileTypeOther},
+		{FileTypeVideo, Purpose_DATA, FileTypeOther},
+		{FileTypeDocumentation, Purpose_DOCUMENTATION, FileTypeDocumentation},
+		{FileTypeSPDX, Purpose_BOM, FileTypeSPDX},
+		{FileTypeOther, Purpose_OTHER, FileTypeOther},
+	} {
+		require.Equal(t, tc.purpose, tc.fileType.Purpose(), tc.fileType)
+		require.Equal(t, tc.back, FileTypeFromPurpose(tc.purpose), tc.fileType)
+	}
+	require.Len(t, fileTypes, 11)
+
+	// Every purpose has a file type
+	for name, value := range Purpose_value {
+		ft := FileTypeFromPurpose(Purpose(value))
+		if Purpose(value) == Purpose_UNKNOWN_PURPOSE {
+			require.Empty(t, ft)
+			continue
+		}
+		require.Contains(t, fileTypes, ft, name)
+	}
+
+	require.Equal(t,
+		[]Purpose{Purpose_SOURCE, Purpose_DATA},
+		FileTypePurposes([]FileType{FileTypeSource, FileTypeText, FileTypeImage}),
+	)
+	require.Nil(t, FileTypePurposes(nil))
+}
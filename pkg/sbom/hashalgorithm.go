@@ -1,10 +1,71 @@
 package sbom
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/spdx/tools-golang/spdx/v2/common"
 )
 
+// ErrUnsupportedHashAlgorithm is returned when a hash algorithm is not one
+// of the algorithms known to protobom
+var ErrUnsupportedHashAlgorithm = errors.New("unsupported hash algorithm")
+
+// ParseHashAlgorithm returns the HashAlgorithm named by name. The names
+// written by the SBOM tools are normalized before looking them up: case is
+// ignored as are dashes, underscores and spaces, so SHA-256, sha256, SHA256
+// and SHA_256 all return HashAlgorithm_SHA256. Returns an error wrapping
+// ErrUnsupportedHashAlgorithm if the name is not a known algorithm.
+func ParseHashAlgorithm(name string) (HashAlgorithm, error) {
+	normalized := normalizeHashAlgorithmName(name)
+	if normalized != "" {
+		for v, n := range HashAlgorithm_name {
+			if HashAlgorithm(v) != HashAlgorithm_UNKNOWN && normalizeHashAlgorithmName(n) == normalized {
+				return HashAlgorithm(v), nil
+			}
+		}
+	}
+	return HashAlgorithm_UNKNOWN, fmt.Errorf("%w: %q", ErrUnsupportedHashAlgorithm, name)
+}
+
+// normalizeHashAlgorithmName returns an algorithm name in upper case without
+// the separators used by the different specs
+func normalizeHashAlgorithmName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', ' ', '\t':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(name)))
+}
+
+// HexLength returns the length of the hex encoded digests of the algorithm.
+// It returns 0 for the algorithms with a variable output size (MD6 and
+// BLAKE3) and for HashAlgorithm_UNKNOWN.
+func (ha HashAlgorithm) HexLength() int {
+	switch ha {
+	case HashAlgorithm_ADLER32:
+		return 8
+	case HashAlgorithm_MD2, HashAlgorithm_MD4, HashAlgorithm_MD5:
+		return 32
+	case HashAlgorithm_SHA1:
+		return 40
+	case HashAlgorithm_SHA224:
+		return 56
+	case HashAlgorithm_SHA256, HashAlgorithm_SHA3_256, HashAlgorithm_BLAKE2B_256:
+		return 64
+	case HashAlgorithm_SHA384, HashAlgorithm_SHA3_384, HashAlgorithm_BLAKE2B_384:
+		return 96
+	case HashAlgorithm_SHA512, HashAlgorithm_SHA3_512, HashAlgorithm_BLAKE2B_512:
+		return 128
+	default:
+		return 0
+	}
+}
+
 func HashAlgorithmFromCycloneDX(cdxAlgo cdx.HashAlgorithm) HashAlgorithm {
 	switch cdxAlgo {
 	case cdx.HashAlgoMD5:
@@ -41,6 +102,8 @@ func (ha HashAlgorithm) ToSPDX() common.ChecksumAlgorithm {
 	switch ha {
 	case HashAlgorithm_ADLER32:
 		return common.ADLER32
+	case HashAlgorithm_MD2:
+		return common.MD2
 	case HashAlgorithm_MD4:
 		return common.MD4
 	case HashAlgorithm_MD5:
@@ -76,10 +139,16 @@ func (ha HashAlgorithm) ToSPDX() common.ChecksumAlgorithm {
 	}
 }
 
+// HashAlgorithmFromSPDX returns the HashAlgorithm of an SPDX checksum
+// algorithm label. Labels not spelled as in the SPDX spec (for example
+// BLAKE2B-256) are matched ignoring case. Returns HashAlgorithm_UNKNOWN if
+// the label is not a known algorithm.
 func HashAlgorithmFromSPDX(spdxAlgo common.ChecksumAlgorithm) HashAlgorithm {
 	switch spdxAlgo {
 	case common.ADLER32:
 		return HashAlgorithm_ADLER32
+	case common.MD2:
+		return HashAlgorithm_MD2
 	case common.MD4:
 		return HashAlgorithm_MD4
 	case common.MD5:
@@ -110,9 +179,15 @@ func HashAlgorithmFromSPDX(spdxAlgo common.ChecksumAlgorithm) HashAlgorithm {
 		return HashAlgorithm_BLAKE2B_512
 	case common.BLAKE3:
 		return HashAlgorithm_BLAKE3
-	default:
-		return HashAlgorithm_UNKNOWN
 	}
+
+	for v := range HashAlgorithm_name {
+		label := HashAlgorithm(v).ToSPDX()
+		if label != "" && strings.EqualFold(string(label), strings.TrimSpace(string(spdxAlgo))) {
+			return HashAlgorithm(v)
+		}
+	}
+	return HashAlgorithm_UNKNOWN
 }
 
 // ToSPDX3 converts the hash algorithm enumeration to an SPDX3 algorithm label.
@@ -0,0 +1,90 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashAlgorithmSPDX(t *testing.T) {
+	// Every algorithm maps to SPDX 2.3 and back
+	for name, value := range HashAlgorithm_value {
+		algo := HashAlgorithm(value)
+		label := algo.ToSPDX()
+		if algo == HashAlgorithm_UNKNOWN {
+			require.Empty(t, label)
+			continue
+		}
+		require.NotEmpty(t, label, name)
+		require.Equal(t, algo, HashAlgorithmFromSPDX(label), name)
+	}
+
+	for label, expected := range map[common.ChecksumAlgorithm]HashAlgorithm{
+		"SHA3-256":    HashAlgorithm_SHA3_256,
+		"BLAKE2b-384": HashAlgorithm_BLAKE2B_384,
+		"BLAKE2B-512": HashAlgorithm_BLAKE2B_512,
+		"adler32":     HashAlgorithm_ADLER32,
+		"MD2":         HashAlgorithm_MD2,
+		"SHA3_256":    HashAlgorithm_UNKNOWN,
+		"CRC32":       HashAlgorithm_UNKNOWN,
+		"":            HashAlgorithm_UNKNOWN,
+	} {
+		require.Equal(t, expected, HashAlgorithmFromSPDX(label), label)
+	}
+}
+
+func TestParseHashAlgorithm(t *testing.T) {
+	// Every algorithm can be parsed from its enum name
+	for name, value := range HashAlgorithm_value {
+		algo, err := ParseHashAlgorithm(name)
+		if HashAlgorithm(value) == HashAlgorithm_UNKNOWN {
+			require.ErrorIs(t, err, ErrUnsupportedHashAlgorithm)
+			continue
+		}
+		require.NoError(t, err, name)
+		require.Equal(t, HashAlgorithm(value), algo, name)
+	}
+
+	for name, expected := range map[string]HashAlgorithm{
+		"SHA-256":     HashAlgorithm_SHA256,
+		"sha256":      HashAlgorithm_SHA256,
+		"SHA256":      HashAlgorithm_SHA256,
+		" Sha_256 ":   HashAlgorithm_SHA256,
+		"SHA-1":       HashAlgorithm_SHA1,
+		"SHA3-512":    HashAlgorithm_SHA3_512,
+		"sha3_384":    HashAlgorithm_SHA3_384,
+		"BLAKE2b-256": HashAlgorithm_BLAKE2B_256,
+		"blake2b512":  HashAlgorithm_BLAKE2B_512,
+		"BLAKE3":      HashAlgorithm_BLAKE3,
+		"adler32":     HashAlgorithm_ADLER32,
+		"md5":         HashAlgorithm_MD5,
+	} {
+		algo, err := ParseHashAlgorithm(name)
+		require.NoError(t, err, name)
+		require.Equal(t, expected, algo, name)
+	}
+
+	for _, name := range []string{"", "CRC32", "SHA", "UNKNOWN", "SHA-257"} {
+		algo, err := ParseHashAlgorithm(name)
+		require.ErrorIs(t, err, ErrUnsupportedHashAlgorithm, name)
+		require.Equal(t, HashAlgorithm_UNKNOWN, algo)
+	}
+}
+
+func TestHashAlgorithmHexLength(t *testing.T) {
+	for algo, expected := range map[HashAlgorithm]int{
+		HashAlgorithm_UNKNOWN:  0,
+		HashAlgorithm_ADLER32:  8,
+		HashAlgorithm_MD5:      32,
+		HashAlgorithm_SHA1:     40,
+		HashAlgorithm_SHA224:   56,
+		HashAlgorithm_SHA256:   64,
+		HashAlgorithm_SHA3_384: 96,
+		HashAlgorithm_SHA512:   128,
+		HashAlgorithm_BLAKE3:   0,
+		HashAlgorithm_MD6:      0,
+	} {
+		require.Equal(t, expected, algo.HexLength(), algo.String())
+	}
+}
@@ -1,11 +1,88 @@
 package sbom
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
 	"strings"
+	"unicode"
 
 	"github.com/bom-squad/protobom/pkg/formats/spdx"
 )
 
+var (
+	// ErrNoPURL is returned by Node.PackageURL when the node has no purl
+	ErrNoPURL = errors.New("node has no purl identifier")
+
+	// ErrNoCPE is returned by Node.CPE when the node has no CPE
+	ErrNoCPE = errors.New("node has no CPE identifier")
+
+	// ErrUnknownEcosystem is returned when the type of a purl is not one of
+	// the well-known package ecosystems
+	ErrUnknownEcosystem = errors.New("unknown package ecosystem")
+)
+
+// knownEcosystems are the package types registered in the purl specification
+var knownEcosystems = map[string]struct{}{
+	"alpm": {}, "apk": {}, "bitbucket": {}, "bitnami": {}, "cargo": {},
+	"cocoapods": {}, "composer": {}, "conan": {}, "conda": {}, "cran": {},
+	"deb": {}, "docker": {}, "gem": {}, "generic": {}, "github": {},
+	"golang": {}, "hackage": {}, "hex": {}, "huggingface": {}, "maven": {},
+	"mlflow": {}, "npm": {}, "nuget": {}, "oci": {}, "pub": {}, "pypi": {},
+	"qpkg": {}, "rpm": {}, "swid": {}, "swift": {},
+}
+
+// PackageURL returns the purl identifier of the node or ErrNoPURL if it
+// has none.
+func (n *Node) PackageURL() (string, error) {
+	if purl := strings.TrimSpace(n.GetIdentifiers()[int32(SoftwareIdentifierType_PURL)]); purl != "" {
+		return purl, nil
+	}
+	return "", ErrNoPURL
+}
+
+// CPE returns the CPE identifier of the node or ErrNoCPE if it has none.
+// CPE 2.3 identifiers are preferred over CPE 2.2 when the node has both.
+func (n *Node) CPE() (string, error) {
+	for _, t := range []SoftwareIdentifierType{SoftwareIdentifierType_CPE23, SoftwareIdentifierType_CPE22} {
+		if cpe := strings.TrimSpace(n.GetIdentifiers()[int32(t)]); cpe != "" {
+			return cpe, nil
+		}
+	}
+	return "", ErrNoCPE
+}
+
+// Ecosystem returns the package ecosystem of the node, read from the type of
+// its purl, for example "npm" or "pypi". It returns ErrNoPURL when the node
+// has no purl and ErrUnknownEcosystem when the purl type is not well-known.
+func (n *Node) Ecosystem() (string, error) {
+	purl, err := n.PackageURL()
+	if err != nil {
+		return "", err
+	}
+	return EcosystemFromPURL(purl)
+}
+
+// EcosystemFromPURL returns the package ecosystem encoded as the type of a
+// package url, for example "pkg:npm/%40babel/core@7.0.0" returns "npm". The
+// type is returned in lowercase as the spec makes it case insensitive. Types
+// not registered in the purl specification return ErrUnknownEcosystem.
+func EcosystemFromPURL(purl string) (string, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(purl), "pkg:")
+	if !ok {
+		return "", fmt.Errorf("package url %q does not start with pkg:", purl)
+	}
+	ecosystem, _, ok := strings.Cut(strings.TrimLeft(rest, "/"), "/")
+	if !ok || ecosystem == "" {
+		return "", fmt.Errorf("package url %q has no type", purl)
+	}
+	ecosystem = strings.ToLower(ecosystem)
+	if _, ok := knownEcosystems[ecosystem]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownEcosystem, ecosystem)
+	}
+	return ecosystem, nil
+}
+
 // SoftwareIdentifierTypeFromString resolves a string into one of our built-in
 // identifier types
 func SoftwareIdentifierTypeFromString(queryString string) SoftwareIdentifierType {
@@ -37,6 +114,10 @@ func SoftwareIdentifierTypeFromSPDXExtRefType(spdxType string) SoftwareIdentifie
 		return SoftwareIdentifierType_CPE23
 	case spdx.ExtRefTypeGitoid:
 		return SoftwareIdentifierType_GITOID
+	case spdx.ExtRefTypeSWID:
+		return SoftwareIdentifierType_SWID
+	case spdx.ExtRefTypeSWH:
+		return SoftwareIdentifierType_SWHID
 	default:
 		return SoftwareIdentifierType_UNKNOWN_IDENTIFIER_TYPE
 	}
@@ -46,11 +127,11 @@ func SoftwareIdentifierTypeFromSPDXExtRefType(spdxType string) SoftwareIdentifie
 // spdx 2.x vocabulary.
 func (i SoftwareIdentifierType) ToSPDX2Category() string {
 	switch i.ToSPDX2Type() {
-	case spdx.ExtRefTypeCPE22, spdx.ExtRefTypeCPE23, "advisory", "fix", "url", "swid":
+	case spdx.ExtRefTypeCPE22, spdx.ExtRefTypeCPE23, "advisory", "fix", "url", spdx.ExtRefTypeSWID:
 		return spdx.CategorySecurity
 	case "maven-central", "npm", "nuget", "bower", spdx.ExtRefTypePurl:
 		return spdx.CategoryPackageManager
-	case "swh", spdx.ExtRefTypeGitoid:
+	case spdx.ExtRefTypeSWH, spdx.ExtRefTypeGitoid:
 		return spdx.CategoryPersistentID
 	default:
 		return spdx.CategoryOther
@@ -68,7 +149,180 @@ func (i SoftwareIdentifierType) ToSPDX2Type() string {
 		return spdx.ExtRefTypeCPE23
 	case SoftwareIdentifierType_GITOID:
 		return spdx.ExtRefTypeGitoid
+	case SoftwareIdentifierType_SWID:
+		return spdx.ExtRefTypeSWID
+	case SoftwareIdentifierType_SWHID:
+		return spdx.ExtRefTypeSWH
 	default:
 		return ""
 	}
 }
+
+// purlNameVersion returns the name, namespace and version encoded in a
+// package url, for example "pkg:npm/%40babel/core@7.0.0" returns "core",
+// "@babel" and "7.0.0".
+func purlNameVersion(purl string) (name, namespace, version string, err error) {
+	rest, ok := strings.CutPrefix(purl, "pkg:")
+	if !ok {
+		return "", "", "", fmt.Errorf("package url %q does not start with pkg:", purl)
+	}
+	rest, _, _ = strings.Cut(rest, "#")
+	rest, _, _ = strings.Cut(rest, "?")
+	if i := strings.LastIndex(rest, "@"); i > strings.LastIndex(rest, "/") {
+		version, err = url.PathUnescape(rest[i+1:])
+		if err != nil {
+			return "", "", "", fmt.Errorf("decoding package url version: %w", err)
+		}
+		rest = rest[:i]
+	}
+
+	segments := strings.Split(strings.Trim(rest, "/"), "/")
+	if len(segments) < 2 || segments[len(segments)-1] == "" {
+		return "", "", "", fmt.Errorf("package url %q has no name", purl)
+	}
+	name, err = url.PathUnescape(segments[len(segments)-1])
+	if err != nil {
+		return "", "", "", fmt.Errorf("decoding package url name: %w", err)
+	}
+	namespace, err = url.PathUnescape(strings.Join(segments[1:len(segments)-1], "/"))
+	if err != nil {
+		return "", "", "", fmt.Errorf("decoding package url namespace: %w", err)
+	}
+	return name, namespace, version, nil
+}
+
+// cpeProductVersion returns the product and version of a CPE in the 2.3
+// formatted string or the 2.2 URI binding. Components set to ANY (*) or NA
+// (-) are returned blank.
+func cpeProductVersion(cpe string) (product, version string, err error) {
+	var fields []string
+	switch {
+	case strings.HasPrefix(cpe, "cpe:2.3:"):
+		fields = splitCPE23(cpe)
+		if len(fields) < 6 {
+			return "", "", fmt.Errorf("CPE %q has too few components", cpe)
+		}
+		product, version = fields[4], fields[5]
+	case strings.HasPrefix(cpe, "cpe:/"):
+		fields = strings.Split(strings.TrimPrefix(cpe, "cpe:/"), ":")
+		if len(fields) > 2 {
+			if product, err = url.PathUnescape(fields[2]); err != nil {
+				return "", "", fmt.Errorf("decoding CPE product: %w", err)
+			}
+		}
+		if len(fields) > 3 {
+			if version, err = url.PathUnescape(fields[3]); err != nil {
+				return "", "", fmt.Errorf("decoding CPE version: %w", err)
+			}
+		}
+	default:
+		return "", "", fmt.Errorf("%q is not a CPE", cpe)
+	}
+
+	if product == "*" || product == "-" {
+		product = ""
+	}
+	if version == "*" || version == "-" {
+		version = ""
+	}
+	return product, version, nil
+}
+
+// splitCPE23 splits a CPE 2.3 formatted string into its unescaped
+// components
+func splitCPE23(cpe string) []string {
+	fields := []string{}
+	var current strings.Builder
+	escaped := false
+	for _, r := range cpe {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ':':
+			fields = append(fields, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(fields, current.String())
+}
+
+// purlWithVersion returns the package url with its version replaced. Package
+// urls without a version are returned unchanged.
+func purlWithVersion(purl, version string) (string, error) {
+	if _, _, _, err := purlNameVersion(purl); err != nil {
+		return "", err
+	}
+
+	// The version is the last @ of the part before the qualifiers and subpath
+	end := len(purl)
+	if i := strings.IndexAny(purl, "?#"); i != -1 {
+		end = i
+	}
+	rest := purl[:end]
+	i := strings.LastIndex(rest, "@")
+	if i < strings.LastIndex(rest, "/") {
+		return purl, nil
+	}
+	if version == "" {
+		return rest[:i] + purl[end:], nil
+	}
+	return rest[:i+1] + url.PathEscape(version) + purl[end:], nil
+}
+
+// cpeWithVersion returns the CPE with its version replaced. CPEs with the
+// version set to ANY (*) or NA (-), or without one, are returned unchanged.
+func cpeWithVersion(cpe, version string) (string, error) {
+	_, current, err := cpeProductVersion(cpe)
+	if err != nil {
+		return "", err
+	}
+	if current == "" {
+		return cpe, nil
+	}
+
+	if strings.HasPrefix(cpe, "cpe:/") {
+		fields := strings.Split(cpe, ":")
+		fields[4] = url.PathEscape(version)
+		// Trailing blank components are dropped from the URI binding
+		return strings.TrimRight(strings.Join(fields, ":"), ":"), nil
+	}
+
+	// Split the formatted string keeping the escaped characters
+	fields := []string{}
+	start, escaped := 0, false
+	for i, r := range cpe {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ':':
+			fields = append(fields, cpe[start:i])
+			start = i + 1
+		}
+	}
+	fields = append(fields, cpe[start:])
+	fields[5] = escapeCPE23(version)
+	return strings.Join(fields, ":"), nil
+}
+
+// escapeCPE23 escapes a value to be used as a component of a CPE 2.3
+// formatted string. Blank values are written as ANY.
+func escapeCPE23(value string) string {
+	if value == "" {
+		return "*"
+	}
+	var b strings.Builder
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' && r != '-' {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
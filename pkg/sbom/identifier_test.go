@@ -16,6 +16,8 @@ func TestIToSPDX2Category(t *testing.T) {
 		{SoftwareIdentifierType_CPE23, spdx.CategorySecurity},
 		{SoftwareIdentifierType_CPE22, spdx.CategorySecurity},
 		{SoftwareIdentifierType_GITOID, spdx.CategoryPersistentID},
+		{SoftwareIdentifierType_SWID, spdx.CategorySecurity},
+		{SoftwareIdentifierType_SWHID, spdx.CategoryPersistentID},
 		{SoftwareIdentifierType(328742873), spdx.CategoryOther},
 	} {
 		require.Equal(t, tc.expected, tc.sut.ToSPDX2Category())
@@ -31,8 +33,112 @@ func TestIToSPDX2Type(t *testing.T) {
 		{SoftwareIdentifierType_CPE23, spdx.ExtRefTypeCPE23},
 		{SoftwareIdentifierType_CPE22, spdx.ExtRefTypeCPE22},
 		{SoftwareIdentifierType_GITOID, spdx.ExtRefTypeGitoid},
+		{SoftwareIdentifierType_SWID, spdx.ExtRefTypeSWID},
+		{SoftwareIdentifierType_SWHID, spdx.ExtRefTypeSWH},
 		{SoftwareIdentifierType(1234123415), ""},
 	} {
 		require.Equal(t, tc.expected, tc.sut.ToSPDX2Type())
 	}
 }
+
+func TestNodePrimaryIdentifiers(t *testing.T) {
+	const (
+		purl  = "pkg:npm/lib-a@1.0.0"
+		cpe22 = "cpe:/a:example:lib-a:1.0.0"
+		cpe23 = "cpe:2.3:a:example:lib-a:1.0.0:*:*:*:*:*:*:*"
+	)
+	for _, tc := range []struct {
+		name         string
+		identifiers  map[SoftwareIdentifierType]string
+		expectedPURL string
+		expectedCPE  string
+	}{
+		{"no identifiers", nil, "", ""},
+		{"purl", map[SoftwareIdentifierType]string{SoftwareIdentifierType_PURL: purl}, purl, ""},
+		{"cpe 2.2", map[SoftwareIdentifierType]string{SoftwareIdentifierType_CPE22: cpe22}, "", cpe22},
+		{
+			"all", map[SoftwareIdentifierType]string{
+				SoftwareIdentifierType_PURL:  purl,
+				SoftwareIdentifierType_CPE22: cpe22,
+				SoftwareIdentifierType_CPE23: cpe23,
+				SoftwareIdentifierType_SWID:  "swid",
+			}, purl, cpe23,
+		},
+		{"blank values", map[SoftwareIdentifierType]string{SoftwareIdentifierType_PURL: " ", SoftwareIdentifierType_CPE23: ""}, "", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &Node{Id: "node", Identifiers: map[int32]string{}}
+			for idType, value := range tc.identifiers {
+				n.Identifiers[int32(idType)] = value
+			}
+
+			p, err := n.PackageURL()
+			if tc.expectedPURL == "" {
+				require.ErrorIs(t, err, ErrNoPURL)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedPURL, p)
+
+			c, err := n.CPE()
+			if tc.expectedCPE == "" {
+				require.ErrorIs(t, err, ErrNoCPE)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedCPE, c)
+		})
+	}
+
+	// Nil nodes have no identifiers
+	var n *Node
+	_, err := n.PackageURL()
+	require.ErrorIs(t, err, ErrNoPURL)
+}
+
+func TestEcosystemFromPURL(t *testing.T) {
+	for _, tc := range []struct {
+		purl     string
+		expected string
+		errIs    error
+		mustErr  bool
+	}{
+		{purl: "pkg:npm/%40babel/core@7.0.0", expected: "npm"},
+		{purl: "pkg:pypi/django@1.11.1", expected: "pypi"},
+		{purl: "pkg:maven/org.apache.commons/io@1.3.4", expected: "maven"},
+		{purl: "pkg:Golang/github.com/google/uuid", expected: "golang"},
+		{purl: "pkg://deb/debian/curl@7.50.3-1?arch=i386", expected: "deb"},
+		{purl: "pkg:nonexistent/lib@1.0", errIs: ErrUnknownEcosystem},
+		{purl: "npm/lib@1.0", mustErr: true},
+		{purl: "pkg:npm", mustErr: true},
+		{purl: "", mustErr: true},
+	} {
+		t.Run(tc.purl, func(t *testing.T) {
+			ecosystem, err := EcosystemFromPURL(tc.purl)
+			switch {
+			case tc.errIs != nil:
+				require.ErrorIs(t, err, tc.errIs)
+			case tc.mustErr:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expected, ecosystem)
+		})
+	}
+}
+
+func TestNodeEcosystem(t *testing.T) {
+	n := &Node{Id: "node"}
+	_, err := n.Ecosystem()
+	require.ErrorIs(t, err, ErrNoPURL)
+
+	n.Identifiers = map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:cargo/rand@0.7.2"}
+	ecosystem, err := n.Ecosystem()
+	require.NoError(t, err)
+	require.Equal(t, "cargo", ecosystem)
+
+	n.Identifiers[int32(SoftwareIdentifierType_PURL)] = "pkg:custom/rand@0.7.2"
+	_, err = n.Ecosystem()
+	require.ErrorIs(t, err, ErrUnknownEcosystem)
+}
@@ -0,0 +1,273 @@
+package sbom
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sigs.k8s.io/release-utils/version"
+)
+
+// MetadataMergePolicy controls how the metadata of two documents is combined
+// by Metadata.Merge.
+type MetadataMergePolicy struct {
+	// JoinComments concatenates the comments (document and creator comments)
+	// of both documents. When false, the comment of the other document is
+	// only used if the receiver's comment is empty.
+	JoinComments bool
+
+	// CommentSeparator is the string inserted between joined comments. If
+	// blank, comments are separated with a newline.
+	CommentSeparator string
+}
+
+// DefaultMetadataMergePolicy joins the comments of the merged documents
+var DefaultMetadataMergePolicy = MetadataMergePolicy{
+	JoinComments:     true,
+	CommentSeparator: "\n",
+}
+
+// Merge combines the metadata in other into m. Tools and authors are added
+// if not already present (tools are compared by name, authors by name and
+// email), the earliest creation date is kept and the document
+// types, annotations, extracted licenses, external documents, external
+// references, compositions and properties of other are appended, skipping
+// duplicates (extracted licenses and external documents are compared by
+// ID). The identity of m (id, version and name) is only filled from other
+// when blank. Protobom is recorded in the tools of m.
+func (m *Metadata) Merge(other *Metadata, policy MetadataMergePolicy) {
+	if other == nil {
+		return
+	}
+
+	if m.Id == "" {
+		m.Id = other.Id
+	}
+	if m.Version == "" {
+		m.Version = other.Version
+	}
+	if m.Name == "" {
+		m.Name = other.Name
+	}
+
+	if other.Date != nil && (m.Date == nil || other.Date.AsTime().Before(m.Date.AsTime())) {
+		m.Date = timestamppb.New(other.Date.AsTime())
+	}
+
+	for _, t := range other.Tools {
+		m.mergeTool(t)
+	}
+	m.AddTool("protobom", version.GetVersionInfo().GitVersion)
+
+	for _, a := range other.Authors {
+		if a == nil || m.hasAuthor(a) {
+			continue
+		}
+		m.Authors = append(m.Authors, a.Copy())
+	}
+
+	for _, dt := range other.DocumentTypes {
+		if dt == nil || m.hasDocumentType(dt) {
+			continue
+		}
+		m.DocumentTypes = append(m.DocumentTypes, proto.Clone(dt).(*DocumentType))
+	}
+
+	for _, a := range other.Annotations {
+		if a == nil || m.hasAnnotation(a) {
+			continue
+		}
+		m.Annotations = append(m.Annotations, a.Copy())
+	}
+
+	for _, l := range other.ExtractedLicenses {
+		if l == nil || m.GetExtractedLicense(l.Id) != nil {
+			continue
+		}
+		m.ExtractedLicenses = append(m.ExtractedLicenses, proto.Clone(l).(*ExtractedLicense))
+	}
+
+	for _, ed := range other.ExternalDocuments {
+		if ed == nil || m.GetExternalDocument(ed.Id) != nil {
+			continue
+		}
+		m.ExternalDocuments = append(m.ExternalDocuments, proto.Clone(ed).(*ExternalDocument))
+	}
+
+	for _, er := range other.ExternalReferences {
+		if er == nil || m.hasExternalReference(er) {
+			continue
+		}
+		m.ExternalReferences = append(m.ExternalReferences, proto.Clone(er).(*ExternalReference))
+	}
+
+	for _, c := range other.Compositions {
+		if c == nil || m.hasComposition(c) {
+			continue
+		}
+		m.Compositions = append(m.Compositions, proto.Clone(c).(*Composition))
+	}
+
+	for _, p := range other.Properties {
+		if p == nil || m.hasProperty(p) {
+			continue
+		}
+		m.Properties = append(m.Properties, p.Copy())
+	}
+
+	m.Comment = mergeComments(m.Comment, other.Comment, policy)
+	m.CreatorComment = mergeComments(m.CreatorComment, other.CreatorComment, policy)
+
+	if m.LicenseListVersion == "" {
+		m.LicenseListVersion = other.LicenseListVersion
+	}
+}
+
+// GetExtractedLicense returns the extracted license with the LicenseRef
+// identifier id or nil if the metadata does not define it.
+func (m *Metadata) GetExtractedLicense(id string) *ExtractedLicense {
+	for _, l := range m.ExtractedLicenses {
+		if l != nil && l.Id == id {
+			return l
+		}
+	}
+	return nil
+}
+
+// GetExternalDocument returns the external document with the specified ID
+// or nil if the metadata does not reference it.
+func (m *Metadata) GetExternalDocument(id string) *ExternalDocument {
+	for _, ed := range m.ExternalDocuments {
+		if ed != nil && ed.Id == id {
+			return ed
+		}
+	}
+	return nil
+}
+
+// AddTool records the tool named name, at version, in the metadata. Tools
+// are identified by their name, if the metadata already lists a tool with
+// the same name it is not added again and only its version is updated.
+func (m *Metadata) AddTool(name, version string) {
+	if name == "" {
+		return
+	}
+	if t := m.getTool(name); t != nil {
+		if version != "" {
+			t.Version = version
+		}
+		return
+	}
+	m.Tools = append(m.Tools, &Tool{Name: name, Version: version})
+}
+
+// mergeTool adds tool t to the metadata unless a tool with the same name is
+// already listed. If it is, its missing version and vendor are completed.
+func (m *Metadata) mergeTool(t *Tool) {
+	if t == nil {
+		return
+	}
+	if mt := m.getTool(t.Name); mt != nil {
+		if mt.Version == "" {
+			mt.Version = t.Version
+		}
+		if mt.Vendor == "" {
+			mt.Vendor = t.Vendor
+		}
+		return
+	}
+	m.Tools = append(m.Tools, &Tool{Name: t.Name, Version: t.Version, Vendor: t.Vendor})
+}
+
+// getTool returns the tool named name or nil if the metadata does not list
+// it. Tools are identified by their name only.
+func (m *Metadata) getTool(name string) *Tool {
+	for _, t := range m.Tools {
+		if t != nil && t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// hasAuthor returns true if the metadata lists an author with the same name
+// and email as a
+func (m *Metadata) hasAuthor(a *Person) bool {
+	for _, ma := range m.Authors {
+		if ma.Name == a.Name && ma.Email == a.Email {
+			return true
+		}
+	}
+	return false
+}
+
+// hasExternalReference returns true if the metadata has an external
+// reference equal to er
+func (m *Metadata) hasExternalReference(er *ExternalReference) bool {
+	for _, mer := range m.ExternalReferences {
+		if proto.Equal(mer, er) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasComposition returns true if the metadata has a composition equal to c
+func (m *Metadata) hasComposition(c *Composition) bool {
+	for _, mc := range m.Compositions {
+		if proto.Equal(mc, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasProperty returns true if the metadata has a property with the same
+// name and value as p
+func (m *Metadata) hasProperty(p *Property) bool {
+	for _, mp := range m.Properties {
+		if mp.GetName() == p.Name && mp.GetValue() == p.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDocumentType returns true if the metadata has a document type equal to dt
+func (m *Metadata) hasDocumentType(dt *DocumentType) bool {
+	for _, mdt := range m.DocumentTypes {
+		if proto.Equal(mdt, dt) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnnotation returns true if the metadata has an annotation equal to a
+func (m *Metadata) hasAnnotation(a *Annotation) bool {
+	for _, ma := range m.Annotations {
+		if ma.flatString() == a.flatString() {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeComments returns the result of combining comment into current as
+// defined by the policy
+func mergeComments(current, comment string, policy MetadataMergePolicy) string {
+	switch {
+	case strings.TrimSpace(comment) == "" || comment == current:
+		return current
+	case current == "":
+		return comment
+	case policy.JoinComments:
+		sep := policy.CommentSeparator
+		if sep == "" {
+			sep = "\n"
+		}
+		return current + sep + comment
+	default:
+		return current
+	}
+}
@@ -0,0 +1,216 @@
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestMetadataMerge(t *testing.T) {
+	early := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	build := DocumentType_BUILD
+	analyzed := DocumentType_ANALYZED
+
+	m := &Metadata{
+		Id:      "urn:uuid:1",
+		Date:    timestamppb.New(late),
+		Tools:   []*Tool{{Name: "syft", Version: "0.96.0"}},
+		Authors: []*Person{{Name: "John Doe", Email: "john@example.com"}},
+		Comment: "First",
+		DocumentTypes: []*DocumentType{
+			{Type: &build},
+		},
+	}
+	other := &Metadata{
+		Id:   "urn:uuid:2",
+		Name: "other",
+		Date: timestamppb.New(early),
+		Tools: []*Tool{
+			{Name: "syft", Version: "0.96.0", Vendor: "Anchore"},
+			{Name: "syft", Version: "1.0.0"},
+			{Name: "trivy", Version: "0.42.1"},
+		},
+		Authors: []*Person{
+			{Name: "John Doe", Email: "john@example.com"},
+			{Name: "Acme", IsOrg: true},
+		},
+		Comment: "Second",
+		DocumentTypes: []*DocumentType{
+			{Type: &build},
+			{Type: &analyzed},
+		},
+	}
+
+	m.Merge(other, DefaultMetadataMergePolicy)
+
+	require.Equal(t, "urn:uuid:1", m.Id)
+	require.Equal(t, "other", m.Name)
+	require.Equal(t, early, m.Date.AsTime())
+	// Tools are identified by name, protobom records itself as a tool
+	require.Len(t, m.Tools, 3)
+	require.True(t, proto.Equal(&Tool{Name: "syft", Version: "0.96.0", Vendor: "Anchore"}, m.Tools[0]))
+	require.Equal(t, "trivy", m.Tools[1].Name)
+	require.Equal(t, "protobom", m.Tools[2].Name)
+	require.Len(t, m.Authors, 2)
+	require.Equal(t, "Acme", m.Authors[1].Name)
+	require.Len(t, m.DocumentTypes, 2)
+	require.Equal(t, "First\nSecond", m.Comment)
+
+	// Merging again does not duplicate data
+	m.Merge(other, DefaultMetadataMergePolicy)
+	require.Len(t, m.Tools, 3)
+	require.Len(t, m.Authors, 2)
+	require.Len(t, m.DocumentTypes, 2)
+
+	// The other metadata is not modified or aliased
+	require.Len(t, other.Tools, 3)
+	m.Tools[1].Name = "changed"
+	require.Equal(t, "trivy", other.Tools[2].Name)
+}
+
+func TestMetadataMergeDate(t *testing.T) {
+	early := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name     string
+		date     *timestamppb.Timestamp
+		other    *timestamppb.Timestamp
+		expected *time.Time
+	}{
+		{"other earlier", timestamppb.New(late), timestamppb.New(early), &early},
+		{"other later", timestamppb.New(early), timestamppb.New(late), &early},
+		{"no date", nil, timestamppb.New(late), &late},
+		{"other without date", timestamppb.New(late), nil, &late},
+		{"none", nil, nil, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Metadata{Date: tc.date}
+			m.Merge(&Metadata{Date: tc.other}, DefaultMetadataMergePolicy)
+			if tc.expected == nil {
+				require.Nil(t, m.Date)
+				return
+			}
+			require.Equal(t, *tc.expected, m.Date.AsTime())
+		})
+	}
+}
+
+func TestMetadataMergeComment(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		comment  string
+		other    string
+		policy   MetadataMergePolicy
+		expected string
+	}{
+		{"join", "a", "b", DefaultMetadataMergePolicy, "a\nb"},
+		{"custom separator", "a", "b", MetadataMergePolicy{JoinComments: true, CommentSeparator: "; "}, "a; b"},
+		{"default separator", "a", "b", MetadataMergePolicy{JoinComments: true}, "a\nb"},
+		{"keep", "a", "b", MetadataMergePolicy{}, "a"},
+		{"fill empty", "", "b", MetadataMergePolicy{}, "b"},
+		{"same comment", "a", "a", DefaultMetadataMergePolicy, "a"},
+		{"blank other", "a", " ", DefaultMetadataMergePolicy, "a"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Metadata{Comment: tc.comment, CreatorComment: tc.comment}
+			m.Merge(&Metadata{Comment: tc.other, CreatorComment: tc.other}, tc.policy)
+			require.Equal(t, tc.expected, m.Comment)
+			require.Equal(t, tc.expected, m.CreatorComment)
+		})
+	}
+}
+
+func TestMetadataMergeExtractedLicenses(t *testing.T) {
+	m := &Metadata{
+		ExtractedLicenses: []*ExtractedLicense{{Id: "LicenseRef-one", Text: "one"}},
+	}
+	other := &Metadata{
+		ExtractedLicenses: []*ExtractedLicense{
+			{Id: "LicenseRef-one", Text: "other one"},
+			{Id: "LicenseRef-two", Text: "two"},
+		},
+	}
+	m.Merge(other, DefaultMetadataMergePolicy)
+	require.Len(t, m.ExtractedLicenses, 2)
+	require.Equal(t, "one", m.GetExtractedLicense("LicenseRef-one").Text)
+	require.Equal(t, "two", m.GetExtractedLicense("LicenseRef-two").Text)
+	require.Nil(t, m.GetExtractedLicense("LicenseRef-three"))
+
+	// Merged licenses are copies
+	other.ExtractedLicenses[1].Text = "changed"
+	require.Equal(t, "two", m.GetExtractedLicense("LicenseRef-two").Text)
+}
+
+func TestMetadataMergeExternalDocuments(t *testing.T) {
+	m := &Metadata{ExternalDocuments: []*ExternalDocument{{Id: "one", Uri: "https://example.com/one"}}}
+	other := &Metadata{ExternalDocuments: []*ExternalDocument{
+		{Id: "one", Uri: "https://example.com/changed"},
+		{Id: "two", Uri: "https://example.com/two"},
+	}}
+
+	m.Merge(other, DefaultMetadataMergePolicy)
+	require.Len(t, m.ExternalDocuments, 2)
+	require.Equal(t, "https://example.com/one", m.GetExternalDocument("one").Uri)
+	require.Equal(t, "https://example.com/two", m.GetExternalDocument("two").Uri)
+	require.Nil(t, m.GetExternalDocument("three"))
+
+	// Merged documents are copies
+	other.ExternalDocuments[1].Uri = "modified"
+	require.Equal(t, "https://example.com/two", m.GetExternalDocument("two").Uri)
+}
+
+func TestMetadataMergeExternalReferences(t *testing.T) {
+	m := &Metadata{ExternalReferences: []*ExternalReference{
+		{Url: "https://example.com/bom.json", Type: ExternalReference_BOM},
+	}}
+	other := &Metadata{ExternalReferences: []*ExternalReference{
+		{Url: "https://example.com/bom.json", Type: ExternalReference_BOM},
+		{Url: "https://ci.example.com/build/1", Type: ExternalReference_BUILD_META},
+	}}
+
+	m.Merge(other, DefaultMetadataMergePolicy)
+	require.Len(t, m.ExternalReferences, 2)
+	require.Equal(t, ExternalReference_BUILD_META, m.ExternalReferences[1].Type)
+
+	// Merged references are copies
+	other.ExternalReferences[1].Url = "modified"
+	require.Equal(t, "https://ci.example.com/build/1", m.ExternalReferences[1].Url)
+}
+
+func TestMetadataMergeCompositions(t *testing.T) {
+	m := &Metadata{Compositions: []*Composition{
+		{Aggregate: Composition_COMPLETE, Assemblies: []string{"lib-a"}},
+	}}
+	other := &Metadata{Compositions: []*Composition{
+		{Aggregate: Composition_COMPLETE, Assemblies: []string{"lib-a"}},
+		{Aggregate: Composition_INCOMPLETE, Dependencies: []string{"lib-b"}},
+	}}
+
+	m.Merge(other, DefaultMetadataMergePolicy)
+	require.Len(t, m.Compositions, 2)
+	require.Equal(t, Composition_INCOMPLETE, m.Compositions[1].Aggregate)
+
+	// Merged compositions are copies
+	other.Compositions[1].Dependencies[0] = "modified"
+	require.Equal(t, []string{"lib-b"}, m.Compositions[1].Dependencies)
+}
+
+func TestMetadataMergeProperties(t *testing.T) {
+	m := &Metadata{Properties: []*Property{{Name: "ci:pipeline", Value: "release"}}}
+	other := &Metadata{Properties: []*Property{
+		{Name: "ci:pipeline", Value: "release"},
+		{Name: "ci:pipeline", Value: "nightly"},
+	}}
+
+	m.Merge(other, DefaultMetadataMergePolicy)
+	require.Len(t, m.Properties, 2)
+	require.Equal(t, "nightly", m.Properties[1].Value)
+
+	// Merged properties are copies
+	other.Properties[1].Value = "modified"
+	require.Equal(t, "nightly", m.Properties[1].Value)
+}
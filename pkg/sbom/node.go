@@ -2,12 +2,14 @@ package sbom
 
 import (
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"maps"
 	"slices"
 	"sort"
 	"strings"
 
+	"google.golang.org/protobuf/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -51,6 +53,9 @@ func (n *Node) Update(n2 *Node) {
 	if len(n2.Licenses) > 0 {
 		n.Licenses = n2.Licenses
 	}
+	if len(n2.LicensesDetected) > 0 {
+		n.LicensesDetected = n2.LicensesDetected
+	}
 	if n2.LicenseConcluded != "" {
 		n.LicenseConcluded = n2.LicenseConcluded
 	}
@@ -102,9 +107,33 @@ func (n *Node) Update(n2 *Node) {
 	if len(n2.Identifiers) > 0 {
 		n.Identifiers = n2.Identifiers
 	}
+	if len(n2.IdentifierComments) > 0 {
+		n.IdentifierComments = n2.IdentifierComments
+	}
 	if len(n2.FileTypes) > 0 {
 		n.FileTypes = n2.FileTypes
 	}
+	if len(n2.Snippets) > 0 {
+		n.Snippets = n2.Snippets
+	}
+	if len(n2.Annotations) > 0 {
+		n.Annotations = n2.Annotations
+	}
+	if n2.VerificationCode != nil {
+		n.VerificationCode = n2.VerificationCode
+	}
+	if n2.FilesAnalyzed != nil {
+		n.FilesAnalyzed = proto.Bool(*n2.FilesAnalyzed)
+	}
+	if n2.Scope != Node_UNKNOWN_SCOPE {
+		n.Scope = n2.Scope
+	}
+	if n2.ExternalDocument != "" {
+		n.ExternalDocument = n2.ExternalDocument
+	}
+	if len(n2.Properties) > 0 {
+		n.Properties = n2.Properties
+	}
 }
 
 // Augment takes updates fields in n with data from n2 which is not already defined
@@ -128,6 +157,9 @@ func (n *Node) Augment(n2 *Node) {
 	if len(n.Licenses) == 0 && len(n2.Licenses) > 0 {
 		n.Licenses = n2.Licenses
 	}
+	if len(n.LicensesDetected) == 0 && len(n2.LicensesDetected) > 0 {
+		n.LicensesDetected = n2.LicensesDetected
+	}
 	if n.LicenseConcluded == "" && n2.LicenseConcluded != "" {
 		n.LicenseConcluded = n2.LicenseConcluded
 	}
@@ -179,9 +211,33 @@ func (n *Node) Augment(n2 *Node) {
 	if len(n.Identifiers) == 0 && len(n2.Identifiers) > 0 {
 		n.Identifiers = n2.Identifiers
 	}
+	if len(n.IdentifierComments) == 0 && len(n2.IdentifierComments) > 0 {
+		n.IdentifierComments = n2.IdentifierComments
+	}
 	if len(n.FileTypes) == 0 && len(n2.FileTypes) > 0 {
 		n.FileTypes = n2.FileTypes
 	}
+	if len(n.Snippets) == 0 && len(n2.Snippets) > 0 {
+		n.Snippets = n2.Snippets
+	}
+	if len(n.Annotations) == 0 && len(n2.Annotations) > 0 {
+		n.Annotations = n2.Annotations
+	}
+	if n.VerificationCode == nil && n2.VerificationCode != nil {
+		n.VerificationCode = n2.VerificationCode
+	}
+	if n.FilesAnalyzed == nil && n2.FilesAnalyzed != nil {
+		n.FilesAnalyzed = proto.Bool(*n2.FilesAnalyzed)
+	}
+	if n.Scope == Node_UNKNOWN_SCOPE && n2.Scope != Node_UNKNOWN_SCOPE {
+		n.Scope = n2.Scope
+	}
+	if n.ExternalDocument == "" && n2.ExternalDocument != "" {
+		n.ExternalDocument = n2.ExternalDocument
+	}
+	if len(n.Properties) == 0 && len(n2.Properties) > 0 {
+		n.Properties = n2.Properties
+	}
 }
 
 // Copy returns a new node that is a copy of the node
@@ -197,10 +253,11 @@ func (n *Node) Copy() *Node {
 		Licenses:           slices.Clone(n.Licenses),
 		LicenseConcluded:   n.LicenseConcluded,
 		LicenseComments:    n.LicenseComments,
+		LicensesDetected:   slices.Clone(n.LicensesDetected),
 		Copyright:          n.Copyright,
 		Hashes:             maps.Clone(n.Hashes),
 		SourceInfo:         n.SourceInfo,
-		PrimaryPurpose:     n.PrimaryPurpose,
+		PrimaryPurpose:     slices.Clone(n.PrimaryPurpose),
 		Comment:            n.Comment,
 		Summary:            n.Summary,
 		Description:        n.Description,
@@ -212,7 +269,13 @@ func (n *Node) Copy() *Node {
 		ValidUntilDate:     nil,
 		ExternalReferences: []*ExternalReference{},
 		Identifiers:        maps.Clone(n.Identifiers),
+		IdentifierComments: maps.Clone(n.IdentifierComments),
 		FileTypes:          slices.Clone(n.FileTypes),
+		Snippets:           []*Snippet{},
+		Annotations:        []*Annotation{},
+		ExternalDocument:   n.ExternalDocument,
+		Scope:              n.Scope,
+		Properties:         copyProperties(n.Properties),
 	}
 
 	if n.ReleaseDate != nil {
@@ -234,10 +297,36 @@ func (n *Node) Copy() *Node {
 	for _, e := range n.ExternalReferences {
 		no.ExternalReferences = append(no.ExternalReferences, e.Copy())
 	}
+	for _, s := range n.Snippets {
+		no.Snippets = append(no.Snippets, s.Copy())
+	}
+	for _, a := range n.Annotations {
+		no.Annotations = append(no.Annotations, a.Copy())
+	}
+	if n.VerificationCode != nil {
+		no.VerificationCode = &VerificationCode{
+			Value:         n.VerificationCode.Value,
+			ExcludedFiles: slices.Clone(n.VerificationCode.ExcludedFiles),
+		}
+	}
+	if n.FilesAnalyzed != nil {
+		no.FilesAnalyzed = proto.Bool(*n.FilesAnalyzed)
+	}
 
 	return no
 }
 
+// Clone returns a deep copy of the node. Unlike proto.Clone it returns a
+// *Node and does not carry over the protobuf internal state of the original.
+// Modifying the clone, including its nested lists and maps, does not affect
+// the original node. Cloning a nil node returns nil.
+func (n *Node) Clone() *Node {
+	if n == nil {
+		return nil
+	}
+	return n.Copy()
+}
+
 // Equal compares Node n to n2 and returns true if they are the same
 func (n *Node) Equal(n2 *Node) bool {
 	if n2 == nil {
@@ -264,6 +353,19 @@ func (n *Node) flatString() string {
 			for _, i := range n.Originators {
 				pairs = append(pairs, fmt.Sprintf("originator:%s", i.flatString()))
 			}
+		case "bomsquad.protobom.Node.snippets":
+			for _, sn := range n.Snippets {
+				pairs = append(pairs, fmt.Sprintf("snippet:%s", sn.flatString()))
+			}
+		case "bomsquad.protobom.Node.annotations":
+			for _, a := range n.Annotations {
+				pairs = append(pairs, fmt.Sprintf("annotation:%s", a.flatString()))
+			}
+		case "bomsquad.protobom.Node.properties":
+			// The order of the properties is significant
+			for i, p := range n.Properties {
+				pairs = append(pairs, fmt.Sprintf("property[%d]:%s", i, p.flatString()))
+			}
 		case "bomsquad.protobom.Node.identifiers":
 			// Index the keys and sort them to make the string deterministic
 			idKeys := []int{}
@@ -286,9 +388,17 @@ func (n *Node) flatString() string {
 			if n.BuildDate != nil {
 				pairs = append(pairs, fmt.Sprintf("%s:%d", fd.FullName(), n.BuildDate.AsTime().Unix()))
 			}
-		case "bomsquad.protobom.Node.hashes":
+		case "bomsquad.protobom.Node.verification_code":
+			if n.VerificationCode != nil {
+				pairs = append(pairs, fmt.Sprintf(
+					"%s:%s:%s", fd.FullName(), n.VerificationCode.Value,
+					strings.Join(n.VerificationCode.ExcludedFiles, ","),
+				))
+			}
+		case "bomsquad.protobom.Node.hashes", "bomsquad.protobom.Node.identifier_comments":
 			pairs = append(pairs, string(fd.FullName())+":"+flatStringMap(v.Map()))
 		case "bomsquad.protobom.Node.licenses",
+			"bomsquad.protobom.Node.licenses_detected",
 			"bomsquad.protobom.Node.attribution",
 			"bomsquad.protobom.Node.file_types",
 			"bomsquad.protobom.Node.primary_purpose":
@@ -386,12 +496,52 @@ func (n *Node) HashesMatch(th map[int32]string) bool {
 
 // AddHash adds a new hash of algorithm algo to the node. If the node
 // already has a hash of the same algorithm it will get silently replaced.
-func (n *Node) AddHash(algo HashAlgorithm, value string) {
+// Empty values are ignored. Returns ErrUnsupportedHashAlgorithm if algo is
+// not a known algorithm, use ParseHashAlgorithm to get the algorithm from
+// its name as written by the SBOM tools.
+func (n *Node) AddHash(algo HashAlgorithm, value string) error {
+	if _, ok := HashAlgorithm_name[int32(algo)]; !ok || algo == HashAlgorithm_UNKNOWN {
+		return fmt.Errorf("%w: %d", ErrUnsupportedHashAlgorithm, algo)
+	}
+	value = strings.TrimSpace(value)
 	if value == "" {
-		return
+		return nil
 	}
 	if n.Hashes == nil {
 		n.Hashes = map[int32]string{}
 	}
 	n.Hashes[int32(algo)] = value
+	return nil
+}
+
+// SetVersion sets the version of the node and replaces the version in its
+// package URL and CPE identifiers. Identifiers without a version are left
+// as they are. The node version is always updated, identifiers that cannot
+// be parsed are not modified and their errors are returned joined.
+func (n *Node) SetVersion(version string) error {
+	n.Version = version
+
+	errs := []error{}
+	for _, t := range []SoftwareIdentifierType{
+		SoftwareIdentifierType_PURL, SoftwareIdentifierType_CPE23, SoftwareIdentifierType_CPE22,
+	} {
+		id := n.Identifiers[int32(t)]
+		if id == "" {
+			continue
+		}
+
+		var updated string
+		var err error
+		if t == SoftwareIdentifierType_PURL {
+			updated, err = purlWithVersion(id, version)
+		} else {
+			updated, err = cpeWithVersion(id, version)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("updating %s identifier: %w", t, err))
+			continue
+		}
+		n.Identifiers[int32(t)] = updated
+	}
+	return errors.Join(errs...)
 }
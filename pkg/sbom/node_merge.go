@@ -0,0 +1,198 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrConflict is returned by Node.MergeWith when both nodes have different
+// non-empty values for the same field
+var ErrConflict = errors.New("conflicting values")
+
+// ConflictResolution defines how Node.MergeWith handles fields that are set
+// to different values in both nodes
+type ConflictResolution int
+
+const (
+	// ConflictFail makes MergeWith return an ErrConflict error. This is the
+	// default strategy.
+	ConflictFail ConflictResolution = iota
+
+	// ConflictPreferReceiver keeps the values of the node MergeWith is
+	// called on.
+	ConflictPreferReceiver
+
+	// ConflictPreferOther keeps the values of the node passed to MergeWith.
+	ConflictPreferOther
+)
+
+// MergeOption configures Node.MergeWith
+type MergeOption func(*mergeOptions)
+
+type mergeOptions struct {
+	conflictResolution ConflictResolution
+}
+
+// WithConflictResolution sets the strategy MergeWith uses when both nodes
+// have different values for the same field
+func WithConflictResolution(strategy ConflictResolution) MergeOption {
+	return func(o *mergeOptions) {
+		o.conflictResolution = strategy
+	}
+}
+
+// FieldConflictError describes a field with different values in the nodes
+// being merged. It matches ErrConflict when checked with errors.Is.
+type FieldConflictError struct {
+	Field string
+	Value string
+	Other string
+}
+
+func (e *FieldConflictError) Error() string {
+	return fmt.Sprintf("%s: field %s is %q and %q", ErrConflict, e.Field, e.Value, e.Other)
+}
+
+func (e *FieldConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// MergeWith returns a new node combining the fields of the node and other,
+// typically the same component described by two different SBOMs. Fields set
+// in only one of the nodes are copied as is, lists are joined without
+// duplicates and maps are joined by key. The merged node keeps the ID of the
+// receiver.
+//
+// When both nodes have different values in the same field MergeWith returns
+// an error matching ErrConflict listing all the differing fields, unless a
+// strategy to resolve them is set with WithConflictResolution. Neither of the
+// nodes is modified.
+func (n *Node) MergeWith(other *Node, opts ...MergeOption) (*Node, error) {
+	options := mergeOptions{}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	merged := n.Clone()
+	if other == nil {
+		return merged, nil
+	}
+
+	// Clone the other node to avoid sharing messages with the result
+	mm := merged.ProtoReflect()
+	om := other.Clone().ProtoReflect()
+
+	errs := []error{}
+	fields := mm.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Name() == "id" || !om.Has(fd) {
+			continue
+		}
+
+		if !mm.Has(fd) {
+			mm.Set(fd, om.Get(fd))
+			continue
+		}
+
+		switch {
+		case fd.IsList():
+			mergeList(mm.Mutable(fd).List(), om.Get(fd).List(), fd)
+		case fd.IsMap():
+			errs = append(errs, mergeMap(mm.Mutable(fd).Map(), om.Get(fd).Map(), fd, options)...)
+		default:
+			v, ov := mm.Get(fd), om.Get(fd)
+			if valuesEqual(fd, v, ov) {
+				continue
+			}
+			switch options.conflictResolution {
+			case ConflictPreferReceiver:
+			case ConflictPreferOther:
+				mm.Set(fd, ov)
+			default:
+				errs = append(errs, &FieldConflictError{
+					Field: string(fd.Name()),
+					Value: valueString(fd, v),
+					Other: valueString(fd, ov),
+				})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("merging node %s: %w", n.Id, errors.Join(errs...))
+	}
+	return merged, nil
+}
+
+// mergeList appends to list the elements of other it does not contain yet
+func mergeList(list, other protoreflect.List, fd protoreflect.FieldDescriptor) {
+	for i := 0; i < other.Len(); i++ {
+		found := false
+		for j := 0; j < list.Len(); j++ {
+			if valuesEqual(fd, list.Get(j), other.Get(i)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list.Append(other.Get(i))
+		}
+	}
+}
+
+// mergeMap adds to m the entries of other. Keys present in both maps with
+// different values are resolved according to the options.
+func mergeMap(m, other protoreflect.Map, fd protoreflect.FieldDescriptor, options mergeOptions) []error {
+	errs := []error{}
+	other.Range(func(k protoreflect.MapKey, ov protoreflect.Value) bool {
+		if !m.Has(k) {
+			m.Set(k, ov)
+			return true
+		}
+		v := m.Get(k)
+		if valuesEqual(fd.MapValue(), v, ov) {
+			return true
+		}
+		switch options.conflictResolution {
+		case ConflictPreferReceiver:
+		case ConflictPreferOther:
+			m.Set(k, ov)
+		default:
+			errs = append(errs, &FieldConflictError{
+				Field: fmt.Sprintf("%s[%s]", fd.Name(), k.String()),
+				Value: valueString(fd.MapValue(), v),
+				Other: valueString(fd.MapValue(), ov),
+			})
+		}
+		return true
+	})
+	return errs
+}
+
+// valuesEqual compares two singular values of the field
+func valuesEqual(fd protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	if fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+		return proto.Equal(a.Message().Interface(), b.Message().Interface())
+	}
+	if fd.Kind() == protoreflect.BytesKind {
+		return string(a.Bytes()) == string(b.Bytes())
+	}
+	return a.Interface() == b.Interface()
+}
+
+// valueString returns a printable version of a singular value of the field
+func valueString(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return fmt.Sprintf("%v", v.Message().Interface())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+	}
+	return v.String()
+}
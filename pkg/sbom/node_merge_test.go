@@ -0,0 +1,171 @@
+package sbom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNodeMergeWith(t *testing.T) {
+	purl := "pkg:golang/github.com/bom-squad/protobom@v1.0.0"
+	for _, tc := range []struct {
+		name        string
+		node        *Node
+		other       *Node
+		opts        []MergeOption
+		expected    *Node
+		conflicts   []string
+		shouldError bool
+	}{
+		{
+			name:     "nil other node",
+			node:     &Node{Id: "a", Name: "protobom"},
+			expected: &Node{Id: "a", Name: "protobom"},
+		},
+		{
+			name: "fields are combined",
+			node: &Node{
+				Id:          "a",
+				Name:        "protobom",
+				Version:     "1.0.0",
+				Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): purl},
+			},
+			other: &Node{
+				Id:          "b",
+				Name:        "protobom",
+				UrlHome:     "https://github.com/bom-squad/protobom",
+				Licenses:    []string{"Apache-2.0"},
+				Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): purl},
+				Hashes:      map[int32]string{int32(HashAlgorithm_SHA1): "f3ae11065cafc14e27a1410ae8be28e600bb8336"},
+				Suppliers:   []*Person{{Name: "BOM Squad"}},
+			},
+			expected: &Node{
+				Id:          "a",
+				Name:        "protobom",
+				Version:     "1.0.0",
+				UrlHome:     "https://github.com/bom-squad/protobom",
+				Licenses:    []string{"Apache-2.0"},
+				Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): purl},
+				Hashes:      map[int32]string{int32(HashAlgorithm_SHA1): "f3ae11065cafc14e27a1410ae8be28e600bb8336"},
+				Suppliers:   []*Person{{Name: "BOM Squad"}},
+			},
+		},
+		{
+			name: "lists are joined without duplicates",
+			node: &Node{
+				Id:        "a",
+				Licenses:  []string{"Apache-2.0"},
+				Suppliers: []*Person{{Name: "BOM Squad"}},
+			},
+			other: &Node{
+				Id:        "a",
+				Licenses:  []string{"MIT", "Apache-2.0"},
+				Suppliers: []*Person{{Name: "BOM Squad"}, {Name: "John Doe"}},
+			},
+			expected: &Node{
+				Id:        "a",
+				Licenses:  []string{"Apache-2.0", "MIT"},
+				Suppliers: []*Person{{Name: "BOM Squad"}, {Name: "John Doe"}},
+			},
+		},
+		{
+			name: "conflicting values",
+			node: &Node{
+				Id:      "a",
+				Version: "1.0.0",
+				Hashes:  map[int32]string{int32(HashAlgorithm_SHA1): "f3ae11065cafc14e27a1410ae8be28e600bb8336"},
+			},
+			other: &Node{
+				Id:      "a",
+				Version: "1.0.1",
+				Hashes:  map[int32]string{int32(HashAlgorithm_SHA1): "9283475928734987"},
+			},
+			conflicts:   []string{"version", "hashes[2]"},
+			shouldError: true,
+		},
+		{
+			name: "prefer receiver",
+			node: &Node{
+				Id:      "a",
+				Version: "1.0.0",
+				Hashes:  map[int32]string{int32(HashAlgorithm_SHA1): "f3ae11065cafc14e27a1410ae8be28e600bb8336"},
+			},
+			other: &Node{
+				Id:       "a",
+				Version:  "1.0.1",
+				FileName: "protobom.tgz",
+				Hashes:   map[int32]string{int32(HashAlgorithm_SHA1): "9283475928734987"},
+			},
+			opts: []MergeOption{WithConflictResolution(ConflictPreferReceiver)},
+			expected: &Node{
+				Id:       "a",
+				Version:  "1.0.0",
+				FileName: "protobom.tgz",
+				Hashes:   map[int32]string{int32(HashAlgorithm_SHA1): "f3ae11065cafc14e27a1410ae8be28e600bb8336"},
+			},
+		},
+		{
+			name: "prefer other",
+			node: &Node{
+				Id:       "a",
+				Version:  "1.0.0",
+				FileName: "protobom.tgz",
+				Hashes:   map[int32]string{int32(HashAlgorithm_SHA1): "f3ae11065cafc14e27a1410ae8be28e600bb8336"},
+			},
+			other: &Node{
+				Id:      "b",
+				Version: "1.0.1",
+				Hashes:  map[int32]string{int32(HashAlgorithm_SHA1): "9283475928734987"},
+			},
+			opts: []MergeOption{WithConflictResolution(ConflictPreferOther)},
+			expected: &Node{
+				Id:       "a",
+				Version:  "1.0.1",
+				FileName: "protobom.tgz",
+				Hashes:   map[int32]string{int32(HashAlgorithm_SHA1): "9283475928734987"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			node, ok := proto.Clone(tc.node).(*Node)
+			require.True(t, ok)
+			var other *Node
+			if tc.other != nil {
+				other, ok = proto.Clone(tc.other).(*Node)
+				require.True(t, ok)
+			}
+
+			merged, err := node.MergeWith(other, tc.opts...)
+
+			// The inputs are never modified
+			require.True(t, proto.Equal(tc.node, node))
+			if tc.other != nil {
+				require.True(t, proto.Equal(tc.other, other))
+			}
+
+			if tc.shouldError {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrConflict))
+				for _, field := range tc.conflicts {
+					require.Contains(t, err.Error(), "field "+field+" ")
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.expected, merged), "expected %v got %v", tc.expected, merged)
+		})
+	}
+}
+
+func TestNodeMergeWithFieldConflictError(t *testing.T) {
+	_, err := (&Node{Id: "a", Name: "one"}).MergeWith(&Node{Id: "a", Name: "two"})
+	require.Error(t, err)
+
+	var conflict *FieldConflictError
+	require.True(t, errors.As(err, &conflict))
+	require.Equal(t, "name", conflict.Field)
+	require.Equal(t, "one", conflict.Value)
+	require.Equal(t, "two", conflict.Other)
+}
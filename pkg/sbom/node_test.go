@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -292,6 +293,7 @@ func TestNodeCopy(t *testing.T) {
 				Email: "jane@doe.com",
 			},
 		},
+		Properties: []*Property{{Name: "foundBy", Value: "cataloger"}},
 	}
 
 	copied := original.Copy()
@@ -302,6 +304,7 @@ func TestNodeCopy(t *testing.T) {
 	original.Licenses[0] = "Licenses Copy Failed"
 	original.Hashes[int32(HashAlgorithm_SHA1)] = "Hashes Copy Failed"
 	original.FileTypes[0] = "FileTypes Copy Failed"
+	original.Properties[0].Value = "Properties Copy Failed"
 
 	// The copied Node should reflect the original values, not the subsequent changes.
 	require.Equal(t, "John Doe", copied.Suppliers[0].Name)
@@ -309,6 +312,60 @@ func TestNodeCopy(t *testing.T) {
 	require.Equal(t, "Apache-2.0", copied.Licenses[0])
 	require.Equal(t, "f3ae11065cafc14e27a1410ae8be28e600bb8336", copied.Hashes[int32(HashAlgorithm_SHA1)])
 	require.Equal(t, "TEXT", copied.FileTypes[0])
+	require.Equal(t, "cataloger", copied.Properties[0].Value)
+}
+
+func TestNodeClone(t *testing.T) {
+	original := &Node{
+		Id:             "pkg",
+		Name:           "pkg",
+		Licenses:       []string{"Apache-2.0"},
+		Hashes:         map[int32]string{int32(HashAlgorithm_SHA1): "f3ae11065cafc14e27a1410ae8be28e600bb8336"},
+		Identifiers:    map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:generic/pkg@1.0"},
+		PrimaryPurpose: []Purpose{Purpose_LIBRARY},
+		Suppliers:      []*Person{{Name: "ACME", Contacts: []*Person{{Name: "John Doe"}}}},
+		ExternalReferences: []*ExternalReference{
+			{Url: "https://example.com/", Hashes: map[int32]string{int32(HashAlgorithm_MD5): "d41d8cd98f00b204e9800998ecf8427e"}},
+		},
+		Snippets:         []*Snippet{{Id: "snippet", Ranges: []*SnippetRange{{StartLine: 1, EndLine: 2}}}},
+		Annotations:      []*Annotation{{Comment: "reviewed", Annotator: &Person{Name: "Jane Doe"}}},
+		VerificationCode: &VerificationCode{Value: "abc", ExcludedFiles: []string{"./excluded"}},
+		FilesAnalyzed:    proto.Bool(true),
+		ReleaseDate:      timestamppb.New(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Properties:       []*Property{{Name: "foundBy", Value: "cataloger"}},
+	}
+
+	clone := original.Clone()
+	require.True(t, proto.Equal(original, clone))
+
+	clone.Licenses[0] = "modified"
+	clone.Hashes[int32(HashAlgorithm_SHA1)] = "modified"
+	clone.Identifiers[int32(SoftwareIdentifierType_PURL)] = "modified"
+	clone.PrimaryPurpose[0] = Purpose_APPLICATION
+	clone.Suppliers[0].Contacts[0].Name = "modified"
+	clone.ExternalReferences[0].Hashes[int32(HashAlgorithm_MD5)] = "modified"
+	clone.Snippets[0].Ranges[0].EndLine = 10
+	clone.Annotations[0].Annotator.Name = "modified"
+	clone.VerificationCode.ExcludedFiles[0] = "modified"
+	*clone.FilesAnalyzed = false
+	clone.ReleaseDate.Seconds = 0
+	clone.Properties[0].Value = "modified"
+
+	require.Equal(t, "Apache-2.0", original.Licenses[0])
+	require.Equal(t, "f3ae11065cafc14e27a1410ae8be28e600bb8336", original.Hashes[int32(HashAlgorithm_SHA1)])
+	require.Equal(t, "pkg:generic/pkg@1.0", original.Identifiers[int32(SoftwareIdentifierType_PURL)])
+	require.Equal(t, Purpose_LIBRARY, original.PrimaryPurpose[0])
+	require.Equal(t, "John Doe", original.Suppliers[0].Contacts[0].Name)
+	require.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", original.ExternalReferences[0].Hashes[int32(HashAlgorithm_MD5)])
+	require.Equal(t, int32(2), original.Snippets[0].Ranges[0].EndLine)
+	require.Equal(t, "Jane Doe", original.Annotations[0].Annotator.Name)
+	require.Equal(t, "./excluded", original.VerificationCode.ExcludedFiles[0])
+	require.True(t, original.GetFilesAnalyzed())
+	require.Equal(t, 2023, original.ReleaseDate.AsTime().Year())
+	require.Equal(t, "cataloger", original.Properties[0].Value)
+
+	var nilNode *Node
+	require.Nil(t, nilNode.Clone())
 }
 
 func TestNodeDescendants(t *testing.T) {
@@ -419,11 +476,12 @@ func TestNodeDescendants(t *testing.T) {
 
 func TestNodeAddHash(t *testing.T) {
 	for _, tc := range []struct {
-		name     string
-		sut      *Node
-		algo     HashAlgorithm
-		val      string
-		expected map[int32]string
+		name      string
+		sut       *Node
+		algo      HashAlgorithm
+		val       string
+		expected  map[int32]string
+		shouldErr bool
 	}{
 		{
 			name: "regular add",
@@ -445,6 +503,31 @@ func TestNodeAddHash(t *testing.T) {
 				int32(HashAlgorithm_SHA256): "c2c306cf6281251126b8bff2e747d89019de78de51324f3a48f9c83b794be46c",
 			},
 		},
+		{
+			name: "value is trimmed",
+			sut:  &Node{},
+			algo: HashAlgorithm_SHA1,
+			val:  " da39a3ee5e6b4b0d3255bfef95601890afd80709\n",
+			expected: map[int32]string{
+				int32(HashAlgorithm_SHA1): "da39a3ee5e6b4b0d3255bfef95601890afd80709",
+			},
+		},
+		{
+			name:      "unknown algorithm",
+			sut:       &Node{},
+			algo:      HashAlgorithm_UNKNOWN,
+			val:       "da39a3ee5e6b4b0d3255bfef95601890afd80709",
+			expected:  map[int32]string{},
+			shouldErr: true,
+		},
+		{
+			name:      "invalid algorithm",
+			sut:       &Node{},
+			algo:      HashAlgorithm(999),
+			val:       "da39a3ee5e6b4b0d3255bfef95601890afd80709",
+			expected:  map[int32]string{},
+			shouldErr: true,
+		},
 		{
 			name: "empty hash does not replace value",
 			sut: &Node{Hashes: map[int32]string{
@@ -458,8 +541,95 @@ func TestNodeAddHash(t *testing.T) {
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.sut.AddHash(tc.algo, tc.val)
+			err := tc.sut.AddHash(tc.algo, tc.val)
+			if tc.shouldErr {
+				require.ErrorIs(t, err, ErrUnsupportedHashAlgorithm)
+			} else {
+				require.NoError(t, err)
+			}
 			require.True(t, tc.sut.Equal(&Node{Hashes: tc.expected}))
 		})
 	}
 }
+
+func TestSetVersion(t *testing.T) {
+	purl := int32(SoftwareIdentifierType_PURL)
+	cpe23 := int32(SoftwareIdentifierType_CPE23)
+	cpe22 := int32(SoftwareIdentifierType_CPE22)
+	for _, tc := range []struct {
+		name        string
+		identifiers map[int32]string
+		version     string
+		expected    map[int32]string
+		mustErr     bool
+	}{
+		{
+			name: "purl and cpes",
+			identifiers: map[int32]string{
+				purl:  "pkg:npm/lodash@4.17.20",
+				cpe23: "cpe:2.3:a:lodash:lodash:4.17.20:*:*:*:*:node.js:*:*",
+				cpe22: "cpe:/a:lodash:lodash:4.17.20",
+			},
+			version: "4.17.21",
+			expected: map[int32]string{
+				purl:  "pkg:npm/lodash@4.17.21",
+				cpe23: "cpe:2.3:a:lodash:lodash:4.17.21:*:*:*:*:node.js:*:*",
+				cpe22: "cpe:/a:lodash:lodash:4.17.21",
+			},
+		},
+		{
+			name:        "purl with qualifiers and subpath",
+			identifiers: map[int32]string{purl: "pkg:maven/org.example/lib@1.0?type=jar#src/main"},
+			version:     "1.1",
+			expected:    map[int32]string{purl: "pkg:maven/org.example/lib@1.1?type=jar#src/main"},
+		},
+		{
+			name:        "escaped values",
+			identifiers: map[int32]string{purl: "pkg:npm/%40babel/core@7.0.0", cpe23: "cpe:2.3:a:babel\\:js:core:7.0.0:*:*:*:*:*:*:*"},
+			version:     "7.1.0+build:1",
+			expected: map[int32]string{
+				purl:  "pkg:npm/%40babel/core@7.1.0+build:1",
+				cpe23: "cpe:2.3:a:babel\\:js:core:7.1.0\\+build\\:1:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name:        "identifiers without version",
+			identifiers: map[int32]string{purl: "pkg:npm/lodash", cpe23: "cpe:2.3:a:lodash:lodash:*:*:*:*:*:*:*:*", cpe22: "cpe:/a:lodash:lodash"},
+			version:     "4.17.21",
+			expected:    map[int32]string{purl: "pkg:npm/lodash", cpe23: "cpe:2.3:a:lodash:lodash:*:*:*:*:*:*:*:*", cpe22: "cpe:/a:lodash:lodash"},
+		},
+		{
+			name:        "blank version",
+			identifiers: map[int32]string{purl: "pkg:npm/lodash@4.17.20?arch=x86", cpe23: "cpe:2.3:a:lodash:lodash:4.17.20:*:*:*:*:*:*:*", cpe22: "cpe:/a:lodash:lodash:4.17.20"},
+			version:     "",
+			expected:    map[int32]string{purl: "pkg:npm/lodash?arch=x86", cpe23: "cpe:2.3:a:lodash:lodash:*:*:*:*:*:*:*:*", cpe22: "cpe:/a:lodash:lodash"},
+		},
+		{
+			name:        "invalid identifiers",
+			identifiers: map[int32]string{purl: "npm/lodash@4.17.20", cpe23: "cpe:2.3:a:lodash", cpe22: "cpe:/a:lodash:lodash:4.17.20"},
+			version:     "4.17.21",
+			expected:    map[int32]string{purl: "npm/lodash@4.17.20", cpe23: "cpe:2.3:a:lodash", cpe22: "cpe:/a:lodash:lodash:4.17.21"},
+			mustErr:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &Node{Version: "4.17.20", Identifiers: tc.identifiers}
+			err := n.SetVersion(tc.version)
+			if tc.mustErr {
+				require.Error(t, err)
+				require.ErrorContains(t, err, "updating PURL identifier")
+				require.ErrorContains(t, err, "updating CPE23 identifier")
+			} else {
+				require.NoError(t, err)
+			}
+			// The version is set even when identifiers fail
+			require.Equal(t, tc.version, n.Version)
+			require.Equal(t, tc.expected, n.Identifiers)
+		})
+	}
+
+	// Nodes without identifiers only get the version
+	n := &Node{}
+	require.NoError(t, n.SetVersion("1.0.0"))
+	require.Equal(t, "1.0.0", n.Version)
+}
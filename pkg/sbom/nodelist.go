@@ -3,6 +3,7 @@ package sbom
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 
@@ -121,8 +122,9 @@ func (nl *NodeList) cleanEdges() {
 			continue
 		}
 
-		// Use a string key for a simpler datastruct
-		edgeKey := edge.From + "+++" + edge.Type.String()
+		// Use a string key for a simpler datastruct. Edges with different
+		// comments or directions are kept apart to preserve them.
+		edgeKey := fmt.Sprintf("%s+++%s+++%s+++%t", edge.From, edge.Type, edge.Comment, edge.Inverse)
 		if _, ok := newTos[edgeKey]; !ok {
 			newTos[edgeKey] = map[string]string{}
 		}
@@ -130,9 +132,11 @@ func (nl *NodeList) cleanEdges() {
 		// If we already saw an equivalent edge, reuse it
 		if _, ok := seenCache[edgeKey]; !ok {
 			seenCache[edgeKey] = &Edge{
-				Type: edge.Type,
-				From: edge.From,
-				To:   []string{},
+				Type:    edge.Type,
+				From:    edge.From,
+				To:      []string{},
+				Comment: edge.Comment,
+				Inverse: edge.Inverse,
 			}
 		}
 
@@ -161,6 +165,55 @@ func (nl *NodeList) AddEdge(e *Edge) {
 	nl.Edges = append(nl.Edges, e)
 }
 
+// NormalizeEdges rewrites the edges of inverse types (contained_by,
+// dependencyOf...) in their canonical direction, flagging them as inverse,
+// and removes the relationships stated more than once. When a relationship
+// is stated in both directions the canonical statement is kept. The order
+// of the edges is preserved. It returns the number of duplicate
+// relationships removed.
+func (nl *NodeList) NormalizeEdges() (removed int) {
+	key := func(e *Edge, to string) string {
+		return e.From + "+++" + e.Type.String() + "+++" + to
+	}
+
+	canonical := []*Edge{}
+	direct := map[string]struct{}{}
+	for _, e := range nl.Edges {
+		for _, c := range e.Canonical() {
+			canonical = append(canonical, c)
+			if c.Inverse {
+				continue
+			}
+			for _, to := range c.To {
+				direct[key(c, to)] = struct{}{}
+			}
+		}
+	}
+
+	edges := []*Edge{}
+	seen := map[string]struct{}{}
+	for _, e := range canonical {
+		to := []string{}
+		for _, t := range e.To {
+			k := key(e, t)
+			_, isDirect := direct[k]
+			if _, ok := seen[k]; ok || (e.Inverse && isDirect) {
+				removed++
+				continue
+			}
+			seen[k] = struct{}{}
+			to = append(to, t)
+		}
+		if len(to) == 0 {
+			continue
+		}
+		e.To = to
+		edges = append(edges, e)
+	}
+	nl.Edges = edges
+	return removed
+}
+
 // AddRootNode adds a node to the nodelist and alos registers it to the
 // RootElements list.
 func (nl *NodeList) AddRootNode(n *Node) {
@@ -252,6 +305,24 @@ func (nl *NodeList) GetEdgeByType(fromElement string, t Edge_Type) *Edge {
 	return nil
 }
 
+// Clone returns a deep copy of the node list with its nodes, edges and root
+// elements. Modifying the clone does not affect the original node list.
+// Cloning a nil node list returns nil.
+func (nl *NodeList) Clone() *NodeList {
+	if nl == nil {
+		return nil
+	}
+	ret := &NodeList{
+		Nodes:        make([]*Node, 0, len(nl.Nodes)),
+		Edges:        copyEdgeList(nl.Edges),
+		RootElements: slices.Clone(nl.RootElements),
+	}
+	for _, n := range nl.Nodes {
+		ret.Nodes = append(ret.Nodes, n.Clone())
+	}
+	return ret
+}
+
 // copyEdgeList is a utility function that deep copies a list of edges
 func copyEdgeList(original []*Edge) []*Edge {
 	nodeCopy := []*Edge{}
@@ -372,6 +443,135 @@ func (nl *NodeList) Union(nl2 *NodeList) *NodeList {
 	return ret
 }
 
+// UnionResolvingCollisions works like Union but first checks the nodes of
+// nl2 that share their ID with a node in nl. When both nodes describe
+// different components, for example two CycloneDX documents using the same
+// bom-ref for different packages, the node of nl2 is given a fresh ID before
+// joining the lists so it does not get merged into the other one. The edges
+// and root elements of nl2 are updated to the new IDs.
+//
+// The returned map has the rewritten IDs of nl2 keyed by their original ID.
+// Neither nl nor nl2 are modified.
+func (nl *NodeList) UnionResolvingCollisions(nl2 *NodeList) (*NodeList, map[string]string) {
+	renamed := map[string]string{}
+	other := &NodeList{
+		Nodes:        []*Node{},
+		Edges:        copyEdgeList(nl2.Edges),
+		RootElements: slices.Clone(nl2.RootElements),
+	}
+
+	used := map[string]struct{}{}
+	for _, n := range nl.Nodes {
+		used[n.Id] = struct{}{}
+	}
+	for _, n := range nl2.Nodes {
+		used[n.Id] = struct{}{}
+	}
+
+	existing := nl.indexNodes()
+	for _, n := range nl2.Nodes {
+		nn := n.Copy()
+		other.Nodes = append(other.Nodes, nn)
+		if en, ok := existing[n.Id]; !ok || !nodesCollide(en, n) {
+			continue
+		}
+		// Nodes repeated in nl2 are renamed once
+		if newID, ok := renamed[n.Id]; ok {
+			nn.Id = newID
+			continue
+		}
+		newID := ""
+		for i := 2; ; i++ {
+			newID = fmt.Sprintf("%s-%d", n.Id, i)
+			if _, ok := used[newID]; !ok {
+				break
+			}
+		}
+		used[newID] = struct{}{}
+		renamed[n.Id] = newID
+		nn.Id = newID
+	}
+
+	if len(renamed) > 0 {
+		for _, e := range other.Edges {
+			if newID, ok := renamed[e.From]; ok {
+				e.From = newID
+			}
+			for i := range e.To {
+				if newID, ok := renamed[e.To[i]]; ok {
+					e.To[i] = newID
+				}
+			}
+		}
+		for i := range other.RootElements {
+			if newID, ok := renamed[other.RootElements[i]]; ok {
+				other.RootElements[i] = newID
+			}
+		}
+	}
+
+	return nl.Union(other), renamed
+}
+
+// nodesCollide returns true when two nodes with the same ID describe
+// different components: their types differ or they have different values in
+// their name, version or package URL. Fields missing in either node are
+// not considered a difference.
+func nodesCollide(n, n2 *Node) bool {
+	if n.Type != n2.Type {
+		return true
+	}
+	for _, v := range [][2]string{
+		{n.Name, n2.Name},
+		{n.Version, n2.Version},
+		{string(n.Purl()), string(n2.Purl())},
+	} {
+		if v[0] != "" && v[1] != "" && v[0] != v[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Difference returns a new NodeList with copies of the nodes in nl that are
+// not found in nl2. As in Intersect, nodes are matched by their ID. The
+// edges of nl are carried along, pruned to the nodes in the returned list
+// and root elements are kept if their node remains.
+func (nl *NodeList) Difference(nl2 *NodeList) *NodeList {
+	ret := &NodeList{
+		Nodes:        []*Node{},
+		Edges:        copyEdgeList(nl.Edges), // copied as they will be cleaned
+		RootElements: []string{},
+	}
+
+	ni2 := nl2.indexNodes()
+	for _, n := range nl.Nodes {
+		if _, ok := ni2[n.Id]; ok {
+			continue
+		}
+		ret.Nodes = append(ret.Nodes, n.Copy())
+	}
+
+	nodeindex := ret.indexNodes()
+	for _, id := range nl.RootElements {
+		if _, ok := nodeindex[id]; ok {
+			ret.RootElements = append(ret.RootElements, id)
+		}
+	}
+
+	ret.cleanEdges()
+
+	return ret
+}
+
+// SymmetricDifference returns a new NodeList with the nodes found in only one
+// of nl and nl2, that is the union of nl.Difference(nl2) and
+// nl2.Difference(nl). The edges and root elements of both lists are carried
+// along as in Difference.
+func (nl *NodeList) SymmetricDifference(nl2 *NodeList) *NodeList {
+	return nl.Difference(nl2).Union(nl2.Difference(nl))
+}
+
 // GetNodesByName returns a list of node pointers whose name equals name
 func (nl *NodeList) GetNodesByName(name string) []*Node {
 	ret := []*Node{}
@@ -513,6 +713,69 @@ func (nl *NodeList) GetRootNodes() []*Node {
 	return ret
 }
 
+// SetRootNodes replaces the root elements of the NodeList with the nodes
+// identified by ids. Repeated IDs are only added once. It returns an error,
+// leaving the root elements unchanged, if any of the IDs is not a node
+// defined in the NodeList.
+func (nl *NodeList) SetRootNodes(ids []string) error {
+	index := nl.indexNodes()
+	roots := []string{}
+	seen := map[string]struct{}{}
+	for _, id := range ids {
+		if _, ok := index[id]; !ok {
+			return fmt.Errorf("unable to set root nodes, node %q not found", id)
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		roots = append(roots, id)
+	}
+	nl.RootElements = roots
+	return nil
+}
+
+// EnsureSingleRoot makes the NodeList have at most one root element for
+// formats that want a single component describing the document. When there
+// are more roots, they are wrapped under the synthetic node, which becomes
+// the only root and contains them. If the synthetic node is nil a package
+// node is created for it and, if it has no ID, an autogenerated one is
+// assigned. The synthetic node is added to the NodeList unless it is
+// already one of its nodes.
+func (nl *NodeList) EnsureSingleRoot(synthetic *Node) {
+	if len(nl.RootElements) <= 1 {
+		return
+	}
+
+	if synthetic == nil {
+		synthetic = &Node{Type: Node_PACKAGE}
+	}
+	if synthetic.Id == "" {
+		synthetic.Id = NewNodeIdentifier("auto")
+	}
+	if nl.GetNodeByID(synthetic.Id) == nil {
+		nl.AddNode(synthetic)
+	}
+
+	wrapped := []string{}
+	for _, id := range nl.RootElements {
+		if id != synthetic.Id {
+			wrapped = append(wrapped, id)
+		}
+	}
+
+	if e := nl.GetEdgeByType(synthetic.Id, Edge_contains); e != nil {
+		for _, id := range wrapped {
+			if !slices.Contains(e.To, id) {
+				e.To = append(e.To, id)
+			}
+		}
+	} else {
+		nl.AddEdge(&Edge{Type: Edge_contains, From: synthetic.Id, To: wrapped})
+	}
+	nl.RootElements = []string{synthetic.Id}
+}
+
 // Equal returns true if the NodeList nl is equal to nl2
 func (nl *NodeList) Equal(nl2 *NodeList) bool {
 	if nl2 == nil {
@@ -569,8 +832,15 @@ func (nl *NodeList) Equal(nl2 *NodeList) bool {
 // RelateNodeAtID creates a relationship between Node n and an existing node
 // in the NodeList specified by nodeID. If the node (as looked up by ID) does not
 // not exist in the NodeList it will be added. If NodeID does not exist an error
-// will be returned.
+// will be returned and the NodeList is left untouched.
 func (nl *NodeList) RelateNodeAtID(n *Node, nodeID string, edgeType Edge_Type) error {
+	if n == nil {
+		return fmt.Errorf("unable to relate node, node is nil")
+	}
+	if n.Id == "" {
+		return fmt.Errorf("unable to relate node, node has no ID")
+	}
+
 	// Check the node exists
 	nlIndex := nl.indexNodes()
 	nlEdges := nl.indexEdges()
@@ -594,8 +864,7 @@ func (nl *NodeList) RelateNodeAtID(n *Node, nodeID string, edgeType Edge_Type) e
 			To:   []string{n.Id},
 		}
 		nl.Edges = append(nl.Edges, edge)
-	} else {
-		// Perhaps we should filter these
+	} else if !slices.Contains(edge.To, n.Id) {
 		edge.To = append(edge.To, n.Id)
 	}
 
@@ -678,6 +947,102 @@ func (nl *NodeList) GetNodesByPurlType(purlType string) *NodeList {
 	return ret
 }
 
+// FlattenOption configures NodeList.Flatten
+type FlattenOption func(*flattenOptions)
+
+type flattenOptions struct {
+	files bool
+}
+
+// WithFileNodes makes Flatten return the file nodes along with the packages
+func WithFileNodes(include bool) FlattenOption {
+	return func(o *flattenOptions) {
+		o.files = include
+	}
+}
+
+// Flatten returns the package nodes of the list as a flat set, discarding
+// the graph. Nodes are deduplicated by ID and by purl, when two nodes
+// describe the same package url only the first one is returned. File nodes
+// are left out unless WithFileNodes is set. The nodes are returned in the
+// order of the list.
+func (nl *NodeList) Flatten(opts ...FlattenOption) []*Node {
+	o := flattenOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ret := []*Node{}
+	ids := map[string]struct{}{}
+	purls := map[PackageURL]struct{}{}
+	for _, n := range nl.GetNodes() {
+		if n.Type == Node_FILE && !o.files {
+			continue
+		}
+		if _, ok := ids[n.Id]; ok {
+			continue
+		}
+		if purl := n.Purl(); purl != "" {
+			if _, ok := purls[purl]; ok {
+				continue
+			}
+			purls[purl] = struct{}{}
+		}
+		ids[n.Id] = struct{}{}
+		ret = append(ret, n)
+	}
+	return ret
+}
+
+// UnknownGroup is the key under which GroupByEcosystem and GroupBySupplier
+// list the nodes that cannot be classified
+const UnknownGroup = "unknown"
+
+// GroupByEcosystem returns the nodes of the list grouped by the ecosystem
+// returned by Node.Ecosystem. Nodes without a purl or with an ecosystem that
+// is not well-known are grouped under UnknownGroup. The nodes keep the order
+// of the list in each group.
+func (nl *NodeList) GroupByEcosystem() map[string][]*Node {
+	ret := map[string][]*Node{}
+	for _, n := range nl.GetNodes() {
+		ecosystem, err := n.Ecosystem()
+		if err != nil {
+			ecosystem = UnknownGroup
+		}
+		ret[ecosystem] = append(ret[ecosystem], n)
+	}
+	return ret
+}
+
+// GroupBySupplier returns the nodes of the list grouped by the name of their
+// suppliers, or their email when the supplier has no name. Nodes with more
+// than one supplier are listed in the group of each of them and nodes with
+// no supplier are grouped under UnknownGroup.
+func (nl *NodeList) GroupBySupplier() map[string][]*Node {
+	ret := map[string][]*Node{}
+	for _, n := range nl.GetNodes() {
+		seen := map[string]struct{}{}
+		for _, p := range n.GetSuppliers() {
+			key := strings.TrimSpace(p.GetName())
+			if key == "" {
+				key = strings.TrimSpace(p.GetEmail())
+			}
+			if key == "" {
+				continue
+			}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			ret[key] = append(ret[key], n)
+		}
+		if len(seen) == 0 {
+			ret[UnknownGroup] = append(ret[UnknownGroup], n)
+		}
+	}
+	return ret
+}
+
 // reconnectOrphanNodes cleans the nodelist graph structure by reconnecting all
 // orphaned nodes to the top of the nodelist
 func (nl *NodeList) reconnectOrphanNodes() {
@@ -890,3 +1255,45 @@ func (nl *NodeList) NodeDescendants(id string, maxDepth int) *NodeList {
 	nl2.cleanEdges()
 	return &nl2
 }
+
+// SortedNodes returns the nodes of the NodeList in a stable order that does
+// not depend on the order they were added: sorted by package URL and then
+// by ID. Nodes with the same purl and ID are ordered by their contents. The
+// NodeList is not modified.
+func (nl *NodeList) SortedNodes() []*Node {
+	nodes := slices.Clone(nl.GetNodes())
+	slices.SortStableFunc(nodes, func(a, b *Node) int {
+		if c := strings.Compare(string(a.Purl()), string(b.Purl())); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Id, b.Id); c != 0 {
+			return c
+		}
+		return strings.Compare(a.flatString(), b.flatString())
+	})
+	return nodes
+}
+
+// SortedEdges returns the edges of the NodeList in a stable order that does
+// not depend on the order they were added: sorted by the ID of the node they
+// start from, their type and the IDs they point to, compared regardless of
+// their order in the edge. The NodeList is not modified.
+func (nl *NodeList) SortedEdges() []*Edge {
+	edges := slices.Clone(nl.GetEdges())
+	slices.SortStableFunc(edges, func(a, b *Edge) int {
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		if c := int(a.Type) - int(b.Type); c != 0 {
+			return c
+		}
+		toA, toB := slices.Clone(a.To), slices.Clone(b.To)
+		slices.Sort(toA)
+		slices.Sort(toB)
+		if c := slices.Compare(toA, toB); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Comment, b.Comment)
+	})
+	return edges
+}
@@ -2,10 +2,14 @@ package sbom
 
 import (
 	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestCleanEdges(t *testing.T) {
@@ -94,6 +98,30 @@ func TestCleanEdges(t *testing.T) {
 				RootElements: []string{"node1"},
 			},
 		},
+		"Edges with different comments are not consolidated": {
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "node1"}, {Id: "node2"}, {Id: "node3"}, {Id: "node4"},
+				},
+				Edges: []*Edge{
+					{Type: Edge_contains, From: "node1", To: []string{"node2"}, Comment: "vendored"},
+					{Type: Edge_contains, From: "node1", To: []string{"node3"}},
+					{Type: Edge_contains, From: "node1", To: []string{"node4"}, Comment: "vendored"},
+				},
+				RootElements: []string{"node1"},
+			},
+
+			expected: &NodeList{
+				Nodes: []*Node{
+					{Id: "node1"}, {Id: "node2"}, {Id: "node3"}, {Id: "node4"},
+				},
+				Edges: []*Edge{
+					{Type: Edge_contains, From: "node1", To: []string{"node2", "node4"}, Comment: "vendored"},
+					{Type: Edge_contains, From: "node1", To: []string{"node3"}},
+				},
+				RootElements: []string{"node1"},
+			},
+		},
 	} {
 		tc.sut.cleanEdges()
 		require.True(t, tc.sut.Equal(tc.expected), m)
@@ -410,6 +438,182 @@ func TestNodeListUnion(t *testing.T) {
 	}
 }
 
+func TestNodeListUnionResolvingCollisions(t *testing.T) {
+	nl := &NodeList{
+		Nodes: []*Node{
+			{Id: "app", Type: Node_PACKAGE, Name: "app"},
+			{Id: "pkg:1", Type: Node_PACKAGE, Name: "lodash", Version: "4.17.21"},
+			{Id: "shared", Type: Node_PACKAGE, Name: "zlib", Version: "1.3"},
+		},
+		Edges: []*Edge{
+			{Type: Edge_dependsOn, From: "app", To: []string{"pkg:1", "shared"}},
+		},
+		RootElements: []string{"app"},
+	}
+	nl2 := &NodeList{
+		Nodes: []*Node{
+			{Id: "pkg:1", Type: Node_PACKAGE, Name: "requests", Version: "2.31.0"},
+			{Id: "shared", Type: Node_PACKAGE, Name: "zlib"},
+			{Id: "pkg:1-2", Type: Node_PACKAGE, Name: "urllib3"},
+		},
+		Edges: []*Edge{
+			{Type: Edge_dependsOn, From: "pkg:1", To: []string{"shared", "pkg:1-2"}},
+		},
+		RootElements: []string{"pkg:1"},
+	}
+
+	res, renamed := nl.UnionResolvingCollisions(nl2)
+
+	// The ID already used in nl2 is skipped
+	require.Equal(t, map[string]string{"pkg:1": "pkg:1-3"}, renamed)
+	require.Len(t, res.Nodes, 5)
+	require.Equal(t, "lodash", res.GetNodeByID("pkg:1").Name)
+	require.Equal(t, "requests", res.GetNodeByID("pkg:1-3").Name)
+
+	// Nodes describing the same component are merged
+	require.Equal(t, "1.3", res.GetNodeByID("shared").Version)
+
+	require.ElementsMatch(t, []string{"pkg:1", "shared"}, res.GetEdgeByType("app", Edge_dependsOn).To)
+	require.ElementsMatch(t, []string{"shared", "pkg:1-2"}, res.GetEdgeByType("pkg:1-3", Edge_dependsOn).To)
+	require.Nil(t, res.GetEdgeByType("pkg:1", Edge_dependsOn))
+	require.Equal(t, []string{"app", "pkg:1-3"}, res.RootElements)
+
+	// The original lists are not modified
+	require.Equal(t, "pkg:1", nl2.Nodes[0].Id)
+	require.Equal(t, "pkg:1", nl2.Edges[0].From)
+	require.Equal(t, []string{"pkg:1"}, nl2.RootElements)
+	require.Len(t, nl.Nodes, 3)
+
+	// Without collisions the result is the union
+	res, renamed = nl.UnionResolvingCollisions(&NodeList{
+		Nodes: []*Node{{Id: "pkg:1", Type: Node_PACKAGE, Name: "lodash"}},
+	})
+	require.Empty(t, renamed)
+	require.Len(t, res.Nodes, 3)
+}
+
+func TestNodesCollide(t *testing.T) {
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+	}
+	for _, tc := range []struct {
+		name     string
+		a, b     *Node
+		expected bool
+	}{
+		{"same", &Node{Name: "a", Version: "1"}, &Node{Name: "a", Version: "1"}, false},
+		{"missing fields", &Node{Name: "a"}, &Node{Version: "1"}, false},
+		{"name", &Node{Name: "a"}, &Node{Name: "b"}, true},
+		{"version", &Node{Name: "a", Version: "1"}, &Node{Name: "a", Version: "2"}, true},
+		{"type", &Node{Name: "a", Type: Node_FILE}, &Node{Name: "a"}, true},
+		{"purl", &Node{Identifiers: purl("pkg:npm/a@1")}, &Node{Identifiers: purl("pkg:pypi/a@1")}, true},
+	} {
+		require.Equal(t, tc.expected, nodesCollide(tc.a, tc.b), tc.name)
+	}
+}
+
+func TestNodeListDifference(t *testing.T) {
+	testNodeList := &NodeList{
+		Nodes: []*Node{
+			{Id: "node1", Type: Node_PACKAGE, Name: "package1", Version: "1.0.0"},
+			{Id: "node2", Type: Node_PACKAGE, Name: "package2", Version: "1.0.0"},
+			{Id: "node3", Type: Node_PACKAGE, Name: "package3", Version: "1.0.0"},
+		},
+		Edges: []*Edge{
+			{Type: Edge_contains, From: "node1", To: []string{"node2", "node3"}},
+			{Type: Edge_dependsOn, From: "node2", To: []string{"node3"}},
+		},
+		RootElements: []string{"node1"},
+	}
+
+	overlapping := &NodeList{
+		Nodes: []*Node{
+			{Id: "node1", Type: Node_PACKAGE, Name: "package1", Version: "2.0.0"},
+			{Id: "node4", Type: Node_PACKAGE, Name: "package4", Version: "1.0.0"},
+		},
+		Edges: []*Edge{
+			{Type: Edge_contains, From: "node1", To: []string{"node4"}},
+		},
+		RootElements: []string{"node1"},
+	}
+
+	disjoint := &NodeList{
+		Nodes: []*Node{
+			{Id: "node5", Type: Node_PACKAGE, Name: "package5", Version: "1.0.0"},
+		},
+		Edges:        []*Edge{},
+		RootElements: []string{"node5"},
+	}
+
+	empty := &NodeList{Nodes: []*Node{}, Edges: []*Edge{}, RootElements: []string{}}
+
+	for title, tc := range map[string]struct {
+		sut           *NodeList
+		other         *NodeList
+		expect        *NodeList
+		expectSymDiff *NodeList
+	}{
+		"identical nodelists": {
+			sut:           testNodeList,
+			other:         testNodeList,
+			expect:        empty,
+			expectSymDiff: empty,
+		},
+		"disjoint nodelists": {
+			sut:    testNodeList,
+			other:  disjoint,
+			expect: testNodeList,
+			expectSymDiff: &NodeList{
+				Nodes: []*Node{
+					{Id: "node1", Type: Node_PACKAGE, Name: "package1", Version: "1.0.0"},
+					{Id: "node2", Type: Node_PACKAGE, Name: "package2", Version: "1.0.0"},
+					{Id: "node3", Type: Node_PACKAGE, Name: "package3", Version: "1.0.0"},
+					{Id: "node5", Type: Node_PACKAGE, Name: "package5", Version: "1.0.0"},
+				},
+				Edges:        testNodeList.Edges,
+				RootElements: []string{"node1", "node5"},
+			},
+		},
+		"overlapping nodelists": {
+			sut:   testNodeList,
+			other: overlapping,
+			expect: &NodeList{
+				Nodes: []*Node{
+					{Id: "node2", Type: Node_PACKAGE, Name: "package2", Version: "1.0.0"},
+					{Id: "node3", Type: Node_PACKAGE, Name: "package3", Version: "1.0.0"},
+				},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "node2", To: []string{"node3"}},
+				},
+				RootElements: []string{},
+			},
+			expectSymDiff: &NodeList{
+				Nodes: []*Node{
+					{Id: "node2", Type: Node_PACKAGE, Name: "package2", Version: "1.0.0"},
+					{Id: "node3", Type: Node_PACKAGE, Name: "package3", Version: "1.0.0"},
+					{Id: "node4", Type: Node_PACKAGE, Name: "package4", Version: "1.0.0"},
+				},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "node2", To: []string{"node3"}},
+				},
+				RootElements: []string{},
+			},
+		},
+	} {
+		diff := tc.sut.Difference(tc.other)
+		require.True(t, tc.expect.Equal(diff), fmt.Sprintf("%s: %v %v", title, tc.expect, diff))
+
+		symDiff := tc.sut.SymmetricDifference(tc.other)
+		require.True(t, tc.expectSymDiff.Equal(symDiff), fmt.Sprintf("%s: %v %v", title, tc.expectSymDiff, symDiff))
+		require.True(t, tc.expectSymDiff.Equal(tc.other.SymmetricDifference(tc.sut)), title)
+	}
+
+	// The returned nodes are copies
+	diff := testNodeList.Difference(overlapping)
+	diff.Nodes[0].Name = "changed"
+	require.Equal(t, "package2", testNodeList.Nodes[1].Name)
+}
+
 func TestGetNodesByName(t *testing.T) {
 	for _, tc := range []struct {
 		sut      *NodeList
@@ -1264,14 +1468,372 @@ func TestRelateNodeAtId(t *testing.T) {
 				Edges: []*Edge{{From: sutId, To: []string{nodeId}, Type: Edge_UNKNOWN}},
 			},
 		},
+		{
+			name: "relate already related node",
+			sut: &NodeList{
+				Nodes: []*Node{{Id: sutId}, {Id: nodeId}},
+				Edges: []*Edge{{From: sutId, To: []string{nodeId}, Type: Edge_UNKNOWN}},
+			},
+			node: testNode,
+			expected: &NodeList{
+				Nodes: []*Node{{Id: sutId}, {Id: nodeId}},
+				Edges: []*Edge{{From: sutId, To: []string{nodeId}, Type: Edge_UNKNOWN}},
+			},
+		},
+		{
+			name: "nil node",
+			sut: &NodeList{
+				Nodes: []*Node{{Id: sutId}},
+			},
+			node:        nil,
+			shouldError: true,
+		},
+		{
+			name: "node without id",
+			sut: &NodeList{
+				Nodes: []*Node{{Id: sutId}},
+			},
+			node:        &Node{Name: "test"},
+			shouldError: true,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
+			original := proto.Clone(tc.sut).(*NodeList)
 			err := tc.sut.RelateNodeAtID(tc.node, sutId, Edge_UNKNOWN)
 			if tc.shouldError {
 				require.Error(t, err)
+				// On error, the nodelist must not be modified
+				require.True(t, tc.sut.Equal(original))
+				require.Len(t, tc.sut.Edges, len(original.Edges))
 				return
 			}
 			require.True(t, tc.sut.Equal(tc.expected))
 		})
 	}
 }
+
+func TestSetRootNodes(t *testing.T) {
+	nl := &NodeList{
+		Nodes:        []*Node{{Id: "app1"}, {Id: "app2"}, {Id: "lib"}},
+		RootElements: []string{"app1"},
+	}
+
+	require.NoError(t, nl.SetRootNodes([]string{"app2", "lib", "app2"}))
+	require.Equal(t, []string{"app2", "lib"}, nl.RootElements)
+	require.Len(t, nl.GetRootNodes(), 2)
+
+	// Undefined nodes are an error and the roots are not modified
+	require.Error(t, nl.SetRootNodes([]string{"app1", "missing"}))
+	require.Equal(t, []string{"app2", "lib"}, nl.RootElements)
+
+	require.NoError(t, nl.SetRootNodes([]string{}))
+	require.Empty(t, nl.GetRootNodes())
+}
+
+func TestEnsureSingleRoot(t *testing.T) {
+	newNodeList := func() *NodeList {
+		return &NodeList{
+			Nodes: []*Node{{Id: "app1"}, {Id: "app2"}, {Id: "app3"}, {Id: "lib"}},
+			Edges: []*Edge{
+				{Type: Edge_dependsOn, From: "app1", To: []string{"lib"}},
+				{Type: Edge_dependsOn, From: "app3", To: []string{"lib"}},
+			},
+			RootElements: []string{"app1", "app2", "app3"},
+		}
+	}
+
+	t.Run("three roots", func(t *testing.T) {
+		nl := newNodeList()
+		nl.EnsureSingleRoot(&Node{Id: "bundle", Type: Node_PACKAGE, Name: "bundle"})
+		require.Equal(t, []string{"bundle"}, nl.RootElements)
+		require.Len(t, nl.Nodes, 5)
+		require.Equal(t, "bundle", nl.GetRootNodes()[0].Name)
+
+		e := nl.GetEdgeByType("bundle", Edge_contains)
+		require.NotNil(t, e)
+		require.Equal(t, []string{"app1", "app2", "app3"}, e.To)
+
+		// The original graph hangs from the synthetic root
+		require.Len(t, nl.NodeGraph("bundle").Nodes, 5)
+	})
+
+	t.Run("synthetic node without id", func(t *testing.T) {
+		nl := newNodeList()
+		nl.EnsureSingleRoot(nil)
+		require.Len(t, nl.RootElements, 1)
+		root := nl.GetRootNodes()
+		require.Len(t, root, 1)
+		require.NotEmpty(t, root[0].Id)
+		require.Equal(t, Node_PACKAGE, root[0].Type)
+		require.Equal(t, []string{"app1", "app2", "app3"}, nl.GetEdgeByType(root[0].Id, Edge_contains).To)
+	})
+
+	t.Run("synthetic node is one of the roots", func(t *testing.T) {
+		nl := newNodeList()
+		nl.EnsureSingleRoot(nl.GetNodeByID("app1"))
+		require.Equal(t, []string{"app1"}, nl.RootElements)
+		require.Len(t, nl.Nodes, 4)
+		require.Equal(t, []string{"app2", "app3"}, nl.GetEdgeByType("app1", Edge_contains).To)
+	})
+
+	t.Run("single root", func(t *testing.T) {
+		nl := newNodeList()
+		nl.RootElements = []string{"app1"}
+		original := proto.Clone(nl).(*NodeList)
+		nl.EnsureSingleRoot(&Node{Id: "bundle"})
+		require.True(t, nl.Equal(original))
+		require.Len(t, nl.Nodes, 4)
+	})
+}
+
+func TestSortedNodesAndEdges(t *testing.T) {
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+	}
+	nodes := []*Node{
+		{Id: "file", Type: Node_FILE, Name: "README"},
+		{Id: "app", Name: "app", Identifiers: purl("pkg:golang/example.com/app@v1.0.0")},
+		{Id: "zlib", Name: "zlib", Identifiers: purl("pkg:generic/zlib@1.3")},
+		{Id: "openssl-b", Name: "openssl", Identifiers: purl("pkg:generic/openssl@3.0.0")},
+		{Id: "openssl-a", Name: "openssl", Identifiers: purl("pkg:generic/openssl@3.0.0")},
+		{Id: "dup", Name: "second"},
+		{Id: "dup", Name: "first"},
+	}
+	edges := []*Edge{
+		{Type: Edge_dependsOn, From: "app", To: []string{"zlib", "openssl-a"}},
+		{Type: Edge_contains, From: "app", To: []string{"file"}},
+		{Type: Edge_dependsOn, From: "app", To: []string{"openssl-b"}},
+		{Type: Edge_dependsOn, From: "openssl-a", To: []string{"zlib"}},
+		{Type: Edge_dependsOn, From: "app", To: []string{"openssl-a", "zlib"}, Comment: "again"},
+	}
+
+	expectedNodes := []string{"dup:first", "dup:second", "file:README", "openssl-a:openssl", "openssl-b:openssl", "zlib:zlib", "app:app"}
+	expectedEdges := []string{
+		"app:contains:file:", "app:dependsOn:openssl-a,zlib:", "app:dependsOn:openssl-a,zlib:again",
+		"app:dependsOn:openssl-b:", "openssl-a:dependsOn:zlib:",
+	}
+
+	rnd := rand.New(rand.NewSource(1)) //nolint:gosec
+	for i := 0; i < 10; i++ {
+		rnd.Shuffle(len(nodes), func(i, j int) { nodes[i], nodes[j] = nodes[j], nodes[i] })
+		rnd.Shuffle(len(edges), func(i, j int) { edges[i], edges[j] = edges[j], edges[i] })
+		nl := &NodeList{Nodes: slices.Clone(nodes), Edges: slices.Clone(edges)}
+
+		for j := 0; j < 2; j++ {
+			got := []string{}
+			for _, n := range nl.SortedNodes() {
+				got = append(got, n.Id+":"+n.Name)
+			}
+			require.Equal(t, expectedNodes, got)
+
+			got = []string{}
+			for _, e := range nl.SortedEdges() {
+				to := slices.Clone(e.To)
+				slices.Sort(to)
+				got = append(got, fmt.Sprintf("%s:%s:%s:%s", e.From, e.Type, strings.Join(to, ","), e.Comment))
+			}
+			require.Equal(t, expectedEdges, got)
+		}
+
+		// The NodeList keeps its order
+		require.Equal(t, nodes, nl.Nodes)
+		require.Equal(t, edges, nl.Edges)
+	}
+}
+
+func TestNormalizeEdges(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      []*Edge
+		expected []*Edge
+		removed  int
+	}{
+		{
+			name:     "canonical edges are kept",
+			sut:      []*Edge{{Type: Edge_contains, From: "a", To: []string{"b", "c"}}},
+			expected: []*Edge{{Type: Edge_contains, From: "a", To: []string{"b", "c"}}},
+		},
+		{
+			name: "inverse edges are turned around",
+			sut: []*Edge{
+				{Type: Edge_contained_by, From: "b", To: []string{"a"}},
+				{Type: Edge_dependencyOf, From: "c", To: []string{"a", "b"}},
+			},
+			expected: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"b"}, Inverse: true},
+				{Type: Edge_dependsOn, From: "a", To: []string{"c"}, Inverse: true},
+				{Type: Edge_dependsOn, From: "b", To: []string{"c"}, Inverse: true},
+			},
+		},
+		{
+			name: "both directions keep the canonical one",
+			sut: []*Edge{
+				{Type: Edge_contained_by, From: "b", To: []string{"a"}},
+				{Type: Edge_contains, From: "a", To: []string{"b", "c"}},
+				{Type: Edge_contained_by, From: "d", To: []string{"a"}},
+			},
+			expected: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"b", "c"}},
+				{Type: Edge_contains, From: "a", To: []string{"d"}, Inverse: true},
+			},
+			removed: 1,
+		},
+		{
+			name: "repeated relationships",
+			sut: []*Edge{
+				{Type: Edge_dependsOn, From: "a", To: []string{"b", "b", "c"}},
+				{Type: Edge_dependsOn, From: "a", To: []string{"c"}, Comment: "again"},
+				{Type: Edge_dependencyOf, From: "d", To: []string{"a"}},
+				{Type: Edge_dependencyOf, From: "d", To: []string{"a"}},
+			},
+			expected: []*Edge{
+				{Type: Edge_dependsOn, From: "a", To: []string{"b", "c"}},
+				{Type: Edge_dependsOn, From: "a", To: []string{"d"}, Inverse: true},
+			},
+			removed: 3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{Edges: tc.sut}
+			require.Equal(t, tc.removed, nl.NormalizeEdges())
+			require.Len(t, nl.Edges, len(tc.expected))
+			for i := range tc.expected {
+				require.True(t, proto.Equal(tc.expected[i], nl.Edges[i]), "edge %d: %v", i, nl.Edges[i])
+			}
+		})
+	}
+}
+
+func TestNodeListClone(t *testing.T) {
+	original := &NodeList{
+		Nodes: []*Node{
+			{Id: "app", Name: "app", Licenses: []string{"MIT"}},
+			{Id: "lib", Name: "lib", Hashes: map[int32]string{int32(HashAlgorithm_SHA256): "abc"}},
+		},
+		Edges: []*Edge{
+			{Type: Edge_dependsOn, From: "app", To: []string{"lib"}},
+		},
+		RootElements: []string{"app"},
+	}
+
+	clone := original.Clone()
+	require.True(t, proto.Equal(original, clone))
+
+	clone.Nodes[0].Name = "modified"
+	clone.Nodes[0].Licenses[0] = "modified"
+	clone.Nodes[1].Hashes[int32(HashAlgorithm_SHA256)] = "modified"
+	clone.Nodes = append(clone.Nodes, &Node{Id: "other"})
+	clone.Edges[0].To[0] = "other"
+	clone.RootElements[0] = "other"
+
+	require.Len(t, original.Nodes, 2)
+	require.Equal(t, "app", original.Nodes[0].Name)
+	require.Equal(t, "MIT", original.Nodes[0].Licenses[0])
+	require.Equal(t, "abc", original.Nodes[1].Hashes[int32(HashAlgorithm_SHA256)])
+	require.Equal(t, []string{"lib"}, original.Edges[0].To)
+	require.Equal(t, []string{"app"}, original.RootElements)
+
+	var nilList *NodeList
+	require.Nil(t, nilList.Clone())
+}
+
+func TestGroupByEcosystem(t *testing.T) {
+	purl := func(id, p string) *Node {
+		n := &Node{Id: id}
+		if p != "" {
+			n.Identifiers = map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+		}
+		return n
+	}
+	nl := &NodeList{
+		Nodes: []*Node{
+			purl("npm-1", "pkg:npm/lodash@4.17.21"),
+			purl("pypi-1", "pkg:pypi/django@1.11.1"),
+			purl("npm-2", "pkg:npm/%40babel/core@7.0.0"),
+			purl("no-purl", ""),
+			purl("custom", "pkg:custom/thing@1.0"),
+		},
+	}
+
+	groups := nl.GroupByEcosystem()
+	ids := map[string][]string{}
+	for ecosystem, nodes := range groups {
+		for _, n := range nodes {
+			ids[ecosystem] = append(ids[ecosystem], n.Id)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"npm":        {"npm-1", "npm-2"},
+		"pypi":       {"pypi-1"},
+		UnknownGroup: {"no-purl", "custom"},
+	}, ids)
+
+	require.Empty(t, (&NodeList{}).GroupByEcosystem())
+}
+
+func TestGroupBySupplier(t *testing.T) {
+	nl := &NodeList{
+		Nodes: []*Node{
+			{Id: "acme-1", Suppliers: []*Person{{Name: "ACME", IsOrg: true}}},
+			{Id: "both", Suppliers: []*Person{{Name: "ACME"}, {Name: "Example Inc"}, {Name: "ACME"}}},
+			{Id: "email", Suppliers: []*Person{{Email: "ops@example.com"}}},
+			{Id: "none"},
+			{Id: "blank", Suppliers: []*Person{{Name: " "}}},
+		},
+	}
+
+	groups := nl.GroupBySupplier()
+	ids := map[string][]string{}
+	for supplier, nodes := range groups {
+		for _, n := range nodes {
+			ids[supplier] = append(ids[supplier], n.Id)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"ACME":            {"acme-1", "both"},
+		"Example Inc":     {"both"},
+		"ops@example.com": {"email"},
+		UnknownGroup:      {"none", "blank"},
+	}, ids)
+}
+
+func TestFlatten(t *testing.T) {
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+	}
+	nl := &NodeList{
+		Nodes: []*Node{
+			{Id: "app", Type: Node_PACKAGE, Name: "app"},
+			{Id: "a", Type: Node_PACKAGE, Name: "a", Identifiers: purl("pkg:npm/a@1.0.0")},
+			{Id: "b", Type: Node_PACKAGE, Name: "b", Identifiers: purl("pkg:npm/b@1.0.0")},
+			{Id: "c", Type: Node_PACKAGE, Name: "c", Identifiers: purl("pkg:npm/c@1.0.0")},
+			{Id: "c-copy", Type: Node_PACKAGE, Name: "c", Identifiers: purl("pkg:npm/c@1.0.0")},
+			{Id: "d", Type: Node_PACKAGE, Name: "d"},
+			{Id: "file", Type: Node_FILE, Name: "a/index.js"},
+		},
+		Edges: []*Edge{
+			{Type: Edge_dependsOn, From: "app", To: []string{"a", "d"}},
+			{Type: Edge_dependsOn, From: "a", To: []string{"b", "c"}},
+			{Type: Edge_dependsOn, From: "b", To: []string{"c-copy"}},
+			{Type: Edge_dependsOn, From: "d", To: []string{"c"}},
+			{Type: Edge_contains, From: "a", To: []string{"file"}},
+		},
+		RootElements: []string{"app"},
+	}
+
+	ids := func(nodes []*Node) []string {
+		ret := []string{}
+		for _, n := range nodes {
+			ret = append(ret, n.Id)
+		}
+		return ret
+	}
+	require.Equal(t, []string{"app", "a", "b", "c", "d"}, ids(nl.Flatten()))
+	require.Equal(t, []string{"app", "a", "b", "c", "d", "file"}, ids(nl.Flatten(WithFileNodes(true))))
+
+	// The node list is not modified
+	require.Len(t, nl.Nodes, 7)
+	require.Len(t, nl.Edges, 5)
+
+	require.Empty(t, (&NodeList{}).Flatten())
+}
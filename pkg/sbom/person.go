@@ -2,16 +2,112 @@ package sbom
 
 import (
 	"fmt"
+	"strings"
 
+	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/bom-squad/protobom/pkg/formats/spdx"
 )
 
+// PersonFromSPDX2ActorString parses an SPDX 2 actor string such as
+// "Organization: Acme (info@acme.com)" into a new Person. Strings without
+// a type prefix are returned as persons. Returns nil if the string is empty
+// or NOASSERTION.
+func PersonFromSPDX2ActorString(s string) *Person {
+	s = strings.TrimSpace(s)
+	if s == "" || s == spdx.NOASSERTION {
+		return nil
+	}
+
+	actorType, name, email := spdx.ParseActorString(s)
+	if name == "" || name == spdx.NOASSERTION {
+		return nil
+	}
+	return &Person{
+		Name:  name,
+		IsOrg: actorType == "org",
+		Email: email,
+	}
+}
+
+// ToSPDX2ActorString returns the person as an SPDX 2 actor string, for example
+// "Person: John Doe (john@example.com)". A nil person or one without a name
+// is returned as NOASSERTION.
+func (p *Person) ToSPDX2ActorString() string {
+	if p == nil || strings.TrimSpace(p.Name) == "" {
+		return spdx.NOASSERTION
+	}
+	return fmt.Sprintf("%s: %s", p.ToSPDX2ClientOrg(), p.ToSPDX2ClientString())
+}
+
+// PersonFromCDXOrganizationalEntity converts a CycloneDX organizational
+// entity into an organization. The entity contacts are captured as the
+// person contacts. Returns nil if the entity is nil or empty.
+func PersonFromCDXOrganizationalEntity(oe *cdx.OrganizationalEntity) *Person {
+	if oe == nil {
+		return nil
+	}
+	p := &Person{
+		Name:     oe.Name,
+		IsOrg:    true,
+		Contacts: []*Person{},
+	}
+	if oe.URL != nil && len(*oe.URL) > 0 {
+		// TODO(degradation): Only the first URL is kept
+		p.Url = (*oe.URL)[0]
+	}
+	if oe.Contact != nil {
+		for _, c := range *oe.Contact {
+			p.Contacts = append(p.Contacts, &Person{
+				Name:  c.Name,
+				Email: c.Email,
+				Phone: c.Phone,
+			})
+		}
+	}
+	if p.Name == "" && p.Url == "" && len(p.Contacts) == 0 {
+		return nil
+	}
+	return p
+}
+
+// ToCDXOrganizationalEntity returns the person as a CycloneDX organizational
+// entity. As CycloneDX suppliers are always organizations, when the person
+// is an individual it is also added as the entity's contact.
+func (p *Person) ToCDXOrganizationalEntity() *cdx.OrganizationalEntity {
+	oe := &cdx.OrganizationalEntity{
+		Name: p.Name,
+	}
+	if p.Url != "" {
+		oe.URL = &[]string{p.Url}
+	}
+
+	contacts := []cdx.OrganizationalContact{}
+	if !p.IsOrg && (p.Email != "" || p.Phone != "") {
+		contacts = append(contacts, cdx.OrganizationalContact{
+			Name:  p.Name,
+			Email: p.Email,
+			Phone: p.Phone,
+		})
+	}
+	for _, c := range p.Contacts {
+		contacts = append(contacts, cdx.OrganizationalContact{
+			Name:  c.Name,
+			Email: c.Email,
+			Phone: c.Phone,
+		})
+	}
+	if len(contacts) > 0 {
+		oe.Contact = &contacts
+	}
+	return oe
+}
+
 // ToSPDX2ClientString converts the person to an SPDX actor string (not valid for
 // an SBOM but to feed into the SPDX go-tools).
 func (p *Person) ToSPDX2ClientString() string {
-	supplierString := p.Name
-	if p.Email != "" {
-		supplierString = fmt.Sprintf("%s (%s)", p.Name, p.Email)
+	supplierString := strings.TrimSpace(p.Name)
+	if email := strings.TrimSpace(p.Email); email != "" {
+		supplierString = fmt.Sprintf("%s (%s)", supplierString, email)
 	}
 	return supplierString
 }
@@ -59,7 +155,7 @@ func (p *Person) Copy() *Person {
 		Contacts: []*Person{},
 	}
 	for _, op := range p.Contacts {
-		op.Contacts = append(op.Contacts, op.Copy())
+		np.Contacts = append(np.Contacts, op.Copy())
 	}
 	return np
 }
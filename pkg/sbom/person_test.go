@@ -0,0 +1,121 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonFromSPDX2ActorString(t *testing.T) {
+	for _, tc := range []struct {
+		sut      string
+		expected *Person
+	}{
+		{"Organization: Acme (info@acme.com)", &Person{Name: "Acme", IsOrg: true, Email: "info@acme.com"}},
+		{"Organization: Acme", &Person{Name: "Acme", IsOrg: true}},
+		{"Person: John Doe (john@example.com)", &Person{Name: "John Doe", Email: "john@example.com"}},
+		{"Person: John Doe", &Person{Name: "John Doe"}},
+		{"  Person:   John Doe   ", &Person{Name: "John Doe"}},
+		{"Acme, Inc. (Engineering) (eng@acme.com)", &Person{Name: "Acme, Inc. (Engineering)", Email: "eng@acme.com"}},
+		{"Person: Jane Doe ()", &Person{Name: "Jane Doe"}},
+		{"Organization: Acme Corp ( )", &Person{Name: "Acme Corp", IsOrg: true}},
+		{"Organization:   Acme Corp   (  contact@acme.example  )  ", &Person{Name: "Acme Corp", IsOrg: true, Email: "contact@acme.example"}},
+		{"Organization:Acme Corp", &Person{Name: "Acme Corp", IsOrg: true}},
+		{" NOASSERTION ", nil},
+		{"NOASSERTION", nil},
+		{"Organization: NOASSERTION", nil},
+		{"", nil},
+	} {
+		require.Equal(t, tc.expected, PersonFromSPDX2ActorString(tc.sut), tc.sut)
+	}
+}
+
+func TestPersonToSPDX2ActorString(t *testing.T) {
+	for _, tc := range []struct {
+		sut      *Person
+		expected string
+	}{
+		{&Person{Name: "Acme", IsOrg: true, Email: "info@acme.com"}, "Organization: Acme (info@acme.com)"},
+		{&Person{Name: "Acme", IsOrg: true}, "Organization: Acme"},
+		{&Person{Name: "John Doe", Email: "john@example.com"}, "Person: John Doe (john@example.com)"},
+		{&Person{Email: "john@example.com"}, "NOASSERTION"},
+		{&Person{Name: "  ", IsOrg: true}, "NOASSERTION"},
+		{nil, "NOASSERTION"},
+	} {
+		res := tc.sut.ToSPDX2ActorString()
+		require.Equal(t, tc.expected, res)
+
+		// Roundtrip the string back
+		if res != "NOASSERTION" {
+			require.Equal(t, tc.sut, PersonFromSPDX2ActorString(res))
+		}
+	}
+}
+
+func TestPersonFromCDXOrganizationalEntity(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      *cdx.OrganizationalEntity
+		expected *Person
+	}{
+		{"nil", nil, nil},
+		{"empty", &cdx.OrganizationalEntity{}, nil},
+		{
+			"full entity",
+			&cdx.OrganizationalEntity{
+				Name: "Acme",
+				URL:  &[]string{"https://acme.com", "https://acme.org"},
+				Contact: &[]cdx.OrganizationalContact{
+					{Name: "John Doe", Email: "john@acme.com", Phone: "555-1234"},
+				},
+			},
+			&Person{
+				Name: "Acme", IsOrg: true, Url: "https://acme.com",
+				Contacts: []*Person{{Name: "John Doe", Email: "john@acme.com", Phone: "555-1234"}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, PersonFromCDXOrganizationalEntity(tc.sut))
+		})
+	}
+}
+
+func TestPersonToCDXOrganizationalEntity(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      *Person
+		expected *cdx.OrganizationalEntity
+	}{
+		{
+			"organization",
+			&Person{
+				Name: "Acme", IsOrg: true, Url: "https://acme.com",
+				Contacts: []*Person{{Name: "John Doe", Email: "john@acme.com"}},
+			},
+			&cdx.OrganizationalEntity{
+				Name:    "Acme",
+				URL:     &[]string{"https://acme.com"},
+				Contact: &[]cdx.OrganizationalContact{{Name: "John Doe", Email: "john@acme.com"}},
+			},
+		},
+		{
+			"person with email",
+			&Person{Name: "John Doe", Email: "john@example.com"},
+			&cdx.OrganizationalEntity{
+				Name:    "John Doe",
+				Contact: &[]cdx.OrganizationalContact{{Name: "John Doe", Email: "john@example.com"}},
+			},
+		},
+		{
+			"organization only name",
+			&Person{Name: "Acme", IsOrg: true},
+			&cdx.OrganizationalEntity{Name: "Acme"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.sut.ToCDXOrganizationalEntity())
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package sbom
+
+// flatString returns a deterministic string that can be used to hash the property
+func (p *Property) flatString() string {
+	return p.Name + "=" + p.Value
+}
+
+// Copy returns an exact copy of Property p.
+func (p *Property) Copy() *Property {
+	return &Property{Name: p.Name, Value: p.Value}
+}
+
+// copyProperties returns a copy of a list of properties
+func copyProperties(props []*Property) []*Property {
+	ret := []*Property{}
+	for _, p := range props {
+		if p != nil {
+			ret = append(ret, p.Copy())
+		}
+	}
+	return ret
+}
@@ -0,0 +1,181 @@
+package sbom
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bom-squad/protobom/pkg/license"
+)
+
+// NodeQuery selects nodes of a document matching a set of conditions. Queries
+// are built by chaining the filter methods, each of them adds a condition to
+// the query and returns it:
+//
+//	nodes := doc.Components().WithVersion("1.2.3").WithLicense("MIT").WithSupplier("Acme").List()
+//
+// The query is only run when List is called.
+type NodeQuery struct {
+	nodeList *NodeList
+	filters  []func(*Node) bool
+}
+
+// Components returns a query selecting the nodes of the document
+func (d *Document) Components() *NodeQuery {
+	return &NodeQuery{nodeList: d.GetNodeList()}
+}
+
+// Where adds a custom condition to the query, only the nodes for which fn
+// returns true are selected.
+func (q *NodeQuery) Where(fn func(*Node) bool) *NodeQuery {
+	q.filters = append(q.filters, fn)
+	return q
+}
+
+// WithName selects the nodes named name
+func (q *NodeQuery) WithName(name string) *NodeQuery {
+	return q.Where(func(n *Node) bool {
+		return n.Name == name
+	})
+}
+
+// WithVersion selects the nodes whose version is v
+func (q *NodeQuery) WithVersion(v string) *NodeQuery {
+	return q.Where(func(n *Node) bool {
+		return n.Version == v
+	})
+}
+
+// WithLicense selects the nodes with the license l in their licenses or
+// concluded license. Identifiers are matched ignoring case and may appear
+// anywhere in a license expression, so WithLicense("MIT") selects nodes
+// licensed "Apache-2.0 OR MIT".
+func (q *NodeQuery) WithLicense(l string) *NodeQuery {
+	l = strings.TrimSpace(l)
+	return q.Where(func(n *Node) bool {
+		for _, expression := range append([]string{n.LicenseConcluded}, n.Licenses...) {
+			if licenseMatches(expression, l) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// WithSupplier selects the nodes with a supplier whose name or email is
+// supplier, compared ignoring case.
+func (q *NodeQuery) WithSupplier(supplier string) *NodeQuery {
+	supplier = strings.TrimSpace(supplier)
+	return q.Where(func(n *Node) bool {
+		for _, p := range n.Suppliers {
+			if strings.EqualFold(strings.TrimSpace(p.GetName()), supplier) ||
+				strings.EqualFold(strings.TrimSpace(p.GetEmail()), supplier) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// List runs the query and returns the nodes matching all its conditions in
+// the order of the node list
+func (q *NodeQuery) List() []*Node {
+	ret := []*Node{}
+nodes:
+	for _, n := range q.nodeList.GetNodes() {
+		for _, fn := range q.filters {
+			if !fn(n) {
+				continue nodes
+			}
+		}
+		ret = append(ret, n)
+	}
+	return ret
+}
+
+// RelationshipQuery selects edges of a document matching a set of
+// conditions. Like NodeQuery, the conditions are added by chaining the
+// filter methods and the query runs when List is called:
+//
+//	deps := doc.Relationships().ByType(sbom.Edge_dependsOn).BySource("app").List()
+//
+// Relationships are matched in their canonical direction, edges of an
+// inverse type (such as dependencyOf or contained_by) are turned into the
+// equivalent canonical edges first, see Edge.Canonical.
+type RelationshipQuery struct {
+	nodeList *NodeList
+	filters  []func(*Edge) bool
+}
+
+// Relationships returns a query selecting the edges of the document
+func (d *Document) Relationships() *RelationshipQuery {
+	return &RelationshipQuery{nodeList: d.GetNodeList()}
+}
+
+// Where adds a custom condition to the query, only the edges for which fn
+// returns true are selected.
+func (q *RelationshipQuery) Where(fn func(*Edge) bool) *RelationshipQuery {
+	q.filters = append(q.filters, fn)
+	return q
+}
+
+// ByType selects the edges of type t
+func (q *RelationshipQuery) ByType(t Edge_Type) *RelationshipQuery {
+	return q.Where(func(e *Edge) bool {
+		return e.Type == t
+	})
+}
+
+// BySource selects the edges from the node with ID nodeID
+func (q *RelationshipQuery) BySource(nodeID string) *RelationshipQuery {
+	return q.Where(func(e *Edge) bool {
+		return e.From == nodeID
+	})
+}
+
+// ByTarget selects the edges pointing to the node with ID nodeID. The edges
+// are returned with all their targets.
+func (q *RelationshipQuery) ByTarget(nodeID string) *RelationshipQuery {
+	return q.Where(func(e *Edge) bool {
+		return slices.Contains(e.To, nodeID)
+	})
+}
+
+// List runs the query and returns the edges matching all its conditions in
+// the order of the node list
+func (q *RelationshipQuery) List() []*Edge {
+	ret := []*Edge{}
+	for _, e := range q.nodeList.GetEdges() {
+	edges:
+		for _, ce := range e.Canonical() {
+			for _, fn := range q.filters {
+				if !fn(ce) {
+					continue edges
+				}
+			}
+			ret = append(ret, ce)
+		}
+	}
+	return ret
+}
+
+// licenseMatches returns true if the license expression is l or includes
+// the license identifier l
+func licenseMatches(expression, l string) bool {
+	expression = strings.TrimSpace(expression)
+	if expression == "" || l == "" {
+		return false
+	}
+	if strings.EqualFold(expression, l) {
+		return true
+	}
+	e, err := license.Parse(expression)
+	if err != nil {
+		return false
+	}
+	for _, id := range e.Licenses() {
+		if strings.EqualFold(id, l) {
+			return true
+		}
+	}
+	return false
+}
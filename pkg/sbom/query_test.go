@@ -0,0 +1,132 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeQuery(t *testing.T) {
+	doc := NewDocument()
+	doc.NodeList.AddNode(&Node{
+		Id: "a", Name: "lib-a", Version: "1.2.3", Licenses: []string{"MIT"},
+		Suppliers: []*Person{{Name: "Acme", IsOrg: true}},
+	})
+	doc.NodeList.AddNode(&Node{
+		Id: "b", Name: "lib-b", Version: "1.2.3", Licenses: []string{"Apache-2.0 OR mit"},
+		Suppliers: []*Person{{Email: "ops@acme.example"}},
+	})
+	doc.NodeList.AddNode(&Node{
+		Id: "c", Name: "lib-c", Version: "2.0.0", LicenseConcluded: "MIT",
+		Suppliers: []*Person{{Name: "Example Inc"}, {Name: "ACME"}},
+	})
+	doc.NodeList.AddNode(&Node{Id: "d", Name: "lib-a", Version: "1.2.3", Licenses: []string{"GPL-2.0-only"}})
+
+	ids := func(nodes []*Node) []string {
+		ret := []string{}
+		for _, n := range nodes {
+			ret = append(ret, n.Id)
+		}
+		return ret
+	}
+
+	for _, tc := range []struct {
+		name     string
+		query    *NodeQuery
+		expected []string
+	}{
+		{"all", doc.Components(), []string{"a", "b", "c", "d"}},
+		{"version", doc.Components().WithVersion("1.2.3"), []string{"a", "b", "d"}},
+		{"name", doc.Components().WithName("lib-a"), []string{"a", "d"}},
+		{"license in expression", doc.Components().WithLicense("MIT"), []string{"a", "b", "c"}},
+		{"whole expression", doc.Components().WithLicense("apache-2.0 or mit"), []string{"b"}},
+		{"supplier", doc.Components().WithSupplier("acme"), []string{"a", "c"}},
+		{"supplier email", doc.Components().WithSupplier("ops@acme.example"), []string{"b"}},
+		{
+			"chained", doc.Components().WithVersion("1.2.3").WithLicense("MIT").WithSupplier("Acme"),
+			[]string{"a"},
+		},
+		{"custom", doc.Components().Where(func(n *Node) bool { return len(n.Suppliers) == 0 }), []string{"d"}},
+		{"no match", doc.Components().WithVersion("3.0.0"), []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ids(tc.query.List()))
+		})
+	}
+
+	// Filters are added to the same query
+	q := doc.Components()
+	require.Same(t, q, q.WithVersion("1.2.3"))
+	require.Len(t, q.List(), 3)
+
+	require.Empty(t, (&Document{}).Components().List())
+}
+
+func TestRelationshipQuery(t *testing.T) {
+	doc := NewDocument()
+	doc.NodeList.Edges = []*Edge{
+		{Type: Edge_dependsOn, From: "app", To: []string{"a", "b"}},
+		{Type: Edge_dependsOn, From: "a", To: []string{"c"}},
+		{Type: Edge_dependencyOf, From: "d", To: []string{"b"}},
+		{Type: Edge_contains, From: "app", To: []string{"file"}},
+		{Type: Edge_contained_by, From: "other-file", To: []string{"a"}},
+	}
+
+	type rel struct {
+		Type Edge_Type
+		From string
+		To   []string
+	}
+	rels := func(edges []*Edge) []rel {
+		ret := []rel{}
+		for _, e := range edges {
+			ret = append(ret, rel{e.Type, e.From, e.To})
+		}
+		return ret
+	}
+
+	for _, tc := range []struct {
+		name     string
+		query    *RelationshipQuery
+		expected []rel
+	}{
+		{
+			"by type", doc.Relationships().ByType(Edge_dependsOn),
+			[]rel{
+				{Edge_dependsOn, "app", []string{"a", "b"}},
+				{Edge_dependsOn, "a", []string{"c"}},
+				{Edge_dependsOn, "b", []string{"d"}},
+			},
+		},
+		{
+			"by source", doc.Relationships().BySource("a"),
+			[]rel{
+				{Edge_dependsOn, "a", []string{"c"}},
+				{Edge_contains, "a", []string{"other-file"}},
+			},
+		},
+		{
+			"by target", doc.Relationships().ByTarget("b"),
+			[]rel{{Edge_dependsOn, "app", []string{"a", "b"}}},
+		},
+		{
+			"chained", doc.Relationships().ByType(Edge_contains).BySource("app"),
+			[]rel{{Edge_contains, "app", []string{"file"}}},
+		},
+		{"no match", doc.Relationships().ByType(Edge_describes), []rel{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, rels(tc.query.List()))
+		})
+	}
+
+	// Filters are added to the same query
+	q := doc.Relationships()
+	require.Same(t, q, q.ByType(Edge_contains))
+	require.Len(t, q.List(), 2)
+
+	// The document edges are not modified
+	require.Equal(t, Edge_dependencyOf, doc.NodeList.Edges[2].Type)
+
+	require.Empty(t, (&Document{}).Relationships().List())
+}
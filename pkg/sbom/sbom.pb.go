@@ -124,6 +124,8 @@ const (
 	SoftwareIdentifierType_CPE22                   SoftwareIdentifierType = 2
 	SoftwareIdentifierType_CPE23                   SoftwareIdentifierType = 3
 	SoftwareIdentifierType_GITOID                  SoftwareIdentifierType = 4
+	SoftwareIdentifierType_SWID                    SoftwareIdentifierType = 5
+	SoftwareIdentifierType_SWHID                   SoftwareIdentifierType = 6
 )
 
 // Enum value maps for SoftwareIdentifierType.
@@ -134,6 +136,8 @@ var (
 		2: "CPE22",
 		3: "CPE23",
 		4: "GITOID",
+		5: "SWID",
+		6: "SWHID",
 	}
 	SoftwareIdentifierType_value = map[string]int32{
 		"UNKNOWN_IDENTIFIER_TYPE": 0,
@@ -141,6 +145,8 @@ var (
 		"CPE22":                   2,
 		"CPE23":                   3,
 		"GITOID":                  4,
+		"SWID":                    5,
+		"SWHID":                   6,
 	}
 )
 
@@ -344,6 +350,128 @@ func (Node_NodeType) EnumDescriptor() ([]byte, []int) {
 	return file_api_sbom_proto_rawDescGZIP(), []int{1, 0}
 }
 
+type Node_Scope int32
+
+const (
+	Node_UNKNOWN_SCOPE Node_Scope = 0
+	Node_REQUIRED      Node_Scope = 1
+	Node_OPTIONAL      Node_Scope = 2
+	Node_EXCLUDED      Node_Scope = 3
+)
+
+// Enum value maps for Node_Scope.
+var (
+	Node_Scope_name = map[int32]string{
+		0: "UNKNOWN_SCOPE",
+		1: "REQUIRED",
+		2: "OPTIONAL",
+		3: "EXCLUDED",
+	}
+	Node_Scope_value = map[string]int32{
+		"UNKNOWN_SCOPE": 0,
+		"REQUIRED":      1,
+		"OPTIONAL":      2,
+		"EXCLUDED":      3,
+	}
+)
+
+func (x Node_Scope) Enum() *Node_Scope {
+	p := new(Node_Scope)
+	*p = x
+	return p
+}
+
+func (x Node_Scope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Node_Scope) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_sbom_proto_enumTypes[4].Descriptor()
+}
+
+func (Node_Scope) Type() protoreflect.EnumType {
+	return &file_api_sbom_proto_enumTypes[4]
+}
+
+func (x Node_Scope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Node_Scope.Descriptor instead.
+func (Node_Scope) EnumDescriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{1, 1}
+}
+
+type Composition_Aggregate int32
+
+const (
+	Composition_NOT_SPECIFIED                           Composition_Aggregate = 0
+	Composition_COMPLETE                                Composition_Aggregate = 1
+	Composition_INCOMPLETE                              Composition_Aggregate = 2
+	Composition_INCOMPLETE_FIRST_PARTY_ONLY             Composition_Aggregate = 3
+	Composition_INCOMPLETE_FIRST_PARTY_PROPRIETARY_ONLY Composition_Aggregate = 4
+	Composition_INCOMPLETE_FIRST_PARTY_OPENSOURCE_ONLY  Composition_Aggregate = 5
+	Composition_INCOMPLETE_THIRD_PARTY_ONLY             Composition_Aggregate = 6
+	Composition_INCOMPLETE_THIRD_PARTY_PROPRIETARY_ONLY Composition_Aggregate = 7
+	Composition_INCOMPLETE_THIRD_PARTY_OPENSOURCE_ONLY  Composition_Aggregate = 8
+	Composition_UNKNOWN                                 Composition_Aggregate = 9
+)
+
+// Enum value maps for Composition_Aggregate.
+var (
+	Composition_Aggregate_name = map[int32]string{
+		0: "NOT_SPECIFIED",
+		1: "COMPLETE",
+		2: "INCOMPLETE",
+		3: "INCOMPLETE_FIRST_PARTY_ONLY",
+		4: "INCOMPLETE_FIRST_PARTY_PROPRIETARY_ONLY",
+		5: "INCOMPLETE_FIRST_PARTY_OPENSOURCE_ONLY",
+		6: "INCOMPLETE_THIRD_PARTY_ONLY",
+		7: "INCOMPLETE_THIRD_PARTY_PROPRIETARY_ONLY",
+		8: "INCOMPLETE_THIRD_PARTY_OPENSOURCE_ONLY",
+		9: "UNKNOWN",
+	}
+	Composition_Aggregate_value = map[string]int32{
+		"NOT_SPECIFIED":               0,
+		"COMPLETE":                    1,
+		"INCOMPLETE":                  2,
+		"INCOMPLETE_FIRST_PARTY_ONLY": 3,
+		"INCOMPLETE_FIRST_PARTY_PROPRIETARY_ONLY": 4,
+		"INCOMPLETE_FIRST_PARTY_OPENSOURCE_ONLY":  5,
+		"INCOMPLETE_THIRD_PARTY_ONLY":             6,
+		"INCOMPLETE_THIRD_PARTY_PROPRIETARY_ONLY": 7,
+		"INCOMPLETE_THIRD_PARTY_OPENSOURCE_ONLY":  8,
+		"UNKNOWN":                                 9,
+	}
+)
+
+func (x Composition_Aggregate) Enum() *Composition_Aggregate {
+	p := new(Composition_Aggregate)
+	*p = x
+	return p
+}
+
+func (x Composition_Aggregate) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Composition_Aggregate) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_sbom_proto_enumTypes[5].Descriptor()
+}
+
+func (Composition_Aggregate) Type() protoreflect.EnumType {
+	return &file_api_sbom_proto_enumTypes[5]
+}
+
+func (x Composition_Aggregate) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Composition_Aggregate.Descriptor instead.
+func (Composition_Aggregate) EnumDescriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{3, 0}
+}
+
 type Edge_Type int32
 
 const (
@@ -503,11 +631,11 @@ func (x Edge_Type) String() string {
 }
 
 func (Edge_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_sbom_proto_enumTypes[4].Descriptor()
+	return file_api_sbom_proto_enumTypes[6].Descriptor()
 }
 
 func (Edge_Type) Type() protoreflect.EnumType {
-	return &file_api_sbom_proto_enumTypes[4]
+	return &file_api_sbom_proto_enumTypes[6]
 }
 
 func (x Edge_Type) Number() protoreflect.EnumNumber {
@@ -516,7 +644,7 @@ func (x Edge_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Edge_Type.Descriptor instead.
 func (Edge_Type) EnumDescriptor() ([]byte, []int) {
-	return file_api_sbom_proto_rawDescGZIP(), []int{3, 0}
+	return file_api_sbom_proto_rawDescGZIP(), []int{5, 0}
 }
 
 type ExternalReference_ExternalReferenceType int32
@@ -726,11 +854,11 @@ func (x ExternalReference_ExternalReferenceType) String() string {
 }
 
 func (ExternalReference_ExternalReferenceType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_sbom_proto_enumTypes[5].Descriptor()
+	return file_api_sbom_proto_enumTypes[7].Descriptor()
 }
 
 func (ExternalReference_ExternalReferenceType) Type() protoreflect.EnumType {
-	return &file_api_sbom_proto_enumTypes[5]
+	return &file_api_sbom_proto_enumTypes[7]
 }
 
 func (x ExternalReference_ExternalReferenceType) Number() protoreflect.EnumNumber {
@@ -739,7 +867,53 @@ func (x ExternalReference_ExternalReferenceType) Number() protoreflect.EnumNumbe
 
 // Deprecated: Use ExternalReference_ExternalReferenceType.Descriptor instead.
 func (ExternalReference_ExternalReferenceType) EnumDescriptor() ([]byte, []int) {
-	return file_api_sbom_proto_rawDescGZIP(), []int{4, 0}
+	return file_api_sbom_proto_rawDescGZIP(), []int{6, 0}
+}
+
+type Annotation_Type int32
+
+const (
+	Annotation_OTHER  Annotation_Type = 0
+	Annotation_REVIEW Annotation_Type = 1
+)
+
+// Enum value maps for Annotation_Type.
+var (
+	Annotation_Type_name = map[int32]string{
+		0: "OTHER",
+		1: "REVIEW",
+	}
+	Annotation_Type_value = map[string]int32{
+		"OTHER":  0,
+		"REVIEW": 1,
+	}
+)
+
+func (x Annotation_Type) Enum() *Annotation_Type {
+	p := new(Annotation_Type)
+	*p = x
+	return p
+}
+
+func (x Annotation_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Annotation_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_sbom_proto_enumTypes[8].Descriptor()
+}
+
+func (Annotation_Type) Type() protoreflect.EnumType {
+	return &file_api_sbom_proto_enumTypes[8]
+}
+
+func (x Annotation_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Annotation_Type.Descriptor instead.
+func (Annotation_Type) EnumDescriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{10, 0}
 }
 
 type DocumentType_SBOMType int32
@@ -793,11 +967,11 @@ func (x DocumentType_SBOMType) String() string {
 }
 
 func (DocumentType_SBOMType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_sbom_proto_enumTypes[6].Descriptor()
+	return file_api_sbom_proto_enumTypes[9].Descriptor()
 }
 
 func (DocumentType_SBOMType) Type() protoreflect.EnumType {
-	return &file_api_sbom_proto_enumTypes[6]
+	return &file_api_sbom_proto_enumTypes[9]
 }
 
 func (x DocumentType_SBOMType) Number() protoreflect.EnumNumber {
@@ -806,7 +980,7 @@ func (x DocumentType_SBOMType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DocumentType_SBOMType.Descriptor instead.
 func (DocumentType_SBOMType) EnumDescriptor() ([]byte, []int) {
-	return file_api_sbom_proto_rawDescGZIP(), []int{7, 0}
+	return file_api_sbom_proto_rawDescGZIP(), []int{15, 0}
 }
 
 type Document struct {
@@ -895,6 +1069,15 @@ type Node struct {
 	Identifiers        map[int32]string       `protobuf:"bytes,28,rep,name=identifiers,proto3" json:"identifiers,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Software identifiers
 	Hashes             map[int32]string       `protobuf:"bytes,29,rep,name=hashes,proto3" json:"hashes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	PrimaryPurpose     []Purpose              `protobuf:"varint,30,rep,packed,name=primary_purpose,json=primaryPurpose,proto3,enum=bomsquad.protobom.Purpose" json:"primary_purpose,omitempty"`
+	Snippets           []*Snippet             `protobuf:"bytes,31,rep,name=snippets,proto3" json:"snippets,omitempty"` // Snippets of a file node (SPDX only)
+	Annotations        []*Annotation          `protobuf:"bytes,32,rep,name=annotations,proto3" json:"annotations,omitempty"`
+	VerificationCode   *VerificationCode      `protobuf:"bytes,33,opt,name=verification_code,json=verificationCode,proto3" json:"verification_code,omitempty"`                                                                                                // SPDX package verification code
+	FilesAnalyzed      *bool                  `protobuf:"varint,34,opt,name=files_analyzed,json=filesAnalyzed,proto3,oneof" json:"files_analyzed,omitempty"`                                                                                                  // SPDX filesAnalyzed, unset when unknown
+	ExternalDocument   string                 `protobuf:"bytes,35,opt,name=external_document,json=externalDocument,proto3" json:"external_document,omitempty"`                                                                                                // ID of the external document defining the node (SPDX DocumentRef)
+	LicensesDetected   []string               `protobuf:"bytes,36,rep,name=licenses_detected,json=licensesDetected,proto3" json:"licenses_detected,omitempty"`                                                                                                // Licenses found in the contents of the node (SPDX licenseInfoFromFiles and licenseInfoInFiles)
+	Scope              Node_Scope             `protobuf:"varint,37,opt,name=scope,proto3,enum=bomsquad.protobom.Node_Scope" json:"scope,omitempty"`                                                                                                           // CycloneDX component scope
+	Properties         []*Property            `protobuf:"bytes,38,rep,name=properties,proto3" json:"properties,omitempty"`                                                                                                                                    // Name/value pairs, CycloneDX component properties
+	IdentifierComments map[int32]string       `protobuf:"bytes,39,rep,name=identifier_comments,json=identifierComments,proto3" json:"identifier_comments,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Comments of the software identifiers, keyed by identifier type (SPDX externalRef comments)
 }
 
 func (x *Node) Reset() {
@@ -1111,19 +1294,90 @@ func (x *Node) GetPrimaryPurpose() []Purpose {
 	return nil
 }
 
+func (x *Node) GetSnippets() []*Snippet {
+	if x != nil {
+		return x.Snippets
+	}
+	return nil
+}
+
+func (x *Node) GetAnnotations() []*Annotation {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *Node) GetVerificationCode() *VerificationCode {
+	if x != nil {
+		return x.VerificationCode
+	}
+	return nil
+}
+
+func (x *Node) GetFilesAnalyzed() bool {
+	if x != nil && x.FilesAnalyzed != nil {
+		return *x.FilesAnalyzed
+	}
+	return false
+}
+
+func (x *Node) GetExternalDocument() string {
+	if x != nil {
+		return x.ExternalDocument
+	}
+	return ""
+}
+
+func (x *Node) GetLicensesDetected() []string {
+	if x != nil {
+		return x.LicensesDetected
+	}
+	return nil
+}
+
+func (x *Node) GetScope() Node_Scope {
+	if x != nil {
+		return x.Scope
+	}
+	return Node_UNKNOWN_SCOPE
+}
+
+func (x *Node) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Node) GetIdentifierComments() map[int32]string {
+	if x != nil {
+		return x.IdentifierComments
+	}
+	return nil
+}
+
 type Metadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`           // Serial number in cyclone, SPDXID in spdx
-	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"` // Int in CDX, but lets string it to capture other possible schemes
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Date          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"` // created date in spdx
-	Tools         []*Tool                `protobuf:"bytes,5,rep,name=tools,proto3" json:"tools,omitempty"`
-	Authors       []*Person              `protobuf:"bytes,6,rep,name=authors,proto3" json:"authors,omitempty"`
-	Comment       string                 `protobuf:"bytes,7,opt,name=comment,proto3" json:"comment,omitempty"`
-	DocumentTypes []*DocumentType        `protobuf:"bytes,8,rep,name=documentTypes,proto3" json:"documentTypes,omitempty"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`           // Serial number in cyclone, SPDXID in spdx
+	Version            string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"` // Int in CDX, but lets string it to capture other possible schemes
+	Name               string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Date               *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"` // created date in spdx
+	Tools              []*Tool                `protobuf:"bytes,5,rep,name=tools,proto3" json:"tools,omitempty"`
+	Authors            []*Person              `protobuf:"bytes,6,rep,name=authors,proto3" json:"authors,omitempty"`
+	Comment            string                 `protobuf:"bytes,7,opt,name=comment,proto3" json:"comment,omitempty"`
+	DocumentTypes      []*DocumentType        `protobuf:"bytes,8,rep,name=documentTypes,proto3" json:"documentTypes,omitempty"`
+	Annotations        []*Annotation          `protobuf:"bytes,9,rep,name=annotations,proto3" json:"annotations,omitempty"`                                            // Annotations about the document
+	ExtractedLicenses  []*ExtractedLicense    `protobuf:"bytes,10,rep,name=extracted_licenses,json=extractedLicenses,proto3" json:"extracted_licenses,omitempty"`      // Licenses not in the SPDX list
+	ExternalDocuments  []*ExternalDocument    `protobuf:"bytes,11,rep,name=external_documents,json=externalDocuments,proto3" json:"external_documents,omitempty"`      // Other documents referenced by the document
+	CreatorComment     string                 `protobuf:"bytes,12,opt,name=creator_comment,json=creatorComment,proto3" json:"creator_comment,omitempty"`               // Comment about how the document was created, SPDX CreatorComment
+	LicenseListVersion string                 `protobuf:"bytes,13,opt,name=license_list_version,json=licenseListVersion,proto3" json:"license_list_version,omitempty"` // Version of the SPDX license list used in the document
+	ExternalReferences []*ExternalReference   `protobuf:"bytes,14,rep,name=external_references,json=externalReferences,proto3" json:"external_references,omitempty"`   // References to resources about the document, CDX externalReferences
+	Compositions       []*Composition         `protobuf:"bytes,15,rep,name=compositions,proto3" json:"compositions,omitempty"`                                         // Completeness of the inventory, CDX compositions
+	Properties         []*Property            `protobuf:"bytes,16,rep,name=properties,proto3" json:"properties,omitempty"`                                             // Name/value pairs about the document, CDX metadata properties
 }
 
 func (x *Metadata) Reset() {
@@ -1214,18 +1468,78 @@ func (x *Metadata) GetDocumentTypes() []*DocumentType {
 	return nil
 }
 
-type Edge struct {
+func (x *Metadata) GetAnnotations() []*Annotation {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *Metadata) GetExtractedLicenses() []*ExtractedLicense {
+	if x != nil {
+		return x.ExtractedLicenses
+	}
+	return nil
+}
+
+func (x *Metadata) GetExternalDocuments() []*ExternalDocument {
+	if x != nil {
+		return x.ExternalDocuments
+	}
+	return nil
+}
+
+func (x *Metadata) GetCreatorComment() string {
+	if x != nil {
+		return x.CreatorComment
+	}
+	return ""
+}
+
+func (x *Metadata) GetLicenseListVersion() string {
+	if x != nil {
+		return x.LicenseListVersion
+	}
+	return ""
+}
+
+func (x *Metadata) GetExternalReferences() []*ExternalReference {
+	if x != nil {
+		return x.ExternalReferences
+	}
+	return nil
+}
+
+func (x *Metadata) GetCompositions() []*Composition {
+	if x != nil {
+		return x.Compositions
+	}
+	return nil
+}
+
+func (x *Metadata) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// Composition declares how complete the information about a set of nodes
+// is. Assemblies are the nodes whose components are described and
+// dependencies the nodes whose dependencies are described.
+type Composition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type Edge_Type `protobuf:"varint,1,opt,name=type,proto3,enum=bomsquad.protobom.Edge_Type" json:"type,omitempty"`
-	From string    `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
-	To   []string  `protobuf:"bytes,3,rep,name=to,proto3" json:"to,omitempty"`
+	Id           string                `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // bom-ref of the composition
+	Aggregate    Composition_Aggregate `protobuf:"varint,2,opt,name=aggregate,proto3,enum=bomsquad.protobom.Composition_Aggregate" json:"aggregate,omitempty"`
+	Assemblies   []string              `protobuf:"bytes,3,rep,name=assemblies,proto3" json:"assemblies,omitempty"`     // IDs of the nodes whose components are covered
+	Dependencies []string              `protobuf:"bytes,4,rep,name=dependencies,proto3" json:"dependencies,omitempty"` // IDs of the nodes whose dependencies are covered
 }
 
-func (x *Edge) Reset() {
-	*x = Edge{}
+func (x *Composition) Reset() {
+	*x = Composition{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_sbom_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1233,13 +1547,13 @@ func (x *Edge) Reset() {
 	}
 }
 
-func (x *Edge) String() string {
+func (x *Composition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Edge) ProtoMessage() {}
+func (*Composition) ProtoMessage() {}
 
-func (x *Edge) ProtoReflect() protoreflect.Message {
+func (x *Composition) ProtoReflect() protoreflect.Message {
 	mi := &file_api_sbom_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1251,52 +1565,54 @@ func (x *Edge) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
-func (*Edge) Descriptor() ([]byte, []int) {
+// Deprecated: Use Composition.ProtoReflect.Descriptor instead.
+func (*Composition) Descriptor() ([]byte, []int) {
 	return file_api_sbom_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Edge) GetType() Edge_Type {
+func (x *Composition) GetId() string {
 	if x != nil {
-		return x.Type
+		return x.Id
 	}
-	return Edge_UNKNOWN
+	return ""
 }
 
-func (x *Edge) GetFrom() string {
+func (x *Composition) GetAggregate() Composition_Aggregate {
 	if x != nil {
-		return x.From
+		return x.Aggregate
 	}
-	return ""
+	return Composition_NOT_SPECIFIED
 }
 
-func (x *Edge) GetTo() []string {
+func (x *Composition) GetAssemblies() []string {
 	if x != nil {
-		return x.To
+		return x.Assemblies
 	}
 	return nil
 }
 
-// ExternalReference is an entry linking an element to a resource defined outside the SBOM standard
-type ExternalReference struct {
+func (x *Composition) GetDependencies() []string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+// ExternalDocument is another SBOM referenced from the document. Nodes
+// defined in it are represented by stub nodes with their external_document
+// field set to the document ID.
+type ExternalDocument struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	// Field 2 was the old string type. Replaced by ExternalReferenceType
-	// see https://github.com/bom-squad/protobom/issues/148
-	// string type = 2;
-	Comment   string `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
-	Authority string `protobuf:"bytes,4,opt,name=authority,proto3" json:"authority,omitempty"`
-	// Field 5 was the old string map.
-	// Removed, see https://github.com/bom-squad/protobom/issues/89
-	Hashes map[int32]string                        `protobuf:"bytes,6,rep,name=hashes,proto3" json:"hashes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Type   ExternalReference_ExternalReferenceType `protobuf:"varint,7,opt,name=type,proto3,enum=bomsquad.protobom.ExternalReference_ExternalReferenceType" json:"type,omitempty"`
+	Id     string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                                  // Document ID, the SPDX DocumentRef without its prefix
+	Uri    string           `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`                                                                                                // URI of the document
+	Hashes map[int32]string `protobuf:"bytes,3,rep,name=hashes,proto3" json:"hashes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Checksums of the document
 }
 
-func (x *ExternalReference) Reset() {
-	*x = ExternalReference{}
+func (x *ExternalDocument) Reset() {
+	*x = ExternalDocument{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_sbom_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1304,13 +1620,13 @@ func (x *ExternalReference) Reset() {
 	}
 }
 
-func (x *ExternalReference) String() string {
+func (x *ExternalDocument) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExternalReference) ProtoMessage() {}
+func (*ExternalDocument) ProtoMessage() {}
 
-func (x *ExternalReference) ProtoReflect() protoreflect.Message {
+func (x *ExternalDocument) ProtoReflect() protoreflect.Message {
 	mi := &file_api_sbom_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1322,61 +1638,46 @@ func (x *ExternalReference) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExternalReference.ProtoReflect.Descriptor instead.
-func (*ExternalReference) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExternalDocument.ProtoReflect.Descriptor instead.
+func (*ExternalDocument) Descriptor() ([]byte, []int) {
 	return file_api_sbom_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ExternalReference) GetUrl() string {
-	if x != nil {
-		return x.Url
-	}
-	return ""
-}
-
-func (x *ExternalReference) GetComment() string {
+func (x *ExternalDocument) GetId() string {
 	if x != nil {
-		return x.Comment
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ExternalReference) GetAuthority() string {
+func (x *ExternalDocument) GetUri() string {
 	if x != nil {
-		return x.Authority
+		return x.Uri
 	}
 	return ""
 }
 
-func (x *ExternalReference) GetHashes() map[int32]string {
+func (x *ExternalDocument) GetHashes() map[int32]string {
 	if x != nil {
 		return x.Hashes
 	}
 	return nil
 }
 
-func (x *ExternalReference) GetType() ExternalReference_ExternalReferenceType {
-	if x != nil {
-		return x.Type
-	}
-	return ExternalReference_UNKNOWN
-}
-
-type Person struct {
+type Edge struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name     string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	IsOrg    bool      `protobuf:"varint,2,opt,name=is_org,json=isOrg,proto3" json:"is_org,omitempty"`
-	Email    string    `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	Url      string    `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
-	Phone    string    `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`       // CDX
-	Contacts []*Person `protobuf:"bytes,6,rep,name=contacts,proto3" json:"contacts,omitempty"` // CDX // Support?
+	Type    Edge_Type `protobuf:"varint,1,opt,name=type,proto3,enum=bomsquad.protobom.Edge_Type" json:"type,omitempty"`
+	From    string    `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To      []string  `protobuf:"bytes,3,rep,name=to,proto3" json:"to,omitempty"`
+	Comment string    `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`  // Comment about the relationship, SPDX RelationshipComment
+	Inverse bool      `protobuf:"varint,5,opt,name=inverse,proto3" json:"inverse,omitempty"` // The relationship was stated in the inverse direction, from the target (eg SPDX CONTAINED_BY)
 }
 
-func (x *Person) Reset() {
-	*x = Person{}
+func (x *Edge) Reset() {
+	*x = Edge{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_sbom_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1384,13 +1685,13 @@ func (x *Person) Reset() {
 	}
 }
 
-func (x *Person) String() string {
+func (x *Edge) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Person) ProtoMessage() {}
+func (*Edge) ProtoMessage() {}
 
-func (x *Person) ProtoReflect() protoreflect.Message {
+func (x *Edge) ProtoReflect() protoreflect.Message {
 	mi := &file_api_sbom_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1402,9 +1703,580 @@ func (x *Person) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Person.ProtoReflect.Descriptor instead.
-func (*Person) Descriptor() ([]byte, []int) {
-	return file_api_sbom_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
+func (*Edge) Descriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Edge) GetType() Edge_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Edge_UNKNOWN
+}
+
+func (x *Edge) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Edge) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *Edge) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Edge) GetInverse() bool {
+	if x != nil {
+		return x.Inverse
+	}
+	return false
+}
+
+// ExternalReference is an entry linking an element to a resource defined outside the SBOM standard
+type ExternalReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Field 2 was the old string type. Replaced by ExternalReferenceType
+	// see https://github.com/bom-squad/protobom/issues/148
+	// string type = 2;
+	Comment   string `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	Authority string `protobuf:"bytes,4,opt,name=authority,proto3" json:"authority,omitempty"`
+	// Field 5 was the old string map.
+	// Removed, see https://github.com/bom-squad/protobom/issues/89
+	Hashes map[int32]string                        `protobuf:"bytes,6,rep,name=hashes,proto3" json:"hashes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Type   ExternalReference_ExternalReferenceType `protobuf:"varint,7,opt,name=type,proto3,enum=bomsquad.protobom.ExternalReference_ExternalReferenceType" json:"type,omitempty"`
+}
+
+func (x *ExternalReference) Reset() {
+	*x = ExternalReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_sbom_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExternalReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalReference) ProtoMessage() {}
+
+func (x *ExternalReference) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sbom_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalReference.ProtoReflect.Descriptor instead.
+func (*ExternalReference) Descriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExternalReference) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ExternalReference) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ExternalReference) GetAuthority() string {
+	if x != nil {
+		return x.Authority
+	}
+	return ""
+}
+
+func (x *ExternalReference) GetHashes() map[int32]string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+func (x *ExternalReference) GetType() ExternalReference_ExternalReferenceType {
+	if x != nil {
+		return x.Type
+	}
+	return ExternalReference_UNKNOWN
+}
+
+// Snippet is a fragment of a file node. Protobom captures them in the file
+// node they belong to.
+type Snippet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Ranges           []*SnippetRange `protobuf:"bytes,3,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	LicenseConcluded string          `protobuf:"bytes,4,opt,name=license_concluded,json=licenseConcluded,proto3" json:"license_concluded,omitempty"`
+	Licenses         []string        `protobuf:"bytes,5,rep,name=licenses,proto3" json:"licenses,omitempty"` // License info in the snippet
+	LicenseComments  string          `protobuf:"bytes,6,opt,name=license_comments,json=licenseComments,proto3" json:"license_comments,omitempty"`
+	Copyright        string          `protobuf:"bytes,7,opt,name=copyright,proto3" json:"copyright,omitempty"`
+	Comment          string          `protobuf:"bytes,8,opt,name=comment,proto3" json:"comment,omitempty"`
+	Attribution      []string        `protobuf:"bytes,9,rep,name=attribution,proto3" json:"attribution,omitempty"`
+}
+
+func (x *Snippet) Reset() {
+	*x = Snippet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_sbom_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snippet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snippet) ProtoMessage() {}
+
+func (x *Snippet) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sbom_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snippet.ProtoReflect.Descriptor instead.
+func (*Snippet) Descriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Snippet) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Snippet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Snippet) GetRanges() []*SnippetRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *Snippet) GetLicenseConcluded() string {
+	if x != nil {
+		return x.LicenseConcluded
+	}
+	return ""
+}
+
+func (x *Snippet) GetLicenses() []string {
+	if x != nil {
+		return x.Licenses
+	}
+	return nil
+}
+
+func (x *Snippet) GetLicenseComments() string {
+	if x != nil {
+		return x.LicenseComments
+	}
+	return ""
+}
+
+func (x *Snippet) GetCopyright() string {
+	if x != nil {
+		return x.Copyright
+	}
+	return ""
+}
+
+func (x *Snippet) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Snippet) GetAttribution() []string {
+	if x != nil {
+		return x.Attribution
+	}
+	return nil
+}
+
+// SnippetRange is a byte and/or line range in a file. Zero values mean
+// the range boundary is not set.
+type SnippetRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartOffset int32 `protobuf:"varint,1,opt,name=start_offset,json=startOffset,proto3" json:"start_offset,omitempty"`
+	EndOffset   int32 `protobuf:"varint,2,opt,name=end_offset,json=endOffset,proto3" json:"end_offset,omitempty"`
+	StartLine   int32 `protobuf:"varint,3,opt,name=start_line,json=startLine,proto3" json:"start_line,omitempty"`
+	EndLine     int32 `protobuf:"varint,4,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`
+}
+
+func (x *SnippetRange) Reset() {
+	*x = SnippetRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_sbom_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnippetRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnippetRange) ProtoMessage() {}
+
+func (x *SnippetRange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sbom_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnippetRange.ProtoReflect.Descriptor instead.
+func (*SnippetRange) Descriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SnippetRange) GetStartOffset() int32 {
+	if x != nil {
+		return x.StartOffset
+	}
+	return 0
+}
+
+func (x *SnippetRange) GetEndOffset() int32 {
+	if x != nil {
+		return x.EndOffset
+	}
+	return 0
+}
+
+func (x *SnippetRange) GetStartLine() int32 {
+	if x != nil {
+		return x.StartLine
+	}
+	return 0
+}
+
+func (x *SnippetRange) GetEndLine() int32 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+// VerificationCode is the SPDX package verification code, computed from the
+// SHA1 hashes of the files in the package not listed in excluded_files.
+type VerificationCode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value         string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	ExcludedFiles []string `protobuf:"bytes,2,rep,name=excluded_files,json=excludedFiles,proto3" json:"excluded_files,omitempty"`
+}
+
+func (x *VerificationCode) Reset() {
+	*x = VerificationCode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_sbom_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerificationCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerificationCode) ProtoMessage() {}
+
+func (x *VerificationCode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sbom_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerificationCode.ProtoReflect.Descriptor instead.
+func (*VerificationCode) Descriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VerificationCode) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *VerificationCode) GetExcludedFiles() []string {
+	if x != nil {
+		return x.ExcludedFiles
+	}
+	return nil
+}
+
+// Annotation is a comment made by a person, organization or tool about a
+// node or the document. It is used, for example, to record reviews.
+type Annotation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Annotator *Person                `protobuf:"bytes,1,opt,name=annotator,proto3" json:"annotator,omitempty"` // Person or organization that made the annotation
+	Tool      *Tool                  `protobuf:"bytes,2,opt,name=tool,proto3" json:"tool,omitempty"`           // Set when the annotation was made by a tool
+	Date      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Type      Annotation_Type        `protobuf:"varint,4,opt,name=type,proto3,enum=bomsquad.protobom.Annotation_Type" json:"type,omitempty"`
+	Comment   string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+}
+
+func (x *Annotation) Reset() {
+	*x = Annotation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_sbom_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Annotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Annotation) ProtoMessage() {}
+
+func (x *Annotation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sbom_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Annotation.ProtoReflect.Descriptor instead.
+func (*Annotation) Descriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Annotation) GetAnnotator() *Person {
+	if x != nil {
+		return x.Annotator
+	}
+	return nil
+}
+
+func (x *Annotation) GetTool() *Tool {
+	if x != nil {
+		return x.Tool
+	}
+	return nil
+}
+
+func (x *Annotation) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *Annotation) GetType() Annotation_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Annotation_OTHER
+}
+
+func (x *Annotation) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// ExtractedLicense is a license not found in the SPDX license list. License
+// expressions refer to it by its LicenseRef- identifier. In SPDX these are
+// the extracted licensing infos of the document.
+type ExtractedLicense struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // LicenseRef- identifier
+	Name    string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Text    string   `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`                      // Full text of the license
+	SeeAlso []string `protobuf:"bytes,4,rep,name=see_also,json=seeAlso,proto3" json:"see_also,omitempty"` // URLs to the license
+	Comment string   `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+}
+
+func (x *ExtractedLicense) Reset() {
+	*x = ExtractedLicense{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_sbom_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtractedLicense) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractedLicense) ProtoMessage() {}
+
+func (x *ExtractedLicense) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sbom_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractedLicense.ProtoReflect.Descriptor instead.
+func (*ExtractedLicense) Descriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExtractedLicense) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExtractedLicense) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExtractedLicense) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ExtractedLicense) GetSeeAlso() []string {
+	if x != nil {
+		return x.SeeAlso
+	}
+	return nil
+}
+
+func (x *ExtractedLicense) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type Person struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	IsOrg    bool      `protobuf:"varint,2,opt,name=is_org,json=isOrg,proto3" json:"is_org,omitempty"`
+	Email    string    `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Url      string    `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Phone    string    `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`       // CDX
+	Contacts []*Person `protobuf:"bytes,6,rep,name=contacts,proto3" json:"contacts,omitempty"` // CDX // Support?
+}
+
+func (x *Person) Reset() {
+	*x = Person{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_sbom_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Person) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Person) ProtoMessage() {}
+
+func (x *Person) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sbom_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Person.ProtoReflect.Descriptor instead.
+func (*Person) Descriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Person) GetName() string {
@@ -1449,6 +2321,63 @@ func (x *Person) GetContacts() []*Person {
 	return nil
 }
 
+// Property is an arbitrary name/value pair, as in CycloneDX properties.
+// Names can repeat and the order of the properties is kept.
+type Property struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Property) Reset() {
+	*x = Property{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_sbom_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Property) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Property) ProtoMessage() {}
+
+func (x *Property) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sbom_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Property.ProtoReflect.Descriptor instead.
+func (*Property) Descriptor() ([]byte, []int) {
+	return file_api_sbom_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Property) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Property) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 type Tool struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1462,7 +2391,7 @@ type Tool struct {
 func (x *Tool) Reset() {
 	*x = Tool{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_sbom_proto_msgTypes[6]
+		mi := &file_api_sbom_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1475,7 +2404,7 @@ func (x *Tool) String() string {
 func (*Tool) ProtoMessage() {}
 
 func (x *Tool) ProtoReflect() protoreflect.Message {
-	mi := &file_api_sbom_proto_msgTypes[6]
+	mi := &file_api_sbom_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1488,7 +2417,7 @@ func (x *Tool) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tool.ProtoReflect.Descriptor instead.
 func (*Tool) Descriptor() ([]byte, []int) {
-	return file_api_sbom_proto_rawDescGZIP(), []int{6}
+	return file_api_sbom_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Tool) GetName() string {
@@ -1525,7 +2454,7 @@ type DocumentType struct {
 func (x *DocumentType) Reset() {
 	*x = DocumentType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_sbom_proto_msgTypes[7]
+		mi := &file_api_sbom_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1538,7 +2467,7 @@ func (x *DocumentType) String() string {
 func (*DocumentType) ProtoMessage() {}
 
 func (x *DocumentType) ProtoReflect() protoreflect.Message {
-	mi := &file_api_sbom_proto_msgTypes[7]
+	mi := &file_api_sbom_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1551,7 +2480,7 @@ func (x *DocumentType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentType.ProtoReflect.Descriptor instead.
 func (*DocumentType) Descriptor() ([]byte, []int) {
-	return file_api_sbom_proto_rawDescGZIP(), []int{7}
+	return file_api_sbom_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DocumentType) GetType() DocumentType_SBOMType {
@@ -1588,7 +2517,7 @@ type NodeList struct {
 func (x *NodeList) Reset() {
 	*x = NodeList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_sbom_proto_msgTypes[8]
+		mi := &file_api_sbom_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1601,7 +2530,7 @@ func (x *NodeList) String() string {
 func (*NodeList) ProtoMessage() {}
 
 func (x *NodeList) ProtoReflect() protoreflect.Message {
-	mi := &file_api_sbom_proto_msgTypes[8]
+	mi := &file_api_sbom_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1614,7 +2543,7 @@ func (x *NodeList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeList.ProtoReflect.Descriptor instead.
 func (*NodeList) Descriptor() ([]byte, []int) {
-	return file_api_sbom_proto_rawDescGZIP(), []int{8}
+	return file_api_sbom_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *NodeList) GetNodes() []*Node {
@@ -1653,7 +2582,7 @@ var file_api_sbom_proto_rawDesc = []byte{
 	0x65, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62,
 	0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
 	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4c,
-	0x69, 0x73, 0x74, 0x22, 0x98, 0x0a, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x73, 0x74, 0x22, 0xdd, 0x0f, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02,
 	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x34, 0x0a, 0x04,
 	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x62, 0x6f, 0x6d,
 	0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4e,
@@ -1725,282 +2654,469 @@ var file_api_sbom_proto_rawDesc = []byte{
 	0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65,
 	0x52, 0x0e, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x50, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65,
-	0x1a, 0x3e, 0x0a, 0x10, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x45,
+	0x12, 0x36, 0x0a, 0x08, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x18, 0x1f, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x08,
+	0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x12, 0x3f, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f,
+	0x6d, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x61, 0x6e,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x50, 0x0a, 0x11, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x21,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x10, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2a, 0x0a, 0x0e, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x5f, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x64, 0x18, 0x22, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x41, 0x6e, 0x61, 0x6c,
+	0x79, 0x7a, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x5f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x23, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73,
+	0x5f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x24, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x10, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x12, 0x33, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1d, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52,
+	0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72,
+	0x74, 0x69, 0x65, 0x73, 0x18, 0x26, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x6f, 0x6d,
+	0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50,
+	0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
+	0x69, 0x65, 0x73, 0x12, 0x60, 0x0a, 0x13, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x27, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2f, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x12, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45,
 	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x1a, 0x39, 0x0a, 0x0b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x21, 0x0a, 0x08, 0x4e,
-	0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x41, 0x43, 0x4b, 0x41,
-	0x47, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x01, 0x22, 0xbd,
-	0x02, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x64, 0x61, 0x74,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x74, 0x6f, 0x6f,
-	0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71,
-	0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x54, 0x6f, 0x6f,
-	0x6c, 0x52, 0x05, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6d, 0x73,
-	0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x65,
-	0x72, 0x73, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x45, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
-	0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x6f, 0x6d, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0xe1,
-	0x06, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x2e, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f,
-	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a,
-	0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x82, 0x06,
-	0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
-	0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x61, 0x6d, 0x65, 0x6e, 0x64, 0x73, 0x10, 0x01, 0x12,
-	0x0c, 0x0a, 0x08, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x10, 0x02, 0x12, 0x13, 0x0a,
-	0x0f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79,
-	0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x54, 0x6f, 0x6f, 0x6c, 0x10,
-	0x04, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x10, 0x05, 0x12,
-	0x10, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x10,
-	0x06, 0x12, 0x08, 0x0a, 0x04, 0x63, 0x6f, 0x70, 0x79, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x64,
-	0x61, 0x74, 0x61, 0x46, 0x69, 0x6c, 0x65, 0x10, 0x08, 0x12, 0x16, 0x0a, 0x12, 0x64, 0x65, 0x70,
-	0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x10,
-	0x09, 0x12, 0x0d, 0x0a, 0x09, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x10, 0x0a,
-	0x12, 0x10, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x4f, 0x66,
-	0x10, 0x0b, 0x12, 0x0e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x61, 0x6e, 0x74,
-	0x10, 0x0c, 0x12, 0x0d, 0x0a, 0x09, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x73, 0x10,
-	0x0d, 0x12, 0x0f, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x42, 0x79,
-	0x10, 0x0e, 0x12, 0x11, 0x0a, 0x0d, 0x64, 0x65, 0x76, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65,
-	0x6e, 0x63, 0x79, 0x10, 0x0f, 0x12, 0x0b, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x54, 0x6f, 0x6f, 0x6c,
-	0x10, 0x10, 0x12, 0x18, 0x0a, 0x14, 0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69,
-	0x6f, 0x6e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x10, 0x11, 0x12, 0x11, 0x0a, 0x0d,
-	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x12, 0x12,
-	0x0f, 0x0a, 0x0b, 0x64, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x10, 0x13,
-	0x12, 0x0b, 0x0a, 0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x10, 0x14, 0x12, 0x17, 0x0a,
-	0x13, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x41, 0x72, 0x63,
-	0x68, 0x69, 0x76, 0x65, 0x10, 0x15, 0x12, 0x0d, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x41, 0x64,
-	0x64, 0x65, 0x64, 0x10, 0x16, 0x12, 0x0f, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x64, 0x10, 0x17, 0x12, 0x10, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x6f,
-	0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x10, 0x18, 0x12, 0x0d, 0x0a, 0x09, 0x67, 0x65, 0x6e, 0x65,
-	0x72, 0x61, 0x74, 0x65, 0x73, 0x10, 0x19, 0x12, 0x11, 0x0a, 0x0d, 0x67, 0x65, 0x6e, 0x65, 0x72,
-	0x61, 0x74, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x10, 0x1a, 0x12, 0x0c, 0x0a, 0x08, 0x6d, 0x65,
-	0x74, 0x61, 0x66, 0x69, 0x6c, 0x65, 0x10, 0x1b, 0x12, 0x15, 0x0a, 0x11, 0x6f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x61, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x10, 0x1c, 0x12,
-	0x16, 0x0a, 0x12, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x44, 0x65, 0x70, 0x65, 0x6e,
-	0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x1d, 0x12, 0x09, 0x0a, 0x05, 0x6f, 0x74, 0x68, 0x65, 0x72,
-	0x10, 0x1e, 0x12, 0x0c, 0x0a, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x10, 0x1f,
-	0x12, 0x09, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x10, 0x20, 0x12, 0x10, 0x0a, 0x0c, 0x70,
-	0x72, 0x65, 0x72, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x10, 0x21, 0x12, 0x13, 0x0a,
-	0x0f, 0x70, 0x72, 0x65, 0x72, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x72,
-	0x10, 0x22, 0x12, 0x16, 0x0a, 0x12, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x64, 0x44, 0x65,
-	0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x23, 0x12, 0x12, 0x0a, 0x0e, 0x72, 0x65,
-	0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x10, 0x24, 0x12, 0x15,
-	0x0a, 0x11, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65,
-	0x6e, 0x63, 0x79, 0x10, 0x25, 0x12, 0x14, 0x0a, 0x10, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x6f, 0x72, 0x10, 0x26, 0x12, 0x0e, 0x0a, 0x0a, 0x73,
-	0x74, 0x61, 0x74, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x10, 0x27, 0x12, 0x08, 0x0a, 0x04, 0x74,
-	0x65, 0x73, 0x74, 0x10, 0x28, 0x12, 0x0c, 0x0a, 0x08, 0x74, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73,
-	0x65, 0x10, 0x29, 0x12, 0x12, 0x0a, 0x0e, 0x74, 0x65, 0x73, 0x74, 0x44, 0x65, 0x70, 0x65, 0x6e,
-	0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x2a, 0x12, 0x0c, 0x0a, 0x08, 0x74, 0x65, 0x73, 0x74, 0x54,
-	0x6f, 0x6f, 0x6c, 0x10, 0x2b, 0x12, 0x0b, 0x0a, 0x07, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74,
-	0x10, 0x2c, 0x22, 0x8b, 0x0c, 0x0a, 0x11, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52,
-	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
-	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d,
-	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74,
-	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
-	0x74, 0x79, 0x12, 0x48, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x30, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52,
-	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x4e, 0x0a, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3a, 0x2e, 0x62, 0x6f, 0x6d,
-	0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45,
-	0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
-	0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
-	0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x1a, 0x39, 0x0a, 0x0b,
-	0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x1a, 0x45, 0x0a, 0x17, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
 	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
 	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd6, 0x09, 0x0a, 0x15, 0x45, 0x78, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f,
-	0x0a, 0x0b, 0x41, 0x54, 0x54, 0x45, 0x53, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12,
-	0x0a, 0x0a, 0x06, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x42,
-	0x4f, 0x4d, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x4f, 0x57, 0x45, 0x52, 0x10, 0x04, 0x12,
-	0x0e, 0x0a, 0x0a, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x4d, 0x45, 0x54, 0x41, 0x10, 0x05, 0x12,
-	0x10, 0x0a, 0x0c, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10,
-	0x06, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x45, 0x52, 0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x49,
-	0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x07, 0x12, 0x08, 0x0a, 0x04, 0x43,
-	0x48, 0x41, 0x54, 0x10, 0x08, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f, 0x44, 0x49, 0x46, 0x49, 0x45,
-	0x44, 0x5f, 0x49, 0x4e, 0x46, 0x52, 0x41, 0x53, 0x54, 0x52, 0x55, 0x43, 0x54, 0x55, 0x52, 0x45,
-	0x10, 0x09, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x4d, 0x50, 0x4f, 0x4e, 0x45, 0x4e, 0x54, 0x5f,
-	0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53, 0x49, 0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10,
-	0x0a, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x55, 0x52, 0x41, 0x54, 0x49,
-	0x4f, 0x4e, 0x10, 0x0b, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x49, 0x53, 0x54, 0x52, 0x49, 0x42, 0x55,
-	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x54, 0x41, 0x4b, 0x45, 0x10, 0x0c, 0x12, 0x11, 0x0a,
-	0x0d, 0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x0d,
-	0x12, 0x0c, 0x0a, 0x08, 0x44, 0x4f, 0x57, 0x4e, 0x4c, 0x4f, 0x41, 0x44, 0x10, 0x0e, 0x12, 0x1b,
-	0x0a, 0x17, 0x44, 0x59, 0x4e, 0x41, 0x4d, 0x49, 0x43, 0x5f, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53,
-	0x49, 0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x0f, 0x12, 0x0e, 0x0a, 0x0a, 0x45,
-	0x4f, 0x4c, 0x5f, 0x4e, 0x4f, 0x54, 0x49, 0x43, 0x45, 0x10, 0x10, 0x12, 0x0c, 0x0a, 0x08, 0x45,
-	0x56, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x11, 0x12, 0x1d, 0x0a, 0x19, 0x45, 0x58, 0x50,
-	0x4f, 0x52, 0x54, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x53, 0x53, 0x45,
-	0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x12, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x4f, 0x52, 0x4d,
-	0x55, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x13, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x55, 0x4e,
-	0x44, 0x49, 0x4e, 0x47, 0x10, 0x14, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x53, 0x53, 0x55, 0x45, 0x5f,
-	0x54, 0x52, 0x41, 0x43, 0x4b, 0x45, 0x52, 0x10, 0x15, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x49, 0x43,
-	0x45, 0x4e, 0x53, 0x45, 0x10, 0x16, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x47, 0x10, 0x17, 0x12,
-	0x10, 0x0a, 0x0c, 0x4d, 0x41, 0x49, 0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10,
-	0x18, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x41, 0x54, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x52, 0x45,
-	0x50, 0x4f, 0x52, 0x54, 0x10, 0x19, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x41, 0x56, 0x45, 0x4e, 0x5f,
-	0x43, 0x45, 0x4e, 0x54, 0x52, 0x41, 0x4c, 0x10, 0x1a, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x45, 0x54,
-	0x52, 0x49, 0x43, 0x53, 0x10, 0x1b, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f,
-	0x43, 0x41, 0x52, 0x44, 0x10, 0x1c, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x50, 0x4d, 0x10, 0x1d, 0x12,
-	0x09, 0x0a, 0x05, 0x4e, 0x55, 0x47, 0x45, 0x54, 0x10, 0x1e, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54,
-	0x48, 0x45, 0x52, 0x10, 0x1f, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x41, 0x4d, 0x10, 0x20, 0x12,
-	0x16, 0x0a, 0x12, 0x50, 0x52, 0x49, 0x56, 0x41, 0x43, 0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53,
-	0x53, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x21, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x52, 0x4f, 0x44, 0x55,
-	0x43, 0x54, 0x5f, 0x4d, 0x45, 0x54, 0x41, 0x44, 0x41, 0x54, 0x41, 0x10, 0x22, 0x12, 0x12, 0x0a,
-	0x0e, 0x50, 0x55, 0x52, 0x43, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x10,
-	0x23, 0x12, 0x1d, 0x0a, 0x19, 0x51, 0x55, 0x41, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x53, 0x53,
-	0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x24,
-	0x12, 0x13, 0x0a, 0x0f, 0x51, 0x55, 0x41, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x4d, 0x45, 0x54, 0x52,
-	0x49, 0x43, 0x53, 0x10, 0x25, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45,
-	0x5f, 0x48, 0x49, 0x53, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x26, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x45,
-	0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x45, 0x53, 0x10, 0x27, 0x12, 0x13, 0x0a,
-	0x0f, 0x52, 0x49, 0x53, 0x4b, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54,
-	0x10, 0x28, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x55, 0x4e, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x41, 0x4e,
-	0x41, 0x4c, 0x59, 0x53, 0x49, 0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x29, 0x12,
-	0x1f, 0x0a, 0x1b, 0x53, 0x45, 0x43, 0x55, 0x52, 0x45, 0x5f, 0x53, 0x4f, 0x46, 0x54, 0x57, 0x41,
-	0x52, 0x45, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x53, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x2a,
-	0x12, 0x1c, 0x0a, 0x18, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x44, 0x56,
-	0x45, 0x52, 0x53, 0x41, 0x52, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x10, 0x2b, 0x12, 0x15,
-	0x0a, 0x11, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x44, 0x56, 0x49, 0x53,
-	0x4f, 0x52, 0x59, 0x10, 0x2c, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54,
-	0x59, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x43, 0x54, 0x10, 0x2d, 0x12, 0x10, 0x0a, 0x0c, 0x53,
-	0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x46, 0x49, 0x58, 0x10, 0x2e, 0x12, 0x12, 0x0a,
-	0x0e, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10,
-	0x2f, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x45,
-	0x4e, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x30, 0x12, 0x13,
-	0x0a, 0x0f, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43,
-	0x59, 0x10, 0x31, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f,
-	0x53, 0x57, 0x49, 0x44, 0x10, 0x32, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49,
-	0x54, 0x59, 0x5f, 0x54, 0x48, 0x52, 0x45, 0x41, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x10,
-	0x33, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4f, 0x43, 0x49, 0x41, 0x4c, 0x10, 0x34, 0x12, 0x13, 0x0a,
-	0x0f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54,
-	0x10, 0x35, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x54, 0x41, 0x54, 0x49, 0x43, 0x5f, 0x41, 0x4e, 0x41,
-	0x4c, 0x59, 0x53, 0x49, 0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x36, 0x12, 0x0b,
-	0x0a, 0x07, 0x53, 0x55, 0x50, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x37, 0x12, 0x07, 0x0a, 0x03, 0x56,
-	0x43, 0x53, 0x10, 0x38, 0x12, 0x1b, 0x0a, 0x17, 0x56, 0x55, 0x4c, 0x4e, 0x45, 0x52, 0x41, 0x42,
-	0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x52, 0x54, 0x49, 0x4f, 0x4e, 0x10,
-	0x39, 0x12, 0x23, 0x0a, 0x1f, 0x56, 0x55, 0x4c, 0x4e, 0x45, 0x52, 0x41, 0x42, 0x49, 0x4c, 0x49,
-	0x54, 0x59, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4c, 0x4f, 0x53, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45,
-	0x50, 0x4f, 0x52, 0x54, 0x10, 0x3a, 0x12, 0x2b, 0x0a, 0x27, 0x56, 0x55, 0x4c, 0x4e, 0x45, 0x52,
-	0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x45, 0x58, 0x50, 0x4c, 0x4f, 0x49, 0x54, 0x41,
-	0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e,
-	0x54, 0x10, 0x3b, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x45, 0x42, 0x53, 0x49, 0x54, 0x45, 0x10, 0x3c,
-	0x22, 0xa8, 0x01, 0x0a, 0x06, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x6f, 0x72, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x05, 0x69, 0x73, 0x4f, 0x72, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x10, 0x0a, 0x03,
-	0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x14,
-	0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70,
-	0x68, 0x6f, 0x6e, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x73,
-	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61,
-	0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x6f,
-	0x6e, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x73, 0x22, 0x4c, 0x0a, 0x04, 0x54,
-	0x6f, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x22, 0xb7, 0x02, 0x0a, 0x0c, 0x44, 0x6f,
-	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x41, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71,
-	0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x44, 0x6f, 0x63,
-	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x53, 0x42, 0x4f, 0x4d, 0x54, 0x79,
-	0x70, 0x65, 0x48, 0x00, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0b, 0x64,
-	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x22, 0x81, 0x01,
-	0x0a, 0x08, 0x53, 0x42, 0x4f, 0x4d, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54,
-	0x48, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x53, 0x49, 0x47, 0x4e, 0x10,
-	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x10, 0x02, 0x12, 0x09, 0x0a,
-	0x05, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x4e, 0x41, 0x4c,
-	0x59, 0x5a, 0x45, 0x44, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x45, 0x50, 0x4c, 0x4f, 0x59,
-	0x45, 0x44, 0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x54, 0x49, 0x4d, 0x45, 0x10,
-	0x06, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x59, 0x10, 0x07,
-	0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x45, 0x43, 0x4f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x10,
-	0x08, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x69, 0x73, 0x74,
-	0x12, 0x2d, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x6f, 0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12,
-	0x2d, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x21, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x01, 0x22, 0x44, 0x0a, 0x05, 0x53, 0x63,
+	0x6f, 0x70, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x53,
+	0x43, 0x4f, 0x50, 0x45, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x4c,
+	0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x58, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x44, 0x10, 0x03,
+	0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x5f, 0x61, 0x6e, 0x61, 0x6c, 0x79,
+	0x7a, 0x65, 0x64, 0x22, 0xd9, 0x06, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2d,
+	0x0a, 0x05, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f,
+	0x6d, 0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x52, 0x05, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x33, 0x0a,
+	0x07, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x6f, 0x6d, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x45, 0x0a, 0x0d,
+	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71,
+	0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x41, 0x6e, 0x6e,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x52, 0x0a, 0x12, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x65,
+	0x64, 0x5f, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x65, 0x64, 0x4c, 0x69,
+	0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x11, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x65, 0x64,
+	0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x52, 0x0a, 0x12, 0x65, 0x78, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0b,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x11, 0x65, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65,
+	0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x12, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x55, 0x0a, 0x13, 0x65, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x0e,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x12, 0x65, 0x78, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x42,
+	0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0f,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x3b, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73,
+	0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61,
+	0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65,
+	0x72, 0x74, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x22,
+	0xe9, 0x03, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x46, 0x0a, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x28, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x09, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x65, 0x6d,
+	0x62, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x73, 0x73,
+	0x65, 0x6d, 0x62, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x65, 0x6e,
+	0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x64,
+	0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x22, 0xbd, 0x02, 0x0a, 0x09,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x54,
+	0x5f, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08,
+	0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x49, 0x4e,
+	0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x49, 0x4e,
+	0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x5f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x5f, 0x50,
+	0x41, 0x52, 0x54, 0x59, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x03, 0x12, 0x2b, 0x0a, 0x27, 0x49,
+	0x4e, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x5f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x5f,
+	0x50, 0x41, 0x52, 0x54, 0x59, 0x5f, 0x50, 0x52, 0x4f, 0x50, 0x52, 0x49, 0x45, 0x54, 0x41, 0x52,
+	0x59, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x04, 0x12, 0x2a, 0x0a, 0x26, 0x49, 0x4e, 0x43, 0x4f,
+	0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x5f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x5f, 0x50, 0x41, 0x52,
+	0x54, 0x59, 0x5f, 0x4f, 0x50, 0x45, 0x4e, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x4f, 0x4e,
+	0x4c, 0x59, 0x10, 0x05, 0x12, 0x1f, 0x0a, 0x1b, 0x49, 0x4e, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45,
+	0x54, 0x45, 0x5f, 0x54, 0x48, 0x49, 0x52, 0x44, 0x5f, 0x50, 0x41, 0x52, 0x54, 0x59, 0x5f, 0x4f,
+	0x4e, 0x4c, 0x59, 0x10, 0x06, 0x12, 0x2b, 0x0a, 0x27, 0x49, 0x4e, 0x43, 0x4f, 0x4d, 0x50, 0x4c,
+	0x45, 0x54, 0x45, 0x5f, 0x54, 0x48, 0x49, 0x52, 0x44, 0x5f, 0x50, 0x41, 0x52, 0x54, 0x59, 0x5f,
+	0x50, 0x52, 0x4f, 0x50, 0x52, 0x49, 0x45, 0x54, 0x41, 0x52, 0x59, 0x5f, 0x4f, 0x4e, 0x4c, 0x59,
+	0x10, 0x07, 0x12, 0x2a, 0x0a, 0x26, 0x49, 0x4e, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45,
+	0x5f, 0x54, 0x48, 0x49, 0x52, 0x44, 0x5f, 0x50, 0x41, 0x52, 0x54, 0x59, 0x5f, 0x4f, 0x50, 0x45,
+	0x4e, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x08, 0x12, 0x0b,
+	0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x09, 0x22, 0xb8, 0x01, 0x0a, 0x10,
+	0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
+	0x72, 0x69, 0x12, 0x47, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x48,
+	0x61, 0x73, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x95, 0x07, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12,
+	0x30, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e,
+	0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f,
+	0x6d, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x65, 0x22, 0x82, 0x06, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
+	0x0a, 0x0a, 0x06, 0x61, 0x6d, 0x65, 0x6e, 0x64, 0x73, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x61,
+	0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x03, 0x12, 0x0d,
+	0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x54, 0x6f, 0x6f, 0x6c, 0x10, 0x04, 0x12, 0x0c, 0x0a,
+	0x08, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x10, 0x06, 0x12, 0x08, 0x0a,
+	0x04, 0x63, 0x6f, 0x70, 0x79, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x46,
+	0x69, 0x6c, 0x65, 0x10, 0x08, 0x12, 0x16, 0x0a, 0x12, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65,
+	0x6e, 0x63, 0x79, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x10, 0x09, 0x12, 0x0d, 0x0a,
+	0x09, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x10, 0x0a, 0x12, 0x10, 0x0a, 0x0c,
+	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x4f, 0x66, 0x10, 0x0b, 0x12, 0x0e,
+	0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x61, 0x6e, 0x74, 0x10, 0x0c, 0x12, 0x0d,
+	0x0a, 0x09, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x73, 0x10, 0x0d, 0x12, 0x0f, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x42, 0x79, 0x10, 0x0e, 0x12, 0x11,
+	0x0a, 0x0d, 0x64, 0x65, 0x76, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10,
+	0x0f, 0x12, 0x0b, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x54, 0x6f, 0x6f, 0x6c, 0x10, 0x10, 0x12, 0x18,
+	0x0a, 0x14, 0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x10, 0x11, 0x12, 0x11, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x12, 0x12, 0x0f, 0x0a, 0x0b, 0x64,
+	0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x10, 0x13, 0x12, 0x0b, 0x0a, 0x07,
+	0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x10, 0x14, 0x12, 0x17, 0x0a, 0x13, 0x65, 0x78, 0x70,
+	0x61, 0x6e, 0x64, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
+	0x10, 0x15, 0x12, 0x0d, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x41, 0x64, 0x64, 0x65, 0x64, 0x10,
+	0x16, 0x12, 0x0f, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x10, 0x17, 0x12, 0x10, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69,
+	0x65, 0x64, 0x10, 0x18, 0x12, 0x0d, 0x0a, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x73, 0x10, 0x19, 0x12, 0x11, 0x0a, 0x0d, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x46, 0x72, 0x6f, 0x6d, 0x10, 0x1a, 0x12, 0x0c, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x66, 0x69,
+	0x6c, 0x65, 0x10, 0x1b, 0x12, 0x15, 0x0a, 0x11, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x10, 0x1c, 0x12, 0x16, 0x0a, 0x12, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63,
+	0x79, 0x10, 0x1d, 0x12, 0x09, 0x0a, 0x05, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x10, 0x1e, 0x12, 0x0c,
+	0x0a, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x10, 0x1f, 0x12, 0x09, 0x0a, 0x05,
+	0x70, 0x61, 0x74, 0x63, 0x68, 0x10, 0x20, 0x12, 0x10, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x72, 0x65,
+	0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x10, 0x21, 0x12, 0x13, 0x0a, 0x0f, 0x70, 0x72, 0x65,
+	0x72, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x72, 0x10, 0x22, 0x12, 0x16,
+	0x0a, 0x12, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x64, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64,
+	0x65, 0x6e, 0x63, 0x79, 0x10, 0x23, 0x12, 0x12, 0x0a, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x10, 0x24, 0x12, 0x15, 0x0a, 0x11, 0x72, 0x75,
+	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10,
+	0x25, 0x12, 0x14, 0x0a, 0x10, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x46, 0x6f, 0x72, 0x10, 0x26, 0x12, 0x0e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x69,
+	0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x10, 0x27, 0x12, 0x08, 0x0a, 0x04, 0x74, 0x65, 0x73, 0x74, 0x10,
+	0x28, 0x12, 0x0c, 0x0a, 0x08, 0x74, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x10, 0x29, 0x12,
+	0x12, 0x0a, 0x0e, 0x74, 0x65, 0x73, 0x74, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63,
+	0x79, 0x10, 0x2a, 0x12, 0x0c, 0x0a, 0x08, 0x74, 0x65, 0x73, 0x74, 0x54, 0x6f, 0x6f, 0x6c, 0x10,
+	0x2b, 0x12, 0x0b, 0x0a, 0x07, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x10, 0x2c, 0x22, 0x8b,
+	0x0c, 0x0a, 0x11, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x48,
+	0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30,
+	0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x6f, 0x6d, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x4e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3a, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61,
+	0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x2e, 0x45, 0x78, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x48, 0x61, 0x73, 0x68,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xd6, 0x09, 0x0a, 0x15, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a,
+	0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x54,
+	0x54, 0x45, 0x53, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x42,
+	0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x4f, 0x4d, 0x10, 0x03,
+	0x12, 0x09, 0x0a, 0x05, 0x42, 0x4f, 0x57, 0x45, 0x52, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x42,
+	0x55, 0x49, 0x4c, 0x44, 0x5f, 0x4d, 0x45, 0x54, 0x41, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x42,
+	0x55, 0x49, 0x4c, 0x44, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x06, 0x12, 0x18, 0x0a,
+	0x14, 0x43, 0x45, 0x52, 0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52,
+	0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x07, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x48, 0x41, 0x54, 0x10,
+	0x08, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f, 0x44, 0x49, 0x46, 0x49, 0x45, 0x44, 0x5f, 0x49, 0x4e,
+	0x46, 0x52, 0x41, 0x53, 0x54, 0x52, 0x55, 0x43, 0x54, 0x55, 0x52, 0x45, 0x10, 0x09, 0x12, 0x1d,
+	0x0a, 0x19, 0x43, 0x4f, 0x4d, 0x50, 0x4f, 0x4e, 0x45, 0x4e, 0x54, 0x5f, 0x41, 0x4e, 0x41, 0x4c,
+	0x59, 0x53, 0x49, 0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x0a, 0x12, 0x11, 0x0a,
+	0x0d, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x55, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x0b,
+	0x12, 0x17, 0x0a, 0x13, 0x44, 0x49, 0x53, 0x54, 0x52, 0x49, 0x42, 0x55, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x49, 0x4e, 0x54, 0x41, 0x4b, 0x45, 0x10, 0x0c, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x4f, 0x43,
+	0x55, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x0d, 0x12, 0x0c, 0x0a, 0x08,
+	0x44, 0x4f, 0x57, 0x4e, 0x4c, 0x4f, 0x41, 0x44, 0x10, 0x0e, 0x12, 0x1b, 0x0a, 0x17, 0x44, 0x59,
+	0x4e, 0x41, 0x4d, 0x49, 0x43, 0x5f, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53, 0x49, 0x53, 0x5f, 0x52,
+	0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x0f, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x4f, 0x4c, 0x5f, 0x4e,
+	0x4f, 0x54, 0x49, 0x43, 0x45, 0x10, 0x10, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x56, 0x49, 0x44, 0x45,
+	0x4e, 0x43, 0x45, 0x10, 0x11, 0x12, 0x1d, 0x0a, 0x19, 0x45, 0x58, 0x50, 0x4f, 0x52, 0x54, 0x5f,
+	0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45,
+	0x4e, 0x54, 0x10, 0x12, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x4f, 0x52, 0x4d, 0x55, 0x4c, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x10, 0x13, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x55, 0x4e, 0x44, 0x49, 0x4e, 0x47,
+	0x10, 0x14, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x53, 0x53, 0x55, 0x45, 0x5f, 0x54, 0x52, 0x41, 0x43,
+	0x4b, 0x45, 0x52, 0x10, 0x15, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x49, 0x43, 0x45, 0x4e, 0x53, 0x45,
+	0x10, 0x16, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x47, 0x10, 0x17, 0x12, 0x10, 0x0a, 0x0c, 0x4d,
+	0x41, 0x49, 0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x18, 0x12, 0x13, 0x0a,
+	0x0f, 0x4d, 0x41, 0x54, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54,
+	0x10, 0x19, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x41, 0x56, 0x45, 0x4e, 0x5f, 0x43, 0x45, 0x4e, 0x54,
+	0x52, 0x41, 0x4c, 0x10, 0x1a, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x53,
+	0x10, 0x1b, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x43, 0x41, 0x52, 0x44,
+	0x10, 0x1c, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x50, 0x4d, 0x10, 0x1d, 0x12, 0x09, 0x0a, 0x05, 0x4e,
+	0x55, 0x47, 0x45, 0x54, 0x10, 0x1e, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10,
+	0x1f, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x41, 0x4d, 0x10, 0x20, 0x12, 0x16, 0x0a, 0x12, 0x50,
+	0x52, 0x49, 0x56, 0x41, 0x43, 0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e,
+	0x54, 0x10, 0x21, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x52, 0x4f, 0x44, 0x55, 0x43, 0x54, 0x5f, 0x4d,
+	0x45, 0x54, 0x41, 0x44, 0x41, 0x54, 0x41, 0x10, 0x22, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x55, 0x52,
+	0x43, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x10, 0x23, 0x12, 0x1d, 0x0a,
+	0x19, 0x51, 0x55, 0x41, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d,
+	0x45, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x24, 0x12, 0x13, 0x0a, 0x0f,
+	0x51, 0x55, 0x41, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x53, 0x10,
+	0x25, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x48, 0x49, 0x53,
+	0x54, 0x4f, 0x52, 0x59, 0x10, 0x26, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53,
+	0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x45, 0x53, 0x10, 0x27, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x49, 0x53,
+	0x4b, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x28, 0x12, 0x1b,
+	0x0a, 0x17, 0x52, 0x55, 0x4e, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53,
+	0x49, 0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x29, 0x12, 0x1f, 0x0a, 0x1b, 0x53,
+	0x45, 0x43, 0x55, 0x52, 0x45, 0x5f, 0x53, 0x4f, 0x46, 0x54, 0x57, 0x41, 0x52, 0x45, 0x5f, 0x41,
+	0x54, 0x54, 0x45, 0x53, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x2a, 0x12, 0x1c, 0x0a, 0x18,
+	0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x44, 0x56, 0x45, 0x52, 0x53, 0x41,
+	0x52, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x10, 0x2b, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x45,
+	0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x44, 0x56, 0x49, 0x53, 0x4f, 0x52, 0x59, 0x10,
+	0x2c, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x43, 0x4f,
+	0x4e, 0x54, 0x41, 0x43, 0x54, 0x10, 0x2d, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x45, 0x43, 0x55, 0x52,
+	0x49, 0x54, 0x59, 0x5f, 0x46, 0x49, 0x58, 0x10, 0x2e, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x43,
+	0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10, 0x2f, 0x12, 0x1b, 0x0a,
+	0x17, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x45, 0x4e, 0x54, 0x45, 0x53,
+	0x54, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x30, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45,
+	0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x10, 0x31, 0x12,
+	0x11, 0x0a, 0x0d, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x53, 0x57, 0x49, 0x44,
+	0x10, 0x32, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x54,
+	0x48, 0x52, 0x45, 0x41, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x10, 0x33, 0x12, 0x0a, 0x0a,
+	0x06, 0x53, 0x4f, 0x43, 0x49, 0x41, 0x4c, 0x10, 0x34, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f, 0x55,
+	0x52, 0x43, 0x45, 0x5f, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x10, 0x35, 0x12, 0x1a,
+	0x0a, 0x16, 0x53, 0x54, 0x41, 0x54, 0x49, 0x43, 0x5f, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53, 0x49,
+	0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x36, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55,
+	0x50, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x37, 0x12, 0x07, 0x0a, 0x03, 0x56, 0x43, 0x53, 0x10, 0x38,
+	0x12, 0x1b, 0x0a, 0x17, 0x56, 0x55, 0x4c, 0x4e, 0x45, 0x52, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54,
+	0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x52, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x39, 0x12, 0x23, 0x0a,
+	0x1f, 0x56, 0x55, 0x4c, 0x4e, 0x45, 0x52, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x44,
+	0x49, 0x53, 0x43, 0x4c, 0x4f, 0x53, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54,
+	0x10, 0x3a, 0x12, 0x2b, 0x0a, 0x27, 0x56, 0x55, 0x4c, 0x4e, 0x45, 0x52, 0x41, 0x42, 0x49, 0x4c,
+	0x49, 0x54, 0x59, 0x5f, 0x45, 0x58, 0x50, 0x4c, 0x4f, 0x49, 0x54, 0x41, 0x42, 0x49, 0x4c, 0x49,
+	0x54, 0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x3b, 0x12,
+	0x0b, 0x0a, 0x07, 0x57, 0x45, 0x42, 0x53, 0x49, 0x54, 0x45, 0x10, 0x3c, 0x22, 0xb4, 0x02, 0x0a,
+	0x07, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x37, 0x0a, 0x06,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62,
+	0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
+	0x2e, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65,
+	0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x29,
+	0x0a, 0x10, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x70,
+	0x79, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f,
+	0x70, 0x79, 0x72, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x8a, 0x01, 0x0a, 0x0c, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x64, 0x5f, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65, 0x6e, 0x64,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x6c, 0x69, 0x6e,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x4c, 0x69, 0x6e, 0x65,
+	0x22, 0x4f, 0x0a, 0x10, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x22, 0x93, 0x02, 0x0a, 0x0a, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x37, 0x0a, 0x09, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x52, 0x09,
+	0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x6f, 0x6f,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75,
+	0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x54, 0x6f, 0x6f, 0x6c,
+	0x52, 0x04, 0x74, 0x6f, 0x6f, 0x6c, 0x12, 0x2e, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x1d, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x52,
+	0x45, 0x56, 0x49, 0x45, 0x57, 0x10, 0x01, 0x22, 0x7f, 0x0a, 0x10, 0x45, 0x78, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x65, 0x64, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x65, 0x78, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x65, 0x65, 0x5f, 0x61, 0x6c, 0x73, 0x6f, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x65, 0x41, 0x6c, 0x73, 0x6f, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0xa8, 0x01, 0x0a, 0x06, 0x50, 0x65, 0x72,
+	0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x6f, 0x72,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x4f, 0x72, 0x67, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x35, 0x0a, 0x08,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
 	0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x6f, 0x6d, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12, 0x23,
-	0x0a, 0x0d, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x6f, 0x6f, 0x74, 0x45, 0x6c, 0x65, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x2a, 0xf0, 0x01, 0x0a, 0x0d, 0x48, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f,
-	0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
-	0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x35, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x53,
-	0x48, 0x41, 0x31, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10,
-	0x03, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x33, 0x38, 0x34, 0x10, 0x04, 0x12, 0x0a, 0x0a,
-	0x06, 0x53, 0x48, 0x41, 0x35, 0x31, 0x32, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41,
-	0x33, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x33, 0x5f,
-	0x33, 0x38, 0x34, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x35, 0x31,
-	0x32, 0x10, 0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x32, 0x42, 0x5f, 0x32,
-	0x35, 0x36, 0x10, 0x09, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x32, 0x42, 0x5f,
-	0x33, 0x38, 0x34, 0x10, 0x0a, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x32, 0x42,
-	0x5f, 0x35, 0x31, 0x32, 0x10, 0x0b, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x33,
-	0x10, 0x0c, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x32, 0x10, 0x0d, 0x12, 0x0b, 0x0a, 0x07, 0x41,
-	0x44, 0x4c, 0x45, 0x52, 0x33, 0x32, 0x10, 0x0e, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x34, 0x10,
-	0x0f, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x36, 0x10, 0x10, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48,
-	0x41, 0x32, 0x32, 0x34, 0x10, 0x11, 0x2a, 0x61, 0x0a, 0x16, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61,
-	0x72, 0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x1b, 0x0a, 0x17, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x49, 0x44, 0x45, 0x4e,
-	0x54, 0x49, 0x46, 0x49, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a,
-	0x04, 0x50, 0x55, 0x52, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x50, 0x45, 0x32, 0x32,
-	0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x50, 0x45, 0x32, 0x33, 0x10, 0x03, 0x12, 0x0a, 0x0a,
-	0x06, 0x47, 0x49, 0x54, 0x4f, 0x49, 0x44, 0x10, 0x04, 0x2a, 0xb7, 0x03, 0x0a, 0x07, 0x50, 0x75,
-	0x72, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
-	0x5f, 0x50, 0x55, 0x52, 0x50, 0x4f, 0x53, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x50,
-	0x50, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x41,
-	0x52, 0x43, 0x48, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x4f, 0x4d, 0x10,
-	0x03, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x55, 0x52, 0x41, 0x54, 0x49,
-	0x4f, 0x4e, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x45,
-	0x52, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x41, 0x54, 0x41, 0x10, 0x06, 0x12, 0x0a, 0x0a,
-	0x06, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x10, 0x07, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x45, 0x56,
-	0x49, 0x43, 0x45, 0x5f, 0x44, 0x52, 0x49, 0x56, 0x45, 0x52, 0x10, 0x08, 0x12, 0x11, 0x0a, 0x0d,
-	0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x09, 0x12,
-	0x0c, 0x0a, 0x08, 0x45, 0x56, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x0a, 0x12, 0x0e, 0x0a,
-	0x0a, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x0b, 0x12, 0x08, 0x0a,
-	0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x0c, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x49, 0x52, 0x4d, 0x57,
-	0x41, 0x52, 0x45, 0x10, 0x0d, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x52, 0x41, 0x4d, 0x45, 0x57, 0x4f,
-	0x52, 0x4b, 0x10, 0x0e, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x53, 0x54, 0x41, 0x4c, 0x4c, 0x10,
-	0x0f, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x49, 0x42, 0x52, 0x41, 0x52, 0x59, 0x10, 0x10, 0x12, 0x1a,
-	0x0a, 0x16, 0x4d, 0x41, 0x43, 0x48, 0x49, 0x4e, 0x45, 0x5f, 0x4c, 0x45, 0x41, 0x52, 0x4e, 0x49,
-	0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x10, 0x11, 0x12, 0x0c, 0x0a, 0x08, 0x4d, 0x41,
-	0x4e, 0x49, 0x46, 0x45, 0x53, 0x54, 0x10, 0x12, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x4f, 0x44, 0x45,
-	0x4c, 0x10, 0x13, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x4f, 0x44, 0x55, 0x4c, 0x45, 0x10, 0x14, 0x12,
-	0x14, 0x0a, 0x10, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x59, 0x53,
-	0x54, 0x45, 0x4d, 0x10, 0x15, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10, 0x16,
-	0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x54, 0x43, 0x48, 0x10, 0x17, 0x12, 0x0c, 0x0a, 0x08, 0x50,
-	0x4c, 0x41, 0x54, 0x46, 0x4f, 0x52, 0x4d, 0x10, 0x18, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x45, 0x51,
-	0x55, 0x49, 0x52, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x19, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4f,
-	0x55, 0x52, 0x43, 0x45, 0x10, 0x1a, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x1b, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x45, 0x53,
-	0x54, 0x10, 0x1c, 0x42, 0x07, 0x5a, 0x05, 0x73, 0x62, 0x6f, 0x6d, 0x2f, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6d, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x63, 0x74, 0x73, 0x22, 0x34, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4c, 0x0a, 0x04, 0x54, 0x6f, 0x6f,
+	0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x22, 0xb7, 0x02, 0x0a, 0x0c, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x41, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61,
+	0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x53, 0x42, 0x4f, 0x4d, 0x54, 0x79, 0x70, 0x65,
+	0x48, 0x00, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x22, 0x81, 0x01, 0x0a, 0x08,
+	0x53, 0x42, 0x4f, 0x4d, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45,
+	0x52, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x53, 0x49, 0x47, 0x4e, 0x10, 0x01, 0x12,
+	0x0a, 0x0a, 0x06, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x42,
+	0x55, 0x49, 0x4c, 0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x5a,
+	0x45, 0x44, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x45, 0x50, 0x4c, 0x4f, 0x59, 0x45, 0x44,
+	0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x06, 0x12,
+	0x0d, 0x0a, 0x09, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x59, 0x10, 0x07, 0x12, 0x0f,
+	0x0a, 0x0b, 0x44, 0x45, 0x43, 0x4f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x08, 0x42,
+	0x07, 0x0a, 0x05, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d,
+	0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x62, 0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f,
+	0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2d, 0x0a,
+	0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62,
+	0x6f, 0x6d, 0x73, 0x71, 0x75, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
+	0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x6f, 0x6f, 0x74, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x2a, 0xf0, 0x01, 0x0a, 0x0d, 0x48, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00,
+	0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x35, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x48, 0x41,
+	0x31, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x03, 0x12,
+	0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x33, 0x38, 0x34, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x53,
+	0x48, 0x41, 0x35, 0x31, 0x32, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x33, 0x5f,
+	0x32, 0x35, 0x36, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x33, 0x38,
+	0x34, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x35, 0x31, 0x32, 0x10,
+	0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x32, 0x42, 0x5f, 0x32, 0x35, 0x36,
+	0x10, 0x09, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x32, 0x42, 0x5f, 0x33, 0x38,
+	0x34, 0x10, 0x0a, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x32, 0x42, 0x5f, 0x35,
+	0x31, 0x32, 0x10, 0x0b, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x33, 0x10, 0x0c,
+	0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x32, 0x10, 0x0d, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x44, 0x4c,
+	0x45, 0x52, 0x33, 0x32, 0x10, 0x0e, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x34, 0x10, 0x0f, 0x12,
+	0x07, 0x0a, 0x03, 0x4d, 0x44, 0x36, 0x10, 0x10, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x32,
+	0x32, 0x34, 0x10, 0x11, 0x2a, 0x76, 0x0a, 0x16, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b,
+	0x0a, 0x17, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x49, 0x44, 0x45, 0x4e, 0x54, 0x49,
+	0x46, 0x49, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x50,
+	0x55, 0x52, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x50, 0x45, 0x32, 0x32, 0x10, 0x02,
+	0x12, 0x09, 0x0a, 0x05, 0x43, 0x50, 0x45, 0x32, 0x33, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x47,
+	0x49, 0x54, 0x4f, 0x49, 0x44, 0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x57, 0x49, 0x44, 0x10,
+	0x05, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x57, 0x48, 0x49, 0x44, 0x10, 0x06, 0x2a, 0xb7, 0x03, 0x0a,
+	0x07, 0x50, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x5f, 0x50, 0x55, 0x52, 0x50, 0x4f, 0x53, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a,
+	0x0b, 0x41, 0x50, 0x50, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0b,
+	0x0a, 0x07, 0x41, 0x52, 0x43, 0x48, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x42,
+	0x4f, 0x4d, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x55, 0x52,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x54, 0x41,
+	0x49, 0x4e, 0x45, 0x52, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x41, 0x54, 0x41, 0x10, 0x06,
+	0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x10, 0x07, 0x12, 0x11, 0x0a, 0x0d,
+	0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x44, 0x52, 0x49, 0x56, 0x45, 0x52, 0x10, 0x08, 0x12,
+	0x11, 0x0a, 0x0d, 0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x10, 0x09, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x56, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x0a,
+	0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x0b,
+	0x12, 0x08, 0x0a, 0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x0c, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x49,
+	0x52, 0x4d, 0x57, 0x41, 0x52, 0x45, 0x10, 0x0d, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x52, 0x41, 0x4d,
+	0x45, 0x57, 0x4f, 0x52, 0x4b, 0x10, 0x0e, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x53, 0x54, 0x41,
+	0x4c, 0x4c, 0x10, 0x0f, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x49, 0x42, 0x52, 0x41, 0x52, 0x59, 0x10,
+	0x10, 0x12, 0x1a, 0x0a, 0x16, 0x4d, 0x41, 0x43, 0x48, 0x49, 0x4e, 0x45, 0x5f, 0x4c, 0x45, 0x41,
+	0x52, 0x4e, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x10, 0x11, 0x12, 0x0c, 0x0a,
+	0x08, 0x4d, 0x41, 0x4e, 0x49, 0x46, 0x45, 0x53, 0x54, 0x10, 0x12, 0x12, 0x09, 0x0a, 0x05, 0x4d,
+	0x4f, 0x44, 0x45, 0x4c, 0x10, 0x13, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x4f, 0x44, 0x55, 0x4c, 0x45,
+	0x10, 0x14, 0x12, 0x14, 0x0a, 0x10, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f,
+	0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x15, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45,
+	0x52, 0x10, 0x16, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x54, 0x43, 0x48, 0x10, 0x17, 0x12, 0x0c,
+	0x0a, 0x08, 0x50, 0x4c, 0x41, 0x54, 0x46, 0x4f, 0x52, 0x4d, 0x10, 0x18, 0x12, 0x0f, 0x0a, 0x0b,
+	0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x19, 0x12, 0x0a, 0x0a,
+	0x06, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x10, 0x1a, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x1b, 0x12, 0x08, 0x0a, 0x04,
+	0x54, 0x45, 0x53, 0x54, 0x10, 0x1c, 0x42, 0x07, 0x5a, 0x05, 0x73, 0x62, 0x6f, 0x6d, 0x2f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2015,59 +3131,91 @@ func file_api_sbom_proto_rawDescGZIP() []byte {
 	return file_api_sbom_proto_rawDescData
 }
 
-var file_api_sbom_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_api_sbom_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_sbom_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_api_sbom_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_api_sbom_proto_goTypes = []interface{}{
 	(HashAlgorithm)(0),          // 0: bomsquad.protobom.HashAlgorithm
 	(SoftwareIdentifierType)(0), // 1: bomsquad.protobom.SoftwareIdentifierType
 	(Purpose)(0),                // 2: bomsquad.protobom.Purpose
 	(Node_NodeType)(0),          // 3: bomsquad.protobom.Node.NodeType
-	(Edge_Type)(0),              // 4: bomsquad.protobom.Edge.Type
-	(ExternalReference_ExternalReferenceType)(0), // 5: bomsquad.protobom.ExternalReference.ExternalReferenceType
-	(DocumentType_SBOMType)(0),                   // 6: bomsquad.protobom.DocumentType.SBOMType
-	(*Document)(nil),                             // 7: bomsquad.protobom.Document
-	(*Node)(nil),                                 // 8: bomsquad.protobom.Node
-	(*Metadata)(nil),                             // 9: bomsquad.protobom.Metadata
-	(*Edge)(nil),                                 // 10: bomsquad.protobom.Edge
-	(*ExternalReference)(nil),                    // 11: bomsquad.protobom.ExternalReference
-	(*Person)(nil),                               // 12: bomsquad.protobom.Person
-	(*Tool)(nil),                                 // 13: bomsquad.protobom.Tool
-	(*DocumentType)(nil),                         // 14: bomsquad.protobom.DocumentType
-	(*NodeList)(nil),                             // 15: bomsquad.protobom.NodeList
-	nil,                                          // 16: bomsquad.protobom.Node.IdentifiersEntry
-	nil,                                          // 17: bomsquad.protobom.Node.HashesEntry
-	nil,                                          // 18: bomsquad.protobom.ExternalReference.HashesEntry
-	(*timestamppb.Timestamp)(nil),                // 19: google.protobuf.Timestamp
+	(Node_Scope)(0),             // 4: bomsquad.protobom.Node.Scope
+	(Composition_Aggregate)(0),  // 5: bomsquad.protobom.Composition.Aggregate
+	(Edge_Type)(0),              // 6: bomsquad.protobom.Edge.Type
+	(ExternalReference_ExternalReferenceType)(0), // 7: bomsquad.protobom.ExternalReference.ExternalReferenceType
+	(Annotation_Type)(0),                         // 8: bomsquad.protobom.Annotation.Type
+	(DocumentType_SBOMType)(0),                   // 9: bomsquad.protobom.DocumentType.SBOMType
+	(*Document)(nil),                             // 10: bomsquad.protobom.Document
+	(*Node)(nil),                                 // 11: bomsquad.protobom.Node
+	(*Metadata)(nil),                             // 12: bomsquad.protobom.Metadata
+	(*Composition)(nil),                          // 13: bomsquad.protobom.Composition
+	(*ExternalDocument)(nil),                     // 14: bomsquad.protobom.ExternalDocument
+	(*Edge)(nil),                                 // 15: bomsquad.protobom.Edge
+	(*ExternalReference)(nil),                    // 16: bomsquad.protobom.ExternalReference
+	(*Snippet)(nil),                              // 17: bomsquad.protobom.Snippet
+	(*SnippetRange)(nil),                         // 18: bomsquad.protobom.SnippetRange
+	(*VerificationCode)(nil),                     // 19: bomsquad.protobom.VerificationCode
+	(*Annotation)(nil),                           // 20: bomsquad.protobom.Annotation
+	(*ExtractedLicense)(nil),                     // 21: bomsquad.protobom.ExtractedLicense
+	(*Person)(nil),                               // 22: bomsquad.protobom.Person
+	(*Property)(nil),                             // 23: bomsquad.protobom.Property
+	(*Tool)(nil),                                 // 24: bomsquad.protobom.Tool
+	(*DocumentType)(nil),                         // 25: bomsquad.protobom.DocumentType
+	(*NodeList)(nil),                             // 26: bomsquad.protobom.NodeList
+	nil,                                          // 27: bomsquad.protobom.Node.IdentifiersEntry
+	nil,                                          // 28: bomsquad.protobom.Node.HashesEntry
+	nil,                                          // 29: bomsquad.protobom.Node.IdentifierCommentsEntry
+	nil,                                          // 30: bomsquad.protobom.ExternalDocument.HashesEntry
+	nil,                                          // 31: bomsquad.protobom.ExternalReference.HashesEntry
+	(*timestamppb.Timestamp)(nil),                // 32: google.protobuf.Timestamp
 }
 var file_api_sbom_proto_depIdxs = []int32{
-	9,  // 0: bomsquad.protobom.Document.metadata:type_name -> bomsquad.protobom.Metadata
-	15, // 1: bomsquad.protobom.Document.node_list:type_name -> bomsquad.protobom.NodeList
+	12, // 0: bomsquad.protobom.Document.metadata:type_name -> bomsquad.protobom.Metadata
+	26, // 1: bomsquad.protobom.Document.node_list:type_name -> bomsquad.protobom.NodeList
 	3,  // 2: bomsquad.protobom.Node.type:type_name -> bomsquad.protobom.Node.NodeType
-	12, // 3: bomsquad.protobom.Node.suppliers:type_name -> bomsquad.protobom.Person
-	12, // 4: bomsquad.protobom.Node.originators:type_name -> bomsquad.protobom.Person
-	19, // 5: bomsquad.protobom.Node.release_date:type_name -> google.protobuf.Timestamp
-	19, // 6: bomsquad.protobom.Node.build_date:type_name -> google.protobuf.Timestamp
-	19, // 7: bomsquad.protobom.Node.valid_until_date:type_name -> google.protobuf.Timestamp
-	11, // 8: bomsquad.protobom.Node.external_references:type_name -> bomsquad.protobom.ExternalReference
-	16, // 9: bomsquad.protobom.Node.identifiers:type_name -> bomsquad.protobom.Node.IdentifiersEntry
-	17, // 10: bomsquad.protobom.Node.hashes:type_name -> bomsquad.protobom.Node.HashesEntry
+	22, // 3: bomsquad.protobom.Node.suppliers:type_name -> bomsquad.protobom.Person
+	22, // 4: bomsquad.protobom.Node.originators:type_name -> bomsquad.protobom.Person
+	32, // 5: bomsquad.protobom.Node.release_date:type_name -> google.protobuf.Timestamp
+	32, // 6: bomsquad.protobom.Node.build_date:type_name -> google.protobuf.Timestamp
+	32, // 7: bomsquad.protobom.Node.valid_until_date:type_name -> google.protobuf.Timestamp
+	16, // 8: bomsquad.protobom.Node.external_references:type_name -> bomsquad.protobom.ExternalReference
+	27, // 9: bomsquad.protobom.Node.identifiers:type_name -> bomsquad.protobom.Node.IdentifiersEntry
+	28, // 10: bomsquad.protobom.Node.hashes:type_name -> bomsquad.protobom.Node.HashesEntry
 	2,  // 11: bomsquad.protobom.Node.primary_purpose:type_name -> bomsquad.protobom.Purpose
-	19, // 12: bomsquad.protobom.Metadata.date:type_name -> google.protobuf.Timestamp
-	13, // 13: bomsquad.protobom.Metadata.tools:type_name -> bomsquad.protobom.Tool
-	12, // 14: bomsquad.protobom.Metadata.authors:type_name -> bomsquad.protobom.Person
-	14, // 15: bomsquad.protobom.Metadata.documentTypes:type_name -> bomsquad.protobom.DocumentType
-	4,  // 16: bomsquad.protobom.Edge.type:type_name -> bomsquad.protobom.Edge.Type
-	18, // 17: bomsquad.protobom.ExternalReference.hashes:type_name -> bomsquad.protobom.ExternalReference.HashesEntry
-	5,  // 18: bomsquad.protobom.ExternalReference.type:type_name -> bomsquad.protobom.ExternalReference.ExternalReferenceType
-	12, // 19: bomsquad.protobom.Person.contacts:type_name -> bomsquad.protobom.Person
-	6,  // 20: bomsquad.protobom.DocumentType.type:type_name -> bomsquad.protobom.DocumentType.SBOMType
-	8,  // 21: bomsquad.protobom.NodeList.nodes:type_name -> bomsquad.protobom.Node
-	10, // 22: bomsquad.protobom.NodeList.edges:type_name -> bomsquad.protobom.Edge
-	23, // [23:23] is the sub-list for method output_type
-	23, // [23:23] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	17, // 12: bomsquad.protobom.Node.snippets:type_name -> bomsquad.protobom.Snippet
+	20, // 13: bomsquad.protobom.Node.annotations:type_name -> bomsquad.protobom.Annotation
+	19, // 14: bomsquad.protobom.Node.verification_code:type_name -> bomsquad.protobom.VerificationCode
+	4,  // 15: bomsquad.protobom.Node.scope:type_name -> bomsquad.protobom.Node.Scope
+	23, // 16: bomsquad.protobom.Node.properties:type_name -> bomsquad.protobom.Property
+	29, // 17: bomsquad.protobom.Node.identifier_comments:type_name -> bomsquad.protobom.Node.IdentifierCommentsEntry
+	32, // 18: bomsquad.protobom.Metadata.date:type_name -> google.protobuf.Timestamp
+	24, // 19: bomsquad.protobom.Metadata.tools:type_name -> bomsquad.protobom.Tool
+	22, // 20: bomsquad.protobom.Metadata.authors:type_name -> bomsquad.protobom.Person
+	25, // 21: bomsquad.protobom.Metadata.documentTypes:type_name -> bomsquad.protobom.DocumentType
+	20, // 22: bomsquad.protobom.Metadata.annotations:type_name -> bomsquad.protobom.Annotation
+	21, // 23: bomsquad.protobom.Metadata.extracted_licenses:type_name -> bomsquad.protobom.ExtractedLicense
+	14, // 24: bomsquad.protobom.Metadata.external_documents:type_name -> bomsquad.protobom.ExternalDocument
+	16, // 25: bomsquad.protobom.Metadata.external_references:type_name -> bomsquad.protobom.ExternalReference
+	13, // 26: bomsquad.protobom.Metadata.compositions:type_name -> bomsquad.protobom.Composition
+	23, // 27: bomsquad.protobom.Metadata.properties:type_name -> bomsquad.protobom.Property
+	5,  // 28: bomsquad.protobom.Composition.aggregate:type_name -> bomsquad.protobom.Composition.Aggregate
+	30, // 29: bomsquad.protobom.ExternalDocument.hashes:type_name -> bomsquad.protobom.ExternalDocument.HashesEntry
+	6,  // 30: bomsquad.protobom.Edge.type:type_name -> bomsquad.protobom.Edge.Type
+	31, // 31: bomsquad.protobom.ExternalReference.hashes:type_name -> bomsquad.protobom.ExternalReference.HashesEntry
+	7,  // 32: bomsquad.protobom.ExternalReference.type:type_name -> bomsquad.protobom.ExternalReference.ExternalReferenceType
+	18, // 33: bomsquad.protobom.Snippet.ranges:type_name -> bomsquad.protobom.SnippetRange
+	22, // 34: bomsquad.protobom.Annotation.annotator:type_name -> bomsquad.protobom.Person
+	24, // 35: bomsquad.protobom.Annotation.tool:type_name -> bomsquad.protobom.Tool
+	32, // 36: bomsquad.protobom.Annotation.date:type_name -> google.protobuf.Timestamp
+	8,  // 37: bomsquad.protobom.Annotation.type:type_name -> bomsquad.protobom.Annotation.Type
+	22, // 38: bomsquad.protobom.Person.contacts:type_name -> bomsquad.protobom.Person
+	9,  // 39: bomsquad.protobom.DocumentType.type:type_name -> bomsquad.protobom.DocumentType.SBOMType
+	11, // 40: bomsquad.protobom.NodeList.nodes:type_name -> bomsquad.protobom.Node
+	15, // 41: bomsquad.protobom.NodeList.edges:type_name -> bomsquad.protobom.Edge
+	42, // [42:42] is the sub-list for method output_type
+	42, // [42:42] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_api_sbom_proto_init() }
@@ -2113,7 +3261,7 @@ func file_api_sbom_proto_init() {
 			}
 		}
 		file_api_sbom_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Edge); i {
+			switch v := v.(*Composition); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2125,7 +3273,7 @@ func file_api_sbom_proto_init() {
 			}
 		}
 		file_api_sbom_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExternalReference); i {
+			switch v := v.(*ExternalDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2137,7 +3285,7 @@ func file_api_sbom_proto_init() {
 			}
 		}
 		file_api_sbom_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Person); i {
+			switch v := v.(*Edge); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2149,7 +3297,7 @@ func file_api_sbom_proto_init() {
 			}
 		}
 		file_api_sbom_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Tool); i {
+			switch v := v.(*ExternalReference); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2161,7 +3309,7 @@ func file_api_sbom_proto_init() {
 			}
 		}
 		file_api_sbom_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DocumentType); i {
+			switch v := v.(*Snippet); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2173,6 +3321,102 @@ func file_api_sbom_proto_init() {
 			}
 		}
 		file_api_sbom_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnippetRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_sbom_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerificationCode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_sbom_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Annotation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_sbom_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtractedLicense); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_sbom_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Person); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_sbom_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Property); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_sbom_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tool); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_sbom_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DocumentType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_sbom_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NodeList); i {
 			case 0:
 				return &v.state
@@ -2185,14 +3429,15 @@ func file_api_sbom_proto_init() {
 			}
 		}
 	}
-	file_api_sbom_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	file_api_sbom_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_api_sbom_proto_msgTypes[15].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_sbom_proto_rawDesc,
-			NumEnums:      7,
-			NumMessages:   12,
+			NumEnums:      10,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
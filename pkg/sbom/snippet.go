@@ -0,0 +1,62 @@
+package sbom
+
+import (
+	"fmt"
+	"slices"
+)
+
+// flatString returns a deterministic string that can be used to hash the snippet
+func (s *Snippet) flatString() string {
+	ret := fmt.Sprintf("(id)%s", s.Id)
+	if s.Name != "" {
+		ret += fmt.Sprintf("(n)%s", s.Name)
+	}
+	for _, r := range s.Ranges {
+		ret += fmt.Sprintf("(r)%d:%d:%d:%d", r.StartOffset, r.EndOffset, r.StartLine, r.EndLine)
+	}
+	if s.LicenseConcluded != "" {
+		ret += fmt.Sprintf("(lc)%s", s.LicenseConcluded)
+	}
+	licenses := slices.Clone(s.Licenses)
+	slices.Sort(licenses)
+	for _, l := range licenses {
+		ret += fmt.Sprintf("(l)%s", l)
+	}
+	if s.LicenseComments != "" {
+		ret += fmt.Sprintf("(lcm)%s", s.LicenseComments)
+	}
+	if s.Copyright != "" {
+		ret += fmt.Sprintf("(cr)%s", s.Copyright)
+	}
+	if s.Comment != "" {
+		ret += fmt.Sprintf("(c)%s", s.Comment)
+	}
+	for _, a := range s.Attribution {
+		ret += fmt.Sprintf("(a)%s", a)
+	}
+	return ret
+}
+
+// Copy returns an exact copy of Snippet s.
+func (s *Snippet) Copy() *Snippet {
+	ns := &Snippet{
+		Id:               s.Id,
+		Name:             s.Name,
+		Ranges:           []*SnippetRange{},
+		LicenseConcluded: s.LicenseConcluded,
+		Licenses:         slices.Clone(s.Licenses),
+		LicenseComments:  s.LicenseComments,
+		Copyright:        s.Copyright,
+		Comment:          s.Comment,
+		Attribution:      slices.Clone(s.Attribution),
+	}
+	for _, r := range s.Ranges {
+		ns.Ranges = append(ns.Ranges, &SnippetRange{
+			StartOffset: r.StartOffset,
+			EndOffset:   r.EndOffset,
+			StartLine:   r.StartLine,
+			EndLine:     r.EndLine,
+		})
+	}
+	return ns
+}
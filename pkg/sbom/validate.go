@@ -0,0 +1,352 @@
+package sbom
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Severity indicates how serious a validation issue is
+type Severity string
+
+const (
+	SeverityError   Severity = "ERROR"
+	SeverityWarning Severity = "WARNING"
+)
+
+// ValidationIssue is a problem found when validating a document. Issues
+// about the document as a whole have an empty NodeID.
+type ValidationIssue struct {
+	NodeID   string
+	Severity Severity
+	Message  string
+}
+
+// String returns a human readable representation of the issue
+func (i ValidationIssue) String() string {
+	if i.NodeID == "" {
+		return fmt.Sprintf("[%s] document: %s", i.Severity, i.Message)
+	}
+	return fmt.Sprintf("[%s] node %s: %s", i.Severity, i.NodeID, i.Message)
+}
+
+// ValidationIssues is the list of issues found when validating a document
+type ValidationIssues []ValidationIssue
+
+// HasErrors returns true if any of the issues has SeverityError
+func (vi ValidationIssues) HasErrors() bool {
+	for _, i := range vi {
+		if i.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns a *ValidationError with the issues of SeverityError or nil
+// if there are none.
+func (vi ValidationIssues) Err() error {
+	errs := ValidationIssues{}
+	for _, i := range vi {
+		if i.Severity == SeverityError {
+			errs = append(errs, i)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Issues: errs}
+}
+
+// ValidationError is returned when a document fails validation
+type ValidationError struct {
+	Issues ValidationIssues
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Issues))
+	for _, i := range e.Issues {
+		msgs = append(msgs, i.String())
+	}
+	return fmt.Sprintf("document is not valid: %s", strings.Join(msgs, "; "))
+}
+
+// ValidateOptions control the checks of Document.ValidateWithOptions
+type ValidateOptions struct {
+	// IdentifierSeverity is the severity of the issues reported for nodes
+	// whose software identifiers disagree with their name or version. If
+	// blank, the identifiers are not checked.
+	IdentifierSeverity Severity
+
+	// HashSeverity is the severity of the issues reported for node hashes
+	// whose values do not look like a digest of their algorithm. If blank,
+	// the hashes are not checked.
+	HashSeverity Severity
+
+	// CycleSeverity is the severity of the issues reported for the cycles
+	// in the dependency graph. If blank, the graph is not checked.
+	CycleSeverity Severity
+}
+
+// DefaultValidateOptions reports dependency cycles as errors and
+// inconsistent identifiers and hashes as warnings
+var DefaultValidateOptions = ValidateOptions{
+	IdentifierSeverity: SeverityWarning,
+	HashSeverity:       SeverityWarning,
+	CycleSeverity:      SeverityError,
+}
+
+// Validate checks the document for structural problems and returns the
+// issues found using the DefaultValidateOptions. The document fails
+// validation if any of the issues has SeverityError, use
+// ValidationIssues.Err to get them as an error.
+//
+// Missing required fields, references to undefined nodes (in edges, root
+// elements or compositions) and cycles in the dependency graph are reported
+// as errors. Packages whose files were not analyzed but contain file nodes
+// are reported as warnings as SPDX writes them without their files.
+// Identifiers that disagree with the node and hashes that do not match the
+// length of their algorithm are reported as warnings too.
+func (d *Document) Validate() ValidationIssues {
+	return d.ValidateWithOptions(DefaultValidateOptions)
+}
+
+// ValidateWithOptions checks the document like Validate using the
+// specified options.
+func (d *Document) ValidateWithOptions(opts ValidateOptions) ValidationIssues {
+	issues := d.validateRequired()
+
+	if opts.CycleSeverity != "" {
+		// The error of DetectCycles only describes the cycles returned
+		cycles, _ := d.DetectCycles()
+		for _, c := range cycles {
+			cycleErr := &CyclicDependencyError{Path: append(slices.Clone(c), c[0])}
+			issues = append(issues, ValidationIssue{
+				NodeID:   c[0],
+				Severity: opts.CycleSeverity,
+				Message:  cycleErr.Error(),
+			})
+		}
+	}
+
+	issues = append(issues, d.validateFilesAnalyzed()...)
+
+	if opts.IdentifierSeverity != "" {
+		for _, n := range d.GetNodeList().GetNodes() {
+			for _, err := range n.ValidateIdentifiers() {
+				issues = append(issues, ValidationIssue{
+					NodeID:   n.Id,
+					Severity: opts.IdentifierSeverity,
+					Message:  err.Error(),
+				})
+			}
+		}
+	}
+
+	if opts.HashSeverity != "" {
+		for _, n := range d.GetNodeList().GetNodes() {
+			for _, err := range n.ValidateHashes() {
+				issues = append(issues, ValidationIssue{
+					NodeID:   n.Id,
+					Severity: opts.HashSeverity,
+					Message:  err.Error(),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// validateRequired returns an error issue for each field required by the
+// SBOM formats that is missing in the document and for each reference to
+// a node not defined in it.
+func (d *Document) validateRequired() ValidationIssues {
+	issues := ValidationIssues{}
+	ids := map[string]struct{}{}
+	for _, n := range d.GetNodeList().GetNodes() {
+		if n.Id == "" {
+			issues = append(issues, ValidationIssue{Severity: SeverityError, Message: fmt.Sprintf("node %q has no id", n.Name)})
+			continue
+		}
+		ids[n.Id] = struct{}{}
+		if n.Name == "" {
+			issues = append(issues, ValidationIssue{NodeID: n.Id, Severity: SeverityError, Message: "node has no name"})
+		}
+	}
+
+	for _, id := range d.GetNodeList().GetRootElements() {
+		if _, ok := ids[id]; !ok {
+			issues = append(issues, ValidationIssue{Severity: SeverityError, Message: fmt.Sprintf("root element %s is not defined", id)})
+		}
+	}
+
+	for _, e := range d.GetNodeList().GetEdges() {
+		for _, id := range append([]string{e.From}, e.To...) {
+			if _, ok := ids[id]; !ok {
+				issues = append(issues, ValidationIssue{
+					NodeID:   e.From,
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("%s relationship references undefined node %s", e.Type, id),
+				})
+			}
+		}
+	}
+
+	for _, c := range d.GetMetadata().GetCompositions() {
+		for _, id := range append(slices.Clone(c.GetAssemblies()), c.GetDependencies()...) {
+			if _, ok := ids[id]; !ok {
+				issues = append(issues, ValidationIssue{
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("composition %s references undefined node %s", c.GetAggregate(), id),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// IdentifierMismatchError is returned by Node.ValidateIdentifiers when the
+// name or version encoded in a software identifier does not match the node
+type IdentifierMismatchError struct {
+	Type  SoftwareIdentifierType
+	Field string // "name" or "version"
+
+	// Expected is the value of the node field, Found the value in the
+	// identifier
+	Expected string
+	Found    string
+}
+
+func (e *IdentifierMismatchError) Error() string {
+	return fmt.Sprintf("%s %s %q does not match the node %s %q", e.Type, e.Field, e.Found, e.Field, e.Expected)
+}
+
+// ValidateIdentifiers checks that the package urls and CPEs of the node are
+// consistent with its name and version and returns an error for each
+// mismatch or identifier that cannot be parsed. Fields blank in either the
+// node or the identifier are not compared.
+//
+// Names are compared ignoring case and the differences between dashes,
+// underscores and spaces. The node name may include the purl namespace
+// (@scope/name, group:name or a go module path) and versions may differ in
+// a leading "v".
+func (n *Node) ValidateIdentifiers() []error {
+	errs := []error{}
+	for _, t := range []SoftwareIdentifierType{
+		SoftwareIdentifierType_PURL, SoftwareIdentifierType_CPE23, SoftwareIdentifierType_CPE22,
+	} {
+		id := n.Identifiers[int32(t)]
+		if id == "" {
+			continue
+		}
+
+		var name, version string
+		var err error
+		if t == SoftwareIdentifierType_PURL {
+			name, _, version, err = purlNameVersion(id)
+		} else {
+			name, version, err = cpeProductVersion(id)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s identifier: %w", t, err))
+			continue
+		}
+
+		if name != "" && n.Name != "" && !identifierNameMatches(n.Name, name) {
+			errs = append(errs, &IdentifierMismatchError{Type: t, Field: "name", Expected: n.Name, Found: name})
+		}
+		if version != "" && n.Version != "" && strings.TrimPrefix(version, "v") != strings.TrimPrefix(n.Version, "v") {
+			errs = append(errs, &IdentifierMismatchError{Type: t, Field: "version", Expected: n.Version, Found: version})
+		}
+	}
+	return errs
+}
+
+// identifierNameMatches returns true if the node name refers to the name
+// found in an identifier
+func identifierNameMatches(nodeName, name string) bool {
+	normalize := strings.NewReplacer("_", "-", " ", "-").Replace
+	nodeName = normalize(strings.ToLower(strings.TrimSpace(nodeName)))
+	name = normalize(strings.ToLower(name))
+	if nodeName == name {
+		return true
+	}
+	// The node name may be qualified with the package namespace
+	return strings.HasSuffix(nodeName, "/"+name) || strings.HasSuffix(nodeName, ":"+name)
+}
+
+// InvalidHashError is returned by Node.ValidateHashes when a hash value is
+// not a hex encoded digest of its algorithm
+type InvalidHashError struct {
+	Algorithm HashAlgorithm
+	Value     string
+
+	// Reason describes the problem with the value
+	Reason string
+}
+
+func (e *InvalidHashError) Error() string {
+	return fmt.Sprintf("%s hash %q %s", e.Algorithm, e.Value, e.Reason)
+}
+
+// ValidateHashes checks that the hashes of the node are hex strings of the
+// length of the digests of their algorithm, for example 40 characters for
+// SHA1 and 64 for SHA256, and returns an *InvalidHashError for each value
+// that is not. This catches values stored under the wrong algorithm. Only
+// the charset is checked for algorithms with a variable output size.
+func (n *Node) ValidateHashes() []error {
+	algos := make([]int32, 0, len(n.GetHashes()))
+	for algo := range n.GetHashes() {
+		algos = append(algos, algo)
+	}
+	slices.Sort(algos)
+
+	errs := []error{}
+	for _, algo := range algos {
+		ha, value := HashAlgorithm(algo), n.Hashes[algo]
+		if _, ok := HashAlgorithm_name[algo]; !ok || ha == HashAlgorithm_UNKNOWN {
+			errs = append(errs, &InvalidHashError{Algorithm: ha, Value: value, Reason: "has an unknown algorithm"})
+			continue
+		}
+		if value == "" || strings.Trim(value, "0123456789abcdefABCDEF") != "" {
+			errs = append(errs, &InvalidHashError{Algorithm: ha, Value: value, Reason: "is not a hex string"})
+			continue
+		}
+		if l := ha.HexLength(); l != 0 && len(value) != l {
+			errs = append(errs, &InvalidHashError{
+				Algorithm: ha, Value: value,
+				Reason: fmt.Sprintf("has %d characters, expected %d", len(value), l),
+			})
+		}
+	}
+	return errs
+}
+
+// validateFilesAnalyzed returns a warning for each package node flagged as
+// not having its files analyzed that contains file nodes. SPDX does not allow
+// those packages to contain files.
+func (d *Document) validateFilesAnalyzed() ValidationIssues {
+	issues := ValidationIssues{}
+	nl := d.GetNodeList()
+	for _, e := range nl.GetEdges() {
+		for _, ce := range e.Canonical() {
+			if ce.Type != Edge_contains {
+				continue
+			}
+			pkg := nl.GetNodeByID(ce.From)
+			if pkg == nil || pkg.Type != Node_PACKAGE || pkg.FilesAnalyzed == nil || *pkg.FilesAnalyzed {
+				continue
+			}
+			for _, id := range ce.To {
+				if f := nl.GetNodeByID(id); f != nil && f.Type == Node_FILE {
+					issues = append(issues, ValidationIssue{
+						NodeID:   pkg.Id,
+						Severity: SeverityWarning,
+						Message:  fmt.Sprintf("package contains file %s but its files were not analyzed", id),
+					})
+				}
+			}
+		}
+	}
+	return issues
+}
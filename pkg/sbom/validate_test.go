@@ -0,0 +1,294 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidateCycles(t *testing.T) {
+	doc := testDependencyDocument(
+		&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}},
+		&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"lib2"}},
+	)
+	issues := doc.Validate()
+	require.Empty(t, issues)
+	require.False(t, issues.HasErrors())
+	require.NoError(t, issues.Err())
+
+	doc.NodeList.Edges = append(doc.NodeList.Edges, &Edge{
+		Type: Edge_dependsOn, From: "lib2", To: []string{"lib1"},
+	})
+	issues = doc.Validate()
+	require.Len(t, issues, 1)
+	require.Equal(t, SeverityError, issues[0].Severity)
+	require.Equal(t, "lib1", issues[0].NodeID)
+	require.Equal(t, "cyclic dependency: lib1 -> lib2 -> lib1", issues[0].Message)
+	require.True(t, issues.HasErrors())
+
+	err := issues.Err()
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Len(t, validationErr.Issues, 1)
+	require.Contains(t, err.Error(), "[ERROR] node lib1: cyclic dependency")
+
+	// The options set the severity of the cycles or skip them
+	issues = doc.ValidateWithOptions(ValidateOptions{CycleSeverity: SeverityWarning})
+	require.Len(t, issues, 1)
+	require.Equal(t, SeverityWarning, issues[0].Severity)
+	require.NoError(t, issues.Err())
+	require.Empty(t, doc.ValidateWithOptions(ValidateOptions{}))
+}
+
+func TestValidateRequired(t *testing.T) {
+	doc := NewDocument()
+	doc.NodeList.AddRootNode(&Node{Id: "app", Name: "app"})
+	doc.NodeList.AddNode(&Node{Id: "lib1", Name: "lib1"})
+	doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib1"}})
+	require.Empty(t, doc.Validate())
+
+	doc.NodeList.AddNode(&Node{Id: "lib2"})
+	doc.NodeList.AddNode(&Node{Name: "anonymous"})
+	doc.NodeList.RootElements = append(doc.NodeList.RootElements, "missing-root")
+	doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "lib1", To: []string{"missing"}})
+	doc.Metadata.Compositions = []*Composition{
+		{Aggregate: Composition_COMPLETE, Assemblies: []string{"app", "lib1"}, Dependencies: []string{"gone"}},
+	}
+
+	messages := []string{}
+	for _, i := range doc.Validate() {
+		require.Equal(t, SeverityError, i.Severity)
+		messages = append(messages, i.String())
+	}
+	require.Equal(t, []string{
+		"[ERROR] node lib2: node has no name",
+		`[ERROR] document: node "anonymous" has no id`,
+		"[ERROR] document: root element missing-root is not defined",
+		"[ERROR] node lib1: dependsOn relationship references undefined node missing",
+		"[ERROR] document: composition COMPLETE references undefined node gone",
+	}, messages)
+}
+
+func TestValidationIssuesErr(t *testing.T) {
+	issues := ValidationIssues{
+		{Severity: SeverityWarning, Message: "just a warning"},
+	}
+	require.False(t, issues.HasErrors())
+	require.NoError(t, issues.Err())
+	require.Equal(t, "[WARNING] document: just a warning", issues[0].String())
+}
+
+func TestValidateIdentifiers(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		node     *Node
+		expected []string
+	}{
+		{
+			name: "consistent",
+			node: &Node{Name: "core", Version: "7.0.0", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_PURL):  "pkg:npm/%40babel/core@7.0.0",
+				int32(SoftwareIdentifierType_CPE23): "cpe:2.3:a:babeljs:core:7.0.0:*:*:*:*:*:*:*",
+			}},
+		},
+		{
+			name: "purl version disagrees",
+			node: &Node{Name: "foo", Version: "1.1.0", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_PURL): "pkg:npm/foo@1.0.0",
+			}},
+			expected: []string{`PURL version "1.0.0" does not match the node version "1.1.0"`},
+		},
+		{
+			name: "purl name disagrees",
+			node: &Node{Name: "foo", Version: "1.0", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_PURL): "pkg:npm/bar@1.0",
+			}},
+			expected: []string{`PURL name "bar" does not match the node name "foo"`},
+		},
+		{
+			name: "namespaced names and v prefix",
+			node: &Node{Name: "github.com/sirupsen/logrus", Version: "v1.9.3", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_PURL): "pkg:golang/github.com/sirupsen/logrus@1.9.3",
+			}},
+		},
+		{
+			name: "cpe mismatches",
+			node: &Node{Name: "Foo_Bar", Version: "2.0", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_CPE23): `cpe:2.3:a:acme:foo-bar:2.1\:beta:*:*:*:*:*:*:*`,
+				int32(SoftwareIdentifierType_CPE22): "cpe:/a:acme:baz:2.0",
+			}},
+			expected: []string{
+				`CPE23 version "2.1:beta" does not match the node version "2.0"`,
+				`CPE22 name "baz" does not match the node name "Foo_Bar"`,
+			},
+		},
+		{
+			name: "cpe any version",
+			node: &Node{Name: "foo", Version: "2.0", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_CPE23): "cpe:2.3:a:acme:foo:*:*:*:*:*:*:*:*",
+			}},
+		},
+		{
+			name: "blank node fields",
+			node: &Node{Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_PURL): "pkg:npm/foo@1.0.0",
+			}},
+		},
+		{
+			name: "invalid purl",
+			node: &Node{Name: "foo", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_PURL): "npm/foo@1.0.0",
+			}},
+			expected: []string{`invalid PURL identifier: package url "npm/foo@1.0.0" does not start with pkg:`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.node.ValidateIdentifiers()
+			messages := []string{}
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			require.Equal(t, len(tc.expected), len(messages), messages)
+			if len(tc.expected) > 0 {
+				require.Equal(t, tc.expected, messages)
+			}
+			for _, err := range errs {
+				var mismatch *IdentifierMismatchError
+				if errors.As(err, &mismatch) {
+					require.Contains(t, []string{"name", "version"}, mismatch.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateIdentifierSeverity(t *testing.T) {
+	doc := NewDocument()
+	doc.NodeList.AddNode(&Node{
+		Id: "foo", Name: "foo", Version: "1.1.0",
+		Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:npm/foo@1.0.0"},
+	})
+
+	// Mismatches are warnings by default
+	issues := doc.Validate()
+	require.Len(t, issues, 1)
+	require.Equal(t, SeverityWarning, issues[0].Severity)
+	require.Equal(t, "foo", issues[0].NodeID)
+	require.NoError(t, issues.Err())
+
+	issues = doc.ValidateWithOptions(ValidateOptions{IdentifierSeverity: SeverityError})
+	require.Len(t, issues, 1)
+	require.Error(t, issues.Err())
+
+	require.Empty(t, doc.ValidateWithOptions(ValidateOptions{}))
+}
+
+func TestValidateFilesAnalyzed(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		filesAnalyzed *bool
+		edge          *Edge
+		expected      []string
+	}{
+		{
+			name:          "analyzed package with files",
+			filesAnalyzed: proto.Bool(true),
+			edge:          &Edge{Type: Edge_contains, From: "pkg", To: []string{"file", "lib"}},
+		},
+		{
+			name: "package without the flag",
+			edge: &Edge{Type: Edge_contains, From: "pkg", To: []string{"file"}},
+		},
+		{
+			name:          "package not analyzed with files",
+			filesAnalyzed: proto.Bool(false),
+			edge:          &Edge{Type: Edge_contains, From: "pkg", To: []string{"file", "lib"}},
+			expected:      []string{"[WARNING] node pkg: package contains file file but its files were not analyzed"},
+		},
+		{
+			name:          "inverse relationship",
+			filesAnalyzed: proto.Bool(false),
+			edge:          &Edge{Type: Edge_contained_by, From: "file", To: []string{"pkg"}},
+			expected:      []string{"[WARNING] node pkg: package contains file file but its files were not analyzed"},
+		},
+		{
+			name:          "package not analyzed with packages",
+			filesAnalyzed: proto.Bool(false),
+			edge:          &Edge{Type: Edge_contains, From: "pkg", To: []string{"lib"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := NewDocument()
+			doc.NodeList.AddNode(&Node{Id: "pkg", Name: "pkg", Type: Node_PACKAGE, FilesAnalyzed: tc.filesAnalyzed})
+			doc.NodeList.AddNode(&Node{Id: "lib", Name: "lib", Type: Node_PACKAGE})
+			doc.NodeList.AddNode(&Node{Id: "file", Name: "main.go", Type: Node_FILE})
+			doc.NodeList.AddEdge(tc.edge)
+
+			messages := []string{}
+			for _, i := range doc.Validate() {
+				messages = append(messages, i.String())
+			}
+			require.Equal(t, append([]string{}, tc.expected...), messages)
+			require.NoError(t, doc.Validate().Err())
+		})
+	}
+}
+
+func TestValidateHashes(t *testing.T) {
+	const (
+		sha1   = "f3ae11065cafc14e27a1410ae8be28e600bb8336"
+		sha256 = "4f232eeb99e1663d07f0af1af6ea262bf594934b694228e71fd8f159f9a19f32"
+	)
+	for _, tc := range []struct {
+		name     string
+		hashes   map[HashAlgorithm]string
+		expected []string
+	}{
+		{"no hashes", nil, nil},
+		{"valid", map[HashAlgorithm]string{HashAlgorithm_SHA1: sha1, HashAlgorithm_SHA256: strings.ToUpper(sha256)}, nil},
+		{"variable length", map[HashAlgorithm]string{HashAlgorithm_BLAKE3: sha1}, nil},
+		{
+			"sha1 in the sha256 slot", map[HashAlgorithm]string{HashAlgorithm_SHA256: sha1},
+			[]string{fmt.Sprintf("SHA256 hash %q has 40 characters, expected 64", sha1)},
+		},
+		{
+			"not hex", map[HashAlgorithm]string{HashAlgorithm_SHA1: "g" + sha1[1:], HashAlgorithm_MD5: ""},
+			[]string{`MD5 hash "" is not a hex string`, fmt.Sprintf("SHA1 hash %q is not a hex string", "g"+sha1[1:])},
+		},
+		{
+			"unknown algorithm", map[HashAlgorithm]string{HashAlgorithm_UNKNOWN: sha1},
+			[]string{fmt.Sprintf("UNKNOWN hash %q has an unknown algorithm", sha1)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &Node{Id: "node", Hashes: map[int32]string{}}
+			for algo, value := range tc.hashes {
+				n.Hashes[int32(algo)] = value
+			}
+			msgs := []string{}
+			for _, err := range n.ValidateHashes() {
+				var invalid *InvalidHashError
+				require.ErrorAs(t, err, &invalid)
+				msgs = append(msgs, err.Error())
+			}
+			require.Equal(t, append([]string{}, tc.expected...), msgs)
+		})
+	}
+
+	// Invalid hashes are reported by Validate as warnings by default
+	doc := NewDocument()
+	doc.NodeList.AddNode(&Node{Id: "foo", Name: "foo", Hashes: map[int32]string{int32(HashAlgorithm_SHA256): sha1}})
+	issues := doc.Validate()
+	require.Len(t, issues, 1)
+	require.Equal(t, SeverityWarning, issues[0].Severity)
+	require.Equal(t, "foo", issues[0].NodeID)
+	require.NoError(t, issues.Err())
+
+	issues = doc.ValidateWithOptions(ValidateOptions{HashSeverity: SeverityError})
+	require.Len(t, issues, 1)
+	require.Error(t, issues.Err())
+}
@@ -0,0 +1,92 @@
+package sbom
+
+import (
+	"strings"
+
+	"github.com/bom-squad/protobom/pkg/formats/spdx"
+)
+
+// Some string fields of the model (the licenses, download location and
+// copyright of nodes and snippets) distinguish between not knowing the value
+// and knowing that there is none. A blank string means the source document
+// has no data about the field, NoAssertionValue means its author explicitly
+// made no assertion about it and NoneValue means the author asserts the
+// element has no value for it.
+const (
+	NoAssertionValue = spdx.NOASSERTION
+	NoneValue        = spdx.NONE
+)
+
+// ValueState is the state of the data of a string field of the model
+type ValueState int
+
+const (
+	// ValueStateUnset is a blank field
+	ValueStateUnset ValueState = iota
+	// ValueStateNoAssertion is a field with no assertion about its value
+	ValueStateNoAssertion
+	// ValueStateNone is a field asserted to have no value
+	ValueStateNone
+	// ValueStateSet is a field with actual data
+	ValueStateSet
+)
+
+// String returns a readable name of the value state
+func (vs ValueState) String() string {
+	switch vs {
+	case ValueStateUnset:
+		return "unset"
+	case ValueStateNoAssertion:
+		return "noassertion"
+	case ValueStateNone:
+		return "none"
+	default:
+		return "set"
+	}
+}
+
+// ValueStateOf returns the state of a field value
+func ValueStateOf(value string) ValueState {
+	switch value {
+	case "":
+		return ValueStateUnset
+	case NoAssertionValue:
+		return ValueStateNoAssertion
+	case NoneValue:
+		return ValueStateNone
+	default:
+		return ValueStateSet
+	}
+}
+
+// HasValue returns true if the value is actual data, that is, it is not
+// blank, NoAssertionValue or NoneValue.
+func HasValue(value string) bool {
+	return ValueStateOf(value) == ValueStateSet
+}
+
+// ValueFromSPDX returns the model value of a field read from an SPDX
+// document. NOASSERTION and NONE are matched regardless of case and
+// surrounding space and returned as NoAssertionValue and NoneValue, any
+// other value is returned unchanged.
+func ValueFromSPDX(value string) string {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case spdx.NOASSERTION:
+		return NoAssertionValue
+	case spdx.NONE:
+		return NoneValue
+	default:
+		return value
+	}
+}
+
+// ValueToSPDX returns the value of a field to write in an SPDX document.
+// When required is true, blank values are written as NOASSERTION as the
+// field cannot be omitted.
+func ValueToSPDX(value string, required bool) string {
+	value = strings.TrimSpace(value)
+	if value == "" && required {
+		return spdx.NOASSERTION
+	}
+	return ValueFromSPDX(value)
+}
@@ -0,0 +1,49 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueStateOf(t *testing.T) {
+	for value, expected := range map[string]ValueState{
+		"":            ValueStateUnset,
+		"NOASSERTION": ValueStateNoAssertion,
+		"NONE":        ValueStateNone,
+		"MIT":         ValueStateSet,
+		"none":        ValueStateSet, // Only normalized values are sentinels
+	} {
+		require.Equal(t, expected, ValueStateOf(value), value)
+		require.Equal(t, expected == ValueStateSet, HasValue(value), value)
+	}
+}
+
+func TestValueFromSPDX(t *testing.T) {
+	for value, expected := range map[string]string{
+		"":                "",
+		"NOASSERTION":     NoAssertionValue,
+		" noassertion ":   NoAssertionValue,
+		"None":            NoneValue,
+		"Copyright 2023 ": "Copyright 2023 ",
+	} {
+		require.Equal(t, expected, ValueFromSPDX(value), value)
+	}
+}
+
+func TestValueToSPDX(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		required bool
+		expected string
+	}{
+		{"", false, ""},
+		{"", true, "NOASSERTION"},
+		{"  ", true, "NOASSERTION"},
+		{NoneValue, true, "NONE"},
+		{"noassertion", false, "NOASSERTION"},
+		{" https://example.com/ ", true, "https://example.com/"},
+	} {
+		require.Equal(t, tc.expected, ValueToSPDX(tc.value, tc.required), "%q %v", tc.value, tc.required)
+	}
+}
@@ -0,0 +1,156 @@
+package sbom
+
+import (
+	"crypto/md5"  //nolint:gosec // Required to verify the hashes listed in SBOMs
+	"crypto/sha1" //nolint:gosec // Required to verify the hashes listed in SBOMs
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// verifiableAlgorithms are the hash algorithms that can be computed to verify
+// the files of a document, from the strongest to the weakest.
+var verifiableAlgorithms = []HashAlgorithm{
+	HashAlgorithm_SHA512,
+	HashAlgorithm_SHA384,
+	HashAlgorithm_SHA256,
+	HashAlgorithm_SHA224,
+	HashAlgorithm_SHA1,
+	HashAlgorithm_MD5,
+}
+
+// HashVerificationResult is the result of checking the hash of a file node
+// against the file on disk
+type HashVerificationResult struct {
+	NodeID string
+
+	// Path is the location of the file checked
+	Path string
+
+	// Algorithm is the hash algorithm used to check the file
+	Algorithm HashAlgorithm
+
+	// ExpectedHash is the hash recorded in the node and ActualHash the hash
+	// computed from the file contents, blank if it could not be computed
+	ExpectedHash string
+	ActualHash   string
+	Match        bool
+
+	// Err is set when the file could not be checked, for example because
+	// it does not exist or the node has no hash that can be computed
+	Err error
+}
+
+// VerifyAllHashes checks the file nodes of the document against the files
+// found under basePath. The name of each file node is read as its path
+// relative to basePath, its contents are hashed and compared to the hash
+// recorded in the node. When a node has more than one hash, the strongest
+// algorithm that can be computed is used (SHA512, SHA384, SHA256, SHA224,
+// SHA1 and MD5 are supported).
+//
+// A result is returned for each file node with hashes, in the order of the
+// nodes in the document. Problems checking a single file are reported in the
+// Err field of its result, an error is only returned if basePath is not a
+// directory.
+func (d *Document) VerifyAllHashes(basePath string) ([]HashVerificationResult, error) {
+	info, err := os.Stat(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("checking base path: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("base path %s is not a directory", basePath)
+	}
+
+	results := []HashVerificationResult{}
+	for _, n := range d.GetNodeList().GetNodes() {
+		if n.Type != Node_FILE || n.ExternalDocument != "" || n.Name == "" || len(n.Hashes) == 0 {
+			continue
+		}
+		results = append(results, verifyFileHash(n, basePath))
+	}
+	return results, nil
+}
+
+// verifyFileHash checks the hash of the file of node n under basePath
+func verifyFileHash(n *Node, basePath string) HashVerificationResult {
+	// Paths are cleaned as if rooted so they cannot point outside basePath
+	res := HashVerificationResult{
+		NodeID: n.Id,
+		Path:   filepath.Join(basePath, filepath.FromSlash(path.Clean("/"+n.Name))),
+	}
+
+	for _, algo := range verifiableAlgorithms {
+		if v := strings.TrimSpace(n.Hashes[int32(algo)]); v != "" {
+			res.Algorithm, res.ExpectedHash = algo, v
+			break
+		}
+	}
+	if res.Algorithm == HashAlgorithm_UNKNOWN {
+		res.Err = fmt.Errorf("%w: node has no hash that can be verified", ErrUnsupportedHashAlgorithm)
+		return res
+	}
+
+	actual, err := hashFile(res.Path, res.Algorithm)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	res.ActualHash = actual
+	res.Match = strings.EqualFold(res.ExpectedHash, actual)
+	return res
+}
+
+// hashFile returns the hex encoded hash of the file at path
+func hashFile(path string, algo HashAlgorithm) (string, error) {
+	h := newHash(algo)
+	if h == nil {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedHashAlgorithm, algo)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", fmt.Errorf("checking file: %w", err)
+	}
+	if info.IsDir() {
+		return "", errors.New("path is a directory")
+	}
+
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("reading file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// newHash returns a new hash of the algorithm or nil if it cannot be computed
+func newHash(algo HashAlgorithm) hash.Hash {
+	switch algo {
+	case HashAlgorithm_MD5:
+		return md5.New() //nolint:gosec
+	case HashAlgorithm_SHA1:
+		return sha1.New() //nolint:gosec
+	case HashAlgorithm_SHA224:
+		return sha256.New224()
+	case HashAlgorithm_SHA256:
+		return sha256.New()
+	case HashAlgorithm_SHA384:
+		return sha512.New384()
+	case HashAlgorithm_SHA512:
+		return sha512.New()
+	default:
+		return nil
+	}
+}
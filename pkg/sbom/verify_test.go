@@ -0,0 +1,69 @@
+package sbom
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAllHashes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package main\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("hello\n"), 0o600))
+
+	// Hashes of "package main\n" and "hello\n"
+	mainSHA256 := "df1d036cbbf3df46e2045071e082245ece204c7f53ecf0a4e022bff9bb228f47"
+	mainSHA1 := "af96a5c06ec8bf0b99b61196b464b2f70533fe93"
+	readmeMD5 := "b1946ac92492d2347c6235b4d2611184"
+
+	doc := NewDocument()
+	doc.NodeList.AddNode(&Node{Id: "pkg", Type: Node_PACKAGE, Name: "pkg", Hashes: map[int32]string{int32(HashAlgorithm_SHA1): mainSHA1}})
+	doc.NodeList.AddNode(&Node{Id: "main", Type: Node_FILE, Name: "./src/main.go", Hashes: map[int32]string{
+		int32(HashAlgorithm_SHA1):   mainSHA1,
+		int32(HashAlgorithm_SHA256): mainSHA256,
+	}})
+	doc.NodeList.AddNode(&Node{Id: "readme", Type: Node_FILE, Name: "README", Hashes: map[int32]string{int32(HashAlgorithm_MD5): "B1946AC92492D2347C6235B4D2611184"}})
+	doc.NodeList.AddNode(&Node{Id: "tampered", Type: Node_FILE, Name: "/README", Hashes: map[int32]string{int32(HashAlgorithm_SHA1): mainSHA1}})
+	doc.NodeList.AddNode(&Node{Id: "missing", Type: Node_FILE, Name: "../missing.txt", Hashes: map[int32]string{int32(HashAlgorithm_SHA1): mainSHA1}})
+	doc.NodeList.AddNode(&Node{Id: "blake", Type: Node_FILE, Name: "README", Hashes: map[int32]string{int32(HashAlgorithm_BLAKE3): "abc"}})
+	doc.NodeList.AddNode(&Node{Id: "nohash", Type: Node_FILE, Name: "README"})
+
+	results, err := doc.VerifyAllHashes(dir)
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	require.Equal(t, HashVerificationResult{
+		NodeID: "main", Path: filepath.Join(dir, "src", "main.go"), Algorithm: HashAlgorithm_SHA256,
+		ExpectedHash: mainSHA256, ActualHash: mainSHA256, Match: true,
+	}, results[0])
+
+	// Hashes are compared ignoring case
+	require.Equal(t, "readme", results[1].NodeID)
+	require.Equal(t, readmeMD5, results[1].ActualHash)
+	require.True(t, results[1].Match)
+	require.NoError(t, results[1].Err)
+
+	require.Equal(t, "tampered", results[2].NodeID)
+	require.Equal(t, filepath.Join(dir, "README"), results[2].Path)
+	require.False(t, results[2].Match)
+	require.NoError(t, results[2].Err)
+	require.NotEmpty(t, results[2].ActualHash)
+
+	// Paths do not leave the base directory
+	require.Equal(t, "missing", results[3].NodeID)
+	require.Equal(t, filepath.Join(dir, "missing.txt"), results[3].Path)
+	require.ErrorIs(t, results[3].Err, os.ErrNotExist)
+	require.False(t, results[3].Match)
+
+	require.Equal(t, "blake", results[4].NodeID)
+	require.ErrorIs(t, results[4].Err, ErrUnsupportedHashAlgorithm)
+	require.False(t, results[4].Match)
+
+	_, err = doc.VerifyAllHashes(filepath.Join(dir, "README"))
+	require.Error(t, err)
+	_, err = doc.VerifyAllHashes(filepath.Join(dir, "nothing"))
+	require.Error(t, err)
+}
@@ -31,6 +31,72 @@ func WithFormatOptions(driverKey string, opts interface{}) WriterOption {
 	}
 }
 
+// WithOmitNoAssertion makes the serializers leave out the optional fields
+// that would be written as NOASSERTION or NONE to produce less noisy
+// documents. Required fields, such as the SPDX download location, are
+// written in any case.
+func WithOmitNoAssertion(omit bool) WriterOption {
+	return func(w *Writer) {
+		so := native.SerializeOptions{}
+		if w.Options.SerializeOptions != nil {
+			so = *w.Options.SerializeOptions
+		}
+		so.OmitNoAssertion = omit
+		w.Options.SerializeOptions = &so
+	}
+}
+
+// WithProtobomTool makes the serializers add protobom to the tools that
+// created the document. By default only the tools recorded in the document
+// metadata are written.
+func WithProtobomTool(add bool) WriterOption {
+	return func(w *Writer) {
+		so := native.SerializeOptions{}
+		if w.Options.SerializeOptions != nil {
+			so = *w.Options.SerializeOptions
+		}
+		so.AddProtobomTool = add
+		w.Options.SerializeOptions = &so
+	}
+}
+
+// WithOmitCarrierProperties makes the serializers leave out the protobom
+// namespaced properties they write to keep the data the target format has
+// no field for, for example the SPDX source information of the packages
+// written as CycloneDX component properties. Without them, converting the
+// document back to its original format loses that data.
+func WithOmitCarrierProperties(omit bool) WriterOption {
+	return func(w *Writer) {
+		so := native.SerializeOptions{}
+		if w.Options.SerializeOptions != nil {
+			so = *w.Options.SerializeOptions
+		}
+		so.OmitCarrierProperties = omit
+		w.Options.SerializeOptions = &so
+	}
+}
+
+// WithLenientMode makes the writer serialize the documents as they are,
+// without validating them. Use it to write partial or draft SBOMs that do
+// not have all the data required by the formats yet. By default, documents
+// missing required fields or referencing undefined nodes are not written,
+// other validation findings such as dependency cycles are logged as warnings.
+func WithLenientMode() WriterOption {
+	return func(w *Writer) {
+		w.Options.Lenient = true
+	}
+}
+
+// WithFlatten makes the writer emit the packages of the documents as a flat
+// list without relationships, for consumers that only need the set of
+// packages, such as vulnerability scanners. Duplicate packages and file nodes
+// are left out, see sbom.NodeList.Flatten.
+func WithFlatten(flatten bool) WriterOption {
+	return func(w *Writer) {
+		w.Options.Flatten = flatten
+	}
+}
+
 func WithFormat(f formats.Format) WriterOption {
 	return func(w *Writer) {
 		w.Options.Format = f
@@ -41,7 +107,14 @@ type Options struct {
 	Format           formats.Format
 	RenderOptions    *native.RenderOptions
 	SerializeOptions *native.SerializeOptions
-	formatOptions    map[string]interface{}
+
+	// Lenient writes the documents without validating them first
+	Lenient bool
+
+	// Flatten writes the packages of the documents without their graph
+	Flatten bool
+
+	formatOptions map[string]interface{}
 }
 
 // argToOptsKeyVal returns a key value to access the options dictionary by using
@@ -64,6 +137,29 @@ func (o *Options) GetFormatOptions(key interface{}) interface{} {
 	return nil
 }
 
+// copy returns a copy of the options that can be modified without
+// changing the original
+func (o *Options) copy() *Options {
+	ret := &Options{
+		Format:        o.Format,
+		Lenient:       o.Lenient,
+		Flatten:       o.Flatten,
+		formatOptions: map[string]interface{}{},
+	}
+	if o.RenderOptions != nil {
+		ro := *o.RenderOptions
+		ret.RenderOptions = &ro
+	}
+	if o.SerializeOptions != nil {
+		so := *o.SerializeOptions
+		ret.SerializeOptions = &so
+	}
+	for k, v := range o.formatOptions {
+		ret.formatOptions[k] = v
+	}
+	return ret
+}
+
 func (o *Options) SetFormatOptions(key, opts interface{}) {
 	if o.formatOptions == nil {
 		o.formatOptions = map[string]interface{}{}
@@ -5,12 +5,18 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"sync"
 
 	"github.com/bom-squad/protobom/pkg/formats"
 	"github.com/bom-squad/protobom/pkg/native"
 	drivers "github.com/bom-squad/protobom/pkg/native/serializers"
 	"github.com/bom-squad/protobom/pkg/sbom"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
 )
 
 type Writer struct {
@@ -29,9 +35,19 @@ var (
 	}
 )
 
+// validateOptions are the checks run on the documents before writing them.
+// Only missing required fields and references to undefined nodes are errors,
+// the formats can express the rest of the findings, like dependency cycles,
+// so they are logged as warnings.
+var validateOptions = sbom.ValidateOptions{
+	IdentifierSeverity: sbom.SeverityWarning,
+	HashSeverity:       sbom.SeverityWarning,
+	CycleSeverity:      sbom.SeverityWarning,
+}
+
 func New(opts ...WriterOption) *Writer {
 	w := &Writer{
-		Options: defaultOptions,
+		Options: defaultOptions.copy(),
 	}
 
 	for _, opt := range opts {
@@ -49,6 +65,8 @@ func init() {
 	serializers[formats.CDX13JSON] = drivers.NewCDX("1.3", formats.JSON)
 	serializers[formats.CDX14JSON] = drivers.NewCDX("1.4", formats.JSON)
 	serializers[formats.CDX15JSON] = drivers.NewCDX("1.5", formats.JSON)
+	serializers[formats.CDX14XML] = drivers.NewCDX("1.4", formats.XML)
+	serializers[formats.CDX15XML] = drivers.NewCDX("1.5", formats.XML)
 	serializers[formats.SPDX23JSON] = drivers.NewSPDX23()
 	regMtx.Unlock()
 }
@@ -99,6 +117,22 @@ func (w *Writer) WriteStreamWithOptions(bom *sbom.Document, wr io.WriteCloser, o
 		return fmt.Errorf("getting serializer: %w", err)
 	}
 
+	if o.Flatten {
+		bom = flattenDocument(bom)
+	}
+
+	if !o.Lenient {
+		issues := bom.ValidateWithOptions(validateOptions)
+		for _, i := range issues {
+			if i.Severity != sbom.SeverityError {
+				logrus.Warn(i.String())
+			}
+		}
+		if err := issues.Err(); err != nil {
+			return err
+		}
+	}
+
 	so := o.SerializeOptions
 	if so == nil {
 		so = defaultOptions.SerializeOptions
@@ -121,6 +155,27 @@ func (w *Writer) WriteStreamWithOptions(bom *sbom.Document, wr io.WriteCloser, o
 	return nil
 }
 
+// flattenDocument returns a document with the flattened packages of bom and
+// no edges. The root elements that are still in the document are kept as
+// the formats need them to know what the document describes.
+func flattenDocument(bom *sbom.Document) *sbom.Document {
+	nl := &sbom.NodeList{
+		Nodes:        bom.GetNodeList().Flatten(),
+		Edges:        []*sbom.Edge{},
+		RootElements: []string{},
+	}
+	ids := map[string]struct{}{}
+	for _, n := range nl.Nodes {
+		ids[n.Id] = struct{}{}
+	}
+	for _, id := range bom.GetNodeList().GetRootElements() {
+		if _, ok := ids[id]; ok {
+			nl.RootElements = append(nl.RootElements, id)
+		}
+	}
+	return &sbom.Document{Metadata: bom.Metadata, NodeList: nl}
+}
+
 func (w *Writer) WriteStream(bom *sbom.Document, wr io.WriteCloser) error {
 	return w.WriteStreamWithOptions(bom, wr, w.Options)
 }
@@ -140,3 +195,96 @@ func (w *Writer) WriteFile(bom *sbom.Document, path string) error {
 	return w.WriteFileWithOptions(
 		bom, path, w.Options)
 }
+
+// WriteSplit writes one SBOM per root node of the document into directory
+// dir. Each file contains the graph of its root node, extracted with
+// NodeGraph, and is named after the component. The files are written in the
+// writer's configured format and their paths are returned in the order of
+// the document's root elements.
+func (w *Writer) WriteSplit(bom *sbom.Document, dir string) ([]string, error) {
+	if bom == nil || bom.NodeList == nil {
+		return nil, errors.New("unable to split sbom, SBOM is nil")
+	}
+
+	roots := bom.GetRootNodes()
+	if len(roots) == 0 {
+		return nil, errors.New("unable to split sbom, document has no root nodes")
+	}
+
+	if err := os.MkdirAll(dir, os.FileMode(0o755)); err != nil {
+		return nil, fmt.Errorf("creating output directory: %w", err)
+	}
+
+	paths := []string{}
+	seen := map[string]int{}
+	for _, root := range roots {
+		doc := &sbom.Document{
+			Metadata: &sbom.Metadata{},
+			NodeList: bom.NodeList.NodeGraph(root.Id),
+		}
+		if bom.Metadata != nil {
+			doc.Metadata = proto.Clone(bom.Metadata).(*sbom.Metadata)
+		}
+		// Each split document is a new SBOM, it needs its own identifier
+		doc.Metadata.Id = "urn:uuid:" + uuid.NewString()
+		doc.Metadata.Name = root.Name
+
+		name := splitFileName(root)
+		seen[name]++
+		if seen[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, seen[name])
+		}
+		path := filepath.Join(dir, name+fileExtension(w.Options.Format))
+
+		if err := w.writeNewFile(doc, path); err != nil {
+			return paths, fmt.Errorf("writing sbom for %s: %w", root.Id, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// writeNewFile creates the file at path and writes the document to it
+func (w *Writer) writeNewFile(bom *sbom.Document, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+	return w.WriteStreamWithOptions(bom, f, w.Options)
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// splitFileName returns a file name for the SBOM of node, built from its
+// name and version. Falls back to the node ID when the node has no name.
+func splitFileName(node *sbom.Node) string {
+	name := node.Name
+	if name == "" {
+		name = node.Id
+	}
+	if node.Version != "" {
+		name += "-" + node.Version
+	}
+	name = strings.Trim(unsafeFileNameChars.ReplaceAllString(name, "_"), "._")
+	if name == "" {
+		name = "sbom"
+	}
+	return name
+}
+
+// fileExtension returns the customary file extension for an SBOM format
+func fileExtension(format formats.Format) string {
+	switch {
+	case format.Type() == formats.SPDXFORMAT && format.Encoding() == formats.JSON:
+		return ".spdx.json"
+	case format.Type() == formats.SPDXFORMAT:
+		return ".spdx"
+	case format.Type() == formats.CDXFORMAT && format.Encoding() == formats.XML:
+		return ".cdx.xml"
+	case format.Type() == formats.CDXFORMAT:
+		return ".cdx.json"
+	default:
+		return ".json"
+	}
+}
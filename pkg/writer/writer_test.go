@@ -2,15 +2,22 @@ package writer_test
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/bom-squad/protobom/pkg/formats"
 	"github.com/bom-squad/protobom/pkg/native"
 	"github.com/bom-squad/protobom/pkg/native/nativefakes"
+	drivers "github.com/bom-squad/protobom/pkg/native/serializers"
+	"github.com/bom-squad/protobom/pkg/reader"
 	"github.com/bom-squad/protobom/pkg/sbom"
 	"github.com/bom-squad/protobom/pkg/writer"
 )
@@ -439,3 +446,276 @@ func TestSerializerRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteSplit(t *testing.T) {
+	writer.RegisterSerializer(formats.SPDX23JSON, drivers.NewSPDX23())
+	writer.RegisterSerializer(formats.CDX15JSON, drivers.NewCDX("1.5", formats.JSON))
+
+	doc := sbom.NewDocument()
+	doc.Metadata.Id = "urn:uuid:2dc8a5a8-3bb9-4c89-9ee3-4f1e3e5c4f45"
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app1", Type: sbom.Node_PACKAGE, Name: "app", Version: "1.0"})
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app2", Type: sbom.Node_PACKAGE, Name: "other/app"})
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app3", Type: sbom.Node_PACKAGE, Name: "app", Version: "1.0"})
+	doc.NodeList.AddNode(&sbom.Node{Id: "lib1", Type: sbom.Node_PACKAGE, Name: "lib1"})
+	doc.NodeList.AddNode(&sbom.Node{Id: "lib2", Type: sbom.Node_PACKAGE, Name: "lib2"})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app1", To: []string{"lib1"}})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app2", To: []string{"lib2"}})
+
+	for _, tc := range []struct {
+		format    formats.Format
+		expected  []string
+		nodeCount []int
+	}{
+		{
+			formats.SPDX23JSON,
+			[]string{"app-1.0.spdx.json", "other_app.spdx.json", "app-1.0-2.spdx.json"},
+			[]int{2, 2, 1},
+		},
+		{
+			formats.CDX15JSON,
+			[]string{"app-1.0.cdx.json", "other_app.cdx.json", "app-1.0-2.cdx.json"},
+			[]int{2, 2, 1},
+		},
+	} {
+		t.Run(string(tc.format), func(t *testing.T) {
+			dir := t.TempDir()
+			paths, err := writer.New(writer.WithFormat(tc.format)).WriteSplit(doc, dir)
+			require.NoError(t, err)
+			require.Len(t, paths, len(tc.expected))
+
+			ids := map[string]struct{}{}
+			for i, p := range paths {
+				require.Equal(t, filepath.Join(dir, tc.expected[i]), p)
+				parsed, err := reader.New().ParseFile(p)
+				require.NoError(t, err)
+				require.Len(t, parsed.NodeList.Nodes, tc.nodeCount[i])
+				require.Len(t, parsed.NodeList.RootElements, 1)
+				ids[parsed.Metadata.Id] = struct{}{}
+			}
+			if tc.format == formats.CDX15JSON {
+				require.Len(t, ids, len(paths))
+			}
+		})
+	}
+
+	_, err := writer.New(writer.WithFormat(formats.SPDX23JSON)).WriteSplit(sbom.NewDocument(), t.TempDir())
+	require.Error(t, err)
+}
+
+func TestWithOmitNoAssertion(t *testing.T) {
+	writer.RegisterSerializer(formats.SPDX23JSON, drivers.NewSPDX23())
+
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(&sbom.Node{
+		Id:               "app",
+		Type:             sbom.Node_PACKAGE,
+		Name:             "app",
+		LicenseConcluded: sbom.NoAssertionValue,
+	})
+
+	for _, tc := range []struct {
+		name     string
+		opts     []writer.WriterOption
+		expected bool
+	}{
+		{"default", []writer.WriterOption{}, true},
+		{"omit", []writer.WriterOption{writer.WithOmitNoAssertion(true)}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := append([]writer.WriterOption{writer.WithFormat(formats.SPDX23JSON)}, tc.opts...)
+			w := writer.New(opts...)
+			fwc := &fakeWriteCloser{bufio.NewWriter(&buf)}
+			require.NoError(t, w.WriteStream(doc, fwc))
+			require.NoError(t, fwc.Flush())
+
+			spdxDoc := map[string]interface{}{}
+			require.NoError(t, json.NewDecoder(&buf).Decode(&spdxDoc))
+			require.Equal(t, "CC0-1.0", spdxDoc["dataLicense"])
+			pkg := spdxDoc["packages"].([]interface{})[0].(map[string]interface{})
+			require.Equal(t, "NOASSERTION", pkg["downloadLocation"])
+			_, ok := pkg["licenseConcluded"]
+			require.Equal(t, tc.expected, ok)
+		})
+	}
+
+	// The option does not change the defaults of other writers
+	require.False(t, writer.New().Options.SerializeOptions.OmitNoAssertion)
+}
+
+func TestWithProtobomTool(t *testing.T) {
+	writer.RegisterSerializer(formats.SPDX23JSON, drivers.NewSPDX23())
+
+	doc := sbom.NewDocument()
+	doc.Metadata.Tools = []*sbom.Tool{{Name: "syft", Version: "0.95.0"}}
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Type: sbom.Node_PACKAGE, Name: "app"})
+
+	for _, tc := range []struct {
+		name     string
+		opts     []writer.WriterOption
+		expected int
+	}{
+		{"default", []writer.WriterOption{}, 1},
+		{"protobom tool", []writer.WriterOption{writer.WithProtobomTool(true)}, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := append([]writer.WriterOption{writer.WithFormat(formats.SPDX23JSON)}, tc.opts...)
+			fwc := &fakeWriteCloser{bufio.NewWriter(&buf)}
+			require.NoError(t, writer.New(opts...).WriteStream(doc, fwc))
+			require.NoError(t, fwc.Flush())
+
+			spdxDoc := struct {
+				CreationInfo struct {
+					Creators []string `json:"creators"`
+				} `json:"creationInfo"`
+			}{}
+			require.NoError(t, json.NewDecoder(&buf).Decode(&spdxDoc))
+			require.Len(t, spdxDoc.CreationInfo.Creators, tc.expected)
+			require.Equal(t, "Tool: syft-0.95.0", spdxDoc.CreationInfo.Creators[0])
+		})
+	}
+}
+
+func TestWriteCDXXML(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.Metadata.Id = "urn:uuid:2dc8a5a8-3bb9-4c89-9ee3-4f1e3e5c4f45"
+	doc.Metadata.Version = "1"
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Type: sbom.Node_PACKAGE, Name: "app", Version: "1.0"})
+	doc.NodeList.AddNode(&sbom.Node{Id: "lib", Type: sbom.Node_PACKAGE, Name: "lib"})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}})
+
+	for format, namespace := range map[formats.Format]string{
+		formats.CDX14XML: "http://cyclonedx.org/schema/bom/1.4",
+		formats.CDX15XML: "http://cyclonedx.org/schema/bom/1.5",
+	} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			fwc := &fakeWriteCloser{bufio.NewWriter(&buf)}
+			w := writer.New(writer.WithFormat(format))
+			w.Options.SetFormatOptions(drivers.NewCDX("1.5", "xml"), &drivers.CDXOptions{SerialNumber: drivers.SerialNumberPreserve})
+			require.NoError(t, w.WriteStream(doc, fwc))
+			require.NoError(t, fwc.Flush())
+
+			require.Contains(t, buf.String(), fmt.Sprintf(`<bom xmlns=%q serialNumber="urn:uuid:2dc8a5a8-3bb9-4c89-9ee3-4f1e3e5c4f45" version="1">`, namespace))
+			require.Contains(t, buf.String(), `<dependency ref="app">`)
+		})
+	}
+}
+
+func TestWriteCDXSerialNumber(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.Metadata.Id = "urn:uuid:2dc8a5a8-3bb9-4c89-9ee3-4f1e3e5c4f45"
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Type: sbom.Node_PACKAGE, Name: "app"})
+
+	// Without format options each document gets a fresh serial number
+	serials := map[string]struct{}{}
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		fwc := &fakeWriteCloser{bufio.NewWriter(&buf)}
+		require.NoError(t, writer.New(writer.WithFormat(formats.CDX15JSON)).WriteStream(doc, fwc))
+		require.NoError(t, fwc.Flush())
+
+		cdxDoc := struct {
+			SerialNumber string `json:"serialNumber"`
+		}{}
+		require.NoError(t, json.NewDecoder(&buf).Decode(&cdxDoc))
+		require.True(t, strings.HasPrefix(cdxDoc.SerialNumber, "urn:uuid:"), cdxDoc.SerialNumber)
+		_, err := uuid.Parse(cdxDoc.SerialNumber)
+		require.NoError(t, err)
+		require.NotEqual(t, doc.Metadata.Id, cdxDoc.SerialNumber)
+		serials[cdxDoc.SerialNumber] = struct{}{}
+	}
+	require.Len(t, serials, 2)
+}
+
+func TestWithLenientMode(t *testing.T) {
+	writer.RegisterSerializer(formats.SPDX23JSON, drivers.NewSPDX23())
+
+	// A draft document with a node that has no name yet
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Type: sbom.Node_PACKAGE})
+
+	var buf bytes.Buffer
+	err := writer.New(writer.WithFormat(formats.SPDX23JSON)).WriteStream(doc, &fakeWriteCloser{bufio.NewWriter(&buf)})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "node app: node has no name")
+	var validationErr *sbom.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	fwc := &fakeWriteCloser{bufio.NewWriter(&buf)}
+	require.NoError(t, writer.New(writer.WithFormat(formats.SPDX23JSON), writer.WithLenientMode()).WriteStream(doc, fwc))
+	require.NoError(t, fwc.Flush())
+	require.NotZero(t, buf.Len())
+
+	// Lenient mode does not change the defaults of other writers
+	require.False(t, writer.New().Options.Lenient)
+}
+
+func TestWriteCyclicDocument(t *testing.T) {
+	// The component of the document depends on itself
+	doc, err := reader.New().ParseFile("../../test/conformance/testdata/cyclonedx/1.5/json/bom-1.5.json")
+	require.NoError(t, err)
+	require.True(t, doc.Validate().HasErrors())
+
+	// Cycles are not an error for the writer
+	var buf bytes.Buffer
+	fwc := &fakeWriteCloser{bufio.NewWriter(&buf)}
+	require.NoError(t, writer.New(writer.WithFormat(formats.CDX15JSON)).WriteStream(doc, fwc))
+	require.NoError(t, fwc.Flush())
+
+	doc2, err := reader.New().ParseStreamWithOptions(bytes.NewReader(buf.Bytes()), &reader.Options{Format: formats.CDX15JSON})
+	require.NoError(t, err)
+	require.Len(t, doc2.NodeList.Nodes, len(doc.NodeList.Nodes))
+	cycles, err := doc2.DetectCycles()
+	require.Error(t, err)
+	require.NotEmpty(t, cycles)
+}
+
+func TestWithFlatten(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Type: sbom.Node_PACKAGE, Name: "app"})
+	for _, id := range []string{"a", "b", "c"} {
+		doc.NodeList.AddNode(&sbom.Node{Id: id, Type: sbom.Node_PACKAGE, Name: id, Version: "1.0.0"})
+	}
+	doc.NodeList.AddNode(&sbom.Node{Id: "file", Type: sbom.Node_FILE, Name: "main.go"})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"a"}})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "a", To: []string{"b"}})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "b", To: []string{"c"}})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_contains, From: "app", To: []string{"file"}})
+
+	var buf bytes.Buffer
+	fwc := &fakeWriteCloser{bufio.NewWriter(&buf)}
+	w := writer.New(writer.WithFormat(formats.CDX15JSON), writer.WithFlatten(true))
+	require.NoError(t, w.WriteStream(doc, fwc))
+	require.NoError(t, fwc.Flush())
+
+	cdxDoc := struct {
+		Metadata struct {
+			Component struct {
+				BOMRef string `json:"bom-ref"`
+			} `json:"component"`
+		} `json:"metadata"`
+		Components []struct {
+			BOMRef string `json:"bom-ref"`
+		} `json:"components"`
+		Dependencies []struct {
+			Ref       string   `json:"ref"`
+			DependsOn []string `json:"dependsOn"`
+		} `json:"dependencies"`
+	}{}
+	require.NoError(t, json.NewDecoder(&buf).Decode(&cdxDoc))
+	require.Equal(t, "app", cdxDoc.Metadata.Component.BOMRef)
+	refs := []string{}
+	for _, c := range cdxDoc.Components {
+		refs = append(refs, c.BOMRef)
+	}
+	require.ElementsMatch(t, []string{"a", "b", "c"}, refs)
+	for _, d := range cdxDoc.Dependencies {
+		require.Empty(t, d.DependsOn)
+	}
+
+	// The document is not modified
+	require.Len(t, doc.NodeList.Edges, 4)
+	require.Len(t, doc.NodeList.Nodes, 5)
+}
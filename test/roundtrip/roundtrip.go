@@ -0,0 +1,267 @@
+// Package roundtrip checks the fidelity of protobom when it reads a
+// document and writes it back in the same format. The fields of the input
+// and the re-serialized output are compared one by one and the differences
+// are returned in a report that tests can check for regressions.
+package roundtrip
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/bom-squad/protobom/pkg/native/serializers"
+	"github.com/bom-squad/protobom/pkg/reader"
+	"github.com/bom-squad/protobom/pkg/writer"
+)
+
+// identityFields are the fields that identify the objects of an array, the
+// first one found in an object is used to address it in the field paths so
+// the comparison does not depend on the order of the elements.
+var identityFields = []string{
+	"bom-ref", "SPDXID", "ref", "alg", "algorithm", "referenceLocator", "url", "externalDocumentId", "licenseId",
+}
+
+// Difference is a field that does not have the same value in the input
+// and the output of a round trip. Values are JSON encoded, they are blank
+// when the field is missing from the document.
+type Difference struct {
+	Path   string
+	Input  string
+	Output string
+}
+
+func (d Difference) String() string {
+	switch {
+	case d.Output == "":
+		return fmt.Sprintf("%s: dropped %s", d.Path, d.Input)
+	case d.Input == "":
+		return fmt.Sprintf("%s: added %s", d.Path, d.Output)
+	default:
+		return fmt.Sprintf("%s: changed %s to %s", d.Path, d.Input, d.Output)
+	}
+}
+
+// Report is the fidelity report of a round trip
+type Report struct {
+	Format formats.Format
+
+	// Output is the re-serialized document
+	Output []byte
+
+	// Dropped lists the fields of the input missing from the output
+	Dropped []Difference
+
+	// Changed lists the fields with a different value in the output
+	Changed []Difference
+
+	// Added lists the fields of the output not found in the input
+	Added []Difference
+}
+
+// Lossless returns true when all the fields of the input are found with the
+// same value in the output. Fields added by protobom are not losses.
+func (r *Report) Lossless() bool {
+	return len(r.Dropped) == 0 && len(r.Changed) == 0
+}
+
+// Losses returns the dropped and changed fields sorted by path
+func (r *Report) Losses() []Difference {
+	losses := append(append([]Difference{}, r.Dropped...), r.Changed...)
+	sort.Slice(losses, func(i, j int) bool { return losses[i].Path < losses[j].Path })
+	return losses
+}
+
+func (r *Report) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s round trip: %d dropped, %d changed, %d added fields", r.Format, len(r.Dropped), len(r.Changed), len(r.Added))
+	for _, d := range r.Losses() {
+		fmt.Fprintf(&sb, "\n  %s", d)
+	}
+	return sb.String()
+}
+
+// Option configures RoundTrip
+type Option func(*options)
+
+type options struct {
+	ignored []*regexp.Regexp
+}
+
+// WithIgnoredFields leaves the fields matching the patterns, and all the
+// fields under them, out of the report. Paths are written as the fields of
+// the JSON documents joined with dots, with the elements of arrays
+// addressed by their identifier in brackets, for example
+// components[pkg-a].hashes[SHA-256].content. An asterisk in a pattern
+// matches any text.
+func WithIgnoredFields(patterns ...string) Option {
+	return func(o *options) {
+		for _, p := range patterns {
+			re := strings.ReplaceAll(regexp.QuoteMeta(p), `\*`, `.*`)
+			o.ignored = append(o.ignored, regexp.MustCompile(`^`+re+`($|[.\[])`))
+		}
+	}
+}
+
+func (o *options) isIgnored(path string) bool {
+	for _, re := range o.ignored {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// RoundTrip reads the input document with protobom, writes it back in the
+// same format and compares both documents field by field. Only JSON
+// encoded formats are supported.
+func RoundTrip(format formats.Format, input []byte, opts ...Option) (*Report, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if format.Encoding() != formats.JSON {
+		return nil, fmt.Errorf("round trips of %s documents are not supported, only JSON", format.Encoding())
+	}
+
+	doc, err := reader.New().ParseStreamWithOptions(bytes.NewReader(input), &reader.Options{Format: format})
+	if err != nil {
+		return nil, fmt.Errorf("reading document: %w", err)
+	}
+
+	// Keep the serial number of CycloneDX documents, a round trip must not
+	// give the document a new identity.
+	wo := &writer.Options{Format: format, Lenient: true}
+	wo.SetFormatOptions(&serializers.CDX{}, &serializers.CDXOptions{SerialNumber: serializers.SerialNumberPreserve})
+
+	var buf bytes.Buffer
+	if err := writer.New().WriteStreamWithOptions(doc, nopCloser{&buf}, wo); err != nil {
+		return nil, fmt.Errorf("writing document: %w", err)
+	}
+
+	in, err := flattenDocument(input)
+	if err != nil {
+		return nil, fmt.Errorf("reading input fields: %w", err)
+	}
+	out, err := flattenDocument(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("reading output fields: %w", err)
+	}
+
+	report := &Report{Format: format, Output: buf.Bytes()}
+	for _, path := range sortedKeys(in) {
+		if o.isIgnored(path) {
+			continue
+		}
+		outValue, ok := out[path]
+		switch {
+		case !ok:
+			report.Dropped = append(report.Dropped, Difference{Path: path, Input: in[path]})
+		case outValue != in[path]:
+			report.Changed = append(report.Changed, Difference{Path: path, Input: in[path], Output: outValue})
+		}
+	}
+	for _, path := range sortedKeys(out) {
+		if _, ok := in[path]; ok || o.isIgnored(path) {
+			continue
+		}
+		report.Added = append(report.Added, Difference{Path: path, Output: out[path]})
+	}
+	return report, nil
+}
+
+// flattenDocument returns the values of the leaf fields of a JSON document
+// indexed by their path
+func flattenDocument(data []byte) (map[string]string, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("parsing JSON: %w", err)
+	}
+	fields := map[string]string{}
+	flatten(v, "", fields)
+	return fields, nil
+}
+
+func flatten(v any, path string, fields map[string]string) {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, child := range value {
+			flatten(child, join(path, k), fields)
+		}
+	case []any:
+		for i, child := range value {
+			flatten(child, fmt.Sprintf("%s[%s]", path, elementKey(child, i)), fields)
+		}
+	case string:
+		// Surrounding whitespace is not significant and empty strings are
+		// the same as missing fields. Dates are compared in UTC.
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			value = t.UTC().Format(time.RFC3339Nano)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			data = []byte(value)
+		}
+		fields[path] = string(data)
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			data = []byte(fmt.Sprint(value))
+		}
+		fields[path] = string(data)
+	}
+}
+
+// elementKey returns the key addressing an element of an array. Objects are
+// addressed by their identity field, SPDX relationships by their elements
+// and type, and scalars by their value. Anything else is addressed by its
+// position in the array.
+func elementKey(v any, i int) string {
+	switch value := v.(type) {
+	case map[string]any:
+		if from, ok := value["spdxElementId"].(string); ok {
+			return fmt.Sprintf("%s %v %v", from, value["relationshipType"], value["relatedSpdxElement"])
+		}
+		for _, f := range identityFields {
+			if id, ok := value[f].(string); ok && id != "" {
+				return id
+			}
+		}
+	case string:
+		return value
+	case float64, bool:
+		return fmt.Sprint(value)
+	}
+	return fmt.Sprint(i)
+}
+
+func join(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
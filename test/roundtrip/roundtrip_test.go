@@ -0,0 +1,116 @@
+package roundtrip
+
+import (
+	"os"
+	"testing"
+
+	"github.com/bom-squad/protobom/pkg/formats"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		format  formats.Format
+		path    string
+		ignored []string
+	}{
+		{
+			name:   "cyclonedx",
+			format: formats.CDX15JSON,
+			path:   "testdata/cdx-1.5.json",
+		},
+		{
+			name:   "spdx",
+			format: formats.SPDX23JSON,
+			path:   "testdata/spdx-2.3.json",
+			// The namespace of the documents is not kept in protobom yet
+			ignored: []string{"documentNamespace"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input, err := os.ReadFile(tc.path)
+			require.NoError(t, err)
+			report, err := RoundTrip(tc.format, input, WithIgnoredFields(tc.ignored...))
+			require.NoError(t, err)
+			require.True(t, report.Lossless(), report.String())
+			require.NotEmpty(t, report.Output)
+		})
+	}
+}
+
+func TestRoundTripReport(t *testing.T) {
+	input := []byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "serialNumber": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "version": 1,
+  "metadata": {
+    "timestamp": "2023-08-01T12:00:00+02:00",
+    "component": {"bom-ref": "app", "type": "application", "name": "app"}
+  },
+  "components": [
+    {
+      "bom-ref": "lib-a",
+      "type": "library",
+      "name": " lib-a ",
+      "version": "1.0.0",
+      "group": "example",
+      "hashes": [{"alg": "MD5", "content": "d41d8cd98f00b204e9800998ecf8427e"}]
+    }
+  ]
+}`)
+
+	report, err := RoundTrip(formats.CDX15JSON, input)
+	require.NoError(t, err)
+	require.False(t, report.Lossless())
+
+	// Whitespace and time zones are not differences
+	require.Equal(t, []Difference{
+		{Path: "components[lib-a].group", Input: `"example"`},
+	}, report.Losses())
+	require.Contains(t, report.String(), `components[lib-a].group: dropped "example"`)
+	require.NotEmpty(t, report.Added)
+
+	// Ignored fields are left out of the report
+	report, err = RoundTrip(formats.CDX15JSON, input, WithIgnoredFields("components[*].group", "$schema"))
+	require.NoError(t, err)
+	require.True(t, report.Lossless(), report.String())
+	for _, d := range report.Added {
+		require.NotEqual(t, "$schema", d.Path)
+	}
+
+	_, err = RoundTrip(formats.SPDX23TV, []byte("SPDXVersion: SPDX-2.3\n"))
+	require.Error(t, err)
+
+	_, err = RoundTrip(formats.CDX15JSON, []byte(`{"bomFormat": `))
+	require.Error(t, err)
+}
+
+func TestDifferenceString(t *testing.T) {
+	for _, tc := range []struct {
+		difference Difference
+		expected   string
+	}{
+		{Difference{Path: "a.b", Input: `"x"`}, `a.b: dropped "x"`},
+		{Difference{Path: "a.b", Output: `"y"`}, `a.b: added "y"`},
+		{Difference{Path: "a.b", Input: `"x"`, Output: `"y"`}, `a.b: changed "x" to "y"`},
+	} {
+		require.Equal(t, tc.expected, tc.difference.String())
+	}
+}
+
+func TestWithIgnoredFields(t *testing.T) {
+	o := &options{}
+	WithIgnoredFields("components[*].hashes", "metadata.tools")(o)
+	for path, expected := range map[string]bool{
+		"components[lib-a].hashes[MD5].content": true,
+		"components[lib-a].hashes":              true,
+		"components[lib-a].hashesExtra":         false,
+		"metadata.tools[0].name":                true,
+		"metadata.toolsVendor":                  false,
+		"metadata.timestamp":                    false,
+	} {
+		require.Equal(t, expected, o.isIgnored(path), path)
+	}
+}